@@ -0,0 +1,176 @@
+// Package adapters is the shared core of the web framework adapters,
+// see the echovalidator, fibervalidator, chivalidator, validatorhttp, grpcvalidator and msgvalidator subpackages.
+// It validates request values using the request's context and converts
+// validation errors into translated field messages ready to be rendered as JSON.
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/pchchv/validator"
+)
+
+const (
+	// IntegrityTag is the tag of the FieldMessages of the structs failing their integrity check,
+	// their message being the error of the check, see validator.RegisterStructIntegrity.
+	IntegrityTag = "integrity"
+	// IntegrityCode is the code of the FieldMessages of the structs failing their integrity check.
+	IntegrityCode = "VAL_INTEGRITY"
+)
+
+// TranslateFunc returns the message of a field error, e. g. using a translator.
+type TranslateFunc func(fe validator.FieldError) string
+
+// Option represents a configurations option of a Core.
+type Option func(*Core)
+
+// WithTranslator sets the func returning the messages of field errors,
+// defaults to FieldError.Error.
+func WithTranslator(fn TranslateFunc) Option {
+	return func(c *Core) {
+		c.translate = fn
+	}
+}
+
+// Core validates request values for the framework adapters.
+type Core struct {
+	validate  *validator.Validate
+	translate TranslateFunc
+}
+
+// New returns a new Core validating using v.
+// When v is nil, a new validator is used whose field names are the `json` tag names.
+func New(v *validator.Validate, opts ...Option) *Core {
+	if v == nil {
+		v = validator.New(validator.WithRequiredStructEnabled(), validator.WithFieldNameTags("json"))
+	}
+
+	c := &Core{validate: v, translate: validator.FieldError.Error}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Validator returns the underlying validator, allowing custom validations to be registered.
+func (c *Core) Validator() *validator.Validate {
+	return c.validate
+}
+
+// ValidateCtx validates i, if it's a struct or a pointer to a struct, using ctx.
+// Other values, e. g. maps or slices bound from a request, aren't validated.
+// Validation failures, including failed integrity checks, are returned as *Error.
+func (c *Core) ValidateCtx(ctx context.Context, i interface{}) error {
+	val := reflect.ValueOf(i)
+	if val.Kind() == reflect.Ptr && !val.IsNil() {
+		val = val.Elem()
+	}
+
+	if val.Kind() != reflect.Struct {
+		return nil
+	}
+
+	err := c.validate.StructCtx(ctx, i)
+	if err == nil {
+		return nil
+	}
+
+	var errs validator.ValidationErrors
+	integrityErrs := integrityErrors(err)
+	if !errors.As(err, &errs) && len(integrityErrs) == 0 {
+		return err
+	}
+
+	e := &Error{Fields: make([]FieldMessage, 0, len(integrityErrs)+len(errs)), err: err}
+	for _, ie := range integrityErrs {
+		e.Fields = append(e.Fields, FieldMessage{
+			Field:     ie.Namespace[strings.LastIndexByte(ie.Namespace, '.')+1:],
+			Namespace: ie.Namespace,
+			Tag:       IntegrityTag,
+			Code:      IntegrityCode,
+			Message:   ie.Err.Error(),
+		})
+	}
+
+	for _, fe := range errs {
+		e.Fields = append(e.Fields, FieldMessage{
+			Field:     fe.Field(),
+			Namespace: fe.Namespace(),
+			Tag:       fe.Tag(),
+			Code:      validator.Details(fe).Code(),
+			Param:     fe.Param(),
+			Message:   c.translate(fe),
+		})
+	}
+	return e
+}
+
+// integrityErrors returns the IntegrityErrors of the structs failing their integrity check,
+// joined by the validator with the ValidationErrors, see validator.RegisterStructIntegrity.
+func integrityErrors(err error) []*validator.IntegrityError {
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return nil
+	}
+
+	var integrityErrs []*validator.IntegrityError
+	for _, e := range joined.Unwrap() {
+		if ie, ok := e.(*validator.IntegrityError); ok {
+			integrityErrs = append(integrityErrs, ie)
+		}
+	}
+	return integrityErrs
+}
+
+// FieldMessage is the translated message of a field error.
+type FieldMessage struct {
+	Field     string `json:"field"`
+	Namespace string `json:"namespace"`
+	Tag       string `json:"tag"`
+	Code      string `json:"code"`
+	Param     string `json:"param,omitempty"`
+	Message   string `json:"message"`
+}
+
+// Error is the validation failure of a request value,
+// it's rendered as JSON as {"errors": [FieldMessage...]}.
+type Error struct {
+	Fields []FieldMessage `json:"errors"`
+	err    error
+}
+
+// Error returns the messages of the field errors, one per line.
+func (e *Error) Error() string {
+	msgs := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		msgs[i] = f.Message
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap returns the error returned by the validator, i. e. the ValidationErrors
+// joined with the IntegrityErrors, if any.
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// WriteError writes err as a JSON response,
+// validation failures are written with the status 422 Unprocessable Entity
+// and their field messages, other errors with the status 400 Bad Request.
+func WriteError(w http.ResponseWriter, err error) {
+	var body interface{} = map[string]string{"error": err.Error()}
+	status := http.StatusBadRequest
+	var e *Error
+	if errors.As(err, &e) {
+		body, status = e, http.StatusUnprocessableEntity
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
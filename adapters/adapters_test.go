@@ -0,0 +1,93 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pchchv/go-assert"
+	"github.com/pchchv/validator"
+)
+
+type user struct {
+	Name  string `json:"name" validate:"required"`
+	Email string `json:"email" validate:"required,email"`
+}
+
+func TestValidateCtx(t *testing.T) {
+	c := New(nil)
+	assert.Equal(t, nil, c.ValidateCtx(context.Background(), user{Name: "joey", Email: "joey@example.com"}))
+	assert.Equal(t, nil, c.ValidateCtx(context.Background(), map[string]string{}))
+	assert.Equal(t, nil, c.ValidateCtx(context.Background(), nil))
+
+	err := c.ValidateCtx(context.Background(), &user{Email: "nope"})
+	var e *Error
+	assert.Equal(t, true, errors.As(err, &e))
+	assert.Equal(t, 2, len(e.Fields))
+	assert.Equal(t, FieldMessage{Field: "name", Namespace: "user.name", Tag: "required", Code: "VAL_REQUIRED", Message: e.Fields[0].Message}, e.Fields[0])
+	assert.Equal(t, "email", e.Fields[1].Tag)
+
+	var errs validator.ValidationErrors
+	assert.Equal(t, true, errors.As(err, &errs))
+	assert.Equal(t, 2, len(errs))
+
+	c = New(validator.New(), WithTranslator(func(fe validator.FieldError) string {
+		return fe.Field() + " is invalid"
+	}))
+	err = c.ValidateCtx(context.Background(), user{Name: "joey"})
+	assert.Equal(t, "Email is invalid", err.Error())
+
+	b, err := json.Marshal(err)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, `{"errors":[{"field":"Email","namespace":"user.Email","tag":"required","code":"VAL_REQUIRED","message":"Email is invalid"}]}`, string(b))
+}
+
+func TestValidateCtxIntegrity(t *testing.T) {
+	v := validator.New(validator.WithFieldNameTags("json"))
+	v.RegisterStructIntegrity(func(ctx context.Context, value interface{}) error {
+		if value.(user).Name == "mallory" {
+			return errors.New("signature mismatch")
+		}
+		return nil
+	}, false, user{})
+
+	c := New(v)
+	err := c.ValidateCtx(context.Background(), user{Name: "mallory"})
+	var e *Error
+	assert.Equal(t, true, errors.As(err, &e))
+	assert.Equal(t, 2, len(e.Fields))
+	assert.Equal(t, FieldMessage{Field: "user", Namespace: "user", Tag: IntegrityTag, Code: IntegrityCode, Message: "signature mismatch"}, e.Fields[0])
+	assert.Equal(t, "email", e.Fields[1].Field)
+
+	var ie *validator.IntegrityError
+	assert.Equal(t, true, errors.As(err, &ie))
+
+	// integrity failures are reported without field errors
+	err = c.ValidateCtx(context.Background(), &user{Name: "mallory", Email: "mallory@example.com"})
+	assert.Equal(t, true, errors.As(err, &e))
+	assert.Equal(t, 1, len(e.Fields))
+	assert.Equal(t, "signature mismatch", err.Error())
+
+	w := httptest.NewRecorder()
+	WriteError(w, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, `{"errors":[{"field":"user","namespace":"user","tag":"integrity","code":"VAL_INTEGRITY","message":"signature mismatch"}]}`+"\n", w.Body.String())
+}
+
+func TestWriteError(t *testing.T) {
+	c := New(validator.New())
+	w := httptest.NewRecorder()
+	WriteError(w, c.ValidateCtx(context.Background(), user{Name: "joey"}))
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.Equal(t, true, strings.HasPrefix(w.Body.String(), `{"errors":[{"field":"Email","namespace":"user.Email","tag":"required"`))
+
+	w = httptest.NewRecorder()
+	WriteError(w, errors.New("unexpected EOF"))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, `{"error":"unexpected EOF"}`+"\n", w.Body.String())
+}
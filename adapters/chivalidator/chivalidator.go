@@ -0,0 +1,44 @@
+// Package chivalidator adapts the validator to chi and other net/http routers, e. g.
+//
+//	v := chivalidator.New(nil)
+//	r.Post("/users", func(w http.ResponseWriter, r *http.Request) {
+//	    var user User
+//	    if err := v.Bind(r, &user); err != nil {
+//	        chivalidator.WriteError(w, err)
+//	        return
+//	    }
+//	    // ...
+//	})
+package chivalidator
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/pchchv/validator"
+	"github.com/pchchv/validator/adapters"
+)
+
+// Validator binds and validates request bodies.
+type Validator struct {
+	*adapters.Core
+}
+
+// New returns a new Validator validating using v, see adapters.New.
+func New(v *validator.Validate, opts ...adapters.Option) *Validator {
+	return &Validator{Core: adapters.New(v, opts...)}
+}
+
+// Bind decodes the JSON body of r into dst and validates it using the request's context.
+// Validation failures are returned as *adapters.Error.
+func (v *Validator) Bind(r *http.Request, dst interface{}) error {
+	if err := json.NewDecoder(r.Body).Decode(dst); err != nil {
+		return err
+	}
+	return v.ValidateCtx(r.Context(), dst)
+}
+
+// WriteError writes err as a JSON response, see adapters.WriteError.
+func WriteError(w http.ResponseWriter, err error) {
+	adapters.WriteError(w, err)
+}
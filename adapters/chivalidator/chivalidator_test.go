@@ -0,0 +1,44 @@
+package chivalidator
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pchchv/go-assert"
+)
+
+type user struct {
+	Name string `json:"name" validate:"required"`
+	Age  int    `json:"age" validate:"gte=18"`
+}
+
+func TestBindAndWriteError(t *testing.T) {
+	v := New(nil)
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		var u user
+		if err := v.Bind(r, &u); err != nil {
+			WriteError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+
+	tests := []struct {
+		body   string
+		status int
+		resp   string
+	}{
+		{`{"name":"joey","age":30}`, http.StatusNoContent, ""},
+		{`{"age":3}`, http.StatusUnprocessableEntity, `"namespace":"user.age","tag":"gte","code":"VAL_GTE","param":"18"`},
+		{`{`, http.StatusBadRequest, `{"error":"unexpected EOF"}`},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(test.body)))
+		assert.Equal(t, test.status, w.Code)
+		assert.Equal(t, true, strings.Contains(w.Body.String(), test.resp))
+	}
+}
@@ -0,0 +1,60 @@
+// Package echovalidator adapts the validator to echo, e. g.
+//
+//	v := echovalidator.New(nil)
+//	e := echo.New()
+//	e.Validator = v
+//	e.Use(v.Middleware())
+//
+// The middleware makes c.Validate(i) validate using the request's context within handlers,
+// without it c.Validate(i) validates without a context, see ValidateRequest.
+package echovalidator
+
+import (
+	"context"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pchchv/validator"
+	"github.com/pchchv/validator/adapters"
+)
+
+// Validator implements echo's Validator interface.
+type Validator struct {
+	*adapters.Core
+}
+
+// New returns a new Validator validating using v, see adapters.New.
+func New(v *validator.Validate, opts ...adapters.Option) *Validator {
+	return &Validator{Core: adapters.New(v, opts...)}
+}
+
+// Validate validates i without a context, as called by echo's Context.Validate outside of Middleware.
+// Validation failures are returned as *adapters.Error.
+func (v *Validator) Validate(i interface{}) error {
+	return v.ValidateCtx(context.Background(), i)
+}
+
+// ValidateRequest validates i using the context of the request of c.
+// Validation failures are returned as *adapters.Error.
+func (v *Validator) ValidateRequest(c echo.Context, i interface{}) error {
+	return v.ValidateCtx(c.Request().Context(), i)
+}
+
+// Middleware returns a middleware making the Validate method of the echo contexts
+// validate using the request's context, see ValidateRequest.
+func (v *Validator) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			return next(&requestContext{Context: c, v: v})
+		}
+	}
+}
+
+// requestContext is an echo context validating using the request's context.
+type requestContext struct {
+	echo.Context
+	v *Validator
+}
+
+func (c *requestContext) Validate(i interface{}) error {
+	return c.v.ValidateRequest(c.Context, i)
+}
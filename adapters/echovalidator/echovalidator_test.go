@@ -0,0 +1,87 @@
+package echovalidator
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pchchv/go-assert"
+	"github.com/pchchv/validator"
+	"github.com/pchchv/validator/adapters"
+)
+
+type tenantKey struct{}
+
+type createUserRequest struct {
+	Tenant string `json:"tenant" validate:"tenant"`
+	Name   string `json:"name" validate:"required"`
+}
+
+func newValidator(t *testing.T) *Validator {
+	v := New(nil)
+	err := v.Validator().RegisterValidationCtx("tenant", func(ctx context.Context, fl validator.FieldLevel) bool {
+		tenant, _ := ctx.Value(tenantKey{}).(string)
+		return fl.Field().String() == tenant
+	})
+	assert.Equal(t, nil, err)
+	return v
+}
+
+func serve(e *echo.Echo, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(body))
+	req = req.WithContext(context.WithValue(req.Context(), tenantKey{}, "acme"))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestMiddleware(t *testing.T) {
+	v := newValidator(t)
+	e := echo.New()
+	e.Validator = v
+	e.Use(v.Middleware())
+	e.POST("/users", func(c echo.Context) error {
+		var req createUserRequest
+		if err := c.Bind(&req); err != nil {
+			return err
+		}
+
+		if err := c.Validate(&req); err != nil {
+			var e *adapters.Error
+			if errors.As(err, &e) {
+				return c.JSON(http.StatusUnprocessableEntity, e)
+			}
+			return err
+		}
+		return c.NoContent(http.StatusCreated)
+	})
+
+	rec := serve(e, `{"tenant":"acme","name":"joey"}`)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	rec = serve(e, `{"tenant":"other"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(t, true, strings.Contains(rec.Body.String(), `"namespace":"createUserRequest.tenant","tag":"tenant"`))
+	assert.Equal(t, true, strings.Contains(rec.Body.String(), `"namespace":"createUserRequest.name","tag":"required"`))
+}
+
+func TestValidate(t *testing.T) {
+	v := newValidator(t)
+	e := echo.New()
+	e.Validator = v
+	e.POST("/users", func(c echo.Context) error {
+		req := &createUserRequest{Tenant: "acme", Name: "joey"}
+		// without the middleware, c.Validate has no request context
+		assert.NotEqual(t, nil, c.Validate(req))
+		assert.Equal(t, nil, v.ValidateRequest(c, req))
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	rec := serve(e, `{}`)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
@@ -0,0 +1,25 @@
+module github.com/pchchv/validator/adapters/echovalidator
+
+go 1.24.0
+
+require (
+	github.com/labstack/echo/v4 v4.13.4
+	github.com/pchchv/go-assert v0.0.0-20250530192653-4d6a8ce5608f
+	github.com/pchchv/validator v1.1.0
+)
+
+require (
+	github.com/gabriel-vasile/mimetype v1.4.9 // indirect
+	github.com/labstack/gommon v0.4.2 // indirect
+	github.com/leodido/go-urn v1.4.0 // indirect
+	github.com/mattn/go-colorable v0.1.14 // indirect
+	github.com/mattn/go-isatty v0.0.20 // indirect
+	github.com/valyala/bytebufferpool v1.0.0 // indirect
+	github.com/valyala/fasttemplate v1.2.2 // indirect
+	golang.org/x/crypto v0.38.0 // indirect
+	golang.org/x/net v0.40.0 // indirect
+	golang.org/x/sys v0.33.0 // indirect
+	golang.org/x/text v0.25.0 // indirect
+)
+
+replace github.com/pchchv/validator => ../../
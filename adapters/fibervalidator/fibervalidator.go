@@ -0,0 +1,55 @@
+// Package fibervalidator adapts the validator to fiber, e. g.
+//
+//	v := fibervalidator.New(nil)
+//	app.Post("/users", func(c fiber.Ctx) error {
+//	    var user User
+//	    if err := v.Bind(c, &user); err != nil {
+//	        return err
+//	    }
+//	    // ...
+//	})
+//
+// Bind validates using the context of c, see ValidateRequest.
+// The Validator also implements fiber's StructValidator interface,
+// i. e. fiber.Config{StructValidator: v}, fiber's binding then validating without a context.
+package fibervalidator
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/pchchv/validator"
+	"github.com/pchchv/validator/adapters"
+)
+
+// Validator implements fiber's StructValidator interface.
+type Validator struct {
+	*adapters.Core
+}
+
+// New returns a new Validator validating using v, see adapters.New.
+func New(v *validator.Validate, opts ...adapters.Option) *Validator {
+	return &Validator{Core: adapters.New(v, opts...)}
+}
+
+// Validate validates out without a context, as called by fiber's binding.
+// Validation failures are returned as *adapters.Error.
+func (v *Validator) Validate(out interface{}) error {
+	return v.ValidateCtx(context.Background(), out)
+}
+
+// ValidateRequest validates out using the context of c.
+// Validation failures are returned as *adapters.Error.
+func (v *Validator) ValidateRequest(c fiber.Ctx, out interface{}) error {
+	return v.ValidateCtx(c.Context(), out)
+}
+
+// Bind binds the body of the request of c into out and validates it using the context of c.
+// The app's StructValidator should be left unset, fiber's binding validating the body first otherwise.
+// Validation failures are returned as *adapters.Error.
+func (v *Validator) Bind(c fiber.Ctx, out interface{}) error {
+	if err := c.Bind().Body(out); err != nil {
+		return err
+	}
+	return v.ValidateRequest(c, out)
+}
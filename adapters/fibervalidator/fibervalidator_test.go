@@ -0,0 +1,91 @@
+package fibervalidator
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/pchchv/go-assert"
+	"github.com/pchchv/validator"
+	"github.com/pchchv/validator/adapters"
+)
+
+type tenantKey struct{}
+
+type createUserRequest struct {
+	Tenant string `json:"tenant" validate:"tenant"`
+	Name   string `json:"name" validate:"required"`
+}
+
+func newApp(handler fiber.Handler) *fiber.App {
+	app := fiber.New()
+	app.Use(func(c fiber.Ctx) error {
+		c.SetContext(context.WithValue(c.Context(), tenantKey{}, "acme"))
+		return c.Next()
+	})
+	app.Post("/users", handler)
+	return app
+}
+
+func newValidator(t *testing.T) *Validator {
+	v := New(nil)
+	err := v.Validator().RegisterValidationCtx("tenant", func(ctx context.Context, fl validator.FieldLevel) bool {
+		tenant, _ := ctx.Value(tenantKey{}).(string)
+		return fl.Field().String() == tenant
+	})
+	assert.Equal(t, nil, err)
+	return v
+}
+
+func post(t *testing.T, app *fiber.App, body string) (int, string) {
+	req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	assert.Equal(t, nil, err)
+
+	b, err := io.ReadAll(resp.Body)
+	assert.Equal(t, nil, err)
+	return resp.StatusCode, string(b)
+}
+
+func TestBind(t *testing.T) {
+	v := newValidator(t)
+	app := newApp(func(c fiber.Ctx) error {
+		var req createUserRequest
+		if err := v.Bind(c, &req); err != nil {
+			var e *adapters.Error
+			if errors.As(err, &e) {
+				return c.Status(http.StatusUnprocessableEntity).JSON(e)
+			}
+			return err
+		}
+		return c.SendStatus(http.StatusCreated)
+	})
+
+	status, _ := post(t, app, `{"tenant":"acme","name":"joey"}`)
+	assert.Equal(t, http.StatusCreated, status)
+
+	status, body := post(t, app, `{"tenant":"other"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, status)
+	assert.Equal(t, true, strings.Contains(body, `"namespace":"createUserRequest.tenant","tag":"tenant"`))
+	assert.Equal(t, true, strings.Contains(body, `"namespace":"createUserRequest.name","tag":"required"`))
+}
+
+func TestValidate(t *testing.T) {
+	v := newValidator(t)
+	app := newApp(func(c fiber.Ctx) error {
+		req := &createUserRequest{Tenant: "acme", Name: "joey"}
+		// without the request context the tenant is unknown
+		assert.NotEqual(t, nil, v.Validate(req))
+		assert.Equal(t, nil, v.ValidateRequest(c, req))
+		return c.SendStatus(http.StatusNoContent)
+	})
+
+	status, _ := post(t, app, `{}`)
+	assert.Equal(t, http.StatusNoContent, status)
+}
@@ -0,0 +1,22 @@
+module github.com/pchchv/validator/adapters/grpcvalidator
+
+go 1.24.0
+
+require (
+	github.com/pchchv/go-assert v0.0.0-20250530192653-4d6a8ce5608f
+	github.com/pchchv/validator v1.1.0
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20250324211829-b45e905df463
+	google.golang.org/grpc v1.73.0
+)
+
+require (
+	github.com/gabriel-vasile/mimetype v1.4.9 // indirect
+	github.com/leodido/go-urn v1.4.0 // indirect
+	golang.org/x/crypto v0.38.0 // indirect
+	golang.org/x/net v0.39.0 // indirect
+	golang.org/x/sys v0.33.0 // indirect
+	golang.org/x/text v0.25.0 // indirect
+	google.golang.org/protobuf v1.36.6 // indirect
+)
+
+replace github.com/pchchv/validator => ../../
@@ -0,0 +1,165 @@
+// Package grpcvalidator validates the request messages of gRPC servers, e. g. with grpc-go:
+//
+//	v := grpcvalidator.New(nil)
+//	srv := grpc.NewServer(
+//	    grpc.UnaryInterceptor(v.UnaryServerInterceptor()),
+//	    grpc.StreamInterceptor(v.StreamServerInterceptor()),
+//	)
+//
+// The message types without rules are skipped, whether a type has rules being cached,
+// and failures are returned as InvalidArgument statuses carrying
+// the field violations of a google.rpc.BadRequest detail, see Status.
+package grpcvalidator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+
+	"github.com/pchchv/validator"
+	"github.com/pchchv/validator/adapters"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryHandler is the handler of a unary RPC, grpc.UnaryHandler converts to it.
+type UnaryHandler func(ctx context.Context, req interface{}) (interface{}, error)
+
+// Receiver receives the messages of a stream, grpc.ServerStream implements it.
+type Receiver interface {
+	Context() context.Context
+	RecvMsg(m interface{}) error
+}
+
+// FieldViolation is the violation of a request field,
+// mirroring the google.rpc.BadRequest.FieldViolation message.
+type FieldViolation struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+// Validator validates request messages.
+type Validator struct {
+	*adapters.Core
+	hasRules sync.Map // whether the message types have rules, by type
+}
+
+// New returns a new Validator validating using v, see adapters.New.
+func New(v *validator.Validate, opts ...adapters.Option) *Validator {
+	return &Validator{Core: adapters.New(v, opts...)}
+}
+
+// UnaryServerInterceptor returns an interceptor validating the request messages of unary RPCs,
+// failures being returned as InvalidArgument statuses, see Status.
+func (v *Validator) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := v.ValidateRequest(ctx, req); err != nil {
+			return nil, Status(err)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns an interceptor validating the messages received from the streams of RPCs,
+// failures being returned by RecvMsg as InvalidArgument statuses, see Status.
+func (v *Validator) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &serverStream{ServerStream: ss, v: v})
+	}
+}
+
+// serverStream validates the messages received from a stream.
+type serverStream struct {
+	grpc.ServerStream
+	v *Validator
+}
+
+func (s *serverStream) RecvMsg(m interface{}) error {
+	if err := s.v.RecvMsg(s.ServerStream, m); err != nil {
+		return Status(err)
+	}
+	return nil
+}
+
+// Unary validates the request message req and calls handler when it's valid.
+// Validation failures are returned as *adapters.Error.
+func (v *Validator) Unary(ctx context.Context, req interface{}, handler UnaryHandler) (interface{}, error) {
+	if err := v.ValidateRequest(ctx, req); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// RecvMsg receives the next message of the stream ss into m and validates it using the stream's context.
+// Validation failures are returned as *adapters.Error.
+func (v *Validator) RecvMsg(ss Receiver, m interface{}) error {
+	if err := ss.RecvMsg(m); err != nil {
+		return err
+	}
+	return v.ValidateRequest(ss.Context(), m)
+}
+
+// ValidateRequest validates the request message req using ctx,
+// skipping the message types without rules, see Validate.HasRules.
+// Validation failures are returned as *adapters.Error.
+func (v *Validator) ValidateRequest(ctx context.Context, req interface{}) error {
+	typ := reflect.TypeOf(req)
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ == nil || typ.Kind() != reflect.Struct || !v.typeHasRules(typ) {
+		return nil
+	}
+	return v.ValidateCtx(ctx, req)
+}
+
+// typeHasRules reports whether the struct type typ has rules, see Validate.HasRules.
+func (v *Validator) typeHasRules(typ reflect.Type) bool {
+	if has, ok := v.hasRules.Load(typ); ok {
+		return has.(bool)
+	}
+
+	has := v.Validator().HasRules(reflect.New(typ).Interface())
+	v.hasRules.Store(typ, has)
+	return has
+}
+
+// Status converts a validation failure returned by the Validator into an InvalidArgument status
+// whose message is the messages of the field errors, with a google.rpc.BadRequest detail listing the field violations.
+// Other errors are returned as is.
+func Status(err error) error {
+	violations := FieldViolations(err)
+	if violations == nil {
+		return err
+	}
+
+	br := &errdetails.BadRequest{FieldViolations: make([]*errdetails.BadRequest_FieldViolation, len(violations))}
+	for i, fv := range violations {
+		br.FieldViolations[i] = &errdetails.BadRequest_FieldViolation{Field: fv.Field, Description: fv.Description}
+	}
+
+	st := status.New(codes.InvalidArgument, err.Error())
+	if withDetails, detailsErr := st.WithDetails(br); detailsErr == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+// FieldViolations returns the field violations of a validation failure returned by the Validator,
+// nil for other errors.
+func FieldViolations(err error) []FieldViolation {
+	var e *adapters.Error
+	if !errors.As(err, &e) {
+		return nil
+	}
+
+	violations := make([]FieldViolation, len(e.Fields))
+	for i, f := range e.Fields {
+		violations[i] = FieldViolation{Field: f.Namespace, Description: f.Message}
+	}
+	return violations
+}
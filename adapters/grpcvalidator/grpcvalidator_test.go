@@ -0,0 +1,155 @@
+package grpcvalidator
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/pchchv/go-assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type createUserRequest struct {
+	Name  string `json:"name" validate:"required"`
+	Email string `json:"email" validate:"required,email"`
+}
+
+type pingRequest struct {
+	Payload string
+}
+
+type stream struct {
+	msgs []createUserRequest
+}
+
+func (s *stream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *stream) RecvMsg(m interface{}) error {
+	if len(s.msgs) == 0 {
+		return io.EOF
+	}
+
+	*m.(*createUserRequest), s.msgs = s.msgs[0], s.msgs[1:]
+	return nil
+}
+
+// grpcStream is a grpc.ServerStream receiving the messages of a stream.
+type grpcStream struct {
+	grpc.ServerStream
+	s *stream
+}
+
+func (ss *grpcStream) Context() context.Context {
+	return ss.s.Context()
+}
+
+func (ss *grpcStream) RecvMsg(m interface{}) error {
+	return ss.s.RecvMsg(m)
+}
+
+func TestUnary(t *testing.T) {
+	v := New(nil)
+	var called int
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		called++
+		return "ok", nil
+	}
+
+	resp, err := v.Unary(context.Background(), &createUserRequest{Name: "joey", Email: "joey@example.com"}, handler)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "ok", resp)
+
+	resp, err = v.Unary(context.Background(), &pingRequest{}, handler)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "ok", resp)
+	assert.Equal(t, 2, called)
+
+	resp, err = v.Unary(context.Background(), &createUserRequest{Email: "joey"}, handler)
+	assert.Equal(t, nil, resp)
+	assert.NotEqual(t, nil, err)
+	assert.Equal(t, 2, called)
+	assert.Equal(t, []FieldViolation{
+		{Field: "createUserRequest.name", Description: "Key: 'createUserRequest.name' Error:Field validation for 'name' failed on the 'required' tag"},
+		{Field: "createUserRequest.email", Description: "Key: 'createUserRequest.email' Error:Field validation for 'email' failed on the 'email' tag"},
+	}, FieldViolations(err))
+	assert.Equal(t, 0, len(FieldViolations(errors.New("other"))))
+}
+
+func TestRecvMsg(t *testing.T) {
+	v := New(nil)
+	ss := &stream{msgs: []createUserRequest{{Name: "joey", Email: "joey@example.com"}, {Name: "joey"}}}
+
+	var req createUserRequest
+	assert.Equal(t, nil, v.RecvMsg(ss, &req))
+	assert.Equal(t, "joey", req.Name)
+
+	err := v.RecvMsg(ss, &req)
+	assert.NotEqual(t, nil, err)
+	assert.Equal(t, 1, len(FieldViolations(err)))
+	assert.Equal(t, io.EOF, v.RecvMsg(ss, &req))
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	interceptor := New(nil).UnaryServerInterceptor()
+	var called int
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		called++
+		return "ok", nil
+	}
+
+	resp, err := interceptor(context.Background(), &createUserRequest{Name: "joey", Email: "joey@example.com"}, &grpc.UnaryServerInfo{}, handler)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "ok", resp)
+
+	resp, err = interceptor(context.Background(), &pingRequest{}, &grpc.UnaryServerInfo{}, handler)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "ok", resp)
+
+	resp, err = interceptor(context.Background(), &createUserRequest{Name: "joey"}, &grpc.UnaryServerInfo{}, handler)
+	assert.Equal(t, nil, resp)
+	assert.Equal(t, 2, called)
+
+	st, ok := status.FromError(err)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Equal(t, "Key: 'createUserRequest.email' Error:Field validation for 'email' failed on the 'required' tag", st.Message())
+	assert.Equal(t, 1, len(st.Details()))
+
+	br := st.Details()[0].(*errdetails.BadRequest)
+	assert.Equal(t, 1, len(br.GetFieldViolations()))
+	assert.Equal(t, "createUserRequest.email", br.GetFieldViolations()[0].GetField())
+	assert.Equal(t, st.Message(), br.GetFieldViolations()[0].GetDescription())
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	interceptor := New(nil).StreamServerInterceptor()
+	ss := &grpcStream{s: &stream{msgs: []createUserRequest{{Name: "joey", Email: "joey@example.com"}, {Email: "joey@example.com"}}}}
+
+	var errs []error
+	err := interceptor(nil, ss, &grpc.StreamServerInfo{}, func(srv interface{}, stream grpc.ServerStream) error {
+		for {
+			var req createUserRequest
+			err := stream.RecvMsg(&req)
+			if err == io.EOF {
+				return nil
+			}
+			errs = append(errs, err)
+		}
+	})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(errs))
+	assert.Equal(t, nil, errs[0])
+	assert.Equal(t, codes.InvalidArgument, status.Code(errs[1]))
+}
+
+func TestStatus(t *testing.T) {
+	other := errors.New("other")
+	assert.Equal(t, other, Status(other))
+	assert.Equal(t, nil, Status(nil))
+}
@@ -0,0 +1,121 @@
+// Package msgvalidator validates the messages of event-driven consumers, e. g. of Kafka or NATS,
+// decoding them into the types registered for their message types, e. g.
+//
+//	c := msgvalidator.New(nil)
+//	c.OnFailure = func(ctx context.Context, f *msgvalidator.Failure) error {
+//	    body, _ := json.Marshal(f)
+//	    return dlq.Produce(ctx, body)
+//	}
+//	c.Register("user.created", UserCreated{}, func(ctx context.Context, msg interface{}) error {
+//	    event := msg.(*UserCreated)
+//	    // ...
+//	})
+//
+//	for msg := range messages {
+//	    if err := c.Consume(ctx, msg.Header("type"), msg.Data); err != nil {
+//	        // retry or nack
+//	    }
+//	}
+//
+// The package doesn't depend on any client, messages are passed as their type and payload.
+package msgvalidator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/pchchv/validator"
+	"github.com/pchchv/validator/adapters"
+)
+
+// DecodeFunc decodes the payload data into dst.
+type DecodeFunc func(data []byte, dst interface{}) error
+
+// Handler handles a valid message, a pointer to a new value of the registered type.
+type Handler func(ctx context.Context, msg interface{}) error
+
+// FailureHandler handles a message failing decoding or validation,
+// e. g. by producing the serialized failure to a dead letter queue.
+type FailureHandler func(ctx context.Context, f *Failure) error
+
+// Failure is a message that failed decoding or validation, serializable as JSON.
+type Failure struct {
+	Type    string                  `json:"type"`             // message type
+	Payload []byte                  `json:"payload"`          // undecoded payload
+	Error   string                  `json:"error"`            // message of the failure
+	Fields  []adapters.FieldMessage `json:"errors,omitempty"` // field messages of validation failures
+	Err     error                   `json:"-"`                // failure, validation failures being *adapters.Error
+}
+
+// route is the registered type and handler of a message type.
+type route struct {
+	typ    reflect.Type
+	handle Handler
+}
+
+// Consumer decodes and validates messages and dispatches them to their handlers.
+type Consumer struct {
+	*adapters.Core
+	Decode    DecodeFunc     // decodes payloads, defaults to json.Unmarshal
+	OnFailure FailureHandler // handles the failures, Consume returns them when nil
+	routes    map[string]route
+}
+
+// New returns a new Consumer validating using v, see adapters.New.
+func New(v *validator.Validate, opts ...adapters.Option) *Consumer {
+	return &Consumer{Core: adapters.New(v, opts...), Decode: json.Unmarshal, routes: make(map[string]route)}
+}
+
+// Register registers the struct type of t and the handler of the messages of type msgType.
+//
+// NOTE: this method is not thread-safe it is intended that these all be registered prior to any consuming.
+func (c *Consumer) Register(msgType string, t interface{}, h Handler) {
+	typ := reflect.TypeOf(t)
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ == nil || typ.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("msgvalidator: type of '%s' must be a struct, got %T", msgType, t))
+	}
+	c.routes[msgType] = route{typ: typ, handle: h}
+}
+
+// Consume decodes the payload data into a new value of the type registered for msgType,
+// validates it using ctx and passes it to the registered handler.
+// Messages of unregistered types, or failing decoding or validation, are passed to OnFailure,
+// whose error is returned, e. g. nil once the message is produced to a dead letter queue.
+// Otherwise the error of the handler is returned.
+func (c *Consumer) Consume(ctx context.Context, msgType string, data []byte) error {
+	r, ok := c.routes[msgType]
+	if !ok {
+		return c.fail(ctx, msgType, data, fmt.Errorf("msgvalidator: no type registered for '%s'", msgType))
+	}
+
+	msg := reflect.New(r.typ).Interface()
+	if err := c.Decode(data, msg); err != nil {
+		return c.fail(ctx, msgType, data, err)
+	}
+
+	if err := c.ValidateCtx(ctx, msg); err != nil {
+		return c.fail(ctx, msgType, data, err)
+	}
+	return r.handle(ctx, msg)
+}
+
+// fail passes the failure err of the message to OnFailure, returning err when it's nil.
+func (c *Consumer) fail(ctx context.Context, msgType string, data []byte, err error) error {
+	if c.OnFailure == nil {
+		return err
+	}
+
+	f := &Failure{Type: msgType, Payload: data, Error: err.Error(), Err: err}
+	var e *adapters.Error
+	if errors.As(err, &e) {
+		f.Fields = e.Fields
+	}
+	return c.OnFailure(ctx, f)
+}
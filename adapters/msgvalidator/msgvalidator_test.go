@@ -0,0 +1,63 @@
+package msgvalidator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/pchchv/go-assert"
+	"github.com/pchchv/validator/adapters"
+)
+
+type userCreated struct {
+	ID    int    `json:"id" validate:"required"`
+	Email string `json:"email" validate:"required,email"`
+}
+
+func TestConsume(t *testing.T) {
+	c := New(nil)
+	var handled []*userCreated
+	c.Register("user.created", &userCreated{}, func(ctx context.Context, msg interface{}) error {
+		handled = append(handled, msg.(*userCreated))
+		return nil
+	})
+
+	assert.Equal(t, nil, c.Consume(context.Background(), "user.created", []byte(`{"id":1,"email":"joey@example.com"}`)))
+	assert.Equal(t, 1, len(handled))
+	assert.Equal(t, "joey@example.com", handled[0].Email)
+
+	err := c.Consume(context.Background(), "user.created", []byte(`{"id":2,"email":"joey"}`))
+	var e *adapters.Error
+	assert.Equal(t, true, errors.As(err, &e))
+	assert.Equal(t, "userCreated.email", e.Fields[0].Namespace)
+
+	err = c.Consume(context.Background(), "user.deleted", []byte(`{}`))
+	assert.Equal(t, "msgvalidator: no type registered for 'user.deleted'", err.Error())
+	assert.Equal(t, 1, len(handled))
+}
+
+func TestConsumeFailures(t *testing.T) {
+	c := New(nil)
+	var dlq []string
+	c.OnFailure = func(ctx context.Context, f *Failure) error {
+		b, err := json.Marshal(f)
+		dlq = append(dlq, string(b))
+		return err
+	}
+	c.Register("user.created", userCreated{}, func(ctx context.Context, msg interface{}) error {
+		return errors.New("unexpected")
+	})
+
+	assert.Equal(t, nil, c.Consume(context.Background(), "user.created", []byte(`{"email":"joey"}`)))
+	assert.Equal(t, nil, c.Consume(context.Background(), "user.created", []byte(`{`)))
+	assert.Equal(t, "unexpected", c.Consume(context.Background(), "user.created", []byte(`{"id":1,"email":"joey@example.com"}`)).Error())
+	assert.Equal(t, []string{
+		`{"type":"user.created","payload":"eyJlbWFpbCI6ImpvZXkifQ==","error":"Key: 'userCreated.id' Error:Field validation for 'id' failed on the 'required' tag\nKey: 'userCreated.email' Error:Field validation for 'email' failed on the 'email' tag","errors":[{"field":"id","namespace":"userCreated.id","tag":"required","code":"VAL_REQUIRED","message":"Key: 'userCreated.id' Error:Field validation for 'id' failed on the 'required' tag"},{"field":"email","namespace":"userCreated.email","tag":"email","code":"VAL_EMAIL","message":"Key: 'userCreated.email' Error:Field validation for 'email' failed on the 'email' tag"}]}`,
+		`{"type":"user.created","payload":"ew==","error":"unexpected end of JSON input"}`,
+	}, dlq)
+}
+
+func TestRegisterPanics(t *testing.T) {
+	assert.PanicMatches(t, func() { New(nil).Register("count", 1, nil) }, "msgvalidator: type of 'count' must be a struct, got int")
+}
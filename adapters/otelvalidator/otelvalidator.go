@@ -0,0 +1,106 @@
+// Package otelvalidator records the validation calls as OpenTelemetry spans and metrics,
+// i. e. their duration, error count and root type name, e. g.
+//
+//	validate := validator.New(otelvalidator.New())
+//
+// using the global tracer and meter providers unless WithTracerProvider or WithMeterProvider are given,
+// see validator.WithHooks.
+package otelvalidator
+
+import (
+	"context"
+
+	"github.com/pchchv/validator"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope name of the tracer and meter.
+const ScopeName = "github.com/pchchv/validator/adapters/otelvalidator"
+
+// Attribute keys of the spans and measurements.
+const (
+	MethodKey = attribute.Key("validation.method") // validation method, e. g. 'Struct'
+	TypeKey   = attribute.Key("validation.type")   // name of the root type, e. g. 'User'
+	ErrorsKey = attribute.Key("validation.errors") // number of errors, set on spans only
+)
+
+// Names of the instruments.
+const (
+	DurationName = "validation.duration" // histogram of the durations of the calls, in seconds
+	ErrorsName   = "validation.errors"   // counter of the errors of the calls
+)
+
+// Option configures New.
+type Option func(*config)
+
+type config struct {
+	tp trace.TracerProvider
+	mp metric.MeterProvider
+}
+
+// WithTracerProvider sets the TracerProvider of the spans, the global one by default.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tp = tp
+	}
+}
+
+// WithMeterProvider sets the MeterProvider of the measurements, the global one by default.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) {
+		c.mp = mp
+	}
+}
+
+// spanKey is the context key of the span of a validation call.
+type spanKey struct{}
+
+// New returns the validator.Option tracing the validation calls and recording their duration and errors.
+// The spans of the calls returning an error other than ValidationErrors,
+// e. g. InvalidValidationError, record the error.
+func New(opts ...Option) validator.Option {
+	c := config{tp: otel.GetTracerProvider(), mp: otel.GetMeterProvider()}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	tracer := c.tp.Tracer(ScopeName)
+	meter := c.mp.Meter(ScopeName)
+	duration, err := meter.Float64Histogram(DurationName, metric.WithUnit("s"), metric.WithDescription("Duration of the validation calls."))
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	failures, err := meter.Int64Counter(ErrorsName, metric.WithUnit("{error}"), metric.WithDescription("Errors of the validation calls."))
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	before := func(ctx context.Context, call validator.ValidationCall) context.Context {
+		ctx, span := tracer.Start(ctx, "validator."+call.Method)
+		return context.WithValue(ctx, spanKey{}, span)
+	}
+
+	return validator.WithHooks(before, func(ctx context.Context, call validator.ValidationCall) {
+		attrs := []attribute.KeyValue{MethodKey.String(call.Method), TypeKey.String(call.TypeName())}
+		duration.Record(ctx, call.Duration.Seconds(), metric.WithAttributes(attrs...))
+		failures.Add(ctx, int64(call.Errors), metric.WithAttributes(attrs...))
+
+		span, ok := ctx.Value(spanKey{}).(trace.Span)
+		if !ok {
+			return
+		}
+
+		span.SetAttributes(append(attrs, ErrorsKey.Int(call.Errors))...)
+		// failed validations aren't span errors
+		if call.Err != nil && call.Errors == 0 {
+			span.RecordError(call.Err)
+			span.SetStatus(codes.Error, call.Err.Error())
+		}
+		span.End()
+	})
+}
@@ -0,0 +1,75 @@
+package otelvalidator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pchchv/go-assert"
+	"github.com/pchchv/validator"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type user struct {
+	Name  string `validate:"required"`
+	Email string `validate:"required,email"`
+}
+
+func TestNew(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	now := time.Unix(0, 0)
+	validate := validator.New(validator.WithClock(func() time.Time {
+		now = now.Add(time.Millisecond)
+		return now
+	}), New(
+		WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))),
+		WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+	))
+
+	assert.Equal(t, nil, validate.Struct(&user{Name: "joey", Email: "joey@example.com"}))
+	assert.NotEqual(t, nil, validate.Struct(user{}))
+	assert.NotEqual(t, nil, validate.Struct("joey"))
+
+	ended := spans.Ended()
+	assert.Equal(t, 3, len(ended))
+	assert.Equal(t, "validator.Struct", ended[0].Name())
+	assert.Equal(t, []attribute.KeyValue{MethodKey.String("Struct"), TypeKey.String("user"), ErrorsKey.Int(0)}, ended[0].Attributes())
+	assert.Equal(t, []attribute.KeyValue{MethodKey.String("Struct"), TypeKey.String("user"), ErrorsKey.Int(2)}, ended[1].Attributes())
+	// failed validations aren't span errors, invalid calls are
+	assert.Equal(t, codes.Unset, ended[1].Status().Code)
+	assert.Equal(t, codes.Error, ended[2].Status().Code)
+	assert.Equal(t, "validator: (nil string)", ended[2].Status().Description)
+	assert.Equal(t, 1, len(ended[2].Events()))
+
+	var rm metricdata.ResourceMetrics
+	assert.Equal(t, nil, reader.Collect(context.Background(), &rm))
+	assert.Equal(t, 1, len(rm.ScopeMetrics))
+	assert.Equal(t, ScopeName, rm.ScopeMetrics[0].Scope.Name)
+
+	userSet := attribute.NewSet(MethodKey.String("Struct"), TypeKey.String("user"))
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		switch m.Name {
+		case DurationName:
+			for _, dp := range m.Data.(metricdata.Histogram[float64]).DataPoints {
+				if dp.Attributes.Equals(&userSet) {
+					assert.Equal(t, uint64(2), dp.Count)
+					assert.Equal(t, 0.002, dp.Sum)
+				}
+			}
+		case ErrorsName:
+			for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				if dp.Attributes.Equals(&userSet) {
+					assert.Equal(t, int64(2), dp.Value)
+				}
+			}
+		default:
+			t.Fatalf("unexpected metric %s", m.Name)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// Package validatorhttp provides net/http middleware decoding request bodies into a type,
+// validating them and passing them to the next handler, e. g.
+//
+//	v := validatorhttp.New(nil)
+//	mux.Handle("POST /users", validatorhttp.Middleware[User](v)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+//	    user, _ := validatorhttp.Body[User](r)
+//	    // ...
+//	})))
+//
+// Validation failures are written with the status 422 Unprocessable Entity and their field messages,
+// the decoding and the responses can be customized using the Decode and EncodeError hooks.
+package validatorhttp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/pchchv/validator"
+	"github.com/pchchv/validator/adapters"
+)
+
+// DecodeFunc decodes the body of r into dst.
+type DecodeFunc func(r *http.Request, dst interface{}) error
+
+// EncodeErrorFunc writes the response of a request whose body failed decoding or validation,
+// validation failures being *adapters.Error.
+type EncodeErrorFunc func(w http.ResponseWriter, r *http.Request, err error)
+
+// bodyKey is the context key of the decoded body of type T.
+type bodyKey[T any] struct{}
+
+// Validator decodes and validates request bodies.
+type Validator struct {
+	*adapters.Core
+	Decode      DecodeFunc      // decodes request bodies, defaults to DecodeJSON
+	EncodeError EncodeErrorFunc // writes the failure responses, defaults to WriteError
+}
+
+// New returns a new Validator validating using v, see adapters.New.
+func New(v *validator.Validate, opts ...adapters.Option) *Validator {
+	return &Validator{Core: adapters.New(v, opts...), Decode: DecodeJSON, EncodeError: WriteError}
+}
+
+// Middleware returns middleware decoding the body of requests into a new T and validating it
+// using the request's context, see adapters.Core.ValidateCtx.
+// Valid bodies are passed to next within the request's context, see Body,
+// otherwise the failure is written using the Validator's EncodeError hook and next isn't called.
+func Middleware[T any](v *Validator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body := new(T)
+			err := v.Decode(r, body)
+			if err == nil {
+				err = v.ValidateCtx(r.Context(), body)
+			}
+
+			if err != nil {
+				v.EncodeError(w, r, err)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), bodyKey[T]{}, body)))
+		})
+	}
+}
+
+// Body returns the body of type T decoded and validated by Middleware, if any.
+func Body[T any](r *http.Request) (*T, bool) {
+	body, ok := r.Context().Value(bodyKey[T]{}).(*T)
+	return body, ok
+}
+
+// DecodeJSON decodes the JSON body of r into dst.
+func DecodeJSON(r *http.Request, dst interface{}) error {
+	return json.NewDecoder(r.Body).Decode(dst)
+}
+
+// WriteError writes err as a JSON response, see adapters.WriteError.
+func WriteError(w http.ResponseWriter, _ *http.Request, err error) {
+	adapters.WriteError(w, err)
+}
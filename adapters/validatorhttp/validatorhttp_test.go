@@ -0,0 +1,76 @@
+package validatorhttp
+
+import (
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pchchv/go-assert"
+	"github.com/pchchv/validator/adapters"
+)
+
+type user struct {
+	Name string `json:"name" xml:"name" validate:"required"`
+	Age  int    `json:"age" xml:"age" validate:"gte=18"`
+}
+
+func TestMiddleware(t *testing.T) {
+	v := New(nil)
+	handler := Middleware[user](v)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		u, ok := Body[user](r)
+		assert.Equal(t, true, ok)
+		assert.Equal(t, "joey", u.Name)
+
+		_, ok = Body[struct{}](r)
+		assert.Equal(t, false, ok)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	tests := []struct {
+		body   string
+		status int
+		resp   string
+	}{
+		{`{"name":"joey","age":30}`, http.StatusNoContent, ""},
+		{`{"age":3}`, http.StatusUnprocessableEntity, `"namespace":"user.age","tag":"gte","code":"VAL_GTE","param":"18"`},
+		{`{`, http.StatusBadRequest, `{"error":"unexpected EOF"}`},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(test.body)))
+		assert.Equal(t, test.status, w.Code)
+		assert.Equal(t, true, strings.Contains(w.Body.String(), test.resp))
+	}
+}
+
+func TestMiddlewareHooks(t *testing.T) {
+	v := New(nil)
+	v.Decode = func(r *http.Request, dst interface{}) error {
+		return xml.NewDecoder(r.Body).Decode(dst)
+	}
+	v.EncodeError = func(w http.ResponseWriter, r *http.Request, err error) {
+		var e *adapters.Error
+		if errors.As(err, &e) {
+			http.Error(w, e.Fields[0].Namespace, http.StatusTeapot)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	}
+
+	handler := Middleware[user](v)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`<user><name>joey</name><age>30</age></user>`)))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`<user><age>30</age></user>`)))
+	assert.Equal(t, http.StatusTeapot, w.Code)
+	assert.Equal(t, "user.name\n", w.Body.String())
+}
@@ -0,0 +1,137 @@
+package validator
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// AuditedField is a struct field traversed during a validation call with an Audit.
+type AuditedField struct {
+	// Namespace is the namespace of the field,
+	// with the tag name taking precedence over the field's actual name.
+	Namespace string
+	// Present reports whether the field holds a non-nil, non-zero value.
+	Present bool
+	// Hash is the salted hash of the value of a present field marked with the sensitive tag,
+	// e. g. "hmac-sha256:2cf24dba…", "" otherwise.
+	Hash string
+}
+
+// Audit holds the fields traversed by a validation call,
+// allowing compliance logging without a second reflection pass and without exposing values.
+//
+// NOTE: an Audit is not safe for concurrent validation calls.
+type Audit struct {
+	Fields []AuditedField
+	salt   []byte
+}
+
+// NewAuditContext returns a context that audits the struct fields traversed by the validation calls it's passed to,
+// the values of the fields marked with the sensitive tag being hashed using HMAC-SHA256 keyed by salt, e. g.
+//
+//	type User struct {
+//	    Email string `validate:"sensitive,required,email"`
+//	}
+//
+//	ctx, audit := validator.NewAuditContext(ctx, salt)
+//	err := validate.StructCtx(ctx, user)
+//	log.Println(audit)
+//
+// Fields skipped by the '-' tag or excluded by StructPartial, StructExcept and StructFiltered aren't audited.
+func NewAuditContext(parent context.Context, salt []byte) (context.Context, *Audit) {
+	audit := &Audit{salt: salt}
+	return withCallState(parent, func(cs *callState) { cs.audit = audit }), audit
+}
+
+// AuditFromContext returns the Audit of the context, if any.
+func AuditFromContext(ctx context.Context) (*Audit, bool) {
+	cs, _ := ctx.Value(callStateKey{}).(*callState)
+	if cs == nil || cs.audit == nil {
+		return nil, false
+	}
+	return cs.audit, true
+}
+
+// String returns one line per audited field.
+func (a *Audit) String() string {
+	var b strings.Builder
+	for i, f := range a.Fields {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+
+		b.WriteString(f.Namespace)
+		if !f.Present {
+			b.WriteString(": absent")
+			continue
+		}
+
+		b.WriteString(": present")
+		if len(f.Hash) > 0 {
+			b.WriteByte(' ')
+			b.WriteString(f.Hash)
+		}
+	}
+
+	return b.String()
+}
+
+// hash returns the salted hash of the bytes of a string or []byte value, or of its fmt.Sprint form.
+func (a *Audit) hash(value interface{}) string {
+	mac := hmac.New(sha256.New, a.salt)
+	switch val := value.(type) {
+	case string:
+		mac.Write([]byte(val))
+	case []byte:
+		mac.Write(val)
+	default:
+		fmt.Fprint(mac, value)
+	}
+	return "hmac-sha256:" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// auditField appends the struct field cf holding current to the Audit of the current validation call, if any.
+func (v *validate) auditField(ns []byte, cf *cField, current reflect.Value) {
+	if v.audit == nil {
+		return
+	}
+
+	current, kind, _ := v.extractTypeInternal(current, false)
+	f := AuditedField{Namespace: string(append(ns, cf.altName...))}
+	switch kind {
+	case reflect.Invalid:
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func:
+		f.Present = !current.IsNil()
+	default:
+		f.Present = !current.IsZero()
+	}
+
+	if f.Present && cf.sensitive {
+		f.Hash = v.audit.hash(getValue(current))
+	}
+	v.audit.Fields = append(v.audit.Fields, f)
+}
+
+// cutSensitive returns tag without the sensitive tags and whether it held any.
+func cutSensitive(tag string) (string, bool) {
+	if !strings.Contains(tag, sensitiveTag) {
+		return tag, false
+	}
+
+	var found bool
+	tags := strings.Split(tag, tagSeparator)
+	for i := 0; i < len(tags); {
+		if tags[i] == sensitiveTag {
+			tags = append(tags[:i], tags[i+1:]...)
+			found = true
+			continue
+		}
+		i++
+	}
+	return strings.Join(tags, tagSeparator), found
+}
@@ -9,33 +9,40 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io/fs"
+	"math"
+	"math/big"
 	"net"
 	"net/mail"
 	"net/url"
-	"os"
+	"path"
 	"reflect"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
-	"syscall"
 	"time"
 	"unicode/utf8"
 
-	"github.com/gabriel-vasile/mimetype"
 	urn "github.com/leodido/go-urn"
 	"golang.org/x/crypto/sha3"
 	"golang.org/x/text/language"
 )
 
 var (
-	errMethodNotFound          = errors.New(`method not found`)
-	errMethodReturnNoValues    = errors.New(`method return o values (void)`)
-	errMethodReturnInvalidType = errors.New(`method should return invalid type`)
-	oneofValsCache             = map[string][]string{}
-	oneofValsCacheRWLock       = sync.RWMutex{}
-	restrictedTags             = map[string]struct{}{
+	oneofValsCache       = map[string][]string{}
+	oneofValsCacheRWLock = sync.RWMutex{}
+	intRangesCache       = map[string][][2]int64{}
+	runeRangesCache      = map[string][][2]rune{}
+	rangesCacheRWLock    = sync.RWMutex{}
+	keyPatternsCache     = map[string]*regexp.Regexp{}
+	keyPatternsRWLock    = sync.RWMutex{}
+	restrictedTags       = map[string]struct{}{
 		diveTag:           {},
+		diveMaxErrsTag:    {},
+		diveParallelTag:   {},
+		strSplitTag:       {},
+		jsonStructTag:     {},
 		keysTag:           {},
 		endKeysTag:        {},
 		structOnlyTag:     {},
@@ -46,190 +53,249 @@ var (
 		utf8HexComma:      {},
 		utf8Pipe:          {},
 		noStructLevelTag:  {},
+		stopChildrenTag:   {},
+		allErrsTag:        {},
+		sensitiveTag:      {},
 		requiredTag:       {},
 		isdefault:         {},
 	}
 	// bakedInAliases is a default mapping of a single validation tag that
 	// defines a common or complex set of validation(s) to simplify adding validation to structs
 	bakedInAliases = map[string]string{
-		"iscolor":         "hexcolor|rgb|rgba|hsl|hsla",
-		"country_code":    "iso3166_1_alpha2|iso3166_1_alpha3|iso3166_1_alpha_numeric",
-		"eu_country_code": "iso3166_1_alpha2_eu|iso3166_1_alpha3_eu|iso3166_1_alpha_numeric_eu",
+		"iscolor":               "hexcolor|rgb|rgba|hsl|hsla",
+		"country_code":          "iso3166_1_alpha2|iso3166_1_alpha3|iso3166_1_alpha_numeric",
+		"eu_country_code":       "iso3166_1_alpha2_eu|iso3166_1_alpha3_eu|iso3166_1_alpha_numeric_eu",
+		"eea_country_code":      "iso3166_1_alpha2_eea|iso3166_1_alpha3_eea|iso3166_1_alpha_numeric_eea",
+		"efta_country_code":     "iso3166_1_alpha2_efta|iso3166_1_alpha3_efta|iso3166_1_alpha_numeric_efta",
+		"schengen_country_code": "iso3166_1_alpha2_schengen|iso3166_1_alpha3_schengen|iso3166_1_alpha_numeric_schengen",
+	}
+	// bakedInSuggestions is the default map of SuggestionFunc,
+	// suggesting the closest allowed value of the tags allowing a list of values
+	bakedInSuggestions = map[string]SuggestionFunc{
+		"oneof":   suggestOneOf,
+		"oneofci": suggestOneOf,
 	}
 	// bakedInValidators is the default map of ValidationFunc
 	// you can add, remove or even replace items to suite your needs,
 	// or even disregard and use your own map if so desired.
 	bakedInValidators = map[string]Func{
-		"required":                      hasValue,
-		"required_if":                   requiredIf,
-		"required_unless":               requiredUnless,
-		"skip_unless":                   skipUnless,
-		"required_with":                 requiredWith,
-		"required_with_all":             requiredWithAll,
-		"required_without":              requiredWithout,
-		"required_without_all":          requiredWithoutAll,
-		"excluded_if":                   excludedIf,
-		"excluded_unless":               excludedUnless,
-		"excluded_with":                 excludedWith,
-		"excluded_with_all":             excludedWithAll,
-		"excluded_without":              excludedWithout,
-		"excluded_without_all":          excludedWithoutAll,
-		"isdefault":                     isDefault,
-		"len":                           hasLengthOf,
-		"min":                           hasMinOf,
-		"max":                           hasMaxOf,
-		"eq":                            isEq,
-		"eq_ignore_case":                isEqIgnoreCase,
-		"ne":                            isNe,
-		"ne_ignore_case":                isNeIgnoreCase,
-		"lt":                            isLt,
-		"lte":                           isLte,
-		"gt":                            isGt,
-		"gte":                           isGte,
-		"eqfield":                       isEqField,
-		"eqcsfield":                     isEqCrossStructField,
-		"necsfield":                     isNeCrossStructField,
-		"gtcsfield":                     isGtCrossStructField,
-		"gtecsfield":                    isGteCrossStructField,
-		"ltcsfield":                     isLtCrossStructField,
-		"ltecsfield":                    isLteCrossStructField,
-		"nefield":                       isNeField,
-		"gtefield":                      isGteField,
-		"gtfield":                       isGtField,
-		"ltefield":                      isLteField,
-		"ltfield":                       isLtField,
-		"fieldcontains":                 fieldContains,
-		"fieldexcludes":                 fieldExcludes,
-		"alpha":                         isAlpha,
-		"alphanum":                      isAlphanum,
-		"alphaunicode":                  isAlphaUnicode,
-		"alphanumunicode":               isAlphanumUnicode,
-		"boolean":                       isBoolean,
-		"numeric":                       isNumeric,
-		"number":                        isNumber,
-		"hexadecimal":                   isHexadecimal,
-		"hexcolor":                      isHEXColor,
-		"rgb":                           isRGB,
-		"rgba":                          isRGBA,
-		"hsl":                           isHSL,
-		"hsla":                          isHSLA,
-		"e164":                          isE164,
-		"email":                         isEmail,
-		"url":                           isURL,
-		"http_url":                      isHttpURL,
-		"uri":                           isURI,
-		"urn_rfc2141":                   isUrnRFC2141, // RFC 2141
-		"file":                          isFile,
-		"filepath":                      isFilePath,
-		"base32":                        isBase32,
-		"base64":                        isBase64,
-		"base64url":                     isBase64URL,
-		"base64rawurl":                  isBase64RawURL,
-		"contains":                      contains,
-		"containsany":                   containsAny,
-		"containsrune":                  containsRune,
-		"excludes":                      excludes,
-		"excludesall":                   excludesAll,
-		"excludesrune":                  excludesRune,
-		"startswith":                    startsWith,
-		"endswith":                      endsWith,
-		"startsnotwith":                 startsNotWith,
-		"endsnotwith":                   endsNotWith,
-		"image":                         isImage,
-		"isbn":                          isISBN,
-		"isbn10":                        isISBN10,
-		"isbn13":                        isISBN13,
-		"issn":                          isISSN,
-		"eth_addr":                      isEthereumAddress,
-		"eth_addr_checksum":             isEthereumAddressChecksum,
-		"btc_addr":                      isBitcoinAddress,
-		"btc_addr_bech32":               isBitcoinBech32Address,
-		"uuid":                          isUUID,
-		"uuid3":                         isUUID3,
-		"uuid4":                         isUUID4,
-		"uuid5":                         isUUID5,
-		"uuid_rfc4122":                  isUUIDRFC4122,
-		"uuid3_rfc4122":                 isUUID3RFC4122,
-		"uuid4_rfc4122":                 isUUID4RFC4122,
-		"uuid5_rfc4122":                 isUUID5RFC4122,
-		"ulid":                          isULID,
-		"md4":                           isMD4,
-		"md5":                           isMD5,
-		"sha256":                        isSHA256,
-		"sha384":                        isSHA384,
-		"sha512":                        isSHA512,
-		"ripemd128":                     isRIPEMD128,
-		"ripemd160":                     isRIPEMD160,
-		"tiger128":                      isTIGER128,
-		"tiger160":                      isTIGER160,
-		"tiger192":                      isTIGER192,
-		"ascii":                         isASCII,
-		"printascii":                    isPrintableASCII,
-		"multibyte":                     hasMultiByteCharacter,
-		"datauri":                       isDataURI,
-		"latitude":                      isLatitude,
-		"longitude":                     isLongitude,
-		"ssn":                           isSSN,
-		"ipv4":                          isIPv4,
-		"ipv6":                          isIPv6,
-		"ip":                            isIP,
-		"cidrv4":                        isCIDRv4,
-		"cidrv6":                        isCIDRv6,
-		"cidr":                          isCIDR,
-		"tcp4_addr":                     isTCP4AddrResolvable,
-		"tcp6_addr":                     isTCP6AddrResolvable,
-		"tcp_addr":                      isTCPAddrResolvable,
-		"udp4_addr":                     isUDP4AddrResolvable,
-		"udp6_addr":                     isUDP6AddrResolvable,
-		"udp_addr":                      isUDPAddrResolvable,
-		"ip4_addr":                      isIP4AddrResolvable,
-		"ip6_addr":                      isIP6AddrResolvable,
-		"ip_addr":                       isIPAddrResolvable,
-		"unix_addr":                     isUnixAddrResolvable,
-		"mac":                           isMAC,
-		"hostname":                      isHostnameRFC952,  // RFC 952
-		"hostname_rfc1123":              isHostnameRFC1123, // RFC 1123
-		"fqdn":                          isFQDN,
-		"unique":                        isUnique,
-		"oneof":                         isOneOf,
-		"oneofci":                       isOneOfCI,
-		"html":                          isHTML,
-		"html_encoded":                  isHTMLEncoded,
-		"url_encoded":                   isURLEncoded,
-		"dir":                           isDir,
-		"dirpath":                       isDirPath,
-		"json":                          isJSON,
-		"jwt":                           isJWT,
-		"hostname_port":                 isHostnamePort,
-		"port":                          isPort,
-		"lowercase":                     isLowercase,
-		"uppercase":                     isUppercase,
-		"datetime":                      isDatetime,
-		"timezone":                      isTimeZone,
-		"iso3166_1_alpha2":              isIso3166Alpha2,
-		"iso3166_1_alpha2_eu":           isIso3166Alpha2EU,
-		"iso3166_1_alpha3":              isIso3166Alpha3,
-		"iso3166_1_alpha3_eu":           isIso3166Alpha3EU,
-		"iso3166_1_alpha_numeric":       isIso3166AlphaNumeric,
-		"iso3166_1_alpha_numeric_eu":    isIso3166AlphaNumericEU,
-		"iso3166_2":                     isIso31662,
-		"iso4217":                       isIso4217,
-		"iso4217_numeric":               isIso4217Numeric,
-		"bcp47_language_tag":            isBCP47LanguageTag,
-		"postcode_iso3166_alpha2":       isPostcodeByIso3166Alpha2,
-		"postcode_iso3166_alpha2_field": isPostcodeByIso3166Alpha2Field,
-		"bic":                           isIsoBicFormat,
-		"semver":                        isSemverFormat,
-		"dns_rfc1035_label":             isDnsRFC1035LabelFormat,
-		"credit_card":                   isCreditCard,
-		"cve":                           isCveFormat,
-		"luhn_checksum":                 hasLuhnChecksum,
-		"mongodb":                       isMongoDBObjectId,
-		"mongodb_connection_string":     isMongoDBConnectionString,
-		"cron":                          isCron,
-		"spicedb":                       isSpiceDB,
-		"ein":                           isEIN,
-		"validateFn":                    isValidateFn,
+		"required":                         hasValue,
+		"required_if":                      requiredIf,
+		"required_unless":                  requiredUnless,
+		"skip_unless":                      skipUnless,
+		"required_with":                    requiredWith,
+		"required_with_all":                requiredWithAll,
+		"required_without":                 requiredWithout,
+		"required_without_all":             requiredWithoutAll,
+		"excluded_if":                      excludedIf,
+		"excluded_unless":                  excludedUnless,
+		"excluded_with":                    excludedWith,
+		"excluded_with_all":                excludedWithAll,
+		"excluded_without":                 excludedWithout,
+		"excluded_without_all":             excludedWithoutAll,
+		"xor":                              isXor,
+		"iff":                              isIff,
+		"min_set":                          isMinSet,
+		"max_set":                          isMaxSet,
+		"isdefault":                        isDefault,
+		"len":                              hasLengthOf,
+		"min":                              hasMinOf,
+		"max":                              hasMaxOf,
+		"eq":                               isEq,
+		"eq_ignore_case":                   isEqIgnoreCase,
+		"ne":                               isNe,
+		"ne_ignore_case":                   isNeIgnoreCase,
+		"lt":                               isLt,
+		"lte":                              isLte,
+		"gt":                               isGt,
+		"gte":                              isGte,
+		"gt_numstr":                        isGtNumStr,
+		"gte_numstr":                       isGteNumStr,
+		"lt_numstr":                        isLtNumStr,
+		"lte_numstr":                       isLteNumStr,
+		"multiple_of":                      isMultipleOf,
+		"step":                             isStep,
+		"finite":                           isFinite,
+		"not_nan":                          isNotNaN,
+		"not_inf":                          isNotInf,
+		"has_flags":                        hasFlags,
+		"no_flags":                         hasNoFlags,
+		"valid_mask":                       isValidMask,
+		"in_ranges":                        isInRanges,
+		"eqfield":                          isEqField,
+		"eqcsfield":                        isEqCrossStructField,
+		"necsfield":                        isNeCrossStructField,
+		"gtcsfield":                        isGtCrossStructField,
+		"gtecsfield":                       isGteCrossStructField,
+		"ltcsfield":                        isLtCrossStructField,
+		"ltecsfield":                       isLteCrossStructField,
+		"nefield":                          isNeField,
+		"gtefield":                         isGteField,
+		"gtfield":                          isGtField,
+		"ltefield":                         isLteField,
+		"ltfield":                          isLtField,
+		"gtprevfield":                      isGtPrevField,
+		"gteprevfield":                     isGtePrevField,
+		"ltprevfield":                      isLtPrevField,
+		"lteprevfield":                     isLtePrevField,
+		"after":                            isAfter,
+		"after_eq":                         isAfterEq,
+		"before":                           isBefore,
+		"before_eq":                        isBeforeEq,
+		"betweenfields":                    isBetweenFields,
+		"between_fields":                   isBetweenFields,
+		"eq_approx":                        isEqApprox,
+		"eqfield_approx":                   isEqFieldApprox,
+		"future":                           isFuture,
+		"past":                             isPast,
+		"future_within":                    isFutureWithin,
+		"past_within":                      isPastWithin,
+		"fieldcontains":                    fieldContains,
+		"fieldexcludes":                    fieldExcludes,
+		"not_similar_to":                   isNotSimilarTo,
+		"same_host":                        isSameHost,
+		"same_origin":                      isSameOrigin,
+		"subpath_of":                       isSubpathOf,
+		"levenshtein_lte":                  isLevenshteinLte,
+		"levenshtein_gt":                   isLevenshteinGt,
+		"alpha":                            isAlpha,
+		"alphanum":                         isAlphanum,
+		"alphaunicode":                     isAlphaUnicode,
+		"alphanumunicode":                  isAlphanumUnicode,
+		"boolean":                          isBoolean,
+		"numeric":                          isNumeric,
+		"number":                           isNumber,
+		"hexadecimal":                      isHexadecimal,
+		"hexcolor":                         isHEXColor,
+		"rgb":                              isRGB,
+		"rgba":                             isRGBA,
+		"hsl":                              isHSL,
+		"hsla":                             isHSLA,
+		"e164":                             isE164,
+		"email":                            isEmail,
+		"url":                              isURL,
+		"http_url":                         isHttpURL,
+		"uri":                              isURI,
+		"urn_rfc2141":                      isUrnRFC2141, // RFC 2141
+		"base32":                           isBase32,
+		"base64":                           isBase64,
+		"base64url":                        isBase64URL,
+		"base64rawurl":                     isBase64RawURL,
+		"contains":                         contains,
+		"containsany":                      containsAny,
+		"containsrune":                     containsRune,
+		"excludes":                         excludes,
+		"excludesall":                      excludesAll,
+		"excludesrune":                     excludesRune,
+		"startswith":                       startsWith,
+		"endswith":                         endsWith,
+		"startsnotwith":                    startsNotWith,
+		"endsnotwith":                      endsNotWith,
+		"isbn":                             isISBN,
+		"isbn10":                           isISBN10,
+		"isbn13":                           isISBN13,
+		"issn":                             isISSN,
+		"eth_addr":                         isEthereumAddress,
+		"eth_addr_checksum":                isEthereumAddressChecksum,
+		"btc_addr":                         isBitcoinAddress,
+		"btc_addr_bech32":                  isBitcoinBech32Address,
+		"uuid":                             isUUID,
+		"uuid3":                            isUUID3,
+		"uuid4":                            isUUID4,
+		"uuid5":                            isUUID5,
+		"uuid_rfc4122":                     isUUIDRFC4122,
+		"uuid3_rfc4122":                    isUUID3RFC4122,
+		"uuid4_rfc4122":                    isUUID4RFC4122,
+		"uuid5_rfc4122":                    isUUID5RFC4122,
+		"ulid":                             isULID,
+		"md4":                              isMD4,
+		"md5":                              isMD5,
+		"sha256":                           isSHA256,
+		"sha384":                           isSHA384,
+		"sha512":                           isSHA512,
+		"ripemd128":                        isRIPEMD128,
+		"ripemd160":                        isRIPEMD160,
+		"tiger128":                         isTIGER128,
+		"tiger160":                         isTIGER160,
+		"tiger192":                         isTIGER192,
+		"ascii":                            isASCII,
+		"printascii":                       isPrintableASCII,
+		"ascii_printable":                  isASCIIPrintable,
+		"valid_utf8":                       isValidUTF8,
+		"latin1":                           isLatin1,
+		"multibyte":                        hasMultiByteCharacter,
+		"datauri":                          isDataURI,
+		"latitude":                         isLatitude,
+		"longitude":                        isLongitude,
+		"ssn":                              isSSN,
+		"ipv4":                             isIPv4,
+		"ipv6":                             isIPv6,
+		"ip":                               isIP,
+		"cidrv4":                           isCIDRv4,
+		"cidrv6":                           isCIDRv6,
+		"cidr":                             isCIDR,
+		"unix_addr":                        isUnixAddrResolvable,
+		"mac":                              isMAC,
+		"hostname":                         isHostnameRFC952,  // RFC 952
+		"hostname_rfc1123":                 isHostnameRFC1123, // RFC 1123
+		"fqdn":                             isFQDN,
+		"unique":                           isUnique,
+		"rows_eq":                          hasRowsEqual,
+		"cols_eq":                          hasColsEqual,
+		"rectangular":                      isRectangular,
+		"maxkeys_prefix":                   hasMaxKeysPrefix,
+		"key_pattern":                      hasKeyPattern,
+		"oneof":                            isOneOf,
+		"oneofci":                          isOneOfCI,
+		"html":                             isHTML,
+		"html_encoded":                     isHTMLEncoded,
+		"url_encoded":                      isURLEncoded,
+		"json":                             isJSON,
+		"jwt":                              isJWT,
+		"oauth_scopes":                     isOAuthScopes,
+		"bearer_token":                     isBearerToken,
+		"hostname_port":                    isHostnamePort,
+		"port":                             isPort,
+		"port_range":                       isPortRange,
+		"hostport_list":                    isHostnamePortList,
+		"url_list":                         isURLList,
+		"lowercase":                        isLowercase,
+		"uppercase":                        isUppercase,
+		"datetime":                         isDatetime,
+		"timezone":                         isTimeZone,
+		"iso3166_1_alpha2":                 isIso3166Alpha2,
+		"iso3166_1_alpha2_eu":              isIso3166Alpha2InGroup(countryGroupEU),
+		"iso3166_1_alpha2_eea":             isIso3166Alpha2InGroup(countryGroupEEA),
+		"iso3166_1_alpha2_efta":            isIso3166Alpha2InGroup(countryGroupEFTA),
+		"iso3166_1_alpha2_schengen":        isIso3166Alpha2InGroup(countryGroupSchengen),
+		"iso3166_1_alpha3":                 isIso3166Alpha3,
+		"iso3166_1_alpha3_eu":              isIso3166Alpha3InGroup(countryGroupEU),
+		"iso3166_1_alpha3_eea":             isIso3166Alpha3InGroup(countryGroupEEA),
+		"iso3166_1_alpha3_efta":            isIso3166Alpha3InGroup(countryGroupEFTA),
+		"iso3166_1_alpha3_schengen":        isIso3166Alpha3InGroup(countryGroupSchengen),
+		"iso3166_1_alpha_numeric":          isIso3166AlphaNumeric,
+		"iso3166_1_alpha_numeric_eu":       isIso3166AlphaNumericInGroup(countryGroupEU),
+		"iso3166_1_alpha_numeric_eea":      isIso3166AlphaNumericInGroup(countryGroupEEA),
+		"iso3166_1_alpha_numeric_efta":     isIso3166AlphaNumericInGroup(countryGroupEFTA),
+		"iso3166_1_alpha_numeric_schengen": isIso3166AlphaNumericInGroup(countryGroupSchengen),
+		"iso3166_2":                        isIso31662,
+		"country_group":                    isInCountryGroup,
+		"iso4217":                          isIso4217,
+		"iso4217_numeric":                  isIso4217Numeric,
+		"bcp47_language_tag":               isBCP47LanguageTag,
+		"postcode_iso3166_alpha2":          isPostcodeByIso3166Alpha2,
+		"postcode_iso3166_alpha2_field":    isPostcodeByIso3166Alpha2Field,
+		"bic":                              isIsoBicFormat,
+		"semver":                           isSemverFormat,
+		"dns_rfc1035_label":                isDnsRFC1035LabelFormat,
+		"credit_card":                      isCreditCard,
+		"cve":                              isCveFormat,
+		"luhn_checksum":                    hasLuhnChecksum,
+		"mongodb":                          isMongoDBObjectId,
+		"mongodb_connection_string":        isMongoDBConnectionString,
+		"cron":                             isCron,
+		"spicedb":                          isSpiceDB,
+		"ein":                              isEIN,
 	}
 )
 
@@ -417,6 +483,62 @@ func requiredWithoutAll(fl FieldLevel) bool {
 	return hasValue(fl)
 }
 
+// isXor is the validation function.
+// Exactly one of the field under validation and the other specified field must be present.
+func isXor(fl FieldLevel) bool {
+	return hasValue(fl) == requireCheckFieldKind(fl, fl.Param(), true)
+}
+
+// isIff is the validation function.
+// The field under validation and the other specified field must both be present or both be empty.
+func isIff(fl FieldLevel) bool {
+	return hasValue(fl) != requireCheckFieldKind(fl, fl.Param(), true)
+}
+
+// isMinSet is the validation function.
+// At least N of the other specified fields must be present, e. g. 'min_set=2 of=Email Phone Address'.
+func isMinSet(fl FieldLevel) bool {
+	n, fields := parseSetParam(fl)
+	return countPresent(fl, fields) >= n
+}
+
+// isMaxSet is the validation function.
+// At most N of the other specified fields may be present, e. g. 'max_set=1 of=Card IBAN'.
+func isMaxSet(fl FieldLevel) bool {
+	n, fields := parseSetParam(fl)
+	return countPresent(fl, fields) <= n
+}
+
+// parseSetParam returns the cardinality and the fields of a min_set or max_set param,
+// e. g. '2 of=Email Phone Address', the 'of=' prefix of the fields being optional.
+func parseSetParam(fl FieldLevel) (int, []string) {
+	params := parseOneOfParam(fl.Param())
+	if len(params) < 2 {
+		panic(fmt.Sprintf("Bad param '%s' for '%s'", fl.Param(), fl.GetTag()))
+	}
+
+	n, err := strconv.Atoi(params[0])
+	if err != nil || n < 0 {
+		panic(fmt.Sprintf("Bad param '%s' for '%s'", fl.Param(), fl.GetTag()))
+	}
+
+	fields := params[1:]
+	if first, ok := strings.CutPrefix(fields[0], "of="); ok {
+		fields = append([]string{first}, fields[1:]...)
+	}
+	return n, fields
+}
+
+// countPresent returns the number of the specified fields that are present.
+func countPresent(fl FieldLevel, fields []string) (n int) {
+	for _, field := range fields {
+		if len(field) > 0 && !requireCheckFieldKind(fl, field, true) {
+			n++
+		}
+	}
+	return
+}
+
 // digitsHaveLuhnChecksum returns true if and only if the last element of the
 // given digits slice is the Luhn checksum of the previous elements.
 func digitsHaveLuhnChecksum(digits []string) bool {
@@ -545,7 +667,7 @@ func hasLengthOf(fl FieldLevel) bool {
 		p := asInt(param)
 		return int64(utf8.RuneCountInString(field.String())) == p
 	case reflect.Slice, reflect.Map, reflect.Array:
-		p := asInt(param)
+		p := asIntFromType(field.Type(), param)
 		return int64(field.Len()) == p
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		p := asIntFromType(field.Type(), param)
@@ -588,6 +710,100 @@ func isOneOf(fl FieldLevel) bool {
 	return false
 }
 
+// isInRanges is the validation function for validating if the current field's integer value
+// is within one of the space separated ranges or values of the param, e.g. 'in_ranges=1-5 10-15 100',
+// or if every rune of a string field is, e.g. 'in_ranges=a-z A-Z _'.
+func isInRanges(fl FieldLevel) bool {
+	field := fl.Field()
+	switch field.Kind() {
+	case reflect.String:
+		ranges := parseRuneRanges(fl.Param())
+	RUNES:
+		for _, r := range field.String() {
+			for _, rng := range ranges {
+				if r >= rng[0] && r <= rng[1] {
+					continue RUNES
+				}
+			}
+			return false
+		}
+		return true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return inIntRanges(parseIntRanges(fl.Param()), field.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u := field.Uint()
+		return u <= math.MaxInt64 && inIntRanges(parseIntRanges(fl.Param()), int64(u))
+	}
+
+	panic(fmt.Sprintf("Bad field type %T", field.Interface()))
+}
+
+func inIntRanges(ranges [][2]int64, i int64) bool {
+	for _, rng := range ranges {
+		if i >= rng[0] && i <= rng[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// parseIntRanges parses the integer ranges of the in_ranges param.
+func parseIntRanges(s string) [][2]int64 {
+	rangesCacheRWLock.RLock()
+	ranges, ok := intRangesCache[s]
+	rangesCacheRWLock.RUnlock()
+	if ok {
+		return ranges
+	}
+
+	for _, f := range strings.Fields(s) {
+		lo, hi := f, f
+		// the first character may be the sign of the lower bound
+		if i := strings.IndexByte(f[1:], '-'); i != -1 {
+			lo, hi = f[:i+1], f[i+2:]
+		}
+
+		rng := [2]int64{asInt(lo), asInt(hi)}
+		if rng[0] > rng[1] {
+			panic(fmt.Sprintf("Bad range '%s' for 'in_ranges'", f))
+		}
+		ranges = append(ranges, rng)
+	}
+
+	rangesCacheRWLock.Lock()
+	intRangesCache[s] = ranges
+	rangesCacheRWLock.Unlock()
+	return ranges
+}
+
+// parseRuneRanges parses the rune ranges of the in_ranges param.
+func parseRuneRanges(s string) [][2]rune {
+	rangesCacheRWLock.RLock()
+	ranges, ok := runeRangesCache[s]
+	rangesCacheRWLock.RUnlock()
+	if ok {
+		return ranges
+	}
+
+	for _, f := range strings.Fields(s) {
+		var rng [2]rune
+		switch rs := []rune(f); {
+		case len(rs) == 1:
+			rng = [2]rune{rs[0], rs[0]}
+		case len(rs) == 3 && rs[1] == '-' && rs[0] <= rs[2]:
+			rng = [2]rune{rs[0], rs[2]}
+		default:
+			panic(fmt.Sprintf("Bad range '%s' for 'in_ranges'", f))
+		}
+		ranges = append(ranges, rng)
+	}
+
+	rangesCacheRWLock.Lock()
+	runeRangesCache[s] = ranges
+	rangesCacheRWLock.Unlock()
+	return ranges
+}
+
 // isOneOfCI is the validation function for validating if the
 // current field's value is one of the provided string values
 // (case insensitive).
@@ -608,6 +824,36 @@ func isOneOfCI(fl FieldLevel) bool {
 	return false
 }
 
+// suggestOneOf is the SuggestionFunc of oneof and oneofci suggesting the allowed value
+// closest to the failed string value, case-insensitively,
+// if it's within half the length of the longest of both.
+// Lists of more than maxSuggestionValues values and values longer than maxSuggestionLen aren't compared.
+func suggestOneOf(fe FieldError) string {
+	s, ok := fe.Value().(string)
+	if !ok || len(s) == 0 || utf8.RuneCountInString(s) > maxSuggestionLen {
+		return ""
+	}
+
+	vals := parseOneOfParam(fe.Param())
+	if len(vals) > maxSuggestionValues {
+		return ""
+	}
+
+	s = strings.ToLower(s)
+	best, bestDist := "", -1
+	for _, val := range vals {
+		d := levenshtein(s, strings.ToLower(val))
+		if d*2 > max(utf8.RuneCountInString(s), utf8.RuneCountInString(val)) {
+			continue
+		}
+
+		if bestDist == -1 || d < bestDist {
+			best, bestDist = val, d
+		}
+	}
+	return best
+}
+
 func isHTML(fl FieldLevel) bool {
 	return hTMLRegex().MatchString(fl.Field().String())
 }
@@ -752,6 +998,125 @@ func isUnique(fl FieldLevel) bool {
 	}
 }
 
+// hasRowsEqual is the validation function for validating that a matrix,
+// i. e. a slice or array of slices or arrays, has exactly N rows, e. g. 'rows_eq=3'.
+func hasRowsEqual(fl FieldLevel) bool {
+	return int64(matrixRows(fl.Field()).Len()) == asInt(fl.Param())
+}
+
+// hasColsEqual is the validation function for validating that every row of a matrix has exactly N columns, e. g. 'cols_eq=3'.
+func hasColsEqual(fl FieldLevel) bool {
+	cols := asInt(fl.Param())
+	rows := matrixRows(fl.Field())
+	for i := 0; i < rows.Len(); i++ {
+		if int64(rowLen(rows.Index(i))) != cols {
+			return false
+		}
+	}
+	return true
+}
+
+// isRectangular is the validation function for validating that all the rows of a matrix have the same number of columns.
+func isRectangular(fl FieldLevel) bool {
+	rows := matrixRows(fl.Field())
+	for i := 1; i < rows.Len(); i++ {
+		if rowLen(rows.Index(i)) != rowLen(rows.Index(0)) {
+			return false
+		}
+	}
+	return true
+}
+
+// matrixRows returns field if it's a slice or array of slices or arrays, or of pointers to them.
+func matrixRows(field reflect.Value) reflect.Value {
+	if kind := field.Kind(); kind == reflect.Slice || kind == reflect.Array {
+		row := field.Type().Elem()
+		if row.Kind() == reflect.Ptr {
+			row = row.Elem()
+		}
+
+		if kind := row.Kind(); kind == reflect.Slice || kind == reflect.Array {
+			return field
+		}
+	}
+	panic(fmt.Sprintf("Bad field type %T", field.Interface()))
+}
+
+// rowLen returns the number of columns of a row of a matrix, a nil row having none.
+func rowLen(row reflect.Value) int {
+	if row.Kind() == reflect.Ptr {
+		if row.IsNil() {
+			return 0
+		}
+		row = row.Elem()
+	}
+	return row.Len()
+}
+
+// hasMaxKeysPrefix is the validation function for validating that at most N keys
+// of a map start with the prefix, e. g. 'maxkeys_prefix=label.=10'.
+func hasMaxKeysPrefix(fl FieldLevel) bool {
+	param := fl.Param()
+	i := strings.LastIndexByte(param, '=')
+	if i == -1 {
+		panic(fmt.Sprintf("Bad param '%s' for 'maxkeys_prefix'", param))
+	}
+
+	prefix, limit := param[:i], asInt(param[i+1:])
+	var n int64
+	for _, key := range mapStringKeys(fl.Field()) {
+		if strings.HasPrefix(key, prefix) {
+			n++
+		}
+	}
+	return n <= limit
+}
+
+// hasKeyPattern is the validation function for validating that all the keys
+// of a map match the regular expression, e. g. 'key_pattern=^[a-z0-9.-]+$'.
+func hasKeyPattern(fl FieldLevel) bool {
+	re := parseKeyPattern(fl.Param())
+	for _, key := range mapStringKeys(fl.Field()) {
+		if !re.MatchString(key) {
+			return false
+		}
+	}
+	return true
+}
+
+// mapStringKeys returns the keys of the map field, whose key kind must be string.
+func mapStringKeys(field reflect.Value) []string {
+	if field.Kind() != reflect.Map || field.Type().Key().Kind() != reflect.String {
+		panic(fmt.Sprintf("Bad field type %T", field.Interface()))
+	}
+
+	keys := make([]string, 0, field.Len())
+	for _, k := range field.MapKeys() {
+		keys = append(keys, k.String())
+	}
+	return keys
+}
+
+// parseKeyPattern compiles the regular expression of the key_pattern param.
+func parseKeyPattern(s string) *regexp.Regexp {
+	keyPatternsRWLock.RLock()
+	re, ok := keyPatternsCache[s]
+	keyPatternsRWLock.RUnlock()
+	if ok {
+		return re
+	}
+
+	re, err := regexp.Compile(s)
+	if err != nil {
+		panic(fmt.Sprintf("Bad param '%s' for 'key_pattern'", s))
+	}
+
+	keyPatternsRWLock.Lock()
+	keyPatternsCache[s] = re
+	keyPatternsRWLock.Unlock()
+	return re
+}
+
 // isLongitude is the validation function for validating if the field's value is a valid longitude coordinate.
 func isLongitude(fl FieldLevel) bool {
 	var v string
@@ -819,6 +1184,52 @@ func isPrintableASCII(fl FieldLevel) bool {
 	return printableASCIIRegex().MatchString(fl.Field().String())
 }
 
+// isValidUTF8 is the validation function for validating if the
+// string or []byte field's value is valid UTF-8.
+func isValidUTF8(fl FieldLevel) bool {
+	return utf8.ValidString(fieldText(fl.Field()))
+}
+
+// isASCIIPrintable is the validation function for validating if the
+// string or []byte field's value only contains printable ASCII characters.
+func isASCIIPrintable(fl FieldLevel) bool {
+	text := fieldText(fl.Field())
+	for i := 0; i < len(text); i++ {
+		if text[i] < 0x20 || text[i] > 0x7E {
+			return false
+		}
+	}
+	return true
+}
+
+// isLatin1 is the validation function for validating if the
+// string or []byte field's value is valid UTF-8 encodable in Latin-1 (ISO-8859-1).
+func isLatin1(fl FieldLevel) bool {
+	text := fieldText(fl.Field())
+	if !utf8.ValidString(text) {
+		return false
+	}
+
+	for _, r := range text {
+		if r > 0xFF {
+			return false
+		}
+	}
+	return true
+}
+
+// fieldText returns the text of a string or []byte field.
+func fieldText(field reflect.Value) string {
+	switch {
+	case field.Kind() == reflect.String:
+		return field.String()
+	case field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.Uint8:
+		return string(field.Bytes())
+	default:
+		panic(fmt.Sprintf("Bad field type %T", field.Interface()))
+	}
+}
+
 // isUUID is the validation function for validating if the
 // field's value is a valid UUID of any version.
 func isUUID(fl FieldLevel) bool {
@@ -1135,7 +1546,7 @@ func isEq(fl FieldLevel) bool {
 	case reflect.String:
 		return field.String() == param
 	case reflect.Slice, reflect.Map, reflect.Array:
-		p := asInt(param)
+		p := asIntFromType(field.Type(), param)
 		return int64(field.Len()) == p
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		p := asIntFromType(field.Type(), param)
@@ -1613,200 +2024,6 @@ func isUrnRFC2141(fl FieldLevel) bool {
 	panic(fmt.Sprintf("Bad field type %T", field.Interface()))
 }
 
-// isDir is the validation function for validating if the
-// current field's value is a valid existing directory.
-func isDir(fl FieldLevel) bool {
-	field := fl.Field()
-	if field.Kind() == reflect.String {
-		fileInfo, err := os.Stat(field.String())
-		if err != nil {
-			return false
-		}
-
-		return fileInfo.IsDir()
-	}
-
-	panic(fmt.Sprintf("Bad field type %T", field.Interface()))
-}
-
-// isDirPath is the validation function for validating if the
-// current field's value is a valid directory.
-func isDirPath(fl FieldLevel) bool {
-	var exists bool
-	var err error
-	field := fl.Field()
-	// if it exists, it obviously is valid
-	// this is done first to avoid code duplication and unnecessary additional logic
-	if exists = isDir(fl); exists {
-		return true
-	}
-
-	// it does not exist but may still be a valid path
-	switch field.Kind() {
-	case reflect.String:
-		// every OS allows for whitespace,
-		// but none let you use a dir with no name (to my knowledge)
-		// unless you're dealing with raw inodes, but I digress
-		if strings.TrimSpace(field.String()) == "" {
-			return false
-		}
-
-		if _, err = os.Stat(field.String()); err != nil {
-			switch t := err.(type) {
-			case *fs.PathError:
-				if t.Err == syscall.EINVAL {
-					// it's definitely an invalid character in the path
-					return false
-				}
-				// it could be a permission error, a does-not-exist error, etc.
-				// out-of-scope for this validation, though
-				// lastly, we make sure it is a directory
-				if strings.HasSuffix(field.String(), string(os.PathSeparator)) {
-					return true
-				} else {
-					return false
-				}
-			default:
-				panic(err)
-			}
-		}
-
-		// repeat the check here to make sure it is an explicit directory in case the above os.Stat didn't trigger an error
-		if strings.HasSuffix(field.String(), string(os.PathSeparator)) {
-			return true
-		} else {
-			return false
-		}
-	}
-
-	panic(fmt.Sprintf("Bad field type %T", field.Interface()))
-}
-
-// isFile is the validation function for validating if the
-// current field's value is a valid existing file path.
-func isFile(fl FieldLevel) bool {
-	field := fl.Field()
-	switch field.Kind() {
-	case reflect.String:
-		fileInfo, err := os.Stat(field.String())
-		if err != nil {
-			return false
-		}
-
-		return !fileInfo.IsDir()
-	}
-
-	panic(fmt.Sprintf("Bad field type %T", field.Interface()))
-}
-
-// isFilePath is the validation function for validating if the
-// current field's value is a valid file path.
-func isFilePath(fl FieldLevel) bool {
-	var exists bool
-	var err error
-	field := fl.Field()
-
-	// not valid if it is a directory
-	if isDir(fl) {
-		return false
-	}
-	// if it exists, it obviously is valid
-	// this is done first to avoid code duplication and unnecessary additional logic
-	if exists = isFile(fl); exists {
-		return true
-	}
-
-	// it does not exist but may still be a valid filepath
-	switch field.Kind() {
-	case reflect.String:
-		// every OS allows for whitespace,
-		// but none let you use a file with no filename (to my knowledge)
-		// unless you're dealing with raw inodes, but I digress
-		if strings.TrimSpace(field.String()) == "" {
-			return false
-		}
-		// make sure it isn't a directory
-		if strings.HasSuffix(field.String(), string(os.PathSeparator)) {
-			return false
-		}
-
-		if _, err = os.Stat(field.String()); err != nil {
-			switch t := err.(type) {
-			case *fs.PathError:
-				if t.Err == syscall.EINVAL {
-					// it's definitely an invalid character in the filepath.
-					return false
-				}
-				// it could be a permission error, a does-not-exist error, etc.
-				// out-of-scope for this validation, though
-				return true
-			default:
-				panic(err)
-			}
-		}
-	}
-
-	panic(fmt.Sprintf("Bad field type %T", field.Interface()))
-}
-
-// isImage is the validation function for validating if the
-// current field's value contains the path to a valid image file
-func isImage(fl FieldLevel) bool {
-	field := fl.Field()
-	mimetypes := map[string]bool{
-		"image/bmp":                true,
-		"image/cis-cod":            true,
-		"image/gif":                true,
-		"image/ief":                true,
-		"image/jpeg":               true,
-		"image/jp2":                true,
-		"image/jpx":                true,
-		"image/jpm":                true,
-		"image/pipeg":              true,
-		"image/png":                true,
-		"image/svg+xml":            true,
-		"image/tiff":               true,
-		"image/webp":               true,
-		"image/x-cmu-raster":       true,
-		"image/x-cmx":              true,
-		"image/x-icon":             true,
-		"image/x-portable-anymap":  true,
-		"image/x-portable-bitmap":  true,
-		"image/x-portable-graymap": true,
-		"image/x-portable-pixmap":  true,
-		"image/x-rgb":              true,
-		"image/x-xbitmap":          true,
-		"image/x-xpixmap":          true,
-		"image/x-xwindowdump":      true,
-	}
-	switch field.Kind() {
-	case reflect.String:
-		filePath := field.String()
-		fileInfo, err := os.Stat(filePath)
-		if err != nil || fileInfo.IsDir() {
-			return false
-		}
-
-		file, err := os.Open(filePath)
-		if err != nil {
-			return false
-		}
-		defer func() {
-			_ = file.Close()
-		}()
-
-		mime, err := mimetype.DetectReader(file)
-		if err != nil {
-			return false
-		}
-
-		if _, ok := mimetypes[mime.String()]; ok {
-			return true
-		}
-	}
-	return false
-}
-
 // isE164 is the validation function for validating if the
 // current field's value is a valid e.164 formatted phone number.
 func isE164(fl FieldLevel) bool {
@@ -1934,7 +2151,7 @@ func isGt(fl FieldLevel) bool {
 		p := asInt(param)
 		return int64(utf8.RuneCountInString(field.String())) > p
 	case reflect.Slice, reflect.Map, reflect.Array:
-		p := asInt(param)
+		p := asIntFromType(field.Type(), param)
 		return int64(field.Len()) > p
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		p := asIntFromType(field.Type(), param)
@@ -1950,7 +2167,7 @@ func isGt(fl FieldLevel) bool {
 		return field.Float() > p
 	case reflect.Struct:
 		if field.Type().ConvertibleTo(timeType) {
-			return field.Convert(timeType).Interface().(time.Time).After(time.Now().UTC())
+			return field.Convert(timeType).Interface().(time.Time).After(nowOf(fl))
 		}
 	}
 
@@ -2002,7 +2219,7 @@ func isGte(fl FieldLevel) bool {
 		p := asInt(param)
 		return int64(utf8.RuneCountInString(field.String())) >= p
 	case reflect.Slice, reflect.Map, reflect.Array:
-		p := asInt(param)
+		p := asIntFromType(field.Type(), param)
 		return int64(field.Len()) >= p
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		p := asIntFromType(field.Type(), param)
@@ -2018,7 +2235,7 @@ func isGte(fl FieldLevel) bool {
 		return field.Float() >= p
 	case reflect.Struct:
 		if field.Type().ConvertibleTo(timeType) {
-			now := time.Now().UTC()
+			now := nowOf(fl)
 			t := field.Convert(timeType).Interface().(time.Time)
 			return t.After(now) || t.Equal(now)
 		}
@@ -2062,18 +2279,6 @@ func isGteField(fl FieldLevel) bool {
 	return len(field.String()) >= len(currentField.String())
 }
 
-func isValidateFn(fl FieldLevel) bool {
-	const defaultParam = `Validate`
-	field := fl.Field()
-	validateFn := cmp.Or(fl.Param(), defaultParam)
-	ok, err := tryCallValidateFn(field, validateFn)
-	if err != nil {
-		return false
-	}
-
-	return ok
-}
-
 // isCron is the validation function for validating if the
 // current field's value is a valid cron expression.
 func isCron(fl FieldLevel) bool {
@@ -2098,15 +2303,44 @@ func isJWT(fl FieldLevel) bool {
 	return jWTRegex().MatchString(fl.Field().String())
 }
 
-// isJSON is the validation function for validating if the
-// current field's value is a valid json string.
-func isJSON(fl FieldLevel) bool {
-	field := fl.Field()
-	switch field.Kind() {
-	case reflect.String:
-		val := field.String()
-		return json.Valid([]byte(val))
-	case reflect.Slice:
+// isOAuthScopes is the validation function for validating if the
+// current field's value is a space-delimited list of OAuth 2.0 scope tokens (RFC 6749),
+// each one being in the allowed set of the param, if any, e. g. 'oauth_scopes=openid profile email'.
+func isOAuthScopes(fl FieldLevel) bool {
+	scopes := fl.Field().String()
+	if !oAuthScopesRegex().MatchString(scopes) {
+		return false
+	}
+
+	if len(fl.Param()) == 0 {
+		return true
+	}
+
+	allowed := parseOneOfParam(fl.Param())
+	for _, scope := range strings.Split(scopes, " ") {
+		if !slices.Contains(allowed, scope) {
+			return false
+		}
+	}
+	return true
+}
+
+// isBearerToken is the validation function for validating if the
+// current field's value is shaped like an OAuth 2.0 bearer token (RFC 6750),
+// optionally prefixed by the 'Bearer ' scheme.
+func isBearerToken(fl FieldLevel) bool {
+	return bearerTokenRegex().MatchString(fl.Field().String())
+}
+
+// isJSON is the validation function for validating if the
+// current field's value is a valid json string.
+func isJSON(fl FieldLevel) bool {
+	field := fl.Field()
+	switch field.Kind() {
+	case reflect.String:
+		val := field.String()
+		return json.Valid([]byte(val))
+	case reflect.Slice:
 		fieldType := field.Type()
 		if fieldType.ConvertibleTo(byteSliceType) {
 			b := field.Convert(byteSliceType).Interface().([]byte)
@@ -2120,78 +2354,112 @@ func isJSON(fl FieldLevel) bool {
 // isIso3166Alpha2 is the validation function for validating if the
 // current field's value is a valid iso3166-1 alpha-2 country code.
 func isIso3166Alpha2(fl FieldLevel) bool {
-	_, ok := iso3166_1_alpha2[fl.Field().String()]
+	_, ok := countriesOf(fl).alpha2[fl.Field().String()]
 	return ok
 }
 
-// isIso3166Alpha2EU is the validation function for validating if the
-// current field's value is a valid iso3166-1 alpha-2 European Union country code.
-func isIso3166Alpha2EU(fl FieldLevel) bool {
-	_, ok := iso3166_1_alpha2_eu[fl.Field().String()]
-	return ok
+// isIso3166Alpha2InGroup returns the validation function for validating if the
+// current field's value is a valid iso3166-1 alpha-2 country code of the country group,
+// e. g. of the European Union.
+func isIso3166Alpha2InGroup(group string) Func {
+	return func(fl FieldLevel) bool {
+		_, ok := countryGroupOf(fl, group)[fl.Field().String()]
+		return ok
+	}
 }
 
 // isIso3166Alpha3 is the validation function for validating if the
 // current field's value is a valid iso3166-1 alpha-3 country code.
 func isIso3166Alpha3(fl FieldLevel) bool {
-	_, ok := iso3166_1_alpha3[fl.Field().String()]
+	_, ok := countriesOf(fl).alpha3[fl.Field().String()]
 	return ok
 }
 
-// isIso3166Alpha3EU is the validation function for validating if the
-// current field's value is a valid iso3166-1 alpha-3 European Union country code.
-func isIso3166Alpha3EU(fl FieldLevel) bool {
-	_, ok := iso3166_1_alpha3_eu[fl.Field().String()]
-	return ok
+// isIso3166Alpha3InGroup returns the validation function for validating if the
+// current field's value is a valid iso3166-1 alpha-3 country code of the country group.
+func isIso3166Alpha3InGroup(group string) Func {
+	return func(fl FieldLevel) bool {
+		alpha2, ok := iso3166_1_by_alpha3[fl.Field().String()]
+		if !ok {
+			return false
+		}
+
+		_, ok = countryGroupOf(fl, group)[alpha2]
+		return ok
+	}
 }
 
 // isIso3166AlphaNumeric is the validation function for validating if the
 // current field's value is a valid iso3166-1 alpha-numeric country code.
 func isIso3166AlphaNumeric(fl FieldLevel) bool {
-	var code int
-	field := fl.Field()
+	code, ok := iso3166NumericCode(fl.Field())
+	if !ok {
+		return false
+	}
+
+	_, ok = countriesOf(fl).numeric[code]
+	return ok
+}
+
+// iso3166NumericCode returns the iso3166-1 numeric country code of a string or integer field,
+// and false for the strings that aren't numbers.
+func iso3166NumericCode(field reflect.Value) (int, bool) {
 	switch field.Kind() {
 	case reflect.String:
 		i, err := strconv.Atoi(field.String())
 		if err != nil {
-			return false
+			return 0, false
 		}
 
-		code = i % 1000
+		return i % 1000, true
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		code = int(field.Int() % 1000)
+		return int(field.Int() % 1000), true
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		code = int(field.Uint() % 1000)
+		return int(field.Uint() % 1000), true
 	default:
 		panic(fmt.Sprintf("Bad field type %T", field.Interface()))
 	}
-
-	_, ok := iso3166_1_alpha_numeric[code]
-	return ok
 }
 
-// isIso3166AlphaNumericEU is the validation function for validating if the
-// current field's value is a valid iso3166-1 alpha-numeric European Union country code.
-func isIso3166AlphaNumericEU(fl FieldLevel) bool {
-	var code int
-	field := fl.Field()
-	switch field.Kind() {
-	case reflect.String:
-		i, err := strconv.Atoi(field.String())
-		if err != nil {
+// isIso3166AlphaNumericInGroup returns the validation function for validating if the
+// current field's value is a valid iso3166-1 alpha-numeric country code of the country group.
+func isIso3166AlphaNumericInGroup(group string) Func {
+	return func(fl FieldLevel) bool {
+		code, ok := iso3166NumericCode(fl.Field())
+		if !ok {
 			return false
 		}
 
-		code = i % 1000
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		code = int(field.Int() % 1000)
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		code = int(field.Uint() % 1000)
-	default:
-		panic(fmt.Sprintf("Bad field type %T", field.Interface()))
+		alpha2, ok := iso3166_1_by_numeric[code]
+		if !ok {
+			return false
+		}
+
+		_, ok = countryGroupOf(fl, group)[alpha2]
+		return ok
+	}
+}
+
+// isInCountryGroup is the validation function for validating if the
+// current field's value is an iso3166-1 alpha-2, alpha-3 or numeric country code
+// of the country group specified by the param's value, e. g. `country_group=eea`.
+func isInCountryGroup(fl FieldLevel) bool {
+	members, ok := lookupCountryGroup(fl, fl.Param())
+	if !ok {
+		panic(fmt.Sprintf("Undefined country group '%s' on field '%s'", fl.Param(), fl.FieldName()))
+	}
+
+	var alpha2 string
+	field := fl.Field()
+	if code, numeric := iso3166NumericCode(field); numeric {
+		alpha2 = iso3166_1_by_numeric[code]
+	} else if code := field.String(); len(code) == 2 {
+		alpha2 = code
+	} else {
+		alpha2 = iso3166_1_by_alpha3[code]
 	}
 
-	_, ok := iso3166_1_alpha_numeric_eu[code]
+	_, ok = members[alpha2]
 	return ok
 }
 
@@ -2205,7 +2473,7 @@ func isIso31662(fl FieldLevel) bool {
 // isIso4217 is the validation function for validating if the
 // current field's value is a valid iso4217 currency code.
 func isIso4217(fl FieldLevel) bool {
-	_, ok := iso4217[fl.Field().String()]
+	_, ok := currenciesOf(fl).alpha3[fl.Field().String()]
 	return ok
 }
 
@@ -2223,7 +2491,7 @@ func isIso4217Numeric(fl FieldLevel) bool {
 		panic(fmt.Sprintf("Bad field type %T", field.Interface()))
 	}
 
-	_, ok := iso4217_numeric[code]
+	_, ok := currenciesOf(fl).numeric[code]
 	return ok
 }
 
@@ -2284,7 +2552,7 @@ func isLt(fl FieldLevel) bool {
 		p := asInt(param)
 		return int64(utf8.RuneCountInString(field.String())) < p
 	case reflect.Slice, reflect.Map, reflect.Array:
-		p := asInt(param)
+		p := asIntFromType(field.Type(), param)
 		return int64(field.Len()) < p
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		p := asIntFromType(field.Type(), param)
@@ -2300,7 +2568,7 @@ func isLt(fl FieldLevel) bool {
 		return field.Float() < p
 	case reflect.Struct:
 		if field.Type().ConvertibleTo(timeType) {
-			return field.Convert(timeType).Interface().(time.Time).Before(time.Now().UTC())
+			return field.Convert(timeType).Interface().(time.Time).Before(nowOf(fl))
 		}
 	}
 
@@ -2342,6 +2610,527 @@ func isLtField(fl FieldLevel) bool {
 	return len(field.String()) < len(currentField.String())
 }
 
+// isGtPrevField is the validation function for validating if the current dive element,
+// or the element's field specified by the param's value, is greater than the previous element's.
+func isGtPrevField(fl FieldLevel) bool {
+	return comparePrevElem(fl, func(c int) bool { return c > 0 })
+}
+
+// isGtePrevField is the validation function for validating if the current dive element,
+// or the element's field specified by the param's value, is greater than or equal to the previous element's.
+func isGtePrevField(fl FieldLevel) bool {
+	return comparePrevElem(fl, func(c int) bool { return c >= 0 })
+}
+
+// isLtPrevField is the validation function for validating if the current dive element,
+// or the element's field specified by the param's value, is less than the previous element's.
+func isLtPrevField(fl FieldLevel) bool {
+	return comparePrevElem(fl, func(c int) bool { return c < 0 })
+}
+
+// isLtePrevField is the validation function for validating if the current dive element,
+// or the element's field specified by the param's value, is less than or equal to the previous element's.
+func isLtePrevField(fl FieldLevel) bool {
+	return comparePrevElem(fl, func(c int) bool { return c <= 0 })
+}
+
+// comparePrevElem compares the current dive element with the previous one,
+// the first element always passes.
+func comparePrevElem(fl FieldLevel, fn func(c int) bool) bool {
+	v := fl.(*validate)
+	if !v.elem.ok || !v.elem.coll.IsValid() {
+		panic(fmt.Sprintf("'%s' must be used within a dive over a slice or array on field '%s'", fl.GetTag(), fl.FieldName()))
+	}
+
+	if v.elem.idx == 0 {
+		return true
+	}
+
+	field := fl.Field()
+	prev, _, _ := v.extractTypeInternal(v.elem.coll.Index(v.elem.idx-1), false)
+	if param := fl.Param(); len(param) > 0 {
+		var ok bool
+		if field, _, _, ok = v.getStructFieldOKInternal(field, param); !ok {
+			return false
+		}
+
+		if prev, _, _, ok = v.getStructFieldOKInternal(prev, param); !ok {
+			return false
+		}
+	}
+
+	c, ok := compareValues(field, prev)
+	return ok && fn(c)
+}
+
+// compareValues compares two values of the same kind, strings are compared lexically.
+// It returns false if the values can't be compared.
+func compareValues(a, b reflect.Value) (int, bool) {
+	if a.Kind() != b.Kind() {
+		return 0, false
+	}
+
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(a.Uint(), b.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(a.Float(), b.Float()), true
+	case reflect.String:
+		return strings.Compare(a.String(), b.String()), true
+	case reflect.Struct:
+		if a.Type().ConvertibleTo(timeType) && b.Type().ConvertibleTo(timeType) {
+			return a.Convert(timeType).Interface().(time.Time).Compare(b.Convert(timeType).Interface().(time.Time)), true
+		}
+	}
+	return 0, false
+}
+
+// isAfter is the validation function for validating if the current field's
+// time is after the time of the field specified by the param's value.
+func isAfter(fl FieldLevel) bool {
+	return compareTimeField(fl, func(c int) bool { return c > 0 })
+}
+
+// isAfterEq is the validation function for validating if the current field's
+// time is after or equal to the time of the field specified by the param's value.
+func isAfterEq(fl FieldLevel) bool {
+	return compareTimeField(fl, func(c int) bool { return c >= 0 })
+}
+
+// isBefore is the validation function for validating if the current field's
+// time is before the time of the field specified by the param's value.
+func isBefore(fl FieldLevel) bool {
+	return compareTimeField(fl, func(c int) bool { return c < 0 })
+}
+
+// isBeforeEq is the validation function for validating if the current field's
+// time is before or equal to the time of the field specified by the param's value.
+func isBeforeEq(fl FieldLevel) bool {
+	return compareTimeField(fl, func(c int) bool { return c <= 0 })
+}
+
+// isBetweenFields is the validation function for validating if the current field's number or time
+// is between the values of the two fields specified by the param's value, inclusive, e. g. 'betweenfields=Min Max',
+// the numbers of the fields having the kind of the current field.
+// It's also registered as 'between_fields'.
+func isBetweenFields(fl FieldLevel) bool {
+	names, layout := splitTimeParam(fl)
+	if len(names) != 2 {
+		panic(fmt.Sprintf("Bad param '%s' for '%s'", fl.Param(), fl.GetTag()))
+	}
+
+	switch fl.Field().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return isBetweenFieldNumbers(fl, names)
+	}
+
+	t, ok := timeOfField(fl.Field(), layout)
+	if !ok {
+		return false
+	}
+
+	start, ok := timeOfStructField(fl, names[0], layout)
+	if !ok {
+		return false
+	}
+
+	end, ok := timeOfStructField(fl, names[1], layout)
+	return ok && !t.Before(start) && !t.After(end)
+}
+
+// isBetweenFieldNumbers reports whether the current field's number
+// is between the numbers of the fields named names, inclusive.
+func isBetweenFieldNumbers(fl FieldLevel, names []string) bool {
+	field := fl.Field()
+	isFloat := field.Kind() == reflect.Float32 || field.Kind() == reflect.Float64
+	if isFloat && math.IsNaN(field.Float()) {
+		return false
+	}
+
+	for i, name := range names {
+		bound, kind, _, ok := fl.GetStructFieldOKAdvanced(fl.Parent(), name)
+		if !ok || kind != field.Kind() || isFloat && math.IsNaN(bound.Float()) {
+			return false
+		}
+
+		c, _ := compareValues(field, bound)
+		if i == 0 && c < 0 || i == 1 && c > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// isEqApprox is the validation function for validating if the current field's float approximately equals
+// the param's value, within an absolute tolerance, e. g. 'eq_approx=0.1;eps=1e-9', or within a number of
+// units in the last place of the field's float size, e. g. 'eq_approx=0.1;ulp=2', defaulting to 'ulp=4'.
+func isEqApprox(fl FieldLevel) bool {
+	value, within := parseApproxParam(fl)
+	field := fl.Field()
+	switch field.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return within(field.Float(), asFloat64(value), field.Kind())
+	default:
+		panic(fmt.Sprintf("Bad field type %T", field.Interface()))
+	}
+}
+
+// isEqFieldApprox is the validation function for validating if the current field's float approximately equals
+// the float of the field specified by the param's value, e. g. 'eqfield_approx=Total;eps=0.005', see isEqApprox.
+func isEqFieldApprox(fl FieldLevel) bool {
+	name, within := parseApproxParam(fl)
+	field := fl.Field()
+	switch field.Kind() {
+	case reflect.Float32, reflect.Float64:
+	default:
+		panic(fmt.Sprintf("Bad field type %T", field.Interface()))
+	}
+
+	other, kind, _, ok := fl.GetStructFieldOKAdvanced(fl.Parent(), name)
+	if !ok || (kind != reflect.Float32 && kind != reflect.Float64) {
+		return false
+	}
+
+	// floats of different sizes are compared in the units of the smallest
+	if kind == reflect.Float32 {
+		return within(field.Float(), other.Float(), kind)
+	}
+	return within(field.Float(), other.Float(), field.Kind())
+}
+
+// parseApproxParam splits the param of the approximate comparisons into the compared value
+// and the comparison of the tolerance given by its 'eps=' or 'ulp=' option.
+func parseApproxParam(fl FieldLevel) (value string, within func(a, b float64, kind reflect.Kind) bool) {
+	param := fl.Param()
+	value, opt, found := strings.Cut(param, ";")
+	if !found {
+		opt = "ulp=4"
+	}
+
+	if eps, ok := strings.CutPrefix(opt, "eps="); ok {
+		e, err := strconv.ParseFloat(eps, 64)
+		if err != nil || e < 0 || math.IsNaN(e) {
+			panic(fmt.Sprintf("Bad param '%s' for '%s'", param, fl.GetTag()))
+		}
+
+		return value, func(a, b float64, _ reflect.Kind) bool {
+			return a == b || math.Abs(a-b) <= e
+		}
+	}
+
+	if ulp, ok := strings.CutPrefix(opt, "ulp="); ok {
+		n, err := strconv.ParseUint(ulp, 10, 64)
+		if err != nil {
+			panic(fmt.Sprintf("Bad param '%s' for '%s'", param, fl.GetTag()))
+		}
+
+		return value, func(a, b float64, kind reflect.Kind) bool {
+			return a == b || ulpDistance(a, b, kind) <= n
+		}
+	}
+
+	panic(fmt.Sprintf("Bad param '%s' for '%s', expected '%s=<value>;eps=<epsilon>' or '%s=<value>;ulp=<units>'", param, fl.GetTag(), fl.GetTag(), fl.GetTag()))
+}
+
+// ulpDistance returns the number of floats of the size of kind between a and b,
+// the maximum distance when either is NaN or infinite.
+func ulpDistance(a, b float64, kind reflect.Kind) uint64 {
+	if math.IsNaN(a) || math.IsNaN(b) || math.IsInf(a, 0) || math.IsInf(b, 0) {
+		return math.MaxUint64
+	}
+
+	var ia, ib int64
+	if kind == reflect.Float32 {
+		ia, ib = orderedBits(uint64(math.Float32bits(float32(a))), 31), orderedBits(uint64(math.Float32bits(float32(b))), 31)
+	} else {
+		ia, ib = orderedBits(math.Float64bits(a), 63), orderedBits(math.Float64bits(b), 63)
+	}
+
+	if ia < ib {
+		ia, ib = ib, ia
+	}
+	return uint64(ia) - uint64(ib)
+}
+
+// orderedBits maps the bits of a float, its sign being the bit sign, to integers ordered as the floats.
+func orderedBits(bits uint64, sign uint) int64 {
+	if bits>>sign != 0 {
+		return -int64(bits &^ (1 << sign))
+	}
+	return int64(bits)
+}
+
+// compareTimeField compares the current field's time with the time of the field specified by the param's value.
+func compareTimeField(fl FieldLevel, fn func(c int) bool) bool {
+	names, layout := splitTimeParam(fl)
+	if len(names) != 1 {
+		panic(fmt.Sprintf("Bad param '%s' for '%s'", fl.Param(), fl.GetTag()))
+	}
+
+	t, ok := timeOfField(fl.Field(), layout)
+	if !ok {
+		return false
+	}
+
+	other, ok := timeOfStructField(fl, names[0], layout)
+	return ok && fn(t.Compare(other))
+}
+
+// splitTimeParam splits the param of a temporal field tag into the field names
+// and the layout of datetime string fields, e. g. 'Start End;2006-01-02'.
+// The layout defaults to time.RFC3339.
+func splitTimeParam(fl FieldLevel) (names []string, layout string) {
+	param, layout, ok := strings.Cut(fl.Param(), ";")
+	if !ok {
+		layout = time.RFC3339
+	}
+	return strings.Fields(param), layout
+}
+
+// timeOfStructField returns the time of the field named name of the current field's parent.
+func timeOfStructField(fl FieldLevel, name, layout string) (time.Time, bool) {
+	field, kind, _, ok := fl.GetStructFieldOKAdvanced(fl.Parent(), name)
+	if !ok || (kind != reflect.String && kind != reflect.Struct) {
+		return time.Time{}, false
+	}
+	return timeOfField(field, layout)
+}
+
+// timeOfField returns the time of a time.Time field or of a datetime string field parsed using layout.
+// It returns false if the datetime string can't be parsed.
+func timeOfField(field reflect.Value, layout string) (time.Time, bool) {
+	switch field.Kind() {
+	case reflect.String:
+		t, err := time.Parse(layout, field.String())
+		return t, err == nil
+	case reflect.Struct:
+		if field.Type().ConvertibleTo(timeType) {
+			return field.Convert(timeType).Interface().(time.Time), true
+		}
+	}
+
+	panic(fmt.Sprintf("Bad field type %T", field.Interface()))
+}
+
+// isFuture is the validation function for validating if the current field's time is after now.
+func isFuture(fl FieldLevel) bool {
+	return timeOfNowField(fl).After(nowOf(fl))
+}
+
+// isPast is the validation function for validating if the current field's time is before now.
+func isPast(fl FieldLevel) bool {
+	return timeOfNowField(fl).Before(nowOf(fl))
+}
+
+// isFutureWithin is the validation function for validating if the current field's time
+// is after now and at most the param's duration from now, e. g. 'future_within=72h'.
+func isFutureWithin(fl FieldLevel) bool {
+	d, t, now := asDuration(fl.Param()), timeOfNowField(fl), nowOf(fl)
+	return t.After(now) && !t.After(now.Add(d))
+}
+
+// isPastWithin is the validation function for validating if the current field's time
+// is before now and at most the param's duration ago, e. g. 'past_within=30d'.
+func isPastWithin(fl FieldLevel) bool {
+	d, t, now := asDuration(fl.Param()), timeOfNowField(fl), nowOf(fl)
+	return t.Before(now) && !t.Before(now.Add(-d))
+}
+
+// timeOfNowField returns the time of the current time.Time field or panics for other types.
+func timeOfNowField(fl FieldLevel) time.Time {
+	field := fl.Field()
+	if field.Kind() == reflect.Struct && field.Type().ConvertibleTo(timeType) {
+		return field.Convert(timeType).Interface().(time.Time)
+	}
+
+	panic(fmt.Sprintf("Bad field type %T", field.Interface()))
+}
+
+// nowOf returns the current time in UTC of the validator's clock, see WithEnvironment.
+func nowOf(fl FieldLevel) time.Time {
+	if v, ok := fl.(*validate); ok {
+		return v.v.now().UTC()
+	}
+
+	return time.Now().UTC()
+}
+
+// isGtNumStr is the validation function for validating if the current field's
+// numeric string value is greater than the param's value, compared as arbitrary-precision numbers.
+func isGtNumStr(fl FieldLevel) bool {
+	return compareNumStr(fl, func(c int) bool { return c > 0 })
+}
+
+// isGteNumStr is the validation function for validating if the current field's
+// numeric string value is greater than or equal to the param's value, compared as arbitrary-precision numbers.
+func isGteNumStr(fl FieldLevel) bool {
+	return compareNumStr(fl, func(c int) bool { return c >= 0 })
+}
+
+// isLtNumStr is the validation function for validating if the current field's
+// numeric string value is less than the param's value, compared as arbitrary-precision numbers.
+func isLtNumStr(fl FieldLevel) bool {
+	return compareNumStr(fl, func(c int) bool { return c < 0 })
+}
+
+// isLteNumStr is the validation function for validating if the current field's
+// numeric string value is less than or equal to the param's value, compared as arbitrary-precision numbers.
+func isLteNumStr(fl FieldLevel) bool {
+	return compareNumStr(fl, func(c int) bool { return c <= 0 })
+}
+
+// compareNumStr compares the current field's numeric string value with the param's value,
+// a field value that isn't a decimal number fails.
+func compareNumStr(fl FieldLevel, fn func(c int) bool) bool {
+	field := fl.Field()
+	if field.Kind() != reflect.String {
+		panic(fmt.Sprintf("Bad field type %T", field.Interface()))
+	}
+
+	p := asRat(fl.Param())
+	val := field.String()
+	if !numericRegex().MatchString(val) {
+		return false
+	}
+
+	r, ok := new(big.Rat).SetString(val)
+	return ok && fn(r.Cmp(p))
+}
+
+// isFinite is the validation function for validating if the
+// current field's value is neither NaN nor an infinity.
+func isFinite(fl FieldLevel) bool {
+	f, ok := asFieldFloat(fl.Field())
+	return !ok || (!math.IsNaN(f) && !math.IsInf(f, 0))
+}
+
+// isNotNaN is the validation function for validating if the current field's value isn't NaN.
+func isNotNaN(fl FieldLevel) bool {
+	f, ok := asFieldFloat(fl.Field())
+	return !ok || !math.IsNaN(f)
+}
+
+// isNotInf is the validation function for validating if the current field's value isn't an infinity.
+func isNotInf(fl FieldLevel) bool {
+	f, ok := asFieldFloat(fl.Field())
+	return !ok || !math.IsInf(f, 0)
+}
+
+// asFieldFloat returns the value of a float field,
+// ok is false for integer fields which are always finite.
+func asFieldFloat(field reflect.Value) (f float64, ok bool) {
+	switch field.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return field.Float(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return 0, false
+	}
+
+	panic(fmt.Sprintf("Bad field type %T", field.Interface()))
+}
+
+// hasFlags is the validation function for validating if all the
+// bits set in the param's value are set in the current field's value.
+func hasFlags(fl FieldLevel) bool {
+	p := asUint(fl.Param())
+	return asFieldBits(fl.Field())&p == p
+}
+
+// hasNoFlags is the validation function for validating if none of the
+// bits set in the param's value are set in the current field's value.
+func hasNoFlags(fl FieldLevel) bool {
+	return asFieldBits(fl.Field())&asUint(fl.Param()) == 0
+}
+
+// isValidMask is the validation function for validating if the current field's
+// value only has bits set that are set in the param's value.
+func isValidMask(fl FieldLevel) bool {
+	return asFieldBits(fl.Field())&^asUint(fl.Param()) == 0
+}
+
+// asFieldBits returns the bit pattern of an integer field.
+func asFieldBits(field reflect.Value) uint64 {
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		// keep the bit pattern of the field's size for negative values
+		return uint64(field.Int()) & (math.MaxUint64 >> (64 - field.Type().Bits()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return field.Uint()
+	}
+
+	panic(fmt.Sprintf("Bad field type %T", field.Interface()))
+}
+
+// isMultipleOf is the validation function for validating if the current field's
+// numeric value is a multiple of the param's value, using exact decimal arithmetic.
+func isMultipleOf(fl FieldLevel) bool {
+	val, ok := asFieldRat(fl.Field())
+	return ok && isRatMultiple(val, asStepRat(fl.Param()))
+}
+
+// isStep is the validation function for validating if the current field's numeric value
+// lies on the steps given by the param, e.g. 'step=5;offset=2' allows 2, 7, 12 and -3,
+// using exact decimal arithmetic.
+func isStep(fl FieldLevel) bool {
+	param := fl.Param()
+	offset := new(big.Rat)
+	if i := strings.IndexByte(param, ';'); i != -1 {
+		o, found := strings.CutPrefix(param[i+1:], "offset=")
+		if !found {
+			panic(fmt.Sprintf("Bad param '%s' for 'step', expected 'step=<step>;offset=<offset>'", param))
+		}
+
+		param, offset = param[:i], asRat(o)
+	}
+
+	val, ok := asFieldRat(fl.Field())
+	return ok && isRatMultiple(val.Sub(val, offset), asStepRat(param))
+}
+
+// asStepRat parses param as a non-zero decimal number or panics.
+func asStepRat(param string) *big.Rat {
+	r := asRat(param)
+	if r.Sign() == 0 {
+		panic(fmt.Sprintf("Bad param '%s', expected a non-zero decimal number", param))
+	}
+	return r
+}
+
+// isRatMultiple reports whether val is an integer multiple of step.
+func isRatMultiple(val, step *big.Rat) bool {
+	return val.Quo(val, step).IsInt()
+}
+
+// asFieldRat returns the numeric value of field as *big.Rat, floats are converted using
+// their shortest decimal representation so e.g. 0.15 is exactly 15/100.
+// Numeric strings are accepted, it returns false for any other string.
+func asFieldRat(field reflect.Value) (*big.Rat, bool) {
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Rat).SetInt64(field.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Rat).SetUint64(field.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		f := field.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, false
+		}
+		return new(big.Rat).SetString(strconv.FormatFloat(f, 'f', -1, field.Type().Bits()))
+	case reflect.String:
+		if !numericRegex().MatchString(field.String()) {
+			return nil, false
+		}
+		return new(big.Rat).SetString(field.String())
+	}
+
+	panic(fmt.Sprintf("Bad field type %T", field.Interface()))
+}
+
 // isLte is the validation function for validating if the
 // current field's value is less than or equal to the param's value.
 func isLte(fl FieldLevel) bool {
@@ -2352,7 +3141,7 @@ func isLte(fl FieldLevel) bool {
 		p := asInt(param)
 		return int64(utf8.RuneCountInString(field.String())) <= p
 	case reflect.Slice, reflect.Map, reflect.Array:
-		p := asInt(param)
+		p := asIntFromType(field.Type(), param)
 		return int64(field.Len()) <= p
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		p := asIntFromType(field.Type(), param)
@@ -2368,7 +3157,7 @@ func isLte(fl FieldLevel) bool {
 		return field.Float() <= p
 	case reflect.Struct:
 		if field.Type().ConvertibleTo(timeType) {
-			now := time.Now().UTC()
+			now := nowOf(fl)
 			t := field.Convert(timeType).Interface().(time.Time)
 			return t.Before(now) || t.Equal(now)
 		}
@@ -2434,109 +3223,199 @@ func isIP6Addr(fl FieldLevel) bool {
 	return ip != nil && ip.To4() == nil
 }
 
+// resolveHostPort resolves the host and port of the address of network, e. g. tcp4, using ctx.
+func resolveHostPort(ctx context.Context, network, addr string) error {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return err
+	}
+
+	if _, err = net.DefaultResolver.LookupPort(ctx, network, port); err != nil || len(host) == 0 {
+		return err
+	}
+
+	_, err = net.DefaultResolver.LookupNetIP(ctx, "ip"+strings.TrimLeft(network, "tcpud"), host)
+	return err
+}
+
+// resolved reports whether an address resolved without err,
+// marking the tag as timed out when the resolution was interrupted by ctx, see MarkTimedOut.
+func resolved(ctx context.Context, fl FieldLevel, err error) bool {
+	if err != nil && ctx.Err() != nil && (errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) {
+		MarkTimedOut(fl)
+	}
+	return err == nil
+}
+
 // isTCP4AddrResolvable is the validation function for validating if the
 // field's value is a resolvable tcp4 address.
-func isTCP4AddrResolvable(fl FieldLevel) bool {
+func isTCP4AddrResolvable(ctx context.Context, fl FieldLevel) bool {
 	if !isIP4Addr(fl) {
 		return false
 	}
 
-	_, err := net.ResolveTCPAddr("tcp4", fl.Field().String())
-	return err == nil
+	return resolved(ctx, fl, resolveHostPort(ctx, "tcp4", fl.Field().String()))
 }
 
 // isTCP6AddrResolvable is the validation function for validating if the
 // field's value is a resolvable tcp6 address.
-func isTCP6AddrResolvable(fl FieldLevel) bool {
+func isTCP6AddrResolvable(ctx context.Context, fl FieldLevel) bool {
 	if !isIP6Addr(fl) {
 		return false
 	}
 
-	_, err := net.ResolveTCPAddr("tcp6", fl.Field().String())
-	return err == nil
+	return resolved(ctx, fl, resolveHostPort(ctx, "tcp6", fl.Field().String()))
 }
 
 // isTCPAddrResolvable is the validation function for validating if the
 // field's value is a resolvable tcp address.
-func isTCPAddrResolvable(fl FieldLevel) bool {
+func isTCPAddrResolvable(ctx context.Context, fl FieldLevel) bool {
 	if !isIP4Addr(fl) && !isIP6Addr(fl) {
 		return false
 	}
 
-	_, err := net.ResolveTCPAddr("tcp", fl.Field().String())
-	return err == nil
+	return resolved(ctx, fl, resolveHostPort(ctx, "tcp", fl.Field().String()))
 }
 
 // isUDP4AddrResolvable is the validation function for validating if the
 // field's value is a resolvable udp4 address.
-func isUDP4AddrResolvable(fl FieldLevel) bool {
+func isUDP4AddrResolvable(ctx context.Context, fl FieldLevel) bool {
 	if !isIP4Addr(fl) {
 		return false
 	}
 
-	_, err := net.ResolveUDPAddr("udp4", fl.Field().String())
-	return err == nil
+	return resolved(ctx, fl, resolveHostPort(ctx, "udp4", fl.Field().String()))
 }
 
 // isUDP6AddrResolvable is the validation function for validating if the
 // field's value is a resolvable udp6 address.
-func isUDP6AddrResolvable(fl FieldLevel) bool {
+func isUDP6AddrResolvable(ctx context.Context, fl FieldLevel) bool {
 	if !isIP6Addr(fl) {
 		return false
 	}
 
-	_, err := net.ResolveUDPAddr("udp6", fl.Field().String())
-	return err == nil
+	return resolved(ctx, fl, resolveHostPort(ctx, "udp6", fl.Field().String()))
 }
 
 // isUDPAddrResolvable is the validation function for validating if the
 // field's value is a resolvable udp address.
-func isUDPAddrResolvable(fl FieldLevel) bool {
+func isUDPAddrResolvable(ctx context.Context, fl FieldLevel) bool {
 	if !isIP4Addr(fl) && !isIP6Addr(fl) {
 		return false
 	}
 
-	_, err := net.ResolveUDPAddr("udp", fl.Field().String())
-	return err == nil
+	return resolved(ctx, fl, resolveHostPort(ctx, "udp", fl.Field().String()))
 }
 
 // isIPAddrResolvable is the validation function for validating if the
 // field's value is a resolvable ip address.
-func isIPAddrResolvable(fl FieldLevel) bool {
+func isIPAddrResolvable(ctx context.Context, fl FieldLevel) bool {
 	if !isIP(fl) {
 		return false
 	}
 
-	_, err := net.ResolveIPAddr("ip", fl.Field().String())
-	return err == nil
+	_, err := net.DefaultResolver.LookupNetIP(ctx, "ip", fl.Field().String())
+	return resolved(ctx, fl, err)
 }
 
 // isIP4AddrResolvable is the validation function for validating if the
 // field's value is a resolvable ip4 address.
-func isIP4AddrResolvable(fl FieldLevel) bool {
+func isIP4AddrResolvable(ctx context.Context, fl FieldLevel) bool {
 	if !isIPv4(fl) {
 		return false
 	}
 
-	_, err := net.ResolveIPAddr("ip4", fl.Field().String())
-	return err == nil
+	_, err := net.DefaultResolver.LookupNetIP(ctx, "ip4", fl.Field().String())
+	return resolved(ctx, fl, err)
 }
 
 // isIP6AddrResolvable is the validation function for validating if the
 // field's value is a resolvable ip6 address.
-func isIP6AddrResolvable(fl FieldLevel) bool {
+func isIP6AddrResolvable(ctx context.Context, fl FieldLevel) bool {
 	if !isIPv6(fl) {
 		return false
 	}
 
-	_, err := net.ResolveIPAddr("ip6", fl.Field().String())
-	return err == nil
+	_, err := net.DefaultResolver.LookupNetIP(ctx, "ip6", fl.Field().String())
+	return resolved(ctx, fl, err)
+}
+
+// portClasses are the port ranges of the port tag's class params.
+var portClasses = map[string][2]uint64{
+	"well_known": {1, 1023},
+	"registered": {1024, 49151},
+	"ephemeral":  {49152, 65535},
 }
 
 // IsPort validates if the current field's value represents a valid port.
+// The param optionally restricts the port to a class, 'well_known', 'registered' or 'ephemeral',
+// or to a range, e.g. 'port=range=8000-9000'.
 func isPort(fl FieldLevel) bool {
-	val := fl.Field().Uint()
-	return val >= 1 && val <= 65535
+	field := fl.Field()
+	var val uint64
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if field.Int() < 0 {
+			return false
+		}
+		val = uint64(field.Int())
+	case reflect.String:
+		var err error
+		if val, err = strconv.ParseUint(field.String(), 10, 64); err != nil {
+			return false
+		}
+	default:
+		val = field.Uint()
+	}
+
+	bounds := portBounds(fl.Param())
+	return val >= bounds[0] && val <= bounds[1]
+}
+
+// isPortRange is the validation function for validating if the current field's value is a
+// '<start>-<end>' port range with start lower than end, both within the bounds of the optional
+// param which is the same as the port tag's.
+func isPortRange(fl FieldLevel) bool {
+	start, end, ok := strings.Cut(fl.Field().String(), "-")
+	if !ok {
+		return false
+	}
+
+	s, err := strconv.ParseUint(start, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	e, err := strconv.ParseUint(end, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	bounds := portBounds(fl.Param())
+	return s < e && s >= bounds[0] && e <= bounds[1]
+}
+
+// portBounds returns the lowest and highest ports allowed by the port tag's param.
+func portBounds(param string) [2]uint64 {
+	if len(param) == 0 {
+		return [2]uint64{1, 65535}
+	}
+
+	if bounds, ok := portClasses[param]; ok {
+		return bounds
+	}
+
+	if rng, ok := strings.CutPrefix(param, "range="); ok {
+		if start, end, ok := strings.Cut(rng, "-"); ok {
+			s, serr := strconv.ParseUint(start, 10, 16)
+			e, eerr := strconv.ParseUint(end, 10, 16)
+			if serr == nil && eerr == nil && s >= 1 && s <= e {
+				return [2]uint64{s, e}
+			}
+		}
+	}
+
+	panic(fmt.Sprintf("Bad param '%s' for 'port'", param))
 }
 
 // isHostnamePort validates a <dns>:<port> combination for fields typically used for socket address.
@@ -2561,6 +3440,57 @@ func isHostnamePort(fl FieldLevel) bool {
 	return true
 }
 
+// isHostnamePortList is the validation function for validating if the current field's value
+// is a delimited list of <dns>:<port> combinations, see validateList.
+func isHostnamePortList(fl FieldLevel) bool {
+	return validateList(fl, isHostnamePort)
+}
+
+// isURLList is the validation function for validating if the current field's value
+// is a delimited list of URLs, see validateList.
+func isURLList(fl FieldLevel) bool {
+	return validateList(fl, isURL)
+}
+
+// elemFieldLevel is the FieldLevel of a single element of a delimited list.
+type elemFieldLevel struct {
+	FieldLevel
+	field reflect.Value
+}
+
+// Field returns the list element.
+func (e elemFieldLevel) Field() reflect.Value {
+	return e.field
+}
+
+// validateList splits the current field's string value using the separator of the param,
+// e.g. 'sep=;', defaulting to a comma, and validates each whitespace trimmed element using fn.
+// The index of the first failing element is reported as the error's param.
+func validateList(fl FieldLevel, fn Func) bool {
+	field := fl.Field()
+	if field.Kind() != reflect.String {
+		panic(fmt.Sprintf("Bad field type %T", field.Interface()))
+	}
+
+	sep := ","
+	if param := fl.Param(); len(param) > 0 {
+		var ok bool
+		if sep, ok = strings.CutPrefix(param, "sep="); !ok || len(sep) == 0 {
+			panic(fmt.Sprintf("Bad param '%s' for '%s', expected 'sep=<separator>'", param, fl.GetTag()))
+		}
+	}
+
+	for i, elem := range strings.Split(field.String(), sep) {
+		if !fn(elemFieldLevel{FieldLevel: fl, field: reflect.ValueOf(strings.TrimSpace(elem))}) {
+			if v, ok := fl.(*validate); ok {
+				v.errParam = strconv.Itoa(i)
+			}
+			return false
+		}
+	}
+	return true
+}
+
 // isUnixAddrResolvable is the validation function for validating if the
 // field's value is a resolvable unix address.
 func isUnixAddrResolvable(fl FieldLevel) bool {
@@ -2712,8 +3642,7 @@ func isCreditCard(fl FieldLevel) bool {
 func isPostcodeByIso3166Alpha2(fl FieldLevel) bool {
 	field := fl.Field()
 	param := fl.Param()
-	postcodeRegexInit.Do(initPostcodes)
-	reg, found := postCodeRegexDict[param]
+	reg, found := postcodeProviderOf(fl).PostcodeRegex(param)
 	if !found {
 		return false
 	}
@@ -2740,8 +3669,7 @@ func isPostcodeByIso3166Alpha2Field(fl FieldLevel) bool {
 		panic(fmt.Sprintf("Bad field type %T", currentField.Interface()))
 	}
 
-	postcodeRegexInit.Do(initPostcodes)
-	reg, found := postCodeRegexDict[currentField.String()]
+	reg, found := postcodeProviderOf(fl).PostcodeRegex(currentField.String())
 	if !found {
 		return false
 	}
@@ -2773,6 +3701,144 @@ func fieldExcludes(fl FieldLevel) bool {
 	return !strings.Contains(field.String(), currentField.String())
 }
 
+// isNotSimilarTo is the validation function for validating that the current field's string value
+// isn't similar to the value of the field specified by the param, e.g. 'not_similar_to=Username'.
+// Values are similar, case-insensitively, when they're within the distance of the param, defaulting to 2,
+// e.g. 'not_similar_to=Username 3', or when the current field's value contains the other's
+// and the other's is at least 3 characters long.
+func isNotSimilarTo(fl FieldLevel) bool {
+	name, n, ok := strings.Cut(fl.Param(), " ")
+	dist := similarDistance
+	if ok {
+		var err error
+		if dist, err = strconv.Atoi(n); err != nil || dist < 0 {
+			panic(fmt.Sprintf("Bad param '%s' for '%s'", fl.Param(), fl.GetTag()))
+		}
+	}
+
+	field := fl.Field()
+	if field.Kind() != reflect.String {
+		panic(fmt.Sprintf("Bad field type %T", field.Interface()))
+	}
+
+	other, kind, _, found := fl.GetStructFieldOKAdvanced(fl.Parent(), name)
+	if !found || kind != reflect.String || other.Len() == 0 {
+		return true
+	}
+
+	s, o := strings.ToLower(field.String()), strings.ToLower(other.String())
+	if utf8.RuneCountInString(o) >= minSimilarLen && strings.Contains(s, o) {
+		return false
+	}
+	return levenshtein(s, o) > dist
+}
+
+// isSameHost is the validation function for validating if the current field's URL
+// has the same host name as the URL of the field specified by the param's value, e. g. 'same_host=SiteURL'.
+func isSameHost(fl FieldLevel) bool {
+	u, other, ok := parseURLFields(fl)
+	return ok && strings.EqualFold(u.Hostname(), other.Hostname())
+}
+
+// isSameOrigin is the validation function for validating if the current field's URL
+// has the same scheme, host name and port as the URL of the field specified by the param's value,
+// e. g. 'same_origin=SiteURL'.
+func isSameOrigin(fl FieldLevel) bool {
+	u, other, ok := parseURLFields(fl)
+	return ok && sameOrigin(u, other)
+}
+
+// isSubpathOf is the validation function for validating if the current field's URL
+// has the same origin as the URL of the field specified by the param's value,
+// and its path is the other's or is below it, e. g. 'subpath_of=BaseURL'.
+// Paths are compared once cleaned, i. e. '/cb/../admin' isn't below '/cb'.
+func isSubpathOf(fl FieldLevel) bool {
+	u, base, ok := parseURLFields(fl)
+	if !ok || !sameOrigin(u, base) {
+		return false
+	}
+
+	p, basePath := path.Clean("/"+u.Path), path.Clean("/"+base.Path)
+	return p == basePath || strings.HasPrefix(p, strings.TrimSuffix(basePath, "/")+"/")
+}
+
+// parseURLFields returns the absolute URLs of the current field
+// and of the field specified by the param's value.
+func parseURLFields(fl FieldLevel) (u, other *url.URL, ok bool) {
+	field := fl.Field()
+	if field.Kind() != reflect.String {
+		panic(fmt.Sprintf("Bad field type %T", field.Interface()))
+	}
+
+	otherField, kind, _, found := fl.GetStructFieldOK()
+	if !found || kind != reflect.String {
+		return nil, nil, false
+	}
+
+	u, err := url.Parse(field.String())
+	if err != nil || len(u.Scheme) == 0 || len(u.Host) == 0 {
+		return nil, nil, false
+	}
+
+	other, err = url.Parse(otherField.String())
+	if err != nil || len(other.Scheme) == 0 || len(other.Host) == 0 {
+		return nil, nil, false
+	}
+	return u, other, true
+}
+
+// sameOrigin reports whether the URLs have the same scheme, host name and port,
+// the default ports of http and https being implied.
+func sameOrigin(u, other *url.URL) bool {
+	return strings.EqualFold(u.Scheme, other.Scheme) &&
+		strings.EqualFold(u.Hostname(), other.Hostname()) &&
+		urlPort(u) == urlPort(other)
+}
+
+// urlPort returns the port of u, or the default port of its scheme.
+func urlPort(u *url.URL) string {
+	if port := u.Port(); len(port) > 0 {
+		return port
+	}
+
+	switch strings.ToLower(u.Scheme) {
+	case "http", "ws":
+		return "80"
+	case "https", "wss":
+		return "443"
+	}
+	return ""
+}
+
+// isLevenshteinLte is the validation function for validating that the current field's string value
+// is within the Levenshtein distance of the param's value, e.g. 'levenshtein_lte=2 kitten'.
+func isLevenshteinLte(fl FieldLevel) bool {
+	dist, val := levenshteinParam(fl)
+	return levenshtein(fl.Field().String(), val) <= dist
+}
+
+// isLevenshteinGt is the validation function for validating that the current field's string value
+// is further than the Levenshtein distance from the param's value, e.g. 'levenshtein_gt=3 password'.
+func isLevenshteinGt(fl FieldLevel) bool {
+	dist, val := levenshteinParam(fl)
+	return levenshtein(fl.Field().String(), val) > dist
+}
+
+// levenshteinParam returns the distance and value of a Levenshtein distance tag's param,
+// panicking if the current field isn't a string.
+func levenshteinParam(fl FieldLevel) (int, string) {
+	n, val, ok := strings.Cut(fl.Param(), " ")
+	dist, err := strconv.Atoi(n)
+	if !ok || err != nil || dist < 0 {
+		panic(fmt.Sprintf("Bad param '%s' for '%s'", fl.Param(), fl.GetTag()))
+	}
+
+	if fl.Field().Kind() != reflect.String {
+		panic(fmt.Sprintf("Bad field type %T", fl.Field().Interface()))
+	}
+	return dist, val
+}
+
 // startsWith is the validation function for validating that the
 // field's value starts with the text specified within the param.
 func startsWith(fl FieldLevel) bool {
@@ -2930,36 +3996,3 @@ func excludesRune(fl FieldLevel) bool {
 func excludesAll(fl FieldLevel) bool {
 	return !containsAny(fl)
 }
-
-func tryCallValidateFn(field reflect.Value, validateFn string) (bool, error) {
-	method := field.MethodByName(validateFn)
-	if field.CanAddr() && !method.IsValid() {
-		method = field.Addr().MethodByName(validateFn)
-	}
-
-	if !method.IsValid() {
-		return false, fmt.Errorf("unable to call %q on type %q: %w",
-			validateFn, field.Type().String(), errMethodNotFound)
-	}
-
-	returnValues := method.Call([]reflect.Value{})
-	if len(returnValues) == 0 {
-		return false, fmt.Errorf("unable to use result of method %q on type %q: %w",
-			validateFn, field.Type().String(), errMethodReturnNoValues)
-	}
-
-	firstReturnValue := returnValues[0]
-	switch firstReturnValue.Kind() {
-	case reflect.Bool:
-		return firstReturnValue.Bool(), nil
-	case reflect.Interface:
-		errorType := reflect.TypeOf((*error)(nil)).Elem()
-		if firstReturnValue.Type().Implements(errorType) {
-			return firstReturnValue.IsNil(), nil
-		}
-
-		return false, fmt.Errorf("unable to use result of method %q on type %q: %w (got interface %v expect error)", validateFn, field.Type().String(), errMethodReturnInvalidType, firstReturnValue.Type().String())
-	default:
-		return false, fmt.Errorf("unable to use result of method %q on type %q: %w (got %v expect error or bool)", validateFn, field.Type().String(), errMethodReturnInvalidType, firstReturnValue.Type().String())
-	}
-}
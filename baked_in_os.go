@@ -0,0 +1,273 @@
+//go:build !tinygo && !validator_lite
+
+package validator
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"reflect"
+	"strings"
+	"syscall"
+
+	"github.com/gabriel-vasile/mimetype"
+)
+
+// The validators in this file depend on the file system or on reflection features
+// unsupported by TinyGo, they're left out when building with the tinygo or validator_lite tags.
+
+var (
+	errMethodNotFound          = errors.New(`method not found`)
+	errMethodReturnNoValues    = errors.New(`method return o values (void)`)
+	errMethodReturnInvalidType = errors.New(`method should return invalid type`)
+)
+
+func init() {
+	bakedInValidators["file"] = isFile
+	bakedInValidators["filepath"] = isFilePath
+	bakedInValidators["image"] = isImage
+	bakedInValidators["dir"] = isDir
+	bakedInValidators["dirpath"] = isDirPath
+	bakedInValidators["validateFn"] = isValidateFn
+}
+
+// isDir is the validation function for validating if the
+// current field's value is a valid existing directory.
+func isDir(fl FieldLevel) bool {
+	field := fl.Field()
+	if field.Kind() == reflect.String {
+		fileInfo, err := os.Stat(field.String())
+		if err != nil {
+			return false
+		}
+
+		return fileInfo.IsDir()
+	}
+
+	panic(fmt.Sprintf("Bad field type %T", field.Interface()))
+}
+
+// isDirPath is the validation function for validating if the
+// current field's value is a valid directory.
+func isDirPath(fl FieldLevel) bool {
+	var exists bool
+	var err error
+	field := fl.Field()
+	// if it exists, it obviously is valid
+	// this is done first to avoid code duplication and unnecessary additional logic
+	if exists = isDir(fl); exists {
+		return true
+	}
+
+	// it does not exist but may still be a valid path
+	switch field.Kind() {
+	case reflect.String:
+		// every OS allows for whitespace,
+		// but none let you use a dir with no name (to my knowledge)
+		// unless you're dealing with raw inodes, but I digress
+		if strings.TrimSpace(field.String()) == "" {
+			return false
+		}
+
+		if _, err = os.Stat(field.String()); err != nil {
+			switch t := err.(type) {
+			case *fs.PathError:
+				if t.Err == syscall.EINVAL {
+					// it's definitely an invalid character in the path
+					return false
+				}
+				// it could be a permission error, a does-not-exist error, etc.
+				// out-of-scope for this validation, though
+				// lastly, we make sure it is a directory
+				if strings.HasSuffix(field.String(), string(os.PathSeparator)) {
+					return true
+				} else {
+					return false
+				}
+			default:
+				panic(err)
+			}
+		}
+
+		// repeat the check here to make sure it is an explicit directory in case the above os.Stat didn't trigger an error
+		if strings.HasSuffix(field.String(), string(os.PathSeparator)) {
+			return true
+		} else {
+			return false
+		}
+	}
+
+	panic(fmt.Sprintf("Bad field type %T", field.Interface()))
+}
+
+// isFile is the validation function for validating if the
+// current field's value is a valid existing file path.
+func isFile(fl FieldLevel) bool {
+	field := fl.Field()
+	switch field.Kind() {
+	case reflect.String:
+		fileInfo, err := os.Stat(field.String())
+		if err != nil {
+			return false
+		}
+
+		return !fileInfo.IsDir()
+	}
+
+	panic(fmt.Sprintf("Bad field type %T", field.Interface()))
+}
+
+// isFilePath is the validation function for validating if the
+// current field's value is a valid file path.
+func isFilePath(fl FieldLevel) bool {
+	var exists bool
+	var err error
+	field := fl.Field()
+
+	// not valid if it is a directory
+	if isDir(fl) {
+		return false
+	}
+	// if it exists, it obviously is valid
+	// this is done first to avoid code duplication and unnecessary additional logic
+	if exists = isFile(fl); exists {
+		return true
+	}
+
+	// it does not exist but may still be a valid filepath
+	switch field.Kind() {
+	case reflect.String:
+		// every OS allows for whitespace,
+		// but none let you use a file with no filename (to my knowledge)
+		// unless you're dealing with raw inodes, but I digress
+		if strings.TrimSpace(field.String()) == "" {
+			return false
+		}
+		// make sure it isn't a directory
+		if strings.HasSuffix(field.String(), string(os.PathSeparator)) {
+			return false
+		}
+
+		if _, err = os.Stat(field.String()); err != nil {
+			switch t := err.(type) {
+			case *fs.PathError:
+				if t.Err == syscall.EINVAL {
+					// it's definitely an invalid character in the filepath.
+					return false
+				}
+				// it could be a permission error, a does-not-exist error, etc.
+				// out-of-scope for this validation, though
+				return true
+			default:
+				panic(err)
+			}
+		}
+	}
+
+	panic(fmt.Sprintf("Bad field type %T", field.Interface()))
+}
+
+// isImage is the validation function for validating if the
+// current field's value contains the path to a valid image file
+func isImage(fl FieldLevel) bool {
+	field := fl.Field()
+	mimetypes := map[string]bool{
+		"image/bmp":                true,
+		"image/cis-cod":            true,
+		"image/gif":                true,
+		"image/ief":                true,
+		"image/jpeg":               true,
+		"image/jp2":                true,
+		"image/jpx":                true,
+		"image/jpm":                true,
+		"image/pipeg":              true,
+		"image/png":                true,
+		"image/svg+xml":            true,
+		"image/tiff":               true,
+		"image/webp":               true,
+		"image/x-cmu-raster":       true,
+		"image/x-cmx":              true,
+		"image/x-icon":             true,
+		"image/x-portable-anymap":  true,
+		"image/x-portable-bitmap":  true,
+		"image/x-portable-graymap": true,
+		"image/x-portable-pixmap":  true,
+		"image/x-rgb":              true,
+		"image/x-xbitmap":          true,
+		"image/x-xpixmap":          true,
+		"image/x-xwindowdump":      true,
+	}
+	switch field.Kind() {
+	case reflect.String:
+		filePath := field.String()
+		fileInfo, err := os.Stat(filePath)
+		if err != nil || fileInfo.IsDir() {
+			return false
+		}
+
+		file, err := os.Open(filePath)
+		if err != nil {
+			return false
+		}
+		defer func() {
+			_ = file.Close()
+		}()
+
+		mime, err := mimetype.DetectReader(file)
+		if err != nil {
+			return false
+		}
+
+		if _, ok := mimetypes[mime.String()]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func isValidateFn(fl FieldLevel) bool {
+	const defaultParam = `Validate`
+	field := fl.Field()
+	validateFn := cmp.Or(fl.Param(), defaultParam)
+	ok, err := tryCallValidateFn(field, validateFn)
+	if err != nil {
+		return false
+	}
+
+	return ok
+}
+
+func tryCallValidateFn(field reflect.Value, validateFn string) (bool, error) {
+	method := field.MethodByName(validateFn)
+	if field.CanAddr() && !method.IsValid() {
+		method = field.Addr().MethodByName(validateFn)
+	}
+
+	if !method.IsValid() {
+		return false, fmt.Errorf("unable to call %q on type %q: %w",
+			validateFn, field.Type().String(), errMethodNotFound)
+	}
+
+	returnValues := method.Call([]reflect.Value{})
+	if len(returnValues) == 0 {
+		return false, fmt.Errorf("unable to use result of method %q on type %q: %w",
+			validateFn, field.Type().String(), errMethodReturnNoValues)
+	}
+
+	firstReturnValue := returnValues[0]
+	switch firstReturnValue.Kind() {
+	case reflect.Bool:
+		return firstReturnValue.Bool(), nil
+	case reflect.Interface:
+		errorType := reflect.TypeOf((*error)(nil)).Elem()
+		if firstReturnValue.Type().Implements(errorType) {
+			return firstReturnValue.IsNil(), nil
+		}
+
+		return false, fmt.Errorf("unable to use result of method %q on type %q: %w (got interface %v expect error)", validateFn, field.Type().String(), errMethodReturnInvalidType, firstReturnValue.Type().String())
+	default:
+		return false, fmt.Errorf("unable to use result of method %q on type %q: %w (got %v expect error or bool)", validateFn, field.Type().String(), errMethodReturnInvalidType, firstReturnValue.Type().String())
+	}
+}
@@ -0,0 +1,88 @@
+package validator
+
+import (
+	"context"
+	"sync"
+)
+
+// Batch validates many values sharing a context,
+// it's created using Validate.Batch.
+//
+// Within a batch, validations registered using RegisterValidationWithBreaker
+// are called once per distinct tag, param and value,
+// the result is reused for every other item of the batch.
+type Batch struct {
+	v     *Validate
+	ctx   context.Context
+	items []interface{}
+}
+
+// batchMemoKey is the context key of the batch memo.
+type batchMemoKey struct{}
+
+// batchKey identifies an external validation call.
+type batchKey struct {
+	tag   string
+	param string
+	value interface{}
+}
+
+// batchMemo holds the results of the external validation calls made within a batch.
+type batchMemo struct {
+	mu      sync.Mutex
+	results map[batchKey]bool
+}
+
+// Batch returns a new Batch validating its items using ctx, e. g.
+//
+//	errs := validate.Batch(ctx).Add(row1).Add(row2).Run()
+func (v *Validate) Batch(ctx context.Context) *Batch {
+	return &Batch{v: v, ctx: ctx}
+}
+
+// Add adds a struct to validate to the batch.
+func (b *Batch) Add(item interface{}) *Batch {
+	b.items = append(b.items, item)
+	return b
+}
+
+// Run validates the items of the batch, see Validate.StructCtx.
+// It returns the errors of each item, in the order the items were added,
+// a nil error means the item is valid.
+func (b *Batch) Run() []error {
+	memo := &batchMemo{results: make(map[batchKey]bool)}
+	ctx := context.WithValue(b.ctx, batchMemoKey{}, memo)
+	errs := make([]error, len(b.items))
+	for i, item := range b.items {
+		errs[i] = b.v.StructCtx(ctx, item)
+	}
+	return errs
+}
+
+// memoizedCall returns the result of call for the current field and tag,
+// reusing the result of an identical call made earlier within the same batch.
+// call reports whether the call was actually made, only those results are reused.
+func memoizedCall(ctx context.Context, fl FieldLevel, call func() (ok bool, made bool)) bool {
+	memo, _ := ctx.Value(batchMemoKey{}).(*batchMemo)
+	field := fl.Field()
+	if memo == nil || !field.IsValid() || !field.CanInterface() || !field.Comparable() {
+		ok, _ := call()
+		return ok
+	}
+
+	key := batchKey{tag: fl.GetTag(), param: fl.Param(), value: field.Interface()}
+	memo.mu.Lock()
+	ok, found := memo.results[key]
+	memo.mu.Unlock()
+	if found {
+		return ok
+	}
+
+	ok, made := call()
+	if made {
+		memo.mu.Lock()
+		memo.results[key] = ok
+		memo.mu.Unlock()
+	}
+	return ok
+}
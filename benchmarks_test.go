@@ -0,0 +1,229 @@
+package validator
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type benchInner struct {
+	Name  string `json:"name" validate:"required,min=1,max=64"`
+	Email string `json:"email" validate:"required,email"`
+}
+
+type benchUser struct {
+	FirstName string                `json:"first_name" validate:"required"`
+	LastName  string                `json:"last_name" validate:"required"`
+	Age       uint8                 `json:"age" validate:"gte=0,lte=130"`
+	Password  string                `json:"password" validate:"required,min=8"`
+	Confirm   string                `json:"confirm" validate:"eqfield=Password"`
+	Start     int                   `json:"start" validate:"required"`
+	End       int                   `json:"end" validate:"gtfield=Start"`
+	Contacts  []*benchInner         `json:"contacts" validate:"required,dive,required"`
+	Matrix    [][]string            `json:"matrix" validate:"dive,dive,required"`
+	Labels    map[string]benchInner `json:"labels" validate:"dive,keys,alpha,endkeys"`
+}
+
+func newBenchUser() *benchUser {
+	return &benchUser{
+		FirstName: "Joey",
+		LastName:  "Bloggs",
+		Age:       27,
+		Password:  "supersecret",
+		Confirm:   "supersecret",
+		Start:     1,
+		End:       2,
+		Contacts: []*benchInner{
+			{Name: "home", Email: "home@example.com"},
+			{Name: "work", Email: "work@example.com"},
+		},
+		Matrix: [][]string{{"a", "b"}, {"c", "d"}},
+		Labels: map[string]benchInner{
+			"primary": {Name: "primary", Email: "primary@example.com"},
+		},
+	}
+}
+
+func jsonTagName(fld reflect.StructField) string {
+	name := strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
+	if name == "-" {
+		return ""
+	}
+
+	return name
+}
+
+func BenchmarkFieldSuccess(b *testing.B) {
+	validate := New()
+	s := "1"
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		_ = validate.Var(&s, "len=1")
+	}
+}
+
+func BenchmarkFieldFailure(b *testing.B) {
+	validate := New()
+	s := "12"
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		_ = validate.Var(&s, "len=1")
+	}
+}
+
+func BenchmarkStructSimpleSuccess(b *testing.B) {
+	validate := New()
+	inner := &benchInner{Name: "name", Email: "name@example.com"}
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		_ = validate.Struct(inner)
+	}
+}
+
+func BenchmarkTypedSimpleSuccess(b *testing.B) {
+	validate := MustCompile[benchInner]()
+	inner := benchInner{Name: "name", Email: "name@example.com"}
+	ctx := context.Background()
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		_ = validate.Validate(ctx, &inner)
+	}
+}
+
+func BenchmarkStructSimpleFailure(b *testing.B) {
+	validate := New()
+	inner := &benchInner{}
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		_ = validate.Struct(inner)
+	}
+}
+
+func BenchmarkStructTagNameFunc(b *testing.B) {
+	validate := New()
+	validate.RegisterTagNameFunc(jsonTagName)
+	user := newBenchUser()
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		_ = validate.Struct(user)
+	}
+}
+
+func BenchmarkStructTagNameFuncFailure(b *testing.B) {
+	validate := New()
+	validate.RegisterTagNameFunc(jsonTagName)
+	user := &benchUser{Contacts: []*benchInner{{}, {}}}
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		_ = validate.Struct(user)
+	}
+}
+
+func BenchmarkStructDeepDive(b *testing.B) {
+	validate := New()
+	user := newBenchUser()
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		_ = validate.Struct(user)
+	}
+}
+
+func BenchmarkVarDeepDive(b *testing.B) {
+	validate := New()
+	matrix := [][][]string{{{"a", "b"}, {"c"}}, {{"d"}, {"e", "f", "g"}}}
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		_ = validate.Var(matrix, "dive,dive,dive,required,alpha")
+	}
+}
+
+func BenchmarkCrossFieldSuccess(b *testing.B) {
+	type Test struct {
+		Start int
+		End   int `validate:"gtfield=Start"`
+	}
+
+	validate := New()
+	test := &Test{Start: 1, End: 2}
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		_ = validate.Struct(test)
+	}
+}
+
+func BenchmarkCrossStructCrossFieldSuccess(b *testing.B) {
+	type Inner struct {
+		Name string
+	}
+
+	type Outer struct {
+		Inner *Inner
+		Name  string `validate:"eqcsfield=Inner.Name"`
+	}
+
+	validate := New()
+	test := &Outer{Inner: &Inner{Name: "NAME"}, Name: "NAME"}
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		_ = validate.Struct(test)
+	}
+}
+
+func BenchmarkStructSimpleSuccessParallel(b *testing.B) {
+	validate := New()
+	validate.RegisterTagNameFunc(jsonTagName)
+	user := newBenchUser()
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = validate.Struct(user)
+		}
+	})
+}
+
+func BenchmarkStructDeepNamespace(b *testing.B) {
+	type Level3WithALongName struct {
+		FieldWithALongName string `validate:"required"`
+	}
+
+	type Level2WithALongName struct {
+		InnerWithALongName Level3WithALongName
+	}
+
+	type Level1WithALongName struct {
+		InnerWithALongName Level2WithALongName
+		OtherWithALongName Level2WithALongName
+	}
+
+	validate := New()
+	test := &Level1WithALongName{}
+	test.InnerWithALongName.InnerWithALongName.FieldWithALongName = "value"
+	test.OtherWithALongName.InnerWithALongName.FieldWithALongName = "value"
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		_ = validate.Struct(test)
+	}
+}
+
+func BenchmarkVarDiveParallel(b *testing.B) {
+	type Row struct {
+		ID    int    `validate:"gt=0"`
+		Email string `validate:"required,email"`
+	}
+
+	rows := make([]Row, 50000)
+	for i := range rows {
+		rows[i] = Row{ID: i + 1, Email: "joey@bloggs.com"}
+	}
+
+	validate := New()
+	for _, tag := range []string{"dive", "dive_parallel"} {
+		b.Run(tag, func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				_ = validate.Var(rows, tag)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ExternalFunc is a validation function backed by an external system, e. g. a remote lookup.
+// It returns an error when the check could not be completed,
+// which counts as a failure of the external system rather than of the field.
+type ExternalFunc func(ctx context.Context, fl FieldLevel) (bool, error)
+
+// BreakerConfig configures the rate limiting and circuit breaking
+// of a validation registered using RegisterValidationWithBreaker.
+type BreakerConfig struct {
+	// Rate is the maximum number of calls per second, 0 means unlimited.
+	// Calls above the rate are shed rather than delayed.
+	Rate float64
+	// Burst is the number of calls allowed at once on top of Rate, defaults to 1.
+	Burst int
+	// MaxFailures is the number of consecutive errors that opens the breaker, 0 never opens it.
+	MaxFailures int
+	// OpenDuration is how long the breaker stays open before calls are let through again.
+	OpenDuration time.Duration
+	// FailOpen makes shed calls, errors and calls while the breaker is open pass the validation,
+	// by default they fail it.
+	FailOpen bool
+}
+
+// breaker rate limits and short-circuits a single tag's ExternalFunc.
+type breaker struct {
+	mu        sync.Mutex
+	cfg       BreakerConfig
+	tokens    float64
+	last      time.Time
+	failures  int
+	openUntil time.Time
+}
+
+// allow reports whether a call may be made at now.
+func (b *breaker) allow(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if now.Before(b.openUntil) {
+		return false
+	}
+
+	if b.cfg.Rate <= 0 {
+		return true
+	}
+
+	burst := float64(max(b.cfg.Burst, 1))
+	if b.last.IsZero() {
+		b.tokens = burst
+	} else {
+		b.tokens = min(burst, b.tokens+now.Sub(b.last).Seconds()*b.cfg.Rate)
+	}
+
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+
+	b.tokens--
+	return true
+}
+
+// done records the outcome of a call made at now.
+func (b *breaker) done(now time.Time, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err == nil {
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.cfg.MaxFailures > 0 && b.failures >= b.cfg.MaxFailures {
+		b.openUntil = now.Add(b.cfg.OpenDuration)
+	}
+}
+
+// RegisterValidationWithBreaker adds a validation backed by an external system with the given tag,
+// rate limiting its calls and short-circuiting them once it keeps failing,
+// so an outage of the external system can't stall every validation using the tag.
+// See BreakerConfig for the outcome of calls that are shed, short-circuited or fail.
+// Identical calls made within a Batch are deduplicated.
+//
+// NOTES:
+// If the key already exists, the previous validation function will be replaced.
+// This method is not thread-safe it is intended that these all be registered prior to any validation.
+func (v *Validate) RegisterValidationWithBreaker(tag string, fn ExternalFunc, cfg BreakerConfig, callValidationEvenIfNull ...bool) error {
+	if fn == nil {
+		return v.RegisterValidationCtx(tag, nil, callValidationEvenIfNull...)
+	}
+
+	b := &breaker{cfg: cfg}
+	return v.RegisterValidationCtx(tag, func(ctx context.Context, fl FieldLevel) bool {
+		return memoizedCall(ctx, fl, func() (bool, bool) {
+			if !b.allow(v.now()) {
+				return cfg.FailOpen, false
+			}
+
+			ok, err := fn(ctx, fl)
+			b.done(v.now(), err)
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				// the outcome of an interrupted call isn't memoized
+				MarkTimedOut(fl)
+				return cfg.FailOpen, false
+			}
+
+			if err != nil {
+				return cfg.FailOpen, true
+			}
+			return ok, true
+		})
+	}, callValidationEvenIfNull...)
+}
@@ -3,6 +3,7 @@ package validator
 import (
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -20,6 +21,11 @@ const (
 	typeEndKeys
 	typeOmitNil
 	typeOmitZero
+	typeStrSplit
+	typeStopChildren
+	typeItems
+	typeJSONStruct
+	typeAllErrs
 )
 
 const (
@@ -28,6 +34,9 @@ const (
 	keysTagNotDefined   = "'" + endKeysTag + "' tag encountered without a corresponding '" + keysTag + "' tag"
 )
 
+// restIdx is the index of the 'rest=' segment of positional rules, see cutItem.
+const restIdx = -1
+
 type tagType uint8
 
 type cTag struct {
@@ -35,7 +44,10 @@ type cTag struct {
 	aliasTag             string
 	actualAliasTag       string
 	param                string
-	keys                 *cTag // only populated when using tag's 'keys' and 'endkeys' for map key validation
+	keys                 *cTag        // only populated when using tag's 'keys' and 'endkeys' for map key validation
+	items                []*cTag      // positional rules of array and slice elements by index, see parseItemsTag
+	rest                 *cTag        // rules of the elements past the positional rules, see parseItemsTag
+	jsonType             reflect.Type // registered type decoded by 'jsonstruct', see RegisterJSONStruct
 	next                 *cTag
 	fn                   FuncCtx
 	typeof               tagType
@@ -44,6 +56,21 @@ type cTag struct {
 	hasParam             bool // true if parameter used e. g. eq = where the equal sign has been set
 	isBlockEnd           bool // indicates the current tag represents the last validation in the block
 	runValidationWhenNil bool
+	sampled              bool // only run when the validation call is sampled, see WithSampling
+	warn                 bool // failures are reported as warnings, see StructWithWarnings
+	timeoutPolicy        TimeoutPolicy
+	maxErrs              int  // errored elements reported by dive_maxerrs, 0 when unlimited
+	parallel             bool // the elements are validated concurrently, see dive_parallel
+}
+
+// stopsChildren reports whether the tags up to the next dive hold stopchildren.
+func (ct *cTag) stopsChildren() bool {
+	for ; ct != nil && ct.typeof != typeDive; ct = ct.next {
+		if ct.typeof == typeStopChildren {
+			return true
+		}
+	}
+	return false
 }
 
 type cField struct {
@@ -51,18 +78,22 @@ type cField struct {
 	name       string
 	altName    string
 	namesEqual bool
+	sensitive  bool // whether the field's values are hashed by audits, see NewAuditContext
 	cTags      *cTag
 }
 
 type cStruct struct {
-	name   string
-	fields []*cField
-	fn     StructLevelFuncCtx
+	name      string
+	fields    []*cField
+	fn        StructLevelFuncCtx
+	integrity *structIntegrity
+	stages    *pipelineStages
 }
 
 type structCache struct {
-	lock sync.Mutex
-	m    atomic.Value
+	lock    sync.Mutex
+	m       atomic.Value
+	version string // payload version selecting the versioned tags, see StructVersion
 }
 
 func (sc *structCache) Get(key reflect.Type) (c *cStruct, found bool) {
@@ -103,11 +134,27 @@ func (tc *tagCache) Set(key string, value *cTag) {
 }
 
 func (v *Validate) parseFieldTagsRecursive(tag string, fieldName string, alias string, hasAlias bool) (firstCtag *cTag, current *cTag) {
+	if first, last, ok := v.parseItemsTag(tupleItems(tag, fieldName), fieldName); ok {
+		return first, last
+	}
+
 	var t string
 	noAlias := len(alias) == 0
 	tags := strings.Split(tag, tagSeparator)
 	for i := 0; i < len(tags); i++ {
 		t = tags[i]
+		warn := false
+		if w, ok := strings.CutPrefix(t, warnTag+versionSep); ok {
+			// only validations and aliases can be warnings, not e. g. dive or omitempty
+			name, _, _ := strings.Cut(w, orSeparator)
+			name, _, _ = strings.Cut(name, tagKeySeparator)
+			_, isValidation := v.validations[name]
+			if _, isAlias := v.aliases[name]; !isValidation && !isAlias {
+				panic(fmt.Sprintf("Invalid warning tag '%s' on field '%s'", t, fieldName))
+			}
+			t, warn = w, true
+		}
+
 		if noAlias {
 			alias = t
 		}
@@ -115,12 +162,21 @@ func (v *Validate) parseFieldTagsRecursive(tag string, fieldName string, alias s
 		// check map for alias and process new tags,
 		// otherwise process as usual
 		if tagsVal, found := v.aliases[t]; found {
+			var next, curr *cTag
 			if i == 0 {
 				firstCtag, current = v.parseFieldTagsRecursive(tagsVal, fieldName, t, true)
+				next, curr = firstCtag, current
 			} else {
-				next, curr := v.parseFieldTagsRecursive(tagsVal, fieldName, t, true)
+				next, curr = v.parseFieldTagsRecursive(tagsVal, fieldName, t, true)
 				current.next, current = next, curr
 			}
+
+			for ct := next; warn && ct != nil; ct = ct.next {
+				ct.warn = true
+				if ct == curr {
+					break
+				}
+			}
 			continue
 		}
 
@@ -135,8 +191,9 @@ func (v *Validate) parseFieldTagsRecursive(tag string, fieldName string, alias s
 		}
 
 		switch t {
-		case diveTag:
+		case diveTag, diveParallelTag:
 			current.typeof = typeDive
+			current.parallel = t == diveParallelTag
 		case keysTag:
 			current.typeof = typeKeys
 			if i == 0 || prevTag != typeDive {
@@ -166,6 +223,15 @@ func (v *Validate) parseFieldTagsRecursive(tag string, fieldName string, alias s
 		case omitzero:
 			current.typeof = typeOmitZero
 			continue
+		case strSplitTag, strSplitTag + tagKeySeparator:
+			// 'strsplit=,,' splits on commas, the comma of the param having split the tag
+			current.typeof = typeStrSplit
+			current.tag = strSplitTag
+			current.param = ","
+			if t != strSplitTag && i+1 < len(tags) && len(tags[i+1]) == 0 {
+				i++
+			}
+			continue
 		case omitempty:
 			current.typeof = typeOmitEmpty
 		case omitnil:
@@ -174,7 +240,51 @@ func (v *Validate) parseFieldTagsRecursive(tag string, fieldName string, alias s
 			current.typeof = typeStructOnly
 		case noStructLevelTag:
 			current.typeof = typeNoStructLevel
+		case stopChildrenTag:
+			current.typeof = typeStopChildren
+		case allErrsTag:
+			current.typeof = typeAllErrs
 		default:
+			if strings.HasPrefix(t, strSplitTag+tagKeySeparator) {
+				current.typeof = typeStrSplit
+				current.tag = strSplitTag
+				current.param = strings.ReplaceAll(strings.ReplaceAll(t[len(strSplitTag)+1:], utf8HexComma, ","), utf8Pipe, "|")
+				current.hasParam = true
+				continue
+			}
+
+			if strings.HasPrefix(t, jsonStructTag+tagKeySeparator) {
+				current.typeof = typeJSONStruct
+				current.tag = jsonStructTag
+				current.param = t[len(jsonStructTag)+1:]
+				current.hasParam = true
+				if noAlias {
+					current.aliasTag = jsonStructTag
+				}
+
+				typ, ok := v.jsonStructs[current.param]
+				if !ok {
+					panic(fmt.Sprintf("Undefined JSON struct '%s' on field '%s', see RegisterJSONStruct", current.param, fieldName))
+				}
+
+				current.jsonType = typ
+				continue
+			}
+
+			if strings.HasPrefix(t, diveMaxErrsTag+tagKeySeparator) {
+				current.typeof = typeDive
+				current.tag = diveMaxErrsTag
+				current.param = t[len(diveMaxErrsTag)+1:]
+				current.hasParam = true
+				n, err := strconv.Atoi(current.param)
+				if err != nil || n < 1 {
+					panic(fmt.Sprintf("Bad param '%s' for '%s' on field '%s'", current.param, diveMaxErrsTag, fieldName))
+				}
+
+				current.maxErrs = n
+				continue
+			}
+
 			if t == isdefault {
 				current.typeof = typeIsDefault
 			}
@@ -204,6 +314,8 @@ func (v *Validate) parseFieldTagsRecursive(tag string, fieldName string, alias s
 				if wrapper, ok := v.validations[current.tag]; ok {
 					current.fn = wrapper.fn
 					current.runValidationWhenNil = wrapper.runValidationOnNil
+					_, current.sampled = v.sampledTags[current.tag]
+					current.timeoutPolicy = v.timeoutPolicies[current.tag]
 				} else {
 					panic(strings.TrimSpace(fmt.Sprintf(undefinedValidation, current.tag, fieldName)))
 				}
@@ -215,6 +327,7 @@ func (v *Validate) parseFieldTagsRecursive(tag string, fieldName string, alias s
 				if len(vals) > 1 {
 					current.param = strings.ReplaceAll(strings.ReplaceAll(vals[1], utf8HexComma, ","), utf8Pipe, "|")
 				}
+				current.warn = warn
 			}
 			current.isBlockEnd = true
 		}
@@ -241,22 +354,28 @@ func (v *Validate) fetchCacheTag(tag string) *cTag {
 }
 
 func (v *Validate) extractStructCache(current reflect.Value, sName string) *cStruct {
-	v.structCache.lock.Lock()
-	defer v.structCache.lock.Unlock() // leave as defer! because if inner panics, it will never get unlocked otherwise!
+	return v.extractStructCacheOf(v.structCache, current, sName)
+}
+
+// extractStructCacheOf parses the struct type of current into the struct cache sc.
+func (v *Validate) extractStructCacheOf(sc *structCache, current reflect.Value, sName string) *cStruct {
+	sc.lock.Lock()
+	defer sc.lock.Unlock() // leave as defer! because if inner panics, it will never get unlocked otherwise!
 
 	typ := current.Type()
 	// could have been multiple trying to access, but once first is done this ensures struct
 	// isn't parsed again.
-	cs, ok := v.structCache.Get(typ)
+	cs, ok := sc.Get(typ)
 	if ok {
 		return cs
 	}
 
-	cs = &cStruct{name: sName, fields: make([]*cField, 0), fn: v.structLevelFuncs[typ]}
+	cs = &cStruct{name: sName, fields: make([]*cField, 0), fn: v.structLevelFuncs[typ], integrity: v.structIntegrity[typ], stages: v.pipelines[typ]}
 	numFields := current.NumField()
 	rules := v.rules[typ]
 
 	var ctag *cTag
+	var sensitive bool
 	var tag, customName string
 	var fld reflect.StructField
 	for i := 0; i < numFields; i++ {
@@ -271,10 +390,16 @@ func (v *Validate) extractStructCache(current reflect.Value, sName string) *cStr
 			tag = fld.Tag.Get(v.tagName)
 		}
 
+		v.recordTagVersions(tag)
+		tag = versionedTag(tag, sc.version)
 		if tag == skipValidationTag {
 			continue
 		}
 
+		tag, sensitive = cutSensitive(tag)
+
+		// the tag name func is only ever invoked here,
+		// its result is cached as the field's altName so it is never run during validation
 		customName = fld.Name
 		if v.hasTagNameFunc {
 			name := v.tagNameFunc(fld)
@@ -300,9 +425,230 @@ func (v *Validate) extractStructCache(current reflect.Value, sName string) *cStr
 			altName:    customName,
 			cTags:      ctag,
 			namesEqual: fld.Name == customName,
+			sensitive:  sensitive,
 		})
 	}
 
-	v.structCache.Set(typ, cs)
+	sc.Set(typ, cs)
 	return cs
 }
+
+// parseItemsTag parses a tag holding the positional rules of the elements of an array or slice,
+// e. g. 'len=2;item0=required,uuid4;item1=required,email', the tags before the first position applying
+// to the collection itself and the rules of a 'rest=' segment to the elements past the positions,
+// returning false if the tag has no positional rules.
+// Positional rules must start a segment, e. g. 'required,item0=min=1' panics rather than
+// parsing 'item0' as a validation.
+func (v *Validate) parseItemsTag(tag, fieldName string) (firstCtag *cTag, current *cTag, ok bool) {
+	segments := strings.Split(tag, versionSegmentSep)
+	first := -1
+	for i, segment := range segments {
+		tags := strings.Split(segment, tagSeparator)
+		if _, _, ok := cutItem(segment); ok {
+			if first == -1 {
+				first = i
+			}
+			tags = tags[1:]
+		}
+
+		for _, t := range tags {
+			if idx, _, ok := cutItem(t); ok {
+				panic(fmt.Sprintf("Positional rules '%s' must start a '%s' separated segment on field '%s'", itemName(idx), versionSegmentSep, fieldName))
+			}
+		}
+	}
+
+	if first == -1 {
+		return nil, nil, false
+	}
+
+	positions := make(map[int]string)
+	last := -1
+	for _, segment := range segments[first:] {
+		idx, tags, ok := cutItem(segment)
+		if !ok {
+			// a segment separator within a param, e. g. 'item0=after=Start;2006-01-02'
+			positions[last] += versionSegmentSep + segment
+			continue
+		}
+
+		if _, dup := positions[idx]; dup {
+			panic(fmt.Sprintf("Duplicate positional rules '%s' on field '%s'", itemName(idx), fieldName))
+		}
+		positions[idx], last = tags, idx
+	}
+
+	items := &cTag{tag: itemTag, aliasTag: itemTag, hasTag: true, typeof: typeItems}
+	for idx, tags := range positions {
+		if idx == restIdx {
+			items.rest, _ = v.parseFieldTagsRecursive(tags, fieldName, "", false)
+			continue
+		}
+
+		if len(items.items) <= idx {
+			items.items = append(items.items, make([]*cTag, idx+1-len(items.items))...)
+		}
+		items.items[idx], _ = v.parseFieldTagsRecursive(tags, fieldName, "", false)
+	}
+
+	if first == 0 {
+		return items, items, true
+	}
+
+	firstCtag, current = v.parseFieldTagsRecursive(strings.Join(segments[:first], versionSegmentSep), fieldName, "", false)
+	current.next = items
+	return firstCtag, items, true
+}
+
+// itemName returns the key of the positional rules of idx, e. g. 'item1' or 'rest'.
+func itemName(idx int) string {
+	if idx == restIdx {
+		return restTag
+	}
+	return itemTag + strconv.Itoa(idx)
+}
+
+// cutItem returns the index and tags of a positional rules segment, e. g. 'item1=required,email',
+// the index of a 'rest=' segment being restIdx.
+func cutItem(segment string) (int, string, bool) {
+	if tags, ok := strings.CutPrefix(segment, restTag+tagKeySeparator); ok && len(tags) > 0 {
+		return restIdx, tags, true
+	}
+
+	rest, ok := strings.CutPrefix(segment, itemTag)
+	if !ok {
+		return 0, "", false
+	}
+
+	n, tags, ok := strings.Cut(rest, tagKeySeparator)
+	if !ok || len(tags) == 0 || len(n) == 0 || n[0] == '+' {
+		return 0, "", false
+	}
+
+	idx, err := strconv.Atoi(n)
+	if err != nil || idx < 0 {
+		return 0, "", false
+	}
+	return idx, tags, true
+}
+
+// tupleItems rewrites a tuple tag, e. g. 'required,tuple=uuid4;email;rest=numeric', into the positional rules
+// parsed by parseItemsTag, e. g. 'required;item0=uuid4;item1=email;rest=numeric', the tags before 'tuple='
+// applying to the collection itself, every segment after it being the rules of the next position
+// and empty segments skipping a position. Other tags are returned as is.
+func tupleItems(tag, fieldName string) string {
+	prefix := tupleTag + tagKeySeparator
+	i := strings.Index(tag, prefix)
+	for i > 0 && tag[i-1] != tagSeparator[0] {
+		// 'tuple=' within a param, e. g. 'contains=tuple='
+		j := strings.Index(tag[i+1:], prefix)
+		if j == -1 {
+			return tag
+		}
+		i += j + 1
+	}
+
+	if i == -1 {
+		return tag
+	}
+
+	own := strings.TrimSuffix(tag[:i], tagSeparator)
+	segments := strings.Split(tag[i+len(prefix):], versionSegmentSep)
+	items := make([]string, 0, len(segments)+1)
+	if len(own) > 0 {
+		items = append(items, own)
+	}
+
+	n := len(items)
+	for idx, segment := range segments {
+		switch {
+		case len(segment) == 0:
+		case strings.HasPrefix(segment, restTag+tagKeySeparator):
+			if idx != len(segments)-1 {
+				panic(fmt.Sprintf("'%s' must be the last position of the '%s' tag on field '%s'", restTag, tupleTag, fieldName))
+			}
+			items = append(items, segment)
+		default:
+			items = append(items, itemTag+strconv.Itoa(idx)+tagKeySeparator+segment)
+		}
+	}
+
+	if len(items) == n {
+		panic(fmt.Sprintf("'%s' tag has no positions on field '%s'", tupleTag, fieldName))
+	}
+	return strings.Join(items, versionSegmentSep)
+}
+
+// versionedTag returns the tags of version of a versioned tag, e. g. 'v1:required;v2:omitempty,uuid4',
+// the tags of a leading segment without a version applying to the versions without a segment,
+// e. g. 'required;v2:omitempty', and "" when there are none.
+// A tag without versions is returned as is.
+func versionedTag(tag, version string) string {
+	if !strings.Contains(tag, versionSep) {
+		return tag
+	}
+
+	var def, current string
+	segments := make(map[string]string)
+	for i, part := range strings.Split(tag, versionSegmentSep) {
+		if ver, tags, ok := cutVersion(part); ok {
+			current = ver
+			segments[ver] = tags
+			continue
+		}
+
+		switch {
+		case i == 0:
+			def = part
+		case len(current) == 0:
+			// a segment separator within a param, e. g. 'after=Start;2006-01-02'
+			def += versionSegmentSep + part
+		default:
+			segments[current] += versionSegmentSep + part
+		}
+	}
+
+	if len(segments) == 0 {
+		return tag
+	}
+
+	if tags, ok := segments[version]; ok {
+		return tags
+	}
+	return def
+}
+
+// recordTagVersions records the versions of a versioned tag, see versionCache.
+func (v *Validate) recordTagVersions(tag string) {
+	if !strings.Contains(tag, versionSep) {
+		return
+	}
+
+	for _, part := range strings.Split(tag, versionSegmentSep) {
+		if ver, _, ok := cutVersion(part); ok {
+			v.tagVersions.Store(ver, struct{}{})
+		}
+	}
+}
+
+// cutVersion returns the version and tags of a versioned tag segment, e. g. 'v2:omitempty,uuid4',
+// a version being 'v' followed by dot separated numbers, e. g. 'v2' or 'v2.1'.
+func cutVersion(segment string) (string, string, bool) {
+	ver, tags, ok := strings.Cut(segment, versionSep)
+	if !ok || len(ver) < 2 || ver[0] != 'v' {
+		return "", "", false
+	}
+
+	for _, num := range strings.Split(ver[1:], ".") {
+		if len(num) == 0 {
+			return "", "", false
+		}
+
+		for i := 0; i < len(num); i++ {
+			if num[i] < '0' || num[i] > '9' {
+				return "", "", false
+			}
+		}
+	}
+	return ver, tags, true
+}
@@ -0,0 +1,47 @@
+package validator
+
+import "context"
+
+// callStateKey is the context key of the callState of a validation call.
+type callStateKey struct{}
+
+// callState holds what a context passes to the validation calls it's passed to,
+// stored under a single context key so that a validation call looks it up once.
+type callState struct {
+	rec   *Recording // see NewRecordingContext
+	audit *Audit     // see NewAuditContext
+	warns *warnings  // see StructWithWarnings
+	skips *skips     // see StructSampled
+}
+
+// withCallState returns a copy of parent holding the callState of parent updated by set,
+// the callState of parent being left unchanged.
+func withCallState(parent context.Context, set func(cs *callState)) context.Context {
+	cs := new(callState)
+	if prev, ok := parent.Value(callStateKey{}).(*callState); ok {
+		*cs = *prev
+	}
+
+	set(cs)
+	return context.WithValue(parent, callStateKey{}, cs)
+}
+
+// callStateFrom returns the callState of the context or nil,
+// without the cost of a context lookup for the background context.
+func callStateFrom(ctx context.Context) *callState {
+	if ctx == nil || ctx == context.Background() {
+		return nil
+	}
+
+	cs, _ := ctx.Value(callStateKey{}).(*callState)
+	return cs
+}
+
+// loadCallState sets the recording, audit, warnings and skips of the validation call from ctx.
+func (v *validate) loadCallState(ctx context.Context) {
+	if cs := callStateFrom(ctx); cs != nil {
+		v.rec, v.audit, v.warns, v.skips = cs.rec, cs.audit, cs.warns, cs.skips
+	} else {
+		v.rec, v.audit, v.warns, v.skips = nil, nil, nil, nil
+	}
+}
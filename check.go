@@ -0,0 +1,188 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// CheckStruct parses the tags of the struct type of t and of the struct types nested within its fields,
+// returning every problem found without validating any value, e. g. in a test or at startup:
+// undefined validations and malformed tags, e. g. `validate:"required,"`,
+// params the validations can't parse, e. g. `validate:"len=a"`, validations not supporting the field's type,
+// and dive, keys, strsplit, jsonstruct and positional rules on fields of the wrong kind.
+//
+// The params are checked by running the baked in validations on the zero value of the fields' types,
+// custom validations and validateFn aren't run.
+// It returns InvalidValidationError for bad values passed in and nil or the joined problems as error otherwise.
+func (v *Validate) CheckStruct(t interface{}) error {
+	typ := reflect.TypeOf(t)
+	if typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ == nil || typ.Kind() != reflect.Struct || typ.ConvertibleTo(timeType) {
+		return &InvalidValidationError{Type: reflect.TypeOf(t)}
+	}
+
+	vd := &validate{v: v}
+	var errs []error
+	v.checkStructType(vd, typ, make(map[reflect.Type]struct{}), &errs)
+	return errors.Join(errs...)
+}
+
+// checkStructType appends the problems of the tags of the struct type typ and of its nested struct types to errs.
+func (v *Validate) checkStructType(vd *validate, typ reflect.Type, seen map[reflect.Type]struct{}, errs *[]error) {
+	if _, ok := seen[typ]; ok {
+		return
+	}
+	seen[typ] = struct{}{}
+
+	parent := reflect.New(typ).Elem()
+	rules := v.rules[typ]
+	for i := 0; i < typ.NumField(); i++ {
+		fld := typ.Field(i)
+		if !v.privateFieldValidation && !fld.Anonymous && len(fld.PkgPath) > 0 {
+			continue
+		}
+
+		tag, ok := rules[fld.Name]
+		if !ok {
+			tag = fld.Tag.Get(v.tagName)
+		}
+
+		tag, _ = cutSensitive(versionedTag(tag, ""))
+		if tag == skipValidationTag {
+			continue
+		}
+
+		if len(tag) > 0 {
+			report := func(err error) {
+				*errs = append(*errs, fmt.Errorf("field '%s' on struct '%s': %w", fld.Name, typ.Name(), err))
+			}
+
+			if ct, err := v.parseCheckedTag(tag, fld.Name); err != nil {
+				report(err)
+			} else {
+				cf := &cField{idx: i, name: fld.Name, altName: fld.Name, namesEqual: true}
+				v.checkTags(vd, parent, cf, ct, fld.Type, report)
+			}
+		}
+
+		if nested := v.coverStructType(fld.Type); nested != nil {
+			v.checkStructType(vd, nested, seen, errs)
+		}
+	}
+}
+
+// parseCheckedTag parses tag, returning its panic as error.
+func (v *Validate) parseCheckedTag(tag, fieldName string) (ct *cTag, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	ct, _ = v.parseFieldTagsRecursive(tag, fieldName, "", false)
+	return ct, nil
+}
+
+// checkTags reports the problems of the ct chain applied to the values of type typ.
+func (v *Validate) checkTags(vd *validate, parent reflect.Value, cf *cField, ct *cTag, typ reflect.Type, report func(error)) {
+	for ; ct != nil; ct = ct.next {
+		for typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+
+		if fn, ok := v.customFuncs[typ]; ok {
+			converted := fn(reflect.New(typ).Elem())
+			if converted == nil {
+				return
+			}
+			typ = reflect.TypeOf(converted)
+		}
+
+		if typ.Kind() == reflect.Interface {
+			// the type of the values is only known when validating
+			return
+		}
+
+		switch ct.typeof {
+		case typeDive:
+			switch typ.Kind() {
+			case reflect.Slice, reflect.Array:
+				typ = typ.Elem()
+			case reflect.Map:
+				if ct.next != nil && ct.next.typeof == typeKeys {
+					v.checkTags(vd, parent, cf, ct.next.keys, typ.Key(), report)
+					ct = ct.next
+				}
+				typ = typ.Elem()
+			default:
+				report(fmt.Errorf("'%s' can only be used on slices, arrays and maps, not %s", diveTag, typ))
+				return
+			}
+		case typeItems:
+			if typ.Kind() != reflect.Slice && typ.Kind() != reflect.Array {
+				report(fmt.Errorf("positional rules can only be used on arrays and slices, not %s", typ))
+				return
+			}
+
+			for _, itemCt := range ct.items {
+				if itemCt != nil {
+					v.checkTags(vd, parent, cf, itemCt, typ.Elem(), report)
+				}
+			}
+
+			if ct.rest != nil {
+				v.checkTags(vd, parent, cf, ct.rest, typ.Elem(), report)
+			}
+			return
+		case typeStrSplit:
+			if typ.Kind() != reflect.String {
+				report(fmt.Errorf("'%s' can only be used on strings, not %s", strSplitTag, typ))
+				return
+			}
+			typ = reflect.TypeOf([]string(nil))
+		case typeJSONStruct:
+			if typ.Kind() != reflect.String && (typ.Kind() != reflect.Slice || typ.Elem().Kind() != reflect.Uint8) {
+				report(fmt.Errorf("'%s' can only be used on strings and []byte, not %s", jsonStructTag, typ))
+				return
+			}
+			typ = ct.jsonType
+		case typeDefault, typeOr:
+			if err := checkParam(vd, parent, cf, ct, typ); err != nil {
+				report(err)
+			}
+		}
+	}
+}
+
+// checkParam runs the baked in validation of ct on the zero value of typ, returning its panic as error,
+// e. g. for a param it can't parse or a type it doesn't support.
+func checkParam(vd *validate, parent reflect.Value, cf *cField, ct *cTag, typ reflect.Type) (err error) {
+	// validateFn runs the methods of the values
+	if _, ok := bakedInValidators[ct.tag]; !ok || ct.fn == nil || ct.tag == "validateFn" {
+		return nil
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tag := ct.tag
+			if ct.hasParam {
+				tag += tagKeySeparator + ct.param
+			}
+			err = fmt.Errorf("'%s' on %s: %v", tag, typ, r)
+		}
+	}()
+
+	vd.top = parent
+	vd.slflParent = parent
+	vd.flField = reflect.New(typ).Elem()
+	vd.fldIsPointer = false
+	vd.cf = cf
+	vd.ct = ct
+	ct.fn(context.Background(), vd)
+	return nil
+}
@@ -0,0 +1,63 @@
+// Command validator-gen generates reflection-free Validate methods from the `validate` tags
+// of the struct types of a package, see the codegen package, e. g. from a go:generate directive:
+//
+//	//go:generate go run github.com/pchchv/validator/cmd/validator-gen -type User,Order
+//
+// The methods are written to the file given by -output, by default the lowercased name
+// of the first type followed by "_validator.go", in the package directory given as argument
+// or the current directory.
+// The command exits with status 1 if the methods can't be generated, or 2 on usage errors.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pchchv/validator/codegen"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stderr))
+}
+
+func run(args []string, stderr io.Writer) int {
+	flags := flag.NewFlagSet("validator-gen", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	typeNames := flags.String("type", "", "comma-separated names of the struct types to generate Validate methods for")
+	output := flags.String("output", "", "name of the generated file, defaults to <type>_validator.go")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if *typeNames == "" || flags.NArg() > 1 {
+		fmt.Fprintln(stderr, "validator-gen: -type is required and at most one package directory can be given")
+		flags.Usage()
+		return 2
+	}
+
+	dir := "."
+	if flags.NArg() == 1 {
+		dir = flags.Arg(0)
+	}
+
+	types := strings.Split(*typeNames, ",")
+	src, err := codegen.Generate(dir, types...)
+	if err != nil {
+		fmt.Fprintln(stderr, "validator-gen:", err)
+		return 1
+	}
+
+	if *output == "" {
+		*output = strings.ToLower(types[0]) + "_validator.go"
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, *output), src, 0o644); err != nil {
+		fmt.Fprintln(stderr, "validator-gen:", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/pchchv/go-assert"
+)
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	src := "package user\n\ntype User struct {\n\tName string `validate:\"required\"`\n\tEmail string `validate:\"email\"`\n}\n"
+	Equal(t, os.WriteFile(filepath.Join(dir, "user.go"), []byte(src), 0o600), nil)
+
+	var stderr bytes.Buffer
+	code := run([]string{"-type", "User", dir}, &stderr)
+	Equal(t, code, 1)
+	Equal(t, stderr.String(), "validator-gen: codegen: field User.Email: unsupported tag 'email'\n")
+
+	src = strings.Replace(src, "email", "alphanum", 1)
+	Equal(t, os.WriteFile(filepath.Join(dir, "user.go"), []byte(src), 0o600), nil)
+	code = run([]string{"-type", "User", dir}, &stderr)
+	Equal(t, code, 0)
+
+	generated, err := os.ReadFile(filepath.Join(dir, "user_validator.go"))
+	Equal(t, err, nil)
+	Equal(t, strings.Contains(string(generated), "func (u *User) Validate() validator.ValidationErrors {"), true)
+
+	code = run([]string{"-type", "User", "-output", "generated.go", dir}, &stderr)
+	Equal(t, code, 0)
+	_, err = os.Stat(filepath.Join(dir, "generated.go"))
+	Equal(t, err, nil)
+
+	code = run([]string{dir}, &stderr)
+	Equal(t, code, 2)
+}
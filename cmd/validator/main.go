@@ -0,0 +1,250 @@
+// Command validator validates JSON and YAML documents against named rule sets
+// using the same tags and semantics as the validator package.
+//
+// Rule sets are loaded from a JSON or YAML file mapping a rule set name to
+// ValidateMap style rules, nested objects describe nested documents,
+// and the elements of arrays of nested documents:
+//
+//	{
+//	    "user": {
+//	        "name": "required",
+//	        "email": "required,email",
+//	        "address": {"city": "required"}
+//	    }
+//	}
+//
+// Documents are read from the files given as arguments or from stdin:
+//
+//	validator -rules rules.json -set user < user.json
+//	validator -rules rules.json -set user fixtures/*.json
+//	validator -rules rules.yaml -set user -format yaml < user.yaml
+//
+// Files are decoded as YAML when their name ends with .yaml or .yml,
+// as JSON otherwise, the -format flag setting the format of stdin and of the documents.
+//
+// The -strict flag also rejects the document keys having no rules and the keys
+// having rules missing from the documents, see validator.WithStrictMaps.
+//
+// Every failure is printed as "namespace: failed on the 'tag' tag", e.g.
+// "phones[1].number: failed on the 'e164' tag", and the command exits
+// with status 1 if any document is invalid, or 2 on usage errors.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/pchchv/validator"
+	"gopkg.in/yaml.v3"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("validator", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	rulesPath := flags.String("rules", "", "path to the JSON or YAML file with the named rule sets")
+	set := flags.String("set", "", "name of the rule set to validate the documents against")
+	strict := flags.Bool("strict", false, "reject the document keys having no rules and the missing keys having rules")
+	format := flags.String("format", "", "format of the documents, json or yaml, defaults to the file extension and json for stdin")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if *format != "" && *format != "json" && *format != "yaml" {
+		fmt.Fprintf(stderr, "validator: unknown format %q\n", *format)
+		return 2
+	}
+
+	if *rulesPath == "" || *set == "" {
+		fmt.Fprintln(stderr, "validator: -rules and -set are required")
+		flags.Usage()
+		return 2
+	}
+
+	rules, err := loadRules(*rulesPath, *set)
+	if err != nil {
+		fmt.Fprintln(stderr, "validator:", err)
+		return 2
+	}
+
+	options := []validator.Option{validator.WithRequiredStructEnabled()}
+	if *strict {
+		options = append(options, validator.WithStrictMaps())
+	}
+
+	validate := validator.New(options...)
+	if flags.NArg() == 0 {
+		return check(validate, rules, formatOf(*format, ""), "", stdin, stdout, stderr)
+	}
+
+	status := 0
+	for _, path := range flags.Args() {
+		f, err := os.Open(path)
+		if err != nil {
+			fmt.Fprintln(stderr, "validator:", err)
+			return 2
+		}
+
+		code := check(validate, rules, formatOf(*format, path), path+": ", f, stdout, stderr)
+		f.Close()
+		if code > status {
+			status = code
+		}
+	}
+	return status
+}
+
+// loadRules returns the rule set named set from the rules file at path.
+func loadRules(path, set string) (map[string]interface{}, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var sets map[string]map[string]interface{}
+	if formatOf("", path) == "yaml" {
+		err = yaml.Unmarshal(b, &sets)
+	} else {
+		err = json.Unmarshal(b, &sets)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid rules file %s: %w", path, err)
+	}
+
+	rules, ok := sets[set]
+	if !ok {
+		return nil, fmt.Errorf("rule set %q not found in %s", set, path)
+	}
+	return rules, nil
+}
+
+// formatOf returns the format of the document at path, format when set,
+// yaml for the .yaml and .yml files and json otherwise.
+func formatOf(format, path string) string {
+	if format != "" {
+		return format
+	}
+
+	if ext := filepath.Ext(path); ext == ".yaml" || ext == ".yml" {
+		return "yaml"
+	}
+	return "json"
+}
+
+// check validates the document in format read from r and prints its errors prefixed by prefix.
+func check(validate *validator.Validate, rules map[string]interface{}, format, prefix string, r io.Reader, stdout, stderr io.Writer) int {
+	var doc map[string]interface{}
+	var err error
+	if format == "yaml" {
+		err = yaml.NewDecoder(r).Decode(&doc)
+	} else {
+		err = json.NewDecoder(r).Decode(&doc)
+	}
+
+	if err != nil {
+		fmt.Fprintf(stderr, "validator: %sinvalid %s document: %v\n", prefix, format, err)
+		return 2
+	}
+
+	lines := validateDoc(validate, "", doc, rules)
+	if len(lines) == 0 {
+		return 0
+	}
+
+	sort.Strings(lines)
+	for _, line := range lines {
+		fmt.Fprintln(stdout, prefix+line)
+	}
+	return 1
+}
+
+// validateDoc validates doc against rules and returns the error lines namespaced by ns.
+// The nested documents are validated one by one, the elements of arrays keeping their index
+// in the namespaces, the other fields being validated by ValidateMap.
+func validateDoc(validate *validator.Validate, ns string, doc map[string]interface{}, rules map[string]interface{}) (lines []string) {
+	flatDoc := make(map[string]interface{}, len(doc))
+	flatRules := make(map[string]interface{}, len(rules))
+	for field, value := range doc {
+		flatDoc[field] = value
+	}
+
+	for field, rule := range rules {
+		ruleObj, ok := rule.(map[string]interface{})
+		if !ok {
+			flatRules[field] = rule
+			continue
+		}
+
+		fieldNs := join(ns, field)
+		switch t := doc[field].(type) {
+		case map[string]interface{}:
+			lines = append(lines, validateDoc(validate, fieldNs, t, ruleObj)...)
+		case []interface{}:
+			objs := make([]map[string]interface{}, 0, len(t))
+			for _, e := range t {
+				if obj, ok := e.(map[string]interface{}); ok {
+					objs = append(objs, obj)
+				}
+			}
+
+			if len(objs) != len(t) {
+				flatRules[field] = rule
+				continue
+			}
+
+			for i, obj := range objs {
+				lines = append(lines, validateDoc(validate, fieldNs+"["+strconv.Itoa(i)+"]", obj, ruleObj)...)
+			}
+		default:
+			flatRules[field] = rule
+			continue
+		}
+		delete(flatDoc, field)
+	}
+	return append(lines, flatten(ns, validate.ValidateMap(flatDoc, flatRules))...)
+}
+
+// join returns the namespace of field nested in ns.
+func join(ns, field string) string {
+	if ns == "" {
+		return field
+	}
+	return ns + "." + field
+}
+
+// flatten turns the nested error map returned by ValidateMap into namespaced error lines.
+func flatten(ns string, errs map[string]interface{}) (lines []string) {
+	for field, e := range errs {
+		fieldNs := join(ns, field)
+		switch t := e.(type) {
+		case map[string]interface{}:
+			lines = append(lines, flatten(fieldNs, t)...)
+		case error:
+			var ve validator.ValidationErrors
+			if !errors.As(t, &ve) {
+				lines = append(lines, fieldNs+": "+t.Error())
+				continue
+			}
+
+			for _, fe := range ve {
+				if fe.Param() != "" {
+					lines = append(lines, fmt.Sprintf("%s: failed on the '%s=%s' tag", fieldNs, fe.Tag(), fe.Param()))
+				} else {
+					lines = append(lines, fmt.Sprintf("%s: failed on the '%s' tag", fieldNs, fe.Tag()))
+				}
+			}
+		}
+	}
+	return
+}
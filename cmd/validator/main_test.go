@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/pchchv/go-assert"
+)
+
+const testRules = `{
+	"user": {
+		"name": "required",
+		"email": "required,email",
+		"age": "gte=0,lte=130",
+		"address": {"city": "required"},
+		"phones": {"number": "required,e164"}
+	}
+}`
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	rulesPath := filepath.Join(dir, "rules.json")
+	Equal(t, os.WriteFile(rulesPath, []byte(testRules), 0o600), nil)
+
+	var stdout, stderr bytes.Buffer
+	valid := `{"name":"joey","email":"joey@bloggs.com","age":30,"address":{"city":"Rome"},"phones":[{"number":"+14155552671"}]}`
+	code := run([]string{"-rules", rulesPath, "-set", "user"}, strings.NewReader(valid), &stdout, &stderr)
+	Equal(t, code, 0)
+	Equal(t, stdout.String(), "")
+
+	stdout.Reset()
+	invalid := `{"email":"joey","age":150,"address":{},"phones":[{"number":"+14155552671"},{"number":"123"}]}`
+	code = run([]string{"-rules", rulesPath, "-set", "user"}, strings.NewReader(invalid), &stdout, &stderr)
+	Equal(t, code, 1)
+	Equal(t, stdout.String(), "address.city: failed on the 'required' tag\n"+
+		"age: failed on the 'lte=130' tag\n"+
+		"email: failed on the 'email' tag\n"+
+		"name: failed on the 'required' tag\n"+
+		"phones[1].number: failed on the 'e164' tag\n")
+
+	docPath := filepath.Join(dir, "doc.json")
+	Equal(t, os.WriteFile(docPath, []byte(`{"email":"joey@bloggs.com","age":1,"address":{"city":"Rome"}}`), 0o600), nil)
+	stdout.Reset()
+	code = run([]string{"-rules", rulesPath, "-set", "user", docPath}, nil, &stdout, &stderr)
+	Equal(t, code, 1)
+	Equal(t, strings.HasPrefix(stdout.String(), docPath+": name: failed on the 'required' tag\n"), true)
+
+	stderr.Reset()
+	code = run([]string{"-rules", rulesPath, "-set", "order"}, strings.NewReader(valid), &stdout, &stderr)
+	Equal(t, code, 2)
+	Equal(t, strings.Contains(stderr.String(), `rule set "order" not found`), true)
+
+	code = run([]string{"-rules", rulesPath}, strings.NewReader(valid), &stdout, &stderr)
+	Equal(t, code, 2)
+
+	stdout.Reset()
+	code = run([]string{"-rules", rulesPath, "-set", "user", "-strict"}, strings.NewReader(valid), &stdout, &stderr)
+	Equal(t, code, 0)
+
+	extra := `{"name":"joey","email":"joey@bloggs.com","admin":true,"address":{"city":"Rome","zip":"00100"},"phones":[{"number":"+14155552671"}]}`
+	code = run([]string{"-rules", rulesPath, "-set", "user", "-strict"}, strings.NewReader(extra), &stdout, &stderr)
+	Equal(t, code, 1)
+	Equal(t, stdout.String(), "address.zip: failed on the 'unknown_key' tag\n"+
+		"admin: failed on the 'unknown_key' tag\n"+
+		"age: failed on the 'missing_key' tag\n")
+}
+
+const testYAMLRules = `
+user:
+  name: required
+  email: required,email
+  address:
+    city: required
+  phones:
+    number: required,e164
+`
+
+func TestRunYAML(t *testing.T) {
+	dir := t.TempDir()
+	rulesPath := filepath.Join(dir, "rules.yaml")
+	Equal(t, os.WriteFile(rulesPath, []byte(testYAMLRules), 0o600), nil)
+
+	var stdout, stderr bytes.Buffer
+	valid := "name: joey\nemail: joey@bloggs.com\naddress:\n  city: Rome\nphones: []\n"
+	code := run([]string{"-rules", rulesPath, "-set", "user", "-format", "yaml"}, strings.NewReader(valid), &stdout, &stderr)
+	Equal(t, code, 0)
+	Equal(t, stdout.String(), "")
+
+	docPath := filepath.Join(dir, "doc.yml")
+	invalid := "email: joey\naddress:\n  city: Rome\nphones:\n  - number: \"+14155552671\"\n  - number: \"123\"\n"
+	Equal(t, os.WriteFile(docPath, []byte(invalid), 0o600), nil)
+	code = run([]string{"-rules", rulesPath, "-set", "user", docPath}, nil, &stdout, &stderr)
+	Equal(t, code, 1)
+	Equal(t, stdout.String(), docPath+": email: failed on the 'email' tag\n"+
+		docPath+": name: failed on the 'required' tag\n"+
+		docPath+": phones[1].number: failed on the 'e164' tag\n")
+
+	// JSON documents are decoded as JSON whatever the format of the rules
+	stdout.Reset()
+	code = run([]string{"-rules", rulesPath, "-set", "user"}, strings.NewReader(`{"name":"joey","email":"joey@bloggs.com","address":{"city":"Rome"},"phones":[]}`), &stdout, &stderr)
+	Equal(t, code, 0)
+
+	stderr.Reset()
+	code = run([]string{"-rules", rulesPath, "-set", "user"}, strings.NewReader(valid), &stdout, &stderr)
+	Equal(t, code, 2)
+	Equal(t, strings.Contains(stderr.String(), "invalid json document"), true)
+
+	code = run([]string{"-rules", rulesPath, "-set", "user", "-format", "toml"}, strings.NewReader(valid), &stdout, &stderr)
+	Equal(t, code, 2)
+}
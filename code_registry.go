@@ -0,0 +1,267 @@
+package validator
+
+import "strings"
+
+const (
+	countryGroupEU       = "eu"
+	countryGroupEEA      = "eea"
+	countryGroupEFTA     = "efta"
+	countryGroupSchengen = "schengen"
+	countryGroupOECD     = "oecd"
+)
+
+var (
+	iso3166_1_by_alpha3  = make(map[string]string, len(iso3166_1))
+	iso3166_1_by_numeric = make(map[int]string, len(iso3166_1))
+	defaultCountryGroups = map[string]map[string]struct{}{
+		countryGroupEU:       iso3166_1_alpha2_eu,
+		countryGroupEEA:      iso3166_1_alpha2_eea,
+		countryGroupEFTA:     iso3166_1_alpha2_efta,
+		countryGroupSchengen: iso3166_1_alpha2_schengen,
+		countryGroupOECD:     iso3166_1_alpha2_oecd,
+	}
+	defaultCountries = &codeSet{
+		alpha2:  iso3166_1_alpha2,
+		alpha3:  iso3166_1_alpha3,
+		numeric: iso3166_1_alpha_numeric,
+	}
+	defaultCurrencies = &codeSet{
+		alpha3:  iso4217,
+		numeric: iso4217_numeric,
+	}
+)
+
+func init() {
+	for alpha2, code := range iso3166_1 {
+		iso3166_1_by_alpha3[code.alpha3] = alpha2
+		iso3166_1_by_numeric[code.numeric] = alpha2
+	}
+}
+
+// countryCode holds the alpha-3 and numeric forms of an iso3166-1 alpha-2 country code.
+type countryCode struct {
+	alpha3  string
+	numeric int
+}
+
+// codeSet is a set of codes in their alphabetic and numeric forms,
+// used to back the iso3166_1 and iso4217 tags.
+type codeSet struct {
+	alpha2  map[string]struct{}
+	alpha3  map[string]struct{}
+	numeric map[int]struct{}
+}
+
+// clone returns a deep copy of the codeSet,
+// so it can be modified without affecting the package level datasets.
+func (cs *codeSet) clone() *codeSet {
+	c := &codeSet{
+		alpha2:  make(map[string]struct{}, len(cs.alpha2)),
+		alpha3:  make(map[string]struct{}, len(cs.alpha3)),
+		numeric: make(map[int]struct{}, len(cs.numeric)),
+	}
+	for k := range cs.alpha2 {
+		c.alpha2[k] = struct{}{}
+	}
+
+	for k := range cs.alpha3 {
+		c.alpha3[k] = struct{}{}
+	}
+
+	for k := range cs.numeric {
+		c.numeric[k] = struct{}{}
+	}
+
+	return c
+}
+
+// add adds the non empty codes to the set, a negative numeric code is ignored.
+func (cs *codeSet) add(alpha2, alpha3 string, numeric int) {
+	if len(alpha2) > 0 {
+		cs.alpha2[strings.ToUpper(alpha2)] = struct{}{}
+	}
+
+	if len(alpha3) > 0 {
+		cs.alpha3[strings.ToUpper(alpha3)] = struct{}{}
+	}
+
+	if numeric >= 0 {
+		cs.numeric[numeric] = struct{}{}
+	}
+}
+
+// remove removes the non empty codes from the set, a negative numeric code is ignored.
+func (cs *codeSet) remove(alpha2, alpha3 string, numeric int) {
+	if len(alpha2) > 0 {
+		delete(cs.alpha2, strings.ToUpper(alpha2))
+	}
+
+	if len(alpha3) > 0 {
+		delete(cs.alpha3, strings.ToUpper(alpha3))
+	}
+
+	if numeric >= 0 {
+		delete(cs.numeric, numeric)
+	}
+}
+
+// AddCountry adds a country to the iso3166_1_alpha2, iso3166_1_alpha3 and
+// iso3166_1_alpha_numeric tags of this validator instance,
+// e. g. to accept a newly assigned code without waiting for a library release.
+// Empty codes and a negative numeric code are ignored,
+// so any of the forms can be added on its own.
+//
+// NOTE: this method is not thread-safe it is intended that these all be registered prior to any validation.
+func (v *Validate) AddCountry(alpha2, alpha3 string, numeric int) {
+	if v.countries == nil {
+		v.countries = defaultCountries.clone()
+	}
+
+	v.countries.add(alpha2, alpha3, numeric)
+}
+
+// RemoveCountry removes a country from the iso3166_1_alpha2, iso3166_1_alpha3 and
+// iso3166_1_alpha_numeric tags of this validator instance,
+// e. g. to exclude sanctioned countries.
+// Empty codes and a negative numeric code are ignored.
+//
+// NOTE: this method is not thread-safe it is intended that these all be registered prior to any validation.
+func (v *Validate) RemoveCountry(alpha2, alpha3 string, numeric int) {
+	if v.countries == nil {
+		v.countries = defaultCountries.clone()
+	}
+
+	v.countries.remove(alpha2, alpha3, numeric)
+}
+
+// AddCurrency adds a currency to the iso4217 and iso4217_numeric tags of this validator instance.
+// An empty code or a negative numeric code is ignored,
+// so either of the forms can be added on its own.
+//
+// NOTE: this method is not thread-safe it is intended that these all be registered prior to any validation.
+func (v *Validate) AddCurrency(code string, numeric int) {
+	if v.currencies == nil {
+		v.currencies = defaultCurrencies.clone()
+	}
+
+	v.currencies.add("", code, numeric)
+}
+
+// RemoveCurrency removes a currency from the iso4217 and iso4217_numeric tags of this validator instance.
+// An empty code or a negative numeric code is ignored.
+//
+// NOTE: this method is not thread-safe it is intended that these all be registered prior to any validation.
+func (v *Validate) RemoveCurrency(code string, numeric int) {
+	if v.currencies == nil {
+		v.currencies = defaultCurrencies.clone()
+	}
+
+	v.currencies.remove("", code, numeric)
+}
+
+// SetEUCountries replaces the European Union member states used by the
+// iso3166_1_alpha2_eu, iso3166_1_alpha3_eu and iso3166_1_alpha_numeric_eu tags
+// of this validator instance with the given iso3166-1 alpha-2 codes.
+// The alpha-3 and numeric forms are derived from the alpha-2 codes.
+//
+// NOTE: this method is not thread-safe it is intended that these all be registered prior to any validation.
+func (v *Validate) SetEUCountries(codes []string) {
+	v.setCountryGroup(countryGroupEU, codes)
+}
+
+// SetEEACountries replaces the European Economic Area member states used by the
+// iso3166_1_alpha2_eea, iso3166_1_alpha3_eea and iso3166_1_alpha_numeric_eea tags
+// of this validator instance with the given iso3166-1 alpha-2 codes.
+//
+// NOTE: this method is not thread-safe it is intended that these all be registered prior to any validation.
+func (v *Validate) SetEEACountries(codes []string) {
+	v.setCountryGroup(countryGroupEEA, codes)
+}
+
+// SetEFTACountries replaces the European Free Trade Association member states used by the
+// iso3166_1_alpha2_efta, iso3166_1_alpha3_efta and iso3166_1_alpha_numeric_efta tags
+// of this validator instance with the given iso3166-1 alpha-2 codes.
+//
+// NOTE: this method is not thread-safe it is intended that these all be registered prior to any validation.
+func (v *Validate) SetEFTACountries(codes []string) {
+	v.setCountryGroup(countryGroupEFTA, codes)
+}
+
+// SetSchengenCountries replaces the Schengen Area member states used by the
+// iso3166_1_alpha2_schengen, iso3166_1_alpha3_schengen and iso3166_1_alpha_numeric_schengen tags
+// of this validator instance with the given iso3166-1 alpha-2 codes.
+//
+// NOTE: this method is not thread-safe it is intended that these all be registered prior to any validation.
+func (v *Validate) SetSchengenCountries(codes []string) {
+	v.setCountryGroup(countryGroupSchengen, codes)
+}
+
+// RegisterCountryGroup registers a named group of countries, given by their iso3166-1 alpha-2 codes,
+// for use with the country_group tag of this validator instance, e. g.
+//
+//	validate.RegisterCountryGroup("supported_markets", []string{"DE", "FR", "NL"})
+//
+// and `validate:"country_group=supported_markets"`.
+// Registering a group of an existing name replaces its members,
+// including the built-in eu, eea, efta, schengen and oecd groups.
+//
+// NOTE: this method is not thread-safe it is intended that these all be registered prior to any validation.
+func (v *Validate) RegisterCountryGroup(name string, codes []string) {
+	if len(name) == 0 {
+		panic("country group name cannot be empty")
+	}
+
+	v.setCountryGroup(name, codes)
+}
+
+func (v *Validate) setCountryGroup(group string, codes []string) {
+	if v.countryGroups == nil {
+		v.countryGroups = make(map[string]map[string]struct{}, len(defaultCountryGroups)+1)
+		for name, members := range defaultCountryGroups {
+			v.countryGroups[name] = members
+		}
+	}
+
+	members := make(map[string]struct{}, len(codes))
+	for _, code := range codes {
+		members[strings.ToUpper(code)] = struct{}{}
+	}
+
+	v.countryGroups[group] = members
+}
+
+// countryGroupOf returns the members of the country group registered on the validator,
+// or the default ones.
+func countryGroupOf(fl FieldLevel, group string) map[string]struct{} {
+	members, _ := lookupCountryGroup(fl, group)
+	return members
+}
+
+// lookupCountryGroup returns the members of the country group and whether the group exists.
+func lookupCountryGroup(fl FieldLevel, group string) (map[string]struct{}, bool) {
+	if v, ok := fl.(*validate); ok && v.v.countryGroups != nil {
+		members, ok := v.v.countryGroups[group]
+		return members, ok
+	}
+
+	members, ok := defaultCountryGroups[group]
+	return members, ok
+}
+
+// countriesOf returns the countries registered on the validator, or the default ones.
+func countriesOf(fl FieldLevel) *codeSet {
+	if v, ok := fl.(*validate); ok && v.v.countries != nil {
+		return v.v.countries
+	}
+
+	return defaultCountries
+}
+
+// currenciesOf returns the currencies registered on the validator, or the default ones.
+func currenciesOf(fl FieldLevel) *codeSet {
+	if v, ok := fl.(*validate); ok && v.v.currencies != nil {
+		return v.v.currencies
+	}
+
+	return defaultCurrencies
+}
@@ -0,0 +1,585 @@
+// Package codegen generates reflection-free validation methods from the `validate` tags of struct types,
+// the tags staying the single source of truth, e. g. using the validator-gen command:
+//
+//	//go:generate go run github.com/pchchv/validator/cmd/validator-gen -type User
+//
+//	type User struct {
+//	    Name    string   `validate:"required,max=64"`
+//	    Age     *int     `validate:"omitempty,gte=18"`
+//	    Address *Address `validate:"required"`
+//	}
+//
+// generates the method
+//
+//	func (u *User) Validate() validator.ValidationErrors
+//
+// reporting the same errors as Validate.Struct of a validator without custom options,
+// for the teams needing the throughput of validation code written by hand.
+// The struct types of the package nested within the fields are generated too.
+//
+// Only the required, omitempty, len, min, max, eq, ne, gt, gte, lt, lte and oneof tags,
+// and the baked in tags matching strings against a regular expression, e. g. alphanum or uuid4, are supported.
+// Generate returns an error for the other tags rather than ignoring them,
+// as for the tags of fields whose types are declared in other packages, which aren't traversed.
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Header is the first line of the generated files.
+const Header = "// Code generated by github.com/pchchv/validator/codegen. DO NOT EDIT."
+
+// regexTags are the supported tags matching strings against the regular expression of validator.RegexPatterns.
+var regexTags = map[string]struct{}{
+	"alpha":           {},
+	"alphanum":        {},
+	"alphaunicode":    {},
+	"alphanumunicode": {},
+	"numeric":         {},
+	"number":          {},
+	"hexadecimal":     {},
+	"e164":            {},
+	"uuid":            {},
+	"uuid3":           {},
+	"uuid4":           {},
+	"uuid5":           {},
+}
+
+// basicKinds are the kinds of the predeclared types of the supported fields.
+var basicKinds = map[string]string{
+	"string":  "string",
+	"bool":    "bool",
+	"int":     "int",
+	"int8":    "int",
+	"int16":   "int",
+	"int32":   "int",
+	"int64":   "int",
+	"rune":    "int",
+	"uint":    "uint",
+	"uint8":   "uint",
+	"uint16":  "uint",
+	"uint32":  "uint",
+	"uint64":  "uint",
+	"uintptr": "uint",
+	"byte":    "uint",
+	"float32": "float",
+	"float64": "float",
+}
+
+// bitSizes are the sizes of the predeclared number types,
+// int, uint and uintptr having the size of the platform running the generator.
+var bitSizes = map[string]int{
+	"int":     strconv.IntSize,
+	"int8":    8,
+	"int16":   16,
+	"int32":   32,
+	"int64":   64,
+	"rune":    32,
+	"uint":    strconv.IntSize,
+	"uint8":   8,
+	"uint16":  16,
+	"uint32":  32,
+	"uint64":  64,
+	"uintptr": strconv.IntSize,
+	"byte":    8,
+	"float32": 32,
+	"float64": 64,
+}
+
+// fieldType is the resolved type of a struct field.
+type fieldType struct {
+	ptr   bool
+	kind  string // string, bool, int, uint, float, slice, map or struct
+	bits  int    // size of number kinds
+	name  string // name of struct kinds
+	named bool   // declared type, e. g. 'type Role string', converted for the functions taking strings
+}
+
+// generator holds the state of a Generate call.
+type generator struct {
+	specs   map[string]*ast.TypeSpec
+	buf     bytes.Buffer
+	queue   []string
+	queued  map[string]struct{}
+	regexes map[string]struct{}
+	utf8    bool
+}
+
+// Generate returns the formatted source of the Validate methods of the struct types named types,
+// and of the struct types of the package nested within their fields,
+// parsed from the Go files of the package in dir, test files excepted.
+// It returns an error if a type isn't a struct type of the package or if a tag isn't supported.
+func Generate(dir string, types ...string) ([]byte, error) {
+	if len(types) == 0 {
+		return nil, fmt.Errorf("codegen: no types to generate")
+	}
+
+	pkg, specs, err := parsePackage(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &generator{specs: specs, queued: make(map[string]struct{}), regexes: make(map[string]struct{})}
+	for _, name := range types {
+		g.enqueue(name)
+	}
+
+	for len(g.queue) > 0 {
+		name := g.queue[0]
+		g.queue = g.queue[1:]
+		if err := g.structType(name); err != nil {
+			return nil, err
+		}
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "%s\n\npackage %s\n\nimport (\n", Header, pkg)
+	if len(g.regexes) > 0 {
+		out.WriteString("\t\"regexp\"\n")
+	}
+
+	if g.utf8 {
+		out.WriteString("\t\"unicode/utf8\"\n")
+	}
+	out.WriteString("\n\t\"github.com/pchchv/validator\"\n)\n")
+	out.Write(g.buf.Bytes())
+
+	tags := make([]string, 0, len(g.regexes))
+	for tag := range g.regexes {
+		tags = append(tags, tag)
+	}
+
+	if len(tags) > 0 {
+		sort.Strings(tags)
+		out.WriteString("\nvar (\n")
+		for _, tag := range tags {
+			fmt.Fprintf(&out, "%s = regexp.MustCompile(validator.RegexPatterns(%q)[0])\n", regexVar(tag), tag)
+		}
+		out.WriteString(")\n")
+	}
+	return format.Source(out.Bytes())
+}
+
+// parsePackage returns the package name and the type declarations of the Go files in dir.
+func parsePackage(dir string) (string, map[string]*ast.TypeSpec, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return "", nil, err
+	}
+
+	var pkg string
+	fset := token.NewFileSet()
+	specs := make(map[string]*ast.TypeSpec)
+	for _, path := range files {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return "", nil, err
+		}
+
+		f, err := parser.ParseFile(fset, path, src, parser.SkipObjectResolution)
+		if err != nil {
+			return "", nil, err
+		}
+
+		pkg = f.Name.Name
+		for _, decl := range f.Decls {
+			if gd, ok := decl.(*ast.GenDecl); ok && gd.Tok == token.TYPE {
+				for _, spec := range gd.Specs {
+					ts := spec.(*ast.TypeSpec)
+					specs[ts.Name.Name] = ts
+				}
+			}
+		}
+	}
+
+	if len(pkg) == 0 {
+		return "", nil, fmt.Errorf("codegen: no Go files in %s", dir)
+	}
+	return pkg, specs, nil
+}
+
+// enqueue queues the struct type name to be generated once.
+func (g *generator) enqueue(name string) {
+	if _, ok := g.queued[name]; !ok {
+		g.queued[name] = struct{}{}
+		g.queue = append(g.queue, name)
+	}
+}
+
+// structType writes the Validate and validateFields methods of the struct type name.
+func (g *generator) structType(name string) error {
+	spec, ok := g.specs[name]
+	if !ok {
+		return fmt.Errorf("codegen: type %s not found", name)
+	}
+
+	st, ok := spec.Type.(*ast.StructType)
+	if !ok || spec.TypeParams != nil {
+		return fmt.Errorf("codegen: type %s must be a non-generic struct type", name)
+	}
+
+	recv := strings.ToLower(name[:1])
+	fmt.Fprintf(&g.buf, "\n// Validate validates %s using its `validate` tags, without reflection, see validator.Validate.Struct.\n", recv)
+	fmt.Fprintf(&g.buf, "func (%s *%s) Validate() validator.ValidationErrors {\n\treturn %s.validateFields(%q, nil)\n}\n", recv, name, recv, name)
+	fmt.Fprintf(&g.buf, "\n// validateFields appends the errors of the fields of %s, whose namespace is ns, to errs.\n", recv)
+	fmt.Fprintf(&g.buf, "func (%s *%s) validateFields(ns string, errs validator.ValidationErrors) validator.ValidationErrors {\n", recv, name)
+	for _, f := range st.Fields.List {
+		var tag string
+		if f.Tag != nil {
+			s, _ := strconv.Unquote(f.Tag.Value)
+			tag = reflect.StructTag(s).Get("validate")
+		}
+
+		if tag == "-" {
+			continue
+		}
+
+		names := make([]string, 0, len(f.Names))
+		for _, n := range f.Names {
+			names = append(names, n.Name)
+		}
+
+		if len(names) == 0 {
+			names = append(names, embeddedName(f.Type))
+		}
+
+		for _, field := range names {
+			if !ast.IsExported(field) {
+				continue
+			}
+
+			if err := g.field(recv+"."+field, name+"."+field, field, f.Type, tag); err != nil {
+				return err
+			}
+		}
+	}
+	g.buf.WriteString("\treturn errs\n}\n")
+	return nil
+}
+
+// embeddedName returns the field name of an embedded field of type expr.
+func embeddedName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedName(t.X)
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	case *ast.Ident:
+		return t.Name
+	}
+	return ""
+}
+
+// field writes the validation of the field accessed by sel, named name in the errors, of type expr.
+func (g *generator) field(sel, ns, name string, expr ast.Expr, tag string) error {
+	ft, ok := g.resolve(expr, false)
+	if !ok {
+		if len(tag) > 0 {
+			return fmt.Errorf("codegen: field %s: unsupported type", ns)
+		}
+		return nil
+	}
+
+	var tags []string
+	if len(tag) > 0 {
+		tags = strings.Split(tag, ",")
+	}
+
+	omitEmpty := len(tags) > 0 && tags[0] == "omitempty"
+	if omitEmpty {
+		tags = tags[1:]
+	}
+
+	// a non-nil pointer has a value
+	required := ft.ptr && len(tags) > 0 && tags[0] == "required"
+	if required {
+		tags = tags[1:]
+	}
+
+	if ft.kind == "struct" && (len(tags) > 0 || omitEmpty && !ft.ptr) {
+		return fmt.Errorf("codegen: field %s: only the required and omitempty tags of pointers to structs are supported", ns)
+	}
+
+	val := sel
+	if ft.ptr {
+		val = "*" + sel
+	}
+
+	var body strings.Builder
+	for i, t := range tags {
+		tagName, param, _ := strings.Cut(t, "=")
+		cond, err := g.cond(val, ft, tagName, param)
+		if err != nil {
+			return fmt.Errorf("codegen: field %s: %w", ns, err)
+		}
+
+		if len(cond) == 0 {
+			continue
+		}
+
+		if cond == "true" {
+			// the tag always fails, the following tags are never reached
+			if body.Len() > 0 {
+				fmt.Fprintf(&body, " else {\n%s\n}", appendErr(name, tagName, param, val))
+			} else {
+				body.WriteString(appendErr(name, tagName, param, val))
+			}
+			break
+		}
+
+		if i > 0 && body.Len() > 0 {
+			body.WriteString(" else ")
+		}
+		fmt.Fprintf(&body, "if %s {\n%s\n}", cond, appendErr(name, tagName, param, val))
+	}
+
+	if ft.kind == "struct" {
+		g.enqueue(ft.name)
+		fmt.Fprintf(&body, "errs = %s.validateFields(ns+%q, errs)", sel, "."+name)
+	}
+
+	switch {
+	case ft.ptr && (required || !omitEmpty && len(tags) > 0):
+		nilTag, nilParam := "required", ""
+		if !required {
+			nilTag, nilParam, _ = strings.Cut(tags[0], "=")
+		}
+
+		fmt.Fprintf(&g.buf, "if %s == nil {\n%s\n}", sel, appendErr(name, nilTag, nilParam, sel))
+		if body.Len() > 0 {
+			fmt.Fprintf(&g.buf, " else {\n%s\n}", body.String())
+		}
+		g.buf.WriteString("\n")
+	case body.Len() == 0:
+	case ft.ptr:
+		fmt.Fprintf(&g.buf, "if %s != nil {\n%s\n}\n", sel, body.String())
+	case omitEmpty:
+		fmt.Fprintf(&g.buf, "if %s {\n%s\n}\n", hasValue(val, ft), body.String())
+	default:
+		fmt.Fprintf(&g.buf, "%s\n", body.String())
+	}
+	return nil
+}
+
+// appendErr returns the statement appending the error of the field name failing tag.
+func appendErr(name, tag, param, val string) string {
+	return fmt.Sprintf("errs = append(errs, validator.NewFieldError(ns+%q, ns+%q, %q, %q, %s))", "."+name, "."+name, tag, param, val)
+}
+
+// hasValue returns the condition of val having a value, see the required tag.
+func hasValue(val string, ft fieldType) string {
+	switch ft.kind {
+	case "string":
+		return val + ` != ""`
+	case "bool":
+		return val
+	case "slice", "map":
+		return val + " != nil"
+	default:
+		return val + " != 0"
+	}
+}
+
+// resolve returns the type of a field of type expr, false if it isn't supported.
+func (g *generator) resolve(expr ast.Expr, named bool) (fieldType, bool) {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		if named {
+			return fieldType{}, false
+		}
+
+		ft, ok := g.resolve(t.X, false)
+		if ft.ptr {
+			return fieldType{}, false
+		}
+
+		ft.ptr = true
+		return ft, ok
+	case *ast.ArrayType:
+		return fieldType{kind: "slice"}, t.Len == nil
+	case *ast.MapType:
+		return fieldType{kind: "map"}, true
+	case *ast.Ident:
+		if kind, ok := basicKinds[t.Name]; ok {
+			return fieldType{kind: kind, bits: bitSizes[t.Name]}, true
+		}
+
+		spec, ok := g.specs[t.Name]
+		if !ok || named {
+			return fieldType{}, false
+		}
+
+		if _, ok := spec.Type.(*ast.StructType); ok {
+			return fieldType{kind: "struct", name: t.Name}, spec.TypeParams == nil
+		}
+		ft, ok := g.resolve(spec.Type, true)
+		ft.named = true
+		return ft, ok
+	}
+	return fieldType{}, false
+}
+
+// cond returns the condition of val failing tag, empty if it can't fail and "true" if it always fails.
+func (g *generator) cond(val string, ft fieldType, tag, param string) (string, error) {
+	switch tag {
+	case "required":
+		switch ft.kind {
+		case "string":
+			return val + ` == ""`, nil
+		case "bool":
+			return "!" + val, nil
+		case "slice", "map":
+			return val + " == nil", nil
+		default:
+			return val + " == 0", nil
+		}
+	case "len", "min", "max", "eq", "ne", "gt", "gte", "lt", "lte":
+		op := map[string]string{"len": "!=", "min": "<", "max": ">", "eq": "!=", "ne": "==", "gt": "<=", "gte": "<", "lt": ">=", "lte": ">"}[tag]
+		switch {
+		case ft.kind == "string" && (tag == "eq" || tag == "ne"):
+			return fmt.Sprintf("%s %s %q", val, op, param), nil
+		case ft.kind == "bool" && (tag == "eq" || tag == "ne"):
+			b, err := strconv.ParseBool(param)
+			if err != nil {
+				return "", fmt.Errorf("invalid param of tag '%s': %w", tag, err)
+			}
+			if b == (tag == "eq") {
+				return "!" + val, nil
+			}
+			return val, nil
+		case ft.kind == "bool":
+			return "", fmt.Errorf("unsupported tag '%s' on a bool", tag)
+		}
+
+		lit, bound, err := literal(ft, param)
+		if err != nil {
+			return "", fmt.Errorf("invalid param of tag '%s': %w", tag, err)
+		}
+
+		if bound != 0 {
+			// the param is out of the range of the field's type, the comparison can't depend on the value
+			if op == "!=" || op != "==" && (op[0] == '<') == (bound > 0) {
+				return "true", nil
+			}
+			return "", nil
+		}
+
+		switch ft.kind {
+		case "string":
+			g.utf8 = true
+			val = fmt.Sprintf("utf8.RuneCountInString(%s)", str(val, ft))
+		case "slice", "map":
+			val = fmt.Sprintf("len(%s)", val)
+		}
+		return fmt.Sprintf("%s %s %s", val, op, lit), nil
+	case "oneof":
+		vals := strings.Fields(param)
+		if len(vals) == 0 || strings.Contains(param, "'") {
+			return "", fmt.Errorf("unsupported param of tag '%s': %s", tag, param)
+		}
+
+		var conds []string
+		for _, v := range vals {
+			if ft.kind == "string" {
+				conds = append(conds, fmt.Sprintf("%s != %q", val, v))
+				continue
+			}
+
+			if ft.kind != "int" && ft.kind != "uint" {
+				return "", fmt.Errorf("unsupported tag '%s' on a %s", tag, ft.kind)
+			}
+
+			lit, bound, err := literal(ft, v)
+			if err != nil || lit != v {
+				return "", fmt.Errorf("invalid param of tag '%s': %s", tag, v)
+			}
+
+			// a value out of the range of the field's type is never matched
+			if bound == 0 {
+				conds = append(conds, fmt.Sprintf("%s != %s", val, lit))
+			}
+		}
+
+		if len(conds) == 0 {
+			return "true", nil
+		}
+		return strings.Join(conds, " && "), nil
+	}
+
+	if _, ok := regexTags[tag]; ok {
+		switch ft.kind {
+		case "string":
+			g.regexes[tag] = struct{}{}
+			return fmt.Sprintf("!%s.MatchString(%s)", regexVar(tag), str(val, ft)), nil
+		case "int", "uint", "float":
+			if tag == "numeric" || tag == "number" {
+				return "", nil
+			}
+		}
+		return "", fmt.Errorf("unsupported tag '%s' on a %s", tag, ft.kind)
+	}
+	return "", fmt.Errorf("unsupported tag '%s'", tag)
+}
+
+// literal returns the Go literal of the param of the comparison tags for ft,
+// an integer for strings and collections, and the side of the range of the integer kinds
+// the param lies beyond, -1 below it, 1 above it and 0 within it.
+func literal(ft fieldType, param string) (string, int, error) {
+	switch ft.kind {
+	case "uint":
+		u, err := strconv.ParseUint(param, 0, 64)
+		if err == nil && ft.bits < 64 && u > 1<<ft.bits-1 {
+			return strconv.FormatUint(u, 10), 1, nil
+		}
+		return strconv.FormatUint(u, 10), 0, err
+	case "float":
+		f, err := strconv.ParseFloat(param, ft.bits)
+		if err == nil && (math.IsInf(f, 0) || math.IsNaN(f)) {
+			err = fmt.Errorf("%s isn't finite", param)
+		}
+		return strconv.FormatFloat(f, 'g', -1, ft.bits), 0, err
+	case "int":
+		i, err := strconv.ParseInt(param, 0, 64)
+		switch {
+		case err != nil || ft.bits == 64:
+		case i < -1<<(ft.bits-1):
+			return strconv.FormatInt(i, 10), -1, nil
+		case i > 1<<(ft.bits-1)-1:
+			return strconv.FormatInt(i, 10), 1, nil
+		}
+		return strconv.FormatInt(i, 10), 0, err
+	default:
+		i, err := strconv.ParseInt(param, 0, 64)
+		return strconv.FormatInt(i, 10), 0, err
+	}
+}
+
+// str returns val as a string, converting declared string types.
+func str(val string, ft fieldType) string {
+	if ft.named {
+		return "string(" + val + ")"
+	}
+	return val
+}
+
+// regexVar returns the name of the variable of the regular expression of tag.
+func regexVar(tag string) string {
+	return "validator" + strings.ToUpper(tag[:1]) + tag[1:] + "Regex"
+}
@@ -0,0 +1,105 @@
+package codegen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pchchv/go-assert"
+	"github.com/pchchv/validator"
+	"github.com/pchchv/validator/codegen/internal/testtypes"
+)
+
+func TestGenerate(t *testing.T) {
+	src, err := Generate("internal/testtypes", "User")
+	assert.Equal(t, nil, err)
+
+	generated, err := os.ReadFile("internal/testtypes/user_validator.go")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, string(generated), string(src))
+}
+
+// errorsOf returns the namespace, tag, param, value and type of the errors.
+func errorsOf(errs validator.ValidationErrors) [][]interface{} {
+	var out [][]interface{}
+	for _, fe := range errs {
+		out = append(out, []interface{}{fe.Namespace(), fe.StructNamespace(), fe.Field(), fe.Tag(), fe.Param(), fe.Value(), fe.Kind(), fe.Type()})
+	}
+	return out
+}
+
+func TestGeneratedValidate(t *testing.T) {
+	age, young := 30, 12
+	id, code, x := "a987fbc9-4bed-4078-8f07-9141ba07c9f3", "y", "x"
+	valid := testtypes.User{
+		Base:     testtypes.Base{Version: 1},
+		Name:     "joey",
+		Nick:     "joey1",
+		Role:     "admin",
+		Age:      &age,
+		Score:    5,
+		Level:    2,
+		Active:   true,
+		Tags:     []string{"a", "b"},
+		ID:       &id,
+		Code:     &code,
+		Address:  &testtypes.Address{City: "Rome", Zip: "00100"},
+		Shipping: testtypes.Address{City: "Milan", Zip: "20100"},
+	}
+
+	invalid := testtypes.User{
+		Name:    "j",
+		Nick:    "joey!",
+		Role:    "root",
+		Age:     &young,
+		Score:   10.5,
+		Level:   4,
+		Limit:   1,
+		Tags:    []string{},
+		Labels:  map[string]string{"a": "1", "b": "2"},
+		Code:    &x,
+		Billing: &testtypes.Address{City: "R0me", Zip: "1234a"},
+	}
+
+	noAddress := valid
+	noAddress.Address, noAddress.Code, noAddress.Name = nil, nil, "joey bloggs"
+
+	v := validator.New()
+	for _, u := range []testtypes.User{valid, invalid, noAddress, {}} {
+		var expected validator.ValidationErrors
+		if err := v.Struct(u); err != nil {
+			expected = err.(validator.ValidationErrors)
+		}
+		assert.Equal(t, errorsOf(expected), errorsOf(u.Validate()))
+	}
+	assert.Equal(t, 0, len(valid.Validate()))
+	assert.Equal(t, 18, len(invalid.Validate()))
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := []struct {
+		src      string
+		expected string
+	}{
+		{src: "type User struct {\n\tEmail string `validate:\"email\"`\n}", expected: "codegen: field User.Email: unsupported tag 'email'"},
+		{src: "type User struct {\n\tName string `validate:\"required,min=a\"`\n}", expected: `codegen: field User.Name: invalid param of tag 'min': strconv.ParseInt: parsing "a": invalid syntax`},
+		{src: "type User struct {\n\tTags []string `validate:\"oneof=a b\"`\n}", expected: "codegen: field User.Tags: unsupported tag 'oneof' on a slice"},
+		{src: "type User struct {\n\tRole string `validate:\"oneof='a b' c\"`\n}", expected: "codegen: field User.Role: unsupported param of tag 'oneof': 'a b' c"},
+		{src: "type User struct {\n\tCreated time.Time `validate:\"required\"`\n}", expected: "codegen: field User.Created: unsupported type"},
+		{src: "type User struct {\n\tAddress Address `validate:\"required\"`\n}\n\ntype Address struct{}", expected: "codegen: field User.Address: only the required and omitempty tags of pointers to structs are supported"},
+		{src: "type User string", expected: "codegen: type User must be a non-generic struct type"},
+		{src: "type Order struct{}", expected: "codegen: type User not found"},
+	}
+
+	for _, test := range tests {
+		dir := t.TempDir()
+		assert.Equal(t, nil, os.WriteFile(filepath.Join(dir, "user.go"), []byte("package user\n\n"+test.src+"\n"), 0o600))
+		_, err := Generate(dir, "User")
+		assert.Equal(t, test.expected, err.Error())
+	}
+
+	_, err := Generate(t.TempDir(), "User")
+	assert.Equal(t, true, err != nil)
+	_, err = Generate("internal/testtypes")
+	assert.Equal(t, "codegen: no types to generate", err.Error())
+}
@@ -0,0 +1,42 @@
+// Package testtypes holds the struct types whose generated Validate methods
+// are checked against the validator by the codegen tests.
+package testtypes
+
+//go:generate go run ../../../cmd/validator-gen -type User
+
+// Role is the role of a User.
+type Role string
+
+// User is a struct type using the supported tags.
+type User struct {
+	Base
+	Name     string            `validate:"required,min=2,max=8"`
+	Nick     string            `validate:"omitempty,alphanum"`
+	Email    string            `validate:"-"`
+	Role     Role              `validate:"oneof=admin user"`
+	Age      *int              `validate:"omitempty,gte=18,lte=130"`
+	Score    float64           `validate:"gt=0,lt=10.5"`
+	Level    uint8             `validate:"oneof=1 2 3"`
+	Offset   int8              `validate:"gte=-200,lte=300"`
+	Limit    uint8             `validate:"omitempty,oneof=1 300,gte=300"`
+	Active   bool              `validate:"eq=true"`
+	Tags     []string          `validate:"required,len=2"`
+	Labels   map[string]string `validate:"omitempty,max=1"`
+	ID       *string           `validate:"required,uuid4"`
+	Code     *string           `validate:"ne=x"`
+	Address  *Address          `validate:"required"`
+	Billing  *Address
+	Shipping Address
+	internal string
+}
+
+// Base is embedded in User.
+type Base struct {
+	Version int `validate:"gte=1"`
+}
+
+// Address is nested in User.
+type Address struct {
+	City string `validate:"required,alpha"`
+	Zip  string `validate:"len=5,numeric"`
+}
@@ -0,0 +1,113 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// Typed validates values of the struct type T, its tags being parsed up front, see Compile.
+type Typed[T any] struct {
+	v   *Validate
+	cs  *cStruct
+	typ reflect.Type
+}
+
+// Compile returns a Typed validator of the struct type T using a new Validate configured with opts, e. g.
+//
+//	var userValidator = validator.MustCompile[User](validator.WithRequiredStructEnabled())
+//
+//	err := userValidator.Validate(ctx, &user)
+//
+// The tags of T and of the struct types nested within its fields are parsed when compiling,
+// their syntax errors, e. g. an undefined validation, being returned rather than panicking mid-request.
+// See CompileWith to use a Validate with custom validations.
+func Compile[T any](opts ...Option) (*Typed[T], error) {
+	return CompileWith[T](New(opts...))
+}
+
+// MustCompile is like Compile but panics if T can't be compiled,
+// simplifying the initialization of global variables.
+func MustCompile[T any](opts ...Option) *Typed[T] {
+	t, err := Compile[T](opts...)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// CompileWith returns a Typed validator of the struct type T using v, see Compile.
+// The validations, aliases and struct level validations used by T must be registered on v beforehand.
+func CompileWith[T any](v *Validate) (t *Typed[T], err error) {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	if typ.Kind() != reflect.Struct || typ.ConvertibleTo(timeType) {
+		return nil, &InvalidValidationError{Type: typ}
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			t, err = nil, fmt.Errorf("validator: compiling %s: %v", typ, r)
+		}
+	}()
+
+	v.compileStructType(typ, make(map[reflect.Type]struct{}))
+	cs, _ := v.structCache.Get(typ)
+	return &Typed[T]{v: v, cs: cs, typ: typ}, nil
+}
+
+// compileStructType parses the tags of the struct type typ and of the struct types nested within its fields.
+func (v *Validate) compileStructType(typ reflect.Type, seen map[reflect.Type]struct{}) {
+	if _, ok := seen[typ]; ok {
+		return
+	}
+	seen[typ] = struct{}{}
+
+	cs, ok := v.structCache.Get(typ)
+	if !ok {
+		cs = v.extractStructCache(reflect.New(typ).Elem(), typ.Name())
+	}
+
+	for _, f := range cs.fields {
+		if nested := v.coverStructType(typ.Field(f.idx).Type); nested != nil {
+			v.compileStructType(nested, seen)
+		}
+	}
+}
+
+// Validator returns the underlying validator.
+func (t *Typed[T]) Validator() *Validate {
+	return t.v
+}
+
+// Validate validates s like Validate.StructCtx, without its type checks
+// and the lookup of the struct type of T in the struct cache.
+// s is taken by pointer so that validating it doesn't copy it to the heap.
+//
+// It returns InvalidValidationError for a nil s, and nil or ValidationErrors as error otherwise.
+func (t *Typed[T]) Validate(ctx context.Context, s *T) (err error) {
+	if s == nil {
+		return &InvalidValidationError{Type: reflect.TypeOf(s)}
+	}
+
+	if t.v.hooked() {
+		var call *ValidationCall
+		ctx, call = t.v.startCall(ctx, "Typed", t.typ)
+		defer t.v.endCall(ctx, call, &err)
+	}
+
+	if t.v.strictErrors {
+		defer recoverStrict(&err, t.typ)
+	}
+
+	val := reflect.ValueOf(s).Elem()
+	vd := t.v.pool.Get().(*validate)
+	vd.top = val
+	vd.sampleHit = t.v.sample()
+	vd.loadCallState(ctx)
+	vd.isPartial = false
+	vd.validateCStruct(ctx, t.cs, val, val, t.typ, vd.ns[0:0], vd.actualNs[0:0], nil)
+	err = vd.result()
+
+	t.v.pool.Put(vd)
+	return
+}
@@ -0,0 +1,178 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// ContextValueResolver resolves the values of the validation context
+// the eqctx and oneof_ctx tags compare fields against, see WithContextValueResolver.
+type ContextValueResolver interface {
+	// ContextValue returns the value of ctx named key, e. g. "tenant",
+	// and whether ctx holds it.
+	ContextValue(ctx context.Context, key string) (interface{}, bool)
+}
+
+// ContextKeys returns the ContextValueResolver looking up the values of the typed context keys of keys by name, e. g.
+//
+//	validator.ContextKeys(map[string]interface{}{"tenant": tenantKey{}, "roles": rolesKey{}})
+//
+// resolves "tenant" as ctx.Value(tenantKey{}).
+// A name without a key or whose value is nil isn't held by the context.
+func ContextKeys(keys map[string]interface{}) ContextValueResolver {
+	return contextKeys(keys)
+}
+
+// contextKeys is the ContextValueResolver returned by ContextKeys.
+type contextKeys map[string]interface{}
+
+// ContextValue returns ctx.Value of the context key named key.
+func (ck contextKeys) ContextValue(ctx context.Context, key string) (interface{}, bool) {
+	k, ok := ck[key]
+	if !ok {
+		return nil, false
+	}
+
+	val := ctx.Value(k)
+	return val, val != nil
+}
+
+// RolesProvider returns the roles of the caller of the validation context, e. g. read from the claims
+// of its access token, deciding which fields the required_role tag requires, see WithRolesProvider.
+type RolesProvider func(ctx context.Context) []string
+
+// bakedInCtxValidators are the default validations needing the validation context.
+var bakedInCtxValidators = map[string]FuncCtx{
+	"eqctx":         isEqCtx,
+	"oneof_ctx":     isOneOfCtx,
+	requiredRoleTag: requiredRole,
+	"tcp4_addr":     isTCP4AddrResolvable,
+	"tcp6_addr":     isTCP6AddrResolvable,
+	"tcp_addr":      isTCPAddrResolvable,
+	"udp4_addr":     isUDP4AddrResolvable,
+	"udp6_addr":     isUDP6AddrResolvable,
+	"udp_addr":      isUDPAddrResolvable,
+	"ip4_addr":      isIP4AddrResolvable,
+	"ip6_addr":      isIP6AddrResolvable,
+	"ip_addr":       isIPAddrResolvable,
+}
+
+// isEqCtx is the validation function for validating that the field's value equals
+// the context value named by the param, e. g. the authenticated tenant ID.
+func isEqCtx(ctx context.Context, fl FieldLevel) bool {
+	val, ok := contextValue(ctx, fl)
+	return ok && contextValueEqual(fl.Field(), reflect.ValueOf(val))
+}
+
+// isOneOfCtx is the validation function for validating that the field's value equals
+// one of the elements of the slice, array or keys of the map context value named by the param,
+// e. g. the IDs of the projects the authenticated user belongs to.
+func isOneOfCtx(ctx context.Context, fl FieldLevel) bool {
+	val, ok := contextValue(ctx, fl)
+	if !ok {
+		return false
+	}
+
+	field, vals := fl.Field(), reflect.ValueOf(val)
+	switch vals.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < vals.Len(); i++ {
+			if contextValueEqual(field, vals.Index(i)) {
+				return true
+			}
+		}
+		return false
+	case reflect.Map:
+		iter := vals.MapRange()
+		for iter.Next() {
+			if contextValueEqual(field, iter.Key()) {
+				return true
+			}
+		}
+		return false
+	default:
+		return contextValueEqual(field, vals)
+	}
+}
+
+// requiredRole is the validation function.
+// The field under validation must be present and not empty only if the caller of the validation context
+// has one of the roles of the param, e. g. 'required_role=admin auditor'.
+func requiredRole(ctx context.Context, fl FieldLevel) bool {
+	var provider RolesProvider
+	if v, ok := fl.(*validate); ok {
+		provider = v.v.rolesProvider
+	}
+
+	if provider == nil {
+		panic(fmt.Sprintf("no RolesProvider set for '%s', see WithRolesProvider", fl.GetTag()))
+	}
+
+	if ctx == nil {
+		return true
+	}
+
+	roles := provider(ctx)
+	for _, role := range strings.Fields(fl.Param()) {
+		if slices.Contains(roles, role) {
+			return hasValue(fl)
+		}
+	}
+	return true
+}
+
+// contextValue returns the context value named by the param of fl and whether the context holds it.
+func contextValue(ctx context.Context, fl FieldLevel) (interface{}, bool) {
+	var resolver ContextValueResolver
+	if v, ok := fl.(*validate); ok {
+		resolver = v.v.contextResolver
+	}
+
+	if resolver == nil {
+		panic(fmt.Sprintf("no ContextValueResolver set for '%s', see WithContextValueResolver", fl.GetTag()))
+	}
+
+	if ctx == nil {
+		return nil, false
+	}
+	return resolver.ContextValue(ctx, fl.Param())
+}
+
+// contextValueEqual reports whether the field's value equals the context value val,
+// numbers of different types being compared by value,
+// and strings being compared with the String() of val when it implements fmt.Stringer.
+func contextValueEqual(field, val reflect.Value) bool {
+	for val.Kind() == reflect.Interface || val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return false
+		}
+		val = val.Elem()
+	}
+
+	switch {
+	case isIntKind(field.Kind()) && isIntKind(val.Kind()):
+		return field.Int() == val.Int()
+	case isUintKind(field.Kind()) && isUintKind(val.Kind()):
+		return field.Uint() == val.Uint()
+	case isIntKind(field.Kind()) && isUintKind(val.Kind()):
+		return field.Int() >= 0 && uint64(field.Int()) == val.Uint()
+	case isUintKind(field.Kind()) && isIntKind(val.Kind()):
+		return val.Int() >= 0 && field.Uint() == uint64(val.Int())
+	case isFloatKind(field.Kind()) && isFloatKind(val.Kind()):
+		return field.Float() == val.Float()
+	case field.Kind() == reflect.String && val.Kind() == reflect.String:
+		return field.String() == val.String()
+	case field.Kind() == reflect.String && val.CanInterface():
+		if s, ok := val.Interface().(fmt.Stringer); ok {
+			return field.String() == s.String()
+		}
+	}
+
+	if field.Type() != val.Type() || !field.Comparable() {
+		return false
+	}
+	return field.Equal(val)
+}
@@ -0,0 +1,194 @@
+package validator
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// CoverageIssueKind is the kind of a CoverageIssue.
+type CoverageIssueKind string
+
+const (
+	// CoverageMissingRule is a field without any rule.
+	CoverageMissingRule CoverageIssueKind = "missing_rule"
+	// CoverageOmitOnly is a field whose rule only holds omitempty, omitzero or omitnil.
+	CoverageOmitOnly CoverageIssueKind = "omit_only"
+	// CoverageDeadTag is a tag that can never fail because of the preceding tags,
+	// e. g. required after omitempty, or that repeats a preceding tag.
+	CoverageDeadTag CoverageIssueKind = "dead_tag"
+)
+
+// requiredTags are the tags that can never fail after omitempty or omitzero.
+var requiredTags = []string{
+	"required", requiredIfTag, requiredUnlessTag, requiredWithTag,
+	requiredWithAllTag, requiredWithoutTag, requiredWithoutAllTag, requiredRoleTag,
+}
+
+// CoverageIssue is a field reported by Validate.Coverage.
+type CoverageIssue struct {
+	Type  string            `json:"type"`          // struct type declaring the field, e. g. 'main.User'
+	Field string            `json:"field"`         // name of the struct field
+	Kind  CoverageIssueKind `json:"kind"`          // kind of the issue
+	Tag   string            `json:"tag,omitempty"` // dead tag of a CoverageDeadTag issue
+}
+
+// CoverageReport is the validation rule coverage of struct types, see Validate.Coverage.
+// It's intended to be marshalled as JSON by CI gates.
+type CoverageReport struct {
+	Fields  int             `json:"fields"`  // number of checked fields
+	Covered int             `json:"covered"` // number of fields with a rule other than omitempty, omitzero or omitnil
+	Issues  []CoverageIssue `json:"issues"`  // issues ordered by type and field
+}
+
+// Coverage reports the fields of the struct types of types lacking rules,
+// fields whose rules only hold omitempty, omitzero or omitnil, and tags that are dead,
+// allowing CI to enforce that every field of external structs has an explicit rule.
+// Without types, the types registered using RegisterStructValidation, RegisterStructValidationMapRules,
+// RegisterStructIntegrity and Pipeline are reported.
+//
+// The rules of a field are its tag and its map rules, a field skipped using the '-' tag is covered.
+// Struct fields are traversed, rather than reported, unless the struct has no exported fields, e. g. time.Time,
+// or is a leaf type, see WithLeafTypes.
+func (v *Validate) Coverage(types ...interface{}) CoverageReport {
+	var typs []reflect.Type
+	for _, t := range types {
+		typs = append(typs, reflect.TypeOf(t))
+	}
+
+	if len(types) == 0 {
+		for typ := range v.structLevelFuncs {
+			typs = append(typs, typ)
+		}
+		for typ := range v.rules {
+			typs = append(typs, typ)
+		}
+		for typ := range v.structIntegrity {
+			typs = append(typs, typ)
+		}
+		for typ := range v.pipelines {
+			typs = append(typs, typ)
+		}
+	}
+
+	report := CoverageReport{Issues: []CoverageIssue{}}
+	seen := make(map[reflect.Type]struct{})
+	for _, typ := range typs {
+		v.coverType(&report, typ, seen)
+	}
+
+	slices.SortFunc(report.Issues, func(a, b CoverageIssue) int {
+		if c := strings.Compare(a.Type, b.Type); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Field, b.Field)
+	})
+	return report
+}
+
+// coverType adds the coverage of the fields of the struct type typ,
+// traversing the struct types of its fields.
+func (v *Validate) coverType(report *CoverageReport, typ reflect.Type, seen map[reflect.Type]struct{}) {
+	typ = v.coverStructType(typ)
+	if typ == nil {
+		return
+	}
+
+	if _, ok := seen[typ]; ok {
+		return
+	}
+	seen[typ] = struct{}{}
+
+	rules := v.rules[typ]
+	for i := 0; i < typ.NumField(); i++ {
+		fld := typ.Field(i)
+		if !fld.Anonymous && !fld.IsExported() && !v.privateFieldValidation {
+			continue
+		}
+
+		tag, ok := rules[fld.Name]
+		if !ok {
+			tag = fld.Tag.Get(v.tagName)
+		}
+
+		if tag == skipValidationTag {
+			continue
+		}
+
+		if nested := v.coverStructType(fld.Type); nested != nil {
+			if len(tag) > 0 {
+				coverTags(report, typ, fld.Name, tag)
+			}
+			v.coverType(report, nested, seen)
+			continue
+		}
+
+		report.Fields++
+		if len(tag) == 0 {
+			report.Issues = append(report.Issues, CoverageIssue{Type: typ.String(), Field: fld.Name, Kind: CoverageMissingRule})
+			continue
+		}
+
+		if coverTags(report, typ, fld.Name, tag) {
+			report.Covered++
+		} else {
+			report.Issues = append(report.Issues, CoverageIssue{Type: typ.String(), Field: fld.Name, Kind: CoverageOmitOnly})
+		}
+	}
+}
+
+// coverTags adds the dead tags of a field's tag and reports whether the tag holds a rule
+// other than omitempty, omitzero or omitnil.
+func coverTags(report *CoverageReport, typ reflect.Type, field, tag string) bool {
+	var hasRule, omitted bool
+	var segment []string
+	for _, t := range strings.Split(tag, tagSeparator) {
+		name, _, _ := strings.Cut(t, tagKeySeparator)
+		switch name {
+		case diveTag, keysTag, endKeysTag, diveMaxErrsTag, diveParallelTag, strSplitTag:
+			// each dive segment applies to other values
+			omitted, segment = false, segment[:0]
+			continue
+		case omitempty, omitzero:
+			omitted = true
+			continue
+		case omitnil, structOnlyTag, noStructLevelTag, stopChildrenTag, allErrsTag, sensitiveTag:
+			continue
+		}
+
+		hasRule = true
+		if slices.Contains(segment, t) || (omitted && slices.Contains(requiredTags, name)) {
+			report.Issues = append(report.Issues, CoverageIssue{Type: typ.String(), Field: field, Kind: CoverageDeadTag, Tag: t})
+		}
+		segment = append(segment, t)
+	}
+	return hasRule
+}
+
+// coverStructType returns the struct type of typ, dereferencing pointers and the elements of collections,
+// nil if it isn't a struct with exported fields or is a custom type.
+func (v *Validate) coverStructType(typ reflect.Type) reflect.Type {
+	for typ != nil {
+		if _, ok := v.customFuncs[typ]; ok {
+			return nil
+		}
+
+		switch typ.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+			typ = typ.Elem()
+			continue
+		case reflect.Struct:
+			if v.isLeafType(typ) {
+				return nil
+			}
+
+			for i := 0; i < typ.NumField(); i++ {
+				if typ.Field(i).IsExported() {
+					return typ
+				}
+			}
+		}
+		return nil
+	}
+	return nil
+}
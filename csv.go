@@ -0,0 +1,231 @@
+package validator
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// CSVError is a validation or conversion failure of a single CSV value.
+type CSVError struct {
+	Row    int    // row number in the CSV, starting at 1 for the header row
+	Column string // header name of the column
+	Err    error  // FieldError of the failed validation or the error converting the value
+}
+
+// Error returns CSVError message.
+func (e CSVError) Error() string {
+	return fmt.Sprintf("row %d column '%s': %v", e.Row, e.Column, e.Err)
+}
+
+// Unwrap returns the FieldError or conversion error.
+func (e CSVError) Unwrap() error {
+	return e.Err
+}
+
+// CSVErrors is an array of CSVError's, in the order of the rows and columns.
+type CSVErrors []CSVError
+
+// Error is intended for use in development + debugging and not intended to be a production error message.
+func (ce CSVErrors) Error() string {
+	buff := make([]string, len(ce))
+	for i, e := range ce {
+		buff[i] = e.Error()
+	}
+	return strings.Join(buff, "\n")
+}
+
+// ValidateCSV validates the rows of the CSV read from r, streaming them,
+// the first row being the header with the column names used in the errors.
+// rowRules holds the validation tag of each column by index,
+// an empty tag or a column without a tag isn't validated.
+//
+// Values are validated as strings, e. g. use 'numeric,gte_numstr=10' rather than 'gte=10' for numbers.
+// It returns the CSVErrors of the invalid values, the error is non-nil if the CSV can't be read.
+func (v *Validate) ValidateCSV(r io.Reader, rowRules []string) (CSVErrors, error) {
+	cr := csv.NewReader(r)
+	cr.ReuseRecord = true
+	header, err := readCSVHeader(cr)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs CSVErrors
+	for row := 2; ; row++ {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return errs, nil
+		} else if err != nil {
+			return errs, err
+		}
+
+		for i, rule := range rowRules {
+			if len(rule) == 0 || i >= len(record) {
+				continue
+			}
+
+			var ve ValidationErrors
+			if err := v.Var(record[i], rule); errors.As(err, &ve) {
+				for _, fe := range ve {
+					errs = append(errs, CSVError{Row: row, Column: header[i], Err: fe})
+				}
+			}
+		}
+	}
+}
+
+// ValidateCSVStruct validates the rows of the CSV read from r, streaming them,
+// by decoding each row into a new value of the struct type of s and validating it.
+// The first row is the header, its columns are mapped to the struct fields by the
+// field's `csv` tag, or by the field's name when it has none, other columns are ignored.
+//
+// String, bool, integer and float fields are supported.
+// A row with a value that can't be converted to its field's type reports the conversion
+// errors and isn't validated.
+// It returns the CSVErrors of the invalid values, the error is non-nil if the CSV can't be read.
+func (v *Validate) ValidateCSVStruct(r io.Reader, s interface{}) (CSVErrors, error) {
+	typ := reflect.TypeOf(s)
+	if typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return nil, &InvalidValidationError{Type: reflect.TypeOf(s)}
+	}
+
+	cr := csv.NewReader(r)
+	cr.ReuseRecord = true
+	header, err := readCSVHeader(cr)
+	if err != nil {
+		return nil, err
+	}
+
+	// field index of each column, -1 for ignored columns
+	fields := make([]int, len(header))
+	columns := make(map[string]string, len(header))
+	for i, name := range header {
+		fields[i] = -1
+		for j := 0; j < typ.NumField(); j++ {
+			fld := typ.Field(j)
+			tag := strings.SplitN(fld.Tag.Get("csv"), ",", 2)[0]
+			if fld.IsExported() && (tag == name || (len(tag) == 0 && fld.Name == name)) {
+				fields[i] = j
+				columns[fld.Name] = name
+				break
+			}
+		}
+	}
+
+	var errs CSVErrors
+	for row := 2; ; row++ {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return errs, nil
+		} else if err != nil {
+			return errs, err
+		}
+
+		val := reflect.New(typ)
+		converted := true
+		for i, idx := range fields {
+			if idx == -1 || i >= len(record) {
+				continue
+			}
+
+			if err := setCSVValue(val.Elem().Field(idx), record[i]); err != nil {
+				errs = append(errs, CSVError{Row: row, Column: header[i], Err: err})
+				converted = false
+			}
+		}
+
+		if !converted {
+			continue
+		}
+
+		if err := v.Struct(val.Interface()); err != nil {
+			var ve ValidationErrors
+			if !errors.As(err, &ve) {
+				return errs, err
+			}
+
+			for _, fe := range ve {
+				errs = append(errs, CSVError{Row: row, Column: columns[csvTopField(fe.StructNamespace())], Err: fe})
+			}
+		}
+	}
+}
+
+// readCSVHeader reads the header row, copying it as records are reused.
+func readCSVHeader(cr *csv.Reader) ([]string, error) {
+	header, err := cr.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("validator: CSV has no header row")
+		}
+		return nil, err
+	}
+	return append([]string(nil), header...), nil
+}
+
+// csvTopField returns the struct field of a struct namespace, e. g. 'Row.Address.City' returns 'Address'.
+func csvTopField(structNs string) string {
+	_, ns, _ := strings.Cut(structNs, namespaceSeparator)
+	name, _, _ := strings.Cut(ns, namespaceSeparator)
+	name, _, _ = strings.Cut(name, leftBracket)
+	return name
+}
+
+// setCSVValue converts s to the type of field and sets it.
+func setCSVValue(field reflect.Value, s string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(s)
+	case reflect.Bool:
+		if len(s) == 0 {
+			return nil
+		}
+
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if len(s) == 0 {
+			return nil
+		}
+
+		i, err := strconv.ParseInt(s, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if len(s) == 0 {
+			return nil
+		}
+
+		u, err := strconv.ParseUint(s, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		if len(s) == 0 {
+			return nil
+		}
+
+		f, err := strconv.ParseFloat(s, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	default:
+		return fmt.Errorf("validator: unsupported CSV field type %s", field.Type())
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package validator
+
+import (
+	"context"
+	"reflect"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// diveChunk is the outcome of the elements validated by a worker of a parallel dive.
+type diveChunk struct {
+	errs          ValidationErrors
+	integrityErrs []error
+	aborted       error
+	panicked      interface{}
+}
+
+// diveKey is a map key of a parallel dive and its namespace segment.
+type diveKey struct {
+	key  reflect.Value
+	name string
+}
+
+// parallelizable reports whether the elements of a dive_parallel can be validated concurrently,
+// the recordings, audits, warnings and sampling skips of the call and the cap of WithMaxErrors needing the elements in order.
+func (v *validate) parallelizable() bool {
+	return v.rec == nil && v.audit == nil && v.warns == nil && v.skips == nil && v.v.maxErrors == 0
+}
+
+// diveParallel validates the elements of the slice, array or map current with ct on contiguous chunks
+// validated concurrently, see WithParallelDive, the errors being merged in the order of the elements,
+// the map elements being ordered by their namespace segment.
+func (v *validate) diveParallel(ctx context.Context, parent reflect.Value, current reflect.Value, ns []byte, structNs []byte, cf *cField, ct *cTag) {
+	var keys []diveKey
+	n := current.Len()
+	if current.Kind() == reflect.Map {
+		keys = make([]diveKey, 0, n)
+		for _, key := range current.MapKeys() {
+			keys = append(keys, diveKey{key: key, name: v.formatKey(key)})
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i].name < keys[j].name })
+	}
+
+	workers := v.v.diveWorkers
+	if workers == 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, n)
+
+	var wg sync.WaitGroup
+	chunks := make([]diveChunk, workers)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(chunk *diveChunk, lo, hi int) {
+			defer wg.Done()
+			v.diveChunk(ctx, chunk, parent, current, keys, lo, hi, ns, structNs, cf, ct)
+		}(&chunks[w], w*n/workers, (w+1)*n/workers)
+	}
+	wg.Wait()
+
+	for _, chunk := range chunks {
+		if chunk.panicked != nil {
+			panic(chunk.panicked)
+		}
+
+		for _, fe := range chunk.errs {
+			v.appendErr(fe)
+		}
+
+		v.integrityErrs = append(v.integrityErrs, chunk.integrityErrs...)
+		if v.aborted == nil {
+			v.aborted = chunk.aborted
+		}
+	}
+}
+
+// diveChunk validates the elements lo to hi of current on a pooled validate sharing the state of the call of v.
+func (v *validate) diveChunk(ctx context.Context, chunk *diveChunk, parent reflect.Value, current reflect.Value, keys []diveKey, lo, hi int, ns []byte, structNs []byte, cf *cField, ct *cTag) {
+	w := v.v.pool.Get().(*validate)
+	defer func() {
+		chunk.panicked = recover()
+		chunk.errs, chunk.integrityErrs, chunk.aborted = w.errs, w.integrityErrs, w.aborted
+		w.errs, w.integrityErrs, w.aborted, w.truncated = nil, nil, nil, false
+		w.top, w.includeExclude, w.sc = reflect.Value{}, nil, nil
+		v.v.pool.Put(w)
+	}()
+
+	w.top, w.sampleHit, w.sc = v.top, v.sampleHit, v.sc
+	w.isPartial, w.hasExcludes, w.includeExclude, w.ffn = v.isPartial, v.hasExcludes, v.includeExclude, v.ffn
+	w.rec, w.audit, w.warns, w.skips = nil, nil, nil, nil
+
+	// the namespaces are appended to, each worker needs its own copies
+	wns := append(w.ns[0:0], ns...)
+	wstructNs := append(w.actualNs[0:0], structNs...)
+	reusableCF := &cField{}
+	for i := lo; i < hi; i++ {
+		if w.stopped(ctx) {
+			break
+		}
+
+		w.misc = append(w.misc[0:0], cf.name...)
+		if keys == nil {
+			w.misc = w.appendIndex(w.misc, i)
+		} else {
+			w.misc = append(append(append(w.misc, '['), keys[i].name...), ']')
+		}
+		reusableCF.name = string(w.misc)
+		if cf.namesEqual {
+			reusableCF.altName = reusableCF.name
+		} else {
+			w.misc = append(w.misc[0:0], cf.altName...)
+			if keys == nil {
+				w.misc = w.appendIndex(w.misc, i)
+			} else {
+				w.misc = append(append(append(w.misc, '['), keys[i].name...), ']')
+			}
+			reusableCF.altName = string(w.misc)
+		}
+
+		if keys == nil {
+			w.elem = diveElem{idx: i, coll: current, ok: true}
+			w.traverseField(ctx, parent, current.Index(i), wns, wstructNs, reusableCF, ct)
+			continue
+		}
+
+		key := keys[i].key
+		w.elem = diveElem{idx: -1, key: key, ok: true}
+		if ct != nil && ct.typeof == typeKeys && ct.keys != nil {
+			w.traverseField(ctx, parent, key, wns, wstructNs, reusableCF, ct.keys)
+			// can be nil when just keys being validated
+			if ct.next != nil {
+				w.traverseField(ctx, parent, current.MapIndex(key), wns, wstructNs, reusableCF, ct.next)
+			}
+		} else {
+			w.traverseField(ctx, parent, current.MapIndex(key), wns, wstructNs, reusableCF, ct)
+		}
+	}
+}
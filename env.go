@@ -0,0 +1,56 @@
+package validator
+
+import (
+	"math/rand/v2"
+	"os"
+	"time"
+)
+
+// Env is the environment of a validator, providing the clock,
+// randomness and hostname used by the validations needing them,
+// see WithEnvironment.
+// Replacing them allows tests to be deterministic.
+type Env struct {
+	// Clock returns the current time, defaults to time.Now.
+	Clock func() time.Time
+	// Rand returns a pseudo-random number in the half-open interval [0.0,1.0),
+	// defaults to rand.Float64 of math/rand/v2.
+	Rand func() float64
+	// Hostname returns the host name, defaults to os.Hostname.
+	Hostname func() (string, error)
+}
+
+// Env returns the environment of the validator with the defaults
+// of unset funcs filled in, allowing custom validations and tooling
+// to share the validator's environment.
+func (v *Validate) Env() Env {
+	env := v.env
+	if env.Clock == nil {
+		env.Clock = time.Now
+	}
+
+	if env.Rand == nil {
+		env.Rand = rand.Float64
+	}
+
+	if env.Hostname == nil {
+		env.Hostname = os.Hostname
+	}
+	return env
+}
+
+// now returns the current time of the validator's clock.
+func (v *Validate) now() time.Time {
+	if v.env.Clock != nil {
+		return v.env.Clock()
+	}
+	return time.Now()
+}
+
+// rand returns a pseudo-random number in the half-open interval [0.0,1.0) of the validator's environment.
+func (v *Validate) rand() float64 {
+	if v.env.Rand != nil {
+		return v.env.Rand()
+	}
+	return rand.Float64()
+}
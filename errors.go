@@ -2,9 +2,16 @@ package validator
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"iter"
 	"reflect"
+	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 )
 
 const fieldErrMsg = "Key: '%s' Error:Field validation for '%s' failed on the '%s' tag"
@@ -12,8 +19,45 @@ const fieldErrMsg = "Key: '%s' Error:Field validation for '%s' failed on the '%s
 var (
 	_ error      = new(fieldError)
 	_ FieldError = new(fieldError)
+	_ error      = ErrTag("")
 )
 
+// Sentinels for the most commonly branched on tags.
+// They can be used with errors.Is to check whether
+// a FieldError failed on the given tag.
+var (
+	ErrRequired = ErrTag(requiredTag)
+	ErrMin      = ErrTag("min")
+	ErrMax      = ErrTag("max")
+	ErrLen      = ErrTag("len")
+	ErrEq       = ErrTag("eq")
+	ErrNe       = ErrTag("ne")
+	ErrLt       = ErrTag("lt")
+	ErrLte      = ErrTag("lte")
+	ErrGt       = ErrTag("gt")
+	ErrGte      = ErrTag("gte")
+	ErrOneOf    = ErrTag("oneof")
+	ErrEmail    = ErrTag("email")
+	ErrURL      = ErrTag("url")
+	ErrUUID     = ErrTag("uuid")
+)
+
+// ErrTag is a sentinel error matching any FieldError that
+// failed on the tag it names, e. g.
+//
+//	if errors.Is(err, validator.ErrTag("required")) {
+//	    ...
+//	}
+//
+// Both the alias (see FieldError.Tag) and the actual tag
+// (see FieldError.ActualTag) are matched.
+type ErrTag string
+
+// Error returns ErrTag message.
+func (e ErrTag) Error() string {
+	return "validator: failed on the '" + string(e) + "' tag"
+}
+
 // FieldError contains all functions to get error details.
 type FieldError interface {
 	// Tag returns the validation tag that failed.
@@ -64,6 +108,85 @@ type FieldError interface {
 	Error() string
 }
 
+// FieldErrorDetails is implemented by the FieldErrors returned by the validator,
+// exposing the details of the errors beyond FieldError, e. g.
+//
+//	if d, ok := fe.(validator.FieldErrorDetails); ok {
+//	    alternatives = d.OrErrors()
+//	}
+type FieldErrorDetails interface {
+	FieldError
+	// ParamValue returns the parsed parameter value of duration and byte size params,
+	// time.Duration for the length and comparison tags of time.Duration fields,
+	// and future_within and past_within, int64 for the length and comparison tags of []byte fields,
+	// or the parameter as a string otherwise.
+	ParamValue() interface{}
+	// ParamString returns the parameter as a string with duration and byte size params normalized,
+	// e. g. '1h30m' for 'lte=5400s' on a time.Duration field and '10MiB' for 'max=10485760' on a []byte field,
+	// or the parameter as returned by Param otherwise.
+	ParamString() string
+	// OrErrors returns the errors of each alternative of a failed 'or' group,
+	// e. g. of 'hexcolor|rgb|rgba', in order, and nil for other errors.
+	// This allows messages to list all the accepted formats.
+	OrErrors() []FieldError
+	// Suggestion returns a hint fixing the failed value computed by the SuggestionFunc
+	// registered for the tag using RegisterSuggestion, e. g. the closest allowed value,
+	// and "" when there is none.
+	Suggestion() string
+	// Code returns the stable machine-readable code of the error registered for the tag
+	// using RegisterErrorCode, or derived from the tag, e. g. 'VAL_GTE' for 'gte'
+	// and 'VAL_REQUIRED_IF' for 'required_if'.
+	Code() string
+	// Translate returns the human-readable message of the error in the given locale, e. g. "en" or "pt-BR",
+	// registered using RegisterTranslation, falling back to the locale's base language,
+	// then to the built-in English catalog, and to Error when no translation is registered.
+	Translate(locale string) string
+}
+
+// Details returns the FieldErrorDetails of fe, the details of FieldErrors
+// implemented outside of the package being derived from their tag and param.
+func Details(fe FieldError) FieldErrorDetails {
+	if d, ok := fe.(FieldErrorDetails); ok {
+		return d
+	}
+	return foreignFieldError{fe}
+}
+
+// foreignFieldError derives the FieldErrorDetails of a FieldError implemented outside of the package.
+type foreignFieldError struct {
+	FieldError
+}
+
+// ParamValue returns the parameter as a string.
+func (fe foreignFieldError) ParamValue() interface{} {
+	return fe.Param()
+}
+
+// ParamString returns the parameter as returned by Param.
+func (fe foreignFieldError) ParamString() string {
+	return fe.Param()
+}
+
+// OrErrors returns nil.
+func (fe foreignFieldError) OrErrors() []FieldError {
+	return nil
+}
+
+// Suggestion returns "".
+func (fe foreignFieldError) Suggestion() string {
+	return ""
+}
+
+// Code returns the code derived from the tag.
+func (fe foreignFieldError) Code() string {
+	return defaultErrorCode(fe.Tag())
+}
+
+// Translate returns the message of the error in locale using the built-in English catalog.
+func (fe foreignFieldError) Translate(locale string) string {
+	return new(Validate).translate(fe, locale)
+}
+
 // ValidationErrors is an array of FieldError's for use in custom error messages post validation.
 type ValidationErrors []FieldError
 
@@ -81,14 +204,217 @@ func (ve ValidationErrors) Error() string {
 	return strings.TrimSpace(buff.String())
 }
 
+// Translate returns the messages of the errors in the given locale keyed by namespace,
+// see FieldErrorDetails.Translate.
+func (ve ValidationErrors) Translate(locale string) map[string]string {
+	trans := make(map[string]string, len(ve))
+	for _, fe := range ve {
+		trans[fe.Namespace()] = Details(fe).Translate(locale)
+	}
+	return trans
+}
+
+// MarshalJSON encodes the errors as a JSON array of objects with
+// the namespace, field, tag, code, param and value of each error, see FieldError.
+func (ve ValidationErrors) MarshalJSON() ([]byte, error) {
+	errs := make([]fieldErrorJSON, len(ve))
+	for i, fe := range ve {
+		errs[i] = newFieldErrorJSON(fe)
+	}
+	return json.Marshal(errs)
+}
+
+// NumericError is a FieldError of a numeric comparison, see ValidationErrors.Numeric.
+type NumericError struct {
+	FieldError
+	Bound  float64 // bound of the tag, e. g. 18 for 'gte=18'
+	Actual float64 // value of the field
+}
+
+// LengthError is a FieldError of a length comparison, see ValidationErrors.Lengths.
+type LengthError struct {
+	FieldError
+	Bound  int // length bound of the tag, e. g. 3 for 'max=3', in bytes for byte sizes, e. g. 1024 for 'max=1KiB'
+	Actual int // length of the field's value, in runes for strings
+}
+
+// Numeric returns an iterator over the errors of the len, min, max, eq, ne, gt, gte, lt and lte tags
+// on integer and float fields other than time.Duration, with their bound and the field's value,
+// allowing to map errors to messages without switching on the tags and parsing their params.
+func (ve ValidationErrors) Numeric() iter.Seq[NumericError] {
+	return func(yield func(NumericError) bool) {
+		for _, fe := range ve {
+			switch fe.ActualTag() {
+			case "len", "min", "max", "eq", "ne", "gt", "gte", "lt", "lte":
+			default:
+				continue
+			}
+
+			if fe.Type() == timeDurationType {
+				continue
+			}
+
+			bound, err := strconv.ParseFloat(fe.Param(), 64)
+			if err != nil {
+				continue
+			}
+
+			val := reflect.ValueOf(rawValue(fe))
+			var actual float64
+			switch {
+			case isIntKind(val.Kind()):
+				actual = float64(val.Int())
+			case isUintKind(val.Kind()):
+				actual = float64(val.Uint())
+			case isFloatKind(val.Kind()):
+				actual = val.Float()
+			default:
+				continue
+			}
+
+			if !yield(NumericError{FieldError: fe, Bound: bound, Actual: actual}) {
+				return
+			}
+		}
+	}
+}
+
+// Lengths returns an iterator over the errors of the len, min, max, gt, gte, lt and lte tags
+// on string, slice, array and map fields, with their length bound and the length of the field's value,
+// allowing to map errors to messages without switching on the tags and parsing their params.
+func (ve ValidationErrors) Lengths() iter.Seq[LengthError] {
+	return func(yield func(LengthError) bool) {
+		for _, fe := range ve {
+			switch fe.ActualTag() {
+			case "len", "min", "max", "gt", "gte", "lt", "lte":
+			default:
+				continue
+			}
+
+			var bound int
+			if n, ok := Details(fe).ParamValue().(int64); ok {
+				bound = int(n)
+			} else if n, err := strconv.Atoi(fe.Param()); err == nil {
+				bound = n
+			} else {
+				continue
+			}
+
+			val := reflect.ValueOf(rawValue(fe))
+			var actual int
+			switch val.Kind() {
+			case reflect.String:
+				actual = utf8.RuneCountInString(val.String())
+			case reflect.Slice, reflect.Array, reflect.Map:
+				actual = val.Len()
+			default:
+				continue
+			}
+
+			if !yield(LengthError{FieldError: fe, Bound: bound, Actual: actual}) {
+				return
+			}
+		}
+	}
+}
+
+// rawValue returns the value of fe, regardless of the ValuePolicy.
+func rawValue(fe FieldError) interface{} {
+	if e, ok := fe.(*fieldError); ok {
+		return e.value
+	}
+	return fe.Value()
+}
+
+// Unwrap returns the individual FieldError's as errors,
+// so errors.Is and errors.As can traverse them and
+// ValidationErrors compose naturally with errors.Join.
+func (ve ValidationErrors) Unwrap() []error {
+	if len(ve) == 0 {
+		return nil
+	}
+
+	errs := make([]error, len(ve))
+	for i := 0; i < len(ve); i++ {
+		errs[i] = ve[i]
+	}
+
+	return errs
+}
+
+// NewFieldError returns the FieldError of a field failing the given tag,
+// e. g. for the reflection-free validation code generated by the codegen package.
+// ns and structNs are the namespaces of the field, e. g. "User.Address.City",
+// the kind and type of the error being the ones of value.
+func NewFieldError(ns, structNs, tag, param string, value interface{}) FieldError {
+	fe := &fieldError{
+		tag:            tag,
+		actualTag:      tag,
+		ns:             ns,
+		structNs:       structNs,
+		fieldLen:       uint8(len(ns) - strings.LastIndexByte(ns, '.') - 1),
+		structfieldLen: uint8(len(structNs) - strings.LastIndexByte(structNs, '.') - 1),
+		value:          value,
+		param:          param,
+	}
+	if value != nil {
+		fe.typ = reflect.TypeOf(value)
+		fe.kind = fe.typ.Kind()
+	}
+	return fe
+}
+
+// Merge returns a new ValidationErrors containing the errors of ve followed by the errors of other.
+func (ve ValidationErrors) Merge(other ValidationErrors) ValidationErrors {
+	merged := make(ValidationErrors, 0, len(ve)+len(other))
+	merged = append(merged, ve...)
+	return append(merged, other...)
+}
+
+// WithNamespacePrefix returns a copy of ve with prefix prepended
+// to the namespace and struct namespace of every error.
+// For example, the prefix "request.body" turns "User.Name" into "request.body.User.Name".
+//
+// NOTE: errors not created by the validator are copied over unchanged.
+func (ve ValidationErrors) WithNamespacePrefix(prefix string) ValidationErrors {
+	if len(prefix) > 0 && !strings.HasSuffix(prefix, namespaceSeparator) {
+		prefix += namespaceSeparator
+	}
+
+	prefixed := make(ValidationErrors, len(ve))
+	for i := 0; i < len(ve); i++ {
+		fe, ok := ve[i].(*fieldError)
+		if !ok {
+			prefixed[i] = ve[i]
+			continue
+		}
+
+		cp := *fe
+		cp.ns = prefix + fe.ns
+		cp.structNs = prefix + fe.structNs
+		prefixed[i] = &cp
+	}
+
+	return prefixed
+}
+
 // InvalidValidationError describes an invalid argument passed to
-// `Struct`, `StructExcept`, StructPartial` or `Field`.
+// `Struct`, `StructExcept`, StructPartial` or `Field`,
+// or, with WithStrictErrors, a malformed tag or a tag used on a type it doesn't support.
 type InvalidValidationError struct {
-	Type reflect.Type
+	Type   reflect.Type
+	Reason string // message of the panic recovered with WithStrictErrors, e. g. "Bad field type int"
 }
 
 // Error returns InvalidValidationError message.
 func (e *InvalidValidationError) Error() string {
+	if len(e.Reason) > 0 {
+		if e.Type == nil {
+			return "validator: " + e.Reason
+		}
+		return "validator: " + e.Reason + " validating " + e.Type.String()
+	}
+
 	if e.Type == nil {
 		return "validator: (nil)"
 	}
@@ -96,6 +422,148 @@ func (e *InvalidValidationError) Error() string {
 	return "validator: (nil " + e.Type.String() + ")"
 }
 
+// recoverStrict recovers the panic of a validation call of typ with WithStrictErrors,
+// returning it as an InvalidValidationError in err.
+func recoverStrict(err *error, typ reflect.Type) {
+	if r := recover(); r != nil {
+		*err = &InvalidValidationError{Type: typ, Reason: fmt.Sprint(r)}
+	}
+}
+
+// AbortedValidationError describes a validation call stopped because its context was done,
+// e. g. its deadline passed, see WithContextAbort.
+// It wraps the context's error, e. g. errors.Is(err, context.DeadlineExceeded) reports true.
+type AbortedValidationError struct {
+	Err    error            // error of the context, e. g. context.DeadlineExceeded
+	Errors ValidationErrors // errors found before the call stopped
+}
+
+// Error returns AbortedValidationError message.
+func (e *AbortedValidationError) Error() string {
+	return "validator: validation aborted: " + e.Err.Error()
+}
+
+// Unwrap returns the error of the context.
+func (e *AbortedValidationError) Unwrap() error {
+	return e.Err
+}
+
+// TruncatedValidationError describes a validation call stopped after its errors reached the cap of WithMaxErrors,
+// more errors possibly being left unreported. It wraps the errors found, e. g.
+//
+//	var te *validator.TruncatedValidationError
+//	if errors.As(err, &te) {
+//	    log.Printf("first %d errors: %v", te.Max, te.Errors)
+//	}
+//
+// errors.As(err, &validationErrors) retrieving them as well.
+type TruncatedValidationError struct {
+	Max    int              // cap of WithMaxErrors
+	Errors ValidationErrors // errors found before the call stopped
+}
+
+// Error returns TruncatedValidationError message.
+func (e *TruncatedValidationError) Error() string {
+	return e.Errors.Error() + "\nvalidator: validation stopped after " + strconv.Itoa(e.Max) + " errors"
+}
+
+// Unwrap returns the errors found before the call stopped.
+func (e *TruncatedValidationError) Unwrap() error {
+	return e.Errors
+}
+
+// IntegrityError describes a struct that failed the holistic
+// integrity check registered with RegisterStructIntegrity.
+// It is reported separately from the field errors, use errors.As to retrieve it:
+//
+//	var ie *validator.IntegrityError
+//	if errors.As(err, &ie) {
+//	    ...
+//	}
+type IntegrityError struct {
+	Namespace string       // namespace of the struct, e.g. 'Payload.Envelope'
+	Type      reflect.Type // type of the struct
+	Err       error        // error returned by the StructIntegrityFunc
+}
+
+// Error returns IntegrityError message.
+func (e *IntegrityError) Error() string {
+	return "validator: integrity check failed for '" + e.Namespace + "': " + e.Err.Error()
+}
+
+// Unwrap returns the error returned by the StructIntegrityFunc.
+func (e *IntegrityError) Unwrap() error {
+	return e.Err
+}
+
+// ValueMode is how a ValuePolicy displays large values.
+type ValueMode uint8
+
+const (
+	// ValueTruncate keeps the first Limit bytes of strings and []byte, or the first Limit elements
+	// of slices and arrays, followed by the length, e. g. "abc…(5242880 bytes)".
+	ValueTruncate ValueMode = iota
+	// ValueHash replaces large values by the hex SHA-256 of their bytes, or of their fmt.Sprint form,
+	// e. g. "sha256:2cf24dba…", allowing to correlate values without exposing them.
+	ValueHash
+	// ValueOmit replaces large values by nil.
+	ValueOmit
+)
+
+// ValuePolicy controls how large values appear in FieldError.Value, see WithValuePolicy.
+type ValuePolicy struct {
+	Mode  ValueMode // how large values are displayed
+	Limit int       // maximum length of strings and []byte in bytes, and of slices, arrays and maps in elements
+}
+
+// apply returns value displayed according to the policy when its length exceeds the limit,
+// large values being displayed as strings.
+func (p ValuePolicy) apply(value interface{}) interface{} {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		if rv.Len() <= p.Limit {
+			return value
+		}
+	default:
+		return value
+	}
+
+	var b []byte
+	switch {
+	case rv.Kind() == reflect.String:
+		b = []byte(rv.String())
+	case rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8:
+		b = rv.Bytes()
+	}
+
+	switch p.Mode {
+	case ValueHash:
+		if b == nil {
+			b = []byte(fmt.Sprint(value))
+		}
+		sum := sha256.Sum256(b)
+		return "sha256:" + hex.EncodeToString(sum[:])
+	case ValueOmit:
+		return nil
+	}
+
+	switch {
+	case b != nil:
+		n := p.Limit
+		for n > 0 && !utf8.RuneStart(b[n]) {
+			n--
+		}
+		return fmt.Sprintf("%s…(%d bytes)", b[:n], len(b))
+	case rv.Kind() == reflect.Map:
+		return fmt.Sprintf("map[…](%d entries)", rv.Len())
+	default:
+		head := reflect.MakeSlice(reflect.SliceOf(rv.Type().Elem()), p.Limit, p.Limit)
+		reflect.Copy(head, rv)
+		return fmt.Sprintf("%v…(%d elements)", head.Interface(), rv.Len())
+	}
+}
+
 // fieldError contains a single field's validation error along with other properties that
 // may be needed for error message creation it complies with the FieldError interface.
 type fieldError struct {
@@ -110,6 +578,7 @@ type fieldError struct {
 	param          string
 	kind           reflect.Kind
 	typ            reflect.Type
+	orErrs         []FieldError
 }
 
 // Tag returns the validation tag that failed.
@@ -147,8 +616,12 @@ func (fe *fieldError) StructField() string {
 }
 
 // Value returns the actual field's value in case needed for creating the error message.
+// Large values are displayed according to the ValuePolicy of WithValuePolicy.
 func (fe *fieldError) Value() interface{} {
-	return fe.value
+	if fe.v == nil || fe.v.valuePolicy.Limit <= 0 {
+		return fe.value
+	}
+	return fe.v.valuePolicy.apply(fe.value)
 }
 
 // Param returns the param value, in string form for comparison.
@@ -157,6 +630,61 @@ func (fe *fieldError) Param() string {
 	return fe.param
 }
 
+// ParamString returns the param value with duration and byte size params normalized,
+// e. g. '5400s' returns '1h30m' and '10485760' returns '10MiB'.
+func (fe *fieldError) ParamString() string {
+	if _, s, ok := fe.unitParam(); ok {
+		return s
+	}
+	return fe.param
+}
+
+// ParamValue returns the parsed param value of duration and byte size params,
+// or the param string otherwise.
+func (fe *fieldError) ParamValue() interface{} {
+	if v, _, ok := fe.unitParam(); ok {
+		return v
+	}
+	return fe.param
+}
+
+// unitParam returns the parsed and normalized param of duration and byte size params.
+func (fe *fieldError) unitParam() (interface{}, string, bool) {
+	if len(fe.param) == 0 || fe.typ == nil {
+		return nil, "", false
+	}
+
+	switch fe.actualTag {
+	case "future_within", "past_within":
+		if d, ok := parseDuration(fe.param); ok {
+			return d, formatDuration(d), true
+		}
+	case "len", "min", "max", "eq", "ne", "gt", "gte", "lt", "lte":
+		switch {
+		case fe.typ == timeDurationType:
+			d, err := time.ParseDuration(fe.param)
+			if err != nil {
+				n, err := strconv.ParseInt(fe.param, 0, 64)
+				if err != nil {
+					return nil, "", false
+				}
+				d = time.Duration(n)
+			}
+			return d, formatDuration(d), true
+		case fe.typ.Kind() == reflect.Slice && fe.typ.Elem().Kind() == reflect.Uint8:
+			n, ok := parseByteSize(fe.param)
+			if !ok {
+				var err error
+				if n, err = strconv.ParseInt(fe.param, 0, 64); err != nil {
+					return nil, "", false
+				}
+			}
+			return n, formatByteSize(n), true
+		}
+	}
+	return nil, "", false
+}
+
 // Kind returns the Field's reflect Kind.
 func (fe *fieldError) Kind() reflect.Kind {
 	return fe.kind
@@ -167,7 +695,110 @@ func (fe *fieldError) Type() reflect.Type {
 	return fe.typ
 }
 
+// OrErrors returns the errors of each alternative of a failed 'or' group.
+func (fe *fieldError) OrErrors() []FieldError {
+	return fe.orErrs
+}
+
+// Suggestion returns the hint of the SuggestionFunc registered for the tag,
+// or for the actual tag of an alias.
+func (fe *fieldError) Suggestion() string {
+	if fe.v == nil {
+		return ""
+	}
+
+	fn, ok := fe.v.suggestions[fe.tag]
+	if !ok {
+		if fn, ok = fe.v.suggestions[fe.actualTag]; !ok {
+			return ""
+		}
+	}
+	return fn(fe)
+}
+
+// Code returns the machine-readable code of the error.
+func (fe *fieldError) Code() string {
+	if fe.v != nil {
+		if code, ok := fe.v.errorCodes[fe.tag]; ok {
+			return code
+		}
+
+		if code, ok := fe.v.errorCodes[fe.actualTag]; ok {
+			return code
+		}
+	}
+	return defaultErrorCode(fe.tag)
+}
+
+// Translate returns the message of the error in locale,
+// errors created by NewFieldError using the built-in English catalog.
+func (fe *fieldError) Translate(locale string) string {
+	if fe.v == nil {
+		return new(Validate).translate(fe, locale)
+	}
+	return fe.v.translate(fe, locale)
+}
+
 // Error returns the fieldError's error message.
 func (fe *fieldError) Error() string {
 	return fmt.Sprintf(fieldErrMsg, fe.ns, fe.Field(), fe.tag)
 }
+
+// MarshalJSON encodes the fieldError as a JSON object with its namespace, field, tag, code, param and value.
+func (fe *fieldError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(newFieldErrorJSON(fe))
+}
+
+// Is reports whether the fieldError matches target,
+// allowing errors.Is to be used with ErrTag sentinels.
+func (fe *fieldError) Is(target error) bool {
+	tag, ok := target.(ErrTag)
+	if !ok {
+		return false
+	}
+
+	return string(tag) == fe.tag || string(tag) == fe.actualTag
+}
+
+// fieldErrorJSON is the JSON encoding of a FieldError.
+type fieldErrorJSON struct {
+	Namespace string          `json:"namespace"`
+	Field     string          `json:"field"`
+	Tag       string          `json:"tag"`
+	Code      string          `json:"code"`
+	Param     string          `json:"param,omitempty"`
+	Value     json.RawMessage `json:"value"`
+}
+
+// newFieldErrorJSON returns the JSON encoding of fe,
+// values that can't be encoded, e. g. funcs, are encoded using their default format.
+func newFieldErrorJSON(fe FieldError) fieldErrorJSON {
+	value, err := json.Marshal(fe.Value())
+	if err != nil {
+		value, _ = json.Marshal(fmt.Sprint(fe.Value()))
+	}
+
+	return fieldErrorJSON{
+		Namespace: fe.Namespace(),
+		Field:     fe.Field(),
+		Tag:       fe.Tag(),
+		Code:      Details(fe).Code(),
+		Param:     fe.Param(),
+		Value:     value,
+	}
+}
+
+// defaultErrorCode returns the code of the errors of tag,
+// its upper cased letters and digits prefixed with 'VAL_', e. g. 'VAL_GTE'.
+func defaultErrorCode(tag string) string {
+	return "VAL_" + strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, tag)
+}
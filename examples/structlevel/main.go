@@ -3,10 +3,7 @@ package structLevel
 import (
 	"encoding/json"
 	"errors"
-	"fmt"
 	"log"
-	"reflect"
-	"strings"
 
 	"github.com/pchchv/validator"
 )
@@ -40,20 +37,6 @@ func (gender Gender) String() string {
 	return terms[gender]
 }
 
-type validationError struct {
-	Namespace       string `json:"namespace"` // can differ when a custom TagNameFunc is registered or
-	Field           string `json:"field"`     // by passing alt name to ReportError like below
-	StructNamespace string `json:"structNamespace"`
-	StructField     string `json:"structField"`
-	Tag             string `json:"tag"`
-	ActualTag       string `json:"actualTag"`
-	Kind            string `json:"kind"`
-	Type            string `json:"type"`
-	Value           string `json:"value"`
-	Param           string `json:"param"`
-	Message         string `json:"message"`
-}
-
 const (
 	Male Gender = iota + 1
 	Female
@@ -80,12 +63,7 @@ func UserStructLevelValidation(sl validator.StructLevel) {
 func main() {
 	validate = validator.New()
 	// register function to get tag name from json tags
-	validate.RegisterTagNameFunc(func(fld reflect.StructField) string {
-		if name := strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]; name != "-" {
-			return name
-		}
-		return ""
-	})
+	validate.RegisterTagNameFunc(validator.JSONTagNameFunc)
 
 	// register validation for 'User'
 	// NOTE: only have to register a non-pointer type for 'User',
@@ -132,27 +110,12 @@ func main() {
 
 		var validateErrs validator.ValidationErrors
 		if errors.As(err, &validateErrs) {
-			for _, err := range validateErrs {
-				e := validationError{
-					Namespace:       err.Namespace(),
-					Field:           err.Field(),
-					StructNamespace: err.StructNamespace(),
-					StructField:     err.StructField(),
-					Tag:             err.Tag(),
-					ActualTag:       err.ActualTag(),
-					Kind:            err.Kind().String(),
-					Type:            err.Type().String(),
-					Value:           fmt.Sprint(err.Value()),
-					Param:           err.Param(),
-					Message:         err.Error(),
-				}
-
-				if indent, err := json.MarshalIndent(e, "", "  "); err != nil {
-					log.Println(err)
-					panic(err)
-				} else {
-					log.Println(string(indent))
-				}
+			// each error is encoded with its namespace, field, tag, param and value
+			if indent, err := json.MarshalIndent(validateErrs, "", "  "); err != nil {
+				log.Println(err)
+				panic(err)
+			} else {
+				log.Println(string(indent))
 			}
 		}
 		// here it is possible to create custom error messages
@@ -2,7 +2,7 @@ package validator
 
 import "reflect"
 
-var _ FieldLevel = new(validate)
+var _ IndexedFieldLevel = new(validate)
 
 // FieldLevel contains all the information and helper functions to validate a field.
 type FieldLevel interface {
@@ -38,6 +38,21 @@ type FieldLevel interface {
 	GetStructFieldOKAdvanced(val reflect.Value, namespace string) (reflect.Value, reflect.Kind, bool, bool)
 }
 
+// IndexedFieldLevel is implemented by the FieldLevel passed to the validation functions,
+// exposing the position of the current element within a dive, e. g.
+//
+//	if il, ok := fl.(validator.IndexedFieldLevel); ok {
+//	    index, key, ok := il.Index()
+//	}
+type IndexedFieldLevel interface {
+	FieldLevel
+	// Index returns the slice or array index, or the map key, of the current element when validating within a dive.
+	// For map elements the index is -1 and for slice and array elements the key is the zero Value.
+	//
+	// NOTE: ok is false when the current field isn't a dive element.
+	Index() (index int, key reflect.Value, ok bool)
+}
+
 // Param returns param for validation against current field.
 func (v *validate) Param() string {
 	return v.ct.param
@@ -74,3 +89,8 @@ func (v *validate) GetStructFieldOK() (reflect.Value, reflect.Kind, bool, bool)
 func (v *validate) GetStructFieldOKAdvanced(val reflect.Value, namespace string) (reflect.Value, reflect.Kind, bool, bool) {
 	return v.getStructFieldOKInternal(val, namespace)
 }
+
+// Index returns the index or map key of the current dive element.
+func (v *validate) Index() (int, reflect.Value, bool) {
+	return v.elem.idx, v.elem.key, v.elem.ok
+}
@@ -0,0 +1,190 @@
+package validator
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// labelTag is the struct tag key of the labels of form fields, see Validate.Form.
+const labelTag = "label"
+
+// formInputTypes are the input types of the string formats of the tags.
+var formInputTypes = map[string]string{
+	"email":    "email",
+	"url":      "url",
+	"http_url": "url",
+	"uri":      "url",
+	"e164":     "tel",
+	"iscolor":  "color",
+	"hexcolor": "color",
+}
+
+// formDateTypes are the input types of the layouts of the datetime tag.
+var formDateTypes = map[string]string{
+	"2006-01-02":                "date",
+	"15:04":                     "time",
+	"15:04:05":                  "time",
+	"2006-01-02T15:04":          "datetime",
+	"2006-01-02T15:04:05Z07:00": "datetime",
+}
+
+// FormField is the UI oriented metadata of a field, see Validate.Form.
+type FormField struct {
+	Name     string   `json:"name"`              // namespace of the field relative to the struct, e. g. 'address.city' when using RegisterTagNameFunc
+	Label    string   `json:"label"`             // label tag of the field, or its name split into words, e. g. 'Postal Code'
+	Type     string   `json:"type"`              // input type, e. g. 'text', 'number', 'checkbox', 'email', 'date', 'select' or 'list'
+	Required bool     `json:"required"`          // whether the field is required
+	Min      *float64 `json:"min,omitempty"`     // minimum length of texts, value of numbers or number of items of lists
+	Max      *float64 `json:"max,omitempty"`     // maximum length of texts, value of numbers or number of items of lists
+	Pattern  string   `json:"pattern,omitempty"` // regular expression texts must match
+	Options  []string `json:"options,omitempty"` // allowed values of selects and multiselects
+}
+
+// Form returns the UI oriented metadata of the fields of the struct type of t, intended for form generation,
+// e. g. in admin panels. The fields of nested structs are flattened, their names being namespaces,
+// e. g. 'Address.City', and the rules are normalized into Required, Min, Max, Pattern and Options,
+// others being ignored. Labels are taken from the 'label' struct tag, e. g. `label:"E-mail address"`.
+// Fields skipped using the '-' tag, and leaf types other than time.Time, aren't described, see WithLeafTypes.
+func (v *Validate) Form(t interface{}) ([]FormField, error) {
+	return v.form(nil, t, "", make(map[reflect.Type]struct{}))
+}
+
+// form appends the form fields of the struct type of t to fields, prefixing their names with prefix.
+func (v *Validate) form(fields []FormField, t interface{}, prefix string, seen map[reflect.Type]struct{}) ([]FormField, error) {
+	descs, err := v.Describe(t)
+	if err != nil {
+		return nil, err
+	}
+
+	typ := reflect.TypeOf(t)
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	seen[typ] = struct{}{}
+	defer delete(seen, typ)
+	for _, d := range descs {
+		ft := d.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+
+		if ft.Kind() == reflect.Struct && ft != timeType {
+			if _, ok := seen[ft]; !ok && !v.isLeafType(ft) {
+				if fields, err = v.form(fields, reflect.New(ft).Interface(), prefix+d.Name+namespaceSeparator, seen); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+
+		sf, _ := typ.FieldByName(d.Field)
+		label := sf.Tag.Get(labelTag)
+		if len(label) == 0 {
+			label = splitWords(d.Field)
+		}
+		fields = append(fields, newFormField(prefix+d.Name, label, ft, d.Rules))
+	}
+	return fields, nil
+}
+
+// newFormField returns the form field of the given type with the rules.
+func newFormField(name, label string, typ reflect.Type, rules []Rule) FormField {
+	f := FormField{Name: name, Label: label}
+	switch {
+	case typ == timeType:
+		f.Type = "datetime"
+	case typ.Kind() == reflect.Bool:
+		f.Type = "checkbox"
+	case isIntKind(typ.Kind()) || isUintKind(typ.Kind()) || isFloatKind(typ.Kind()):
+		f.Type = "number"
+	case typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array || typ.Kind() == reflect.Map:
+		f.Type = "list"
+	default:
+		f.Type = "text"
+	}
+
+	// exclusive bounds of integers and lengths are converted to inclusive ones
+	list, step := f.Type == "list", 1.0
+	if isFloatKind(typ.Kind()) {
+		step = 0
+	}
+
+	for i, r := range rules {
+		if r.Warn || r.Or || (i > 0 && rules[i-1].Or) {
+			continue
+		}
+
+		if r.Tag == itemTag || r.Tag == restTag {
+			// positional rules apply to the items
+			break
+		}
+
+		if r.Tag == diveTag || r.Tag == diveMaxErrsTag || r.Tag == diveParallelTag {
+			// the options of the items of a list make a multiselect
+			for _, er := range rules[i+1:] {
+				if er.Tag == "oneof" && list {
+					f.Type, f.Options = "multiselect", append([]string(nil), parseOneOfParam(er.Param)...)
+				}
+			}
+			break
+		}
+
+		switch r.Tag {
+		case requiredTag:
+			f.Required = true
+		case "oneof":
+			f.Type, f.Options = "select", append([]string(nil), parseOneOfParam(r.Param)...)
+		case "datetime":
+			if in, ok := formDateTypes[r.Param]; ok {
+				f.Type = in
+			}
+		case "len":
+			f.Min, f.Max = formBound(r.Param, 0), formBound(r.Param, 0)
+		case "min", "gte":
+			f.Min = formBound(r.Param, 0)
+		case "max", "lte":
+			f.Max = formBound(r.Param, 0)
+		case "gt":
+			f.Min = formBound(r.Param, step)
+		case "lt":
+			f.Max = formBound(r.Param, -step)
+		default:
+			if in, ok := formInputTypes[r.Tag]; ok && f.Type == "text" {
+				f.Type = in
+			} else if patterns := tagRegexes[r.Tag]; len(patterns) == 1 && f.Type == "text" && len(f.Pattern) == 0 {
+				f.Pattern = patterns[0]
+			}
+		}
+	}
+	return f
+}
+
+// formBound returns the number param offset by delta, e. g. the minimum length of 'gt=3' is 4,
+// nil if it isn't a number.
+func formBound(param string, delta float64) *float64 {
+	n, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return nil
+	}
+
+	n += delta
+	return &n
+}
+
+// splitWords returns the words of a Go identifier separated by spaces,
+// e. g. "PostalCode" returns "Postal Code" and "UserID" returns "User ID".
+func splitWords(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) &&
+			(unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+			b.WriteByte(' ')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -2,7 +2,10 @@ module github.com/pchchv/validator
 
 go 1.24.0
 
-require golang.org/x/crypto v0.38.0
+require (
+	golang.org/x/crypto v0.38.0
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require golang.org/x/net v0.39.0 // indirect
 
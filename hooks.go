@@ -0,0 +1,83 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// ValidationCall describes a validation call to the hooks, see WithHooks.
+type ValidationCall struct {
+	Method   string        // validation method, e. g. 'Struct', 'StructPartial', 'Var' or 'Typed'
+	Type     reflect.Type  // type of the validated value, e. g. the root struct type of Struct, nil for a nil interface
+	Start    time.Time     // start of the call, read from the clock, see WithClock
+	Duration time.Duration // duration of the call, set for the after hook
+	Errors   int           // number of the ValidationErrors returned, set for the after hook
+	Err      error         // error returned, set for the after hook
+}
+
+// TypeName returns the name of the validated type, dereferencing pointers, e. g. 'User' for *User.
+func (c ValidationCall) TypeName() string {
+	typ := c.Type
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ == nil {
+		return "nil"
+	}
+
+	if len(typ.Name()) > 0 {
+		return typ.Name()
+	}
+	return typ.String()
+}
+
+// BeforeHook is called before a validation call, the context it returns, e. g. holding a span,
+// being used by the call and passed to the AfterHook, see WithHooks.
+type BeforeHook func(ctx context.Context, call ValidationCall) context.Context
+
+// AfterHook is called after a validation call with the outcome of the call, see WithHooks.
+type AfterHook func(ctx context.Context, call ValidationCall)
+
+// hooked reports whether the validation calls run hooks.
+func (v *Validate) hooked() bool {
+	return v.beforeHook != nil || v.afterHook != nil
+}
+
+// startCall runs the before hook of a validation call of method on a value of type typ.
+func (v *Validate) startCall(ctx context.Context, method string, typ reflect.Type) (context.Context, *ValidationCall) {
+	call := &ValidationCall{Method: method, Type: typ, Start: v.now()}
+	if v.beforeHook != nil {
+		if hctx := v.beforeHook(ctx, *call); hctx != nil {
+			ctx = hctx
+		}
+	}
+	return ctx, call
+}
+
+// endCall runs the after hook of a validation call returning *err,
+// a panicking call being reported as an *InvalidValidationError before panicking again.
+// It must be deferred before recoverStrict.
+func (v *Validate) endCall(ctx context.Context, call *ValidationCall, err *error) {
+	r := recover()
+	if v.afterHook != nil {
+		call.Duration = v.now().Sub(call.Start)
+		call.Err = *err
+		if r != nil {
+			call.Err = &InvalidValidationError{Type: call.Type, Reason: fmt.Sprint(r)}
+		}
+
+		var errs ValidationErrors
+		if errors.As(call.Err, &errs) {
+			call.Errors = len(errs)
+		}
+		v.afterHook(ctx, *call)
+	}
+
+	if r != nil {
+		panic(r)
+	}
+}
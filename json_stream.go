@@ -0,0 +1,63 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// JSONElementFunc handles the element i of a JSON array decoded by DecodeJSONArray,
+// err being nil or the ValidationErrors of the element.
+// Returning a non-nil error stops the decoding, e. g. returning err rejects the payload
+// on its first invalid element without decoding the rest.
+type JSONElementFunc[T any] func(i int, elem *T, err error) error
+
+// DecodeJSONArray decodes the JSON array read from r element by element, streaming it,
+// each element being decoded into a new value of the struct type T, validated using ctx and passed to fn, e. g.
+//
+//	err := validator.DecodeJSONArray(ctx, validate, r, func(i int, u *User, err error) error {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    return store.Insert(ctx, u)
+//	})
+//
+// Only the element being decoded is held in memory, allowing huge payloads to be validated
+// and rejected early rather than once completely unmarshaled.
+// It returns InvalidValidationError if T isn't a struct, the errors decoding the array and the error returned by fn.
+func DecodeJSONArray[T any](ctx context.Context, v *Validate, r io.Reader, fn JSONElementFunc[T]) error {
+	dec := json.NewDecoder(r)
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if tok != json.Delim('[') {
+		return fmt.Errorf("validator: expected JSON array, got %v", tok)
+	}
+
+	for i := 0; dec.More(); i++ {
+		elem := new(T)
+		if err := dec.Decode(elem); err != nil {
+			return fmt.Errorf("validator: decoding JSON array element %d: %w", i, err)
+		}
+
+		if err := validateJSONElement(ctx, v, i, elem, fn); err != nil {
+			return err
+		}
+	}
+
+	_, err = dec.Token()
+	return err
+}
+
+// validateJSONElement validates the decoded element i and passes it to fn,
+// returning InvalidValidationError rather than passing it.
+func validateJSONElement[T any](ctx context.Context, v *Validate, i int, elem *T, fn JSONElementFunc[T]) error {
+	err := v.StructCtx(ctx, elem)
+	if _, ok := err.(*InvalidValidationError); ok {
+		return err
+	}
+	return fn(i, elem, err)
+}
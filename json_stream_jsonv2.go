@@ -0,0 +1,44 @@
+//go:build go1.27 && goexperiment.jsonv2
+
+package validator
+
+import (
+	"context"
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+	"fmt"
+)
+
+// DecodeJSONTextArray decodes the JSON array read from dec element by element like DecodeJSONArray,
+// unmarshaling each element straight from the jsontext tokens using opts, e. g.
+//
+//	dec := jsontext.NewDecoder(r)
+//	err := validator.DecodeJSONTextArray(ctx, validate, dec, func(i int, u *User, err error) error {
+//	    return err
+//	}, json.RejectUnknownMembers(true))
+//
+// The array must be the next value of dec, which is left after its end.
+func DecodeJSONTextArray[T any](ctx context.Context, v *Validate, dec *jsontext.Decoder, fn JSONElementFunc[T], opts ...json.Options) error {
+	tok, err := dec.ReadToken()
+	if err != nil {
+		return err
+	}
+
+	if tok.Kind() != '[' {
+		return fmt.Errorf("validator: expected JSON array, got %v", tok.Kind())
+	}
+
+	for i := 0; dec.PeekKind() != ']'; i++ {
+		elem := new(T)
+		if err := json.UnmarshalDecode(dec, elem, opts...); err != nil {
+			return fmt.Errorf("validator: decoding JSON array element %d: %w", i, err)
+		}
+
+		if err := validateJSONElement(ctx, v, i, elem, fn); err != nil {
+			return err
+		}
+	}
+
+	_, err = dec.ReadToken()
+	return err
+}
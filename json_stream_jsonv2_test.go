@@ -0,0 +1,61 @@
+//go:build go1.27 && goexperiment.jsonv2
+
+package validator
+
+import (
+	"context"
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+	"strings"
+	"testing"
+
+	. "github.com/pchchv/go-assert"
+)
+
+func TestDecodeJSONTextArray(t *testing.T) {
+	type User struct {
+		Name string `json:"name" validate:"required"`
+		Age  int    `json:"age" validate:"gte=18"`
+	}
+
+	validate := New()
+	var names []string
+	var invalid []int
+	dec := jsontext.NewDecoder(strings.NewReader(`[{"name":"joey","age":20},{"age":12},{"name":"zoey","age":30}] {}`))
+	err := DecodeJSONTextArray(context.Background(), validate, dec, func(i int, u *User, err error) error {
+		if err != nil {
+			invalid = append(invalid, i)
+			return nil
+		}
+		names = append(names, u.Name)
+		return nil
+	})
+	Equal(t, err, nil)
+	Equal(t, names, []string{"joey", "zoey"})
+	Equal(t, invalid, []int{1})
+	Equal(t, dec.PeekKind(), jsontext.Kind('{'))
+
+	// early rejection, the elements after the first invalid one aren't decoded
+	var decoded int
+	dec = jsontext.NewDecoder(strings.NewReader(`[{"name":"joey","age":20},{"age":12},{"name":`))
+	err = DecodeJSONTextArray(context.Background(), validate, dec, func(i int, u *User, err error) error {
+		decoded++
+		return err
+	})
+	NotEqual(t, err, nil)
+	Equal(t, decoded, 2)
+	AssertError(t, err.(ValidationErrors), "User.Age", "User.Age", "Age", "Age", "gte")
+
+	dec = jsontext.NewDecoder(strings.NewReader(`[{"name":"joey","age":20,"email":"joey@example.com"}]`))
+	err = DecodeJSONTextArray(context.Background(), validate, dec, func(i int, u *User, err error) error {
+		return err
+	}, json.RejectUnknownMembers(true))
+	NotEqual(t, err, nil)
+	Equal(t, strings.HasPrefix(err.Error(), "validator: decoding JSON array element 0: "), true)
+
+	dec = jsontext.NewDecoder(strings.NewReader(`{"name":"joey"}`))
+	err = DecodeJSONTextArray(context.Background(), validate, dec, func(i int, u *User, err error) error {
+		return err
+	})
+	Equal(t, err.Error(), "validator: expected JSON array, got {")
+}
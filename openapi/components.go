@@ -0,0 +1,441 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pchchv/validator"
+)
+
+// formats are the string formats of the tags.
+var formats = map[string]string{
+	"email":            "email",
+	"url":              "uri",
+	"http_url":         "uri",
+	"uri":              "uri",
+	"uuid":             "uuid",
+	"uuid3":            "uuid",
+	"uuid4":            "uuid",
+	"uuid5":            "uuid",
+	"uuid_rfc4122":     "uuid",
+	"uuid3_rfc4122":    "uuid",
+	"uuid4_rfc4122":    "uuid",
+	"uuid5_rfc4122":    "uuid",
+	"ipv4":             "ipv4",
+	"ipv6":             "ipv6",
+	"hostname":         "hostname",
+	"hostname_rfc1123": "hostname",
+	"fqdn":             "hostname",
+	"base64":           "byte",
+}
+
+// dateTimeFormats are the formats of the layouts of the datetime tag.
+var dateTimeFormats = map[string]string{
+	time.RFC3339:  "date-time",
+	time.DateOnly: "date",
+	time.TimeOnly: "time",
+}
+
+// patterns are the regular expressions of the tags matching strings.
+var patterns = map[string]string{
+	"alpha":           "^[a-zA-Z]+$",
+	"alphanum":        "^[a-zA-Z0-9]+$",
+	"alphaunicode":    "^[\\p{L}]+$",
+	"alphanumunicode": "^[\\p{L}\\p{N}]+$",
+	"numeric":         "^[-+]?[0-9]+(?:\\.[0-9]+)?$",
+	"number":          "^[0-9]+$",
+	"hexadecimal":     "^(0[xX])?[0-9a-fA-F]+$",
+	"e164":            "^\\+[1-9]?[0-9]{7,14}$",
+}
+
+// ignoredTags are the tags that don't constrain values or are enforced by the schemas' structure.
+var ignoredTags = map[string]struct{}{
+	"omitempty": {}, "omitzero": {}, "omitnil": {}, "structonly": {}, "nostructlevel": {}, "stopchildren": {},
+}
+
+// splitParams splits the values of a oneof param, values containing spaces being single quoted.
+var splitParams = regexp.MustCompile(`'[^']*'|\S+`)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// exporter converts struct types into schemas.
+type exporter struct {
+	v       *validator.Validate
+	schemas map[string]interface{}
+	skipped []string
+}
+
+// Components returns the OpenAPI 3.1 schema objects of the struct types of types and of their nested
+// named struct types, keyed by type name, to be set as the components.schemas of a document, e. g.
+//
+//	schemas, skipped, err := openapi.Components(validate, User{}, Order{})
+//
+// The fields are described as validated by v, see Validate.Describe, the property names honouring
+// RegisterTagNameFunc, required fields being listed as required, and the rules being converted to
+// the keywords minLength, maxLength, minItems, maxItems, minProperties, maxProperties, minimum, maximum,
+// exclusiveMinimum, exclusiveMaximum, multipleOf, uniqueItems, const, enum, pattern and format,
+// the rules following dive applying to the items and the values of maps.
+// The rules that can't be converted, e. g. the rules of an 'or' or eqfield, are returned as skipped,
+// formatted as the JSON pointer of the property followed by the rule,
+// e. g. "#/components/schemas/User/properties/email: eqfield=Confirm".
+func Components(v *validator.Validate, types ...interface{}) (map[string]interface{}, []string, error) {
+	exp := &exporter{v: v, schemas: make(map[string]interface{})}
+	for _, t := range types {
+		typ := reflect.TypeOf(t)
+		for typ != nil && typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+
+		if typ == nil || typ.Kind() != reflect.Struct || len(typ.Name()) == 0 {
+			return nil, nil, fmt.Errorf("openapi: type '%v' is not a named struct", reflect.TypeOf(t))
+		}
+
+		if _, err := exp.component(typ); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	slices.Sort(exp.skipped)
+	return exp.schemas, slices.Compact(exp.skipped), nil
+}
+
+// component adds the schema of the named struct type typ and returns its reference.
+func (exp *exporter) component(typ reflect.Type) (map[string]interface{}, error) {
+	name := typ.Name()
+	ref := map[string]interface{}{"$ref": "#/components/schemas/" + escapePointer(name)}
+	if _, ok := exp.schemas[name]; ok {
+		return ref, nil
+	}
+
+	// recursive types reference the schema being converted
+	exp.schemas[name] = nil
+	schema, err := exp.object(typ, "#/components/schemas/"+escapePointer(name))
+	if err != nil {
+		return nil, err
+	}
+
+	exp.schemas[name] = schema
+	return ref, nil
+}
+
+// object returns the schema of the struct type typ.
+func (exp *exporter) object(typ reflect.Type, ptr string) (map[string]interface{}, error) {
+	fields, err := exp.v.Describe(reflect.New(typ).Interface())
+	if err != nil {
+		return nil, err
+	}
+
+	props := make(map[string]interface{}, len(fields))
+	var required []string
+	for _, f := range fields {
+		prop, req, err := exp.property(f.Type, f.Rules, ptr+"/properties/"+escapePointer(f.Name))
+		if err != nil {
+			return nil, err
+		}
+
+		props[f.Name] = prop
+		if req {
+			required = append(required, f.Name)
+		}
+	}
+
+	schema := map[string]interface{}{"type": "object", "properties": props}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema, nil
+}
+
+// property returns the schema of a value of type typ with the rules,
+// and whether the rules require the value.
+func (exp *exporter) property(typ reflect.Type, rules []validator.Rule, ptr string) (map[string]interface{}, bool, error) {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	schema, err := exp.typeSchema(typ, ptr)
+	if err != nil {
+		return nil, false, err
+	}
+
+	head, tail := rules, []validator.Rule(nil)
+	for i, r := range rules {
+		if r.Tag == "dive" || r.Tag == "dive_maxerrs" || r.Tag == "dive_parallel" || r.Tag == "strsplit" || r.Tag == "item" || r.Tag == "rest" {
+			head, tail = rules[:i], rules[i:]
+			break
+		}
+	}
+
+	var required bool
+	for i, r := range head {
+		if r.Warn {
+			// warnings aren't enforced, see validator.Validate.StructWithWarnings
+			continue
+		}
+
+		if r.Or || (i > 0 && head[i-1].Or) {
+			exp.skip(ptr, r)
+			continue
+		}
+
+		if r.Tag == "required" {
+			required = true
+			continue
+		}
+
+		if _, ok := ignoredTags[r.Tag]; !ok && !constrain(schema, typ, r) {
+			exp.skip(ptr, r)
+		}
+	}
+
+	if len(tail) > 0 {
+		if err := exp.dive(schema, typ, tail, ptr); err != nil {
+			return nil, false, err
+		}
+	}
+	return schema, required, nil
+}
+
+// dive applies the rules following a dive to the items of an array or the values of a map,
+// the positional rules of items being skipped.
+func (exp *exporter) dive(schema map[string]interface{}, typ reflect.Type, rules []validator.Rule, ptr string) error {
+	if rules[0].Tag == "strsplit" || rules[0].Tag == "item" || rules[0].Tag == "rest" || (typ.Kind() != reflect.Slice && typ.Kind() != reflect.Array && typ.Kind() != reflect.Map) {
+		for _, r := range rules {
+			exp.skip(ptr, r)
+		}
+		return nil
+	}
+
+	rules = rules[1:]
+	if len(rules) > 0 && rules[0].Tag == "keys" {
+		end := slices.IndexFunc(rules, func(r validator.Rule) bool { return r.Tag == "endkeys" })
+		if end < 0 {
+			end = len(rules)
+		}
+
+		keys := rules[1:end]
+		if typ.Kind() == reflect.Map && typ.Key().Kind() == reflect.String {
+			names, _, err := exp.property(typ.Key(), keys, ptr+"/propertyNames")
+			if err != nil {
+				return err
+			}
+			schema["propertyNames"] = names
+		} else {
+			for _, r := range keys {
+				exp.skip(ptr, r)
+			}
+		}
+		rules = rules[min(end+1, len(rules)):]
+	}
+
+	key, elemPtr := "items", ptr+"/items"
+	if typ.Kind() == reflect.Map {
+		key, elemPtr = "additionalProperties", ptr+"/additionalProperties"
+	}
+
+	elem, _, err := exp.property(typ.Elem(), rules, elemPtr)
+	if err != nil {
+		return err
+	}
+	schema[key] = elem
+	return nil
+}
+
+// typeSchema returns the schema of the values of type typ.
+func (exp *exporter) typeSchema(typ reflect.Type, ptr string) (map[string]interface{}, error) {
+	if typ == timeType {
+		return map[string]interface{}{"type": "string", "format": "date-time"}, nil
+	}
+
+	switch typ.Kind() {
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}, nil
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}, nil
+	case reflect.Int32:
+		return map[string]interface{}{"type": "integer", "format": "int32"}, nil
+	case reflect.Int64:
+		return map[string]interface{}{"type": "integer", "format": "int64"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}, nil
+	case reflect.Float32:
+		return map[string]interface{}{"type": "number", "format": "float"}, nil
+	case reflect.Float64:
+		return map[string]interface{}{"type": "number", "format": "double"}, nil
+	case reflect.Slice, reflect.Array:
+		if typ.Elem().Kind() == reflect.Uint8 && typ.Kind() == reflect.Slice {
+			// encoding/json encodes byte slices as base64 strings
+			return map[string]interface{}{"type": "string", "format": "byte"}, nil
+		}
+
+		items, _, err := exp.property(typ.Elem(), nil, ptr+"/items")
+		if err != nil {
+			return nil, err
+		}
+
+		schema := map[string]interface{}{"type": "array", "items": items}
+		if typ.Kind() == reflect.Array {
+			schema["minItems"], schema["maxItems"] = typ.Len(), typ.Len()
+		}
+		return schema, nil
+	case reflect.Map:
+		values, _, err := exp.property(typ.Elem(), nil, ptr+"/additionalProperties")
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": "object", "additionalProperties": values}, nil
+	case reflect.Struct:
+		if len(typ.Name()) == 0 {
+			return exp.object(typ, ptr)
+		}
+		return exp.component(typ)
+	default:
+		return map[string]interface{}{}, nil
+	}
+}
+
+// constrain adds the keyword of the rule r to the schema of the values of type typ,
+// reporting whether the rule could be converted.
+func constrain(schema map[string]interface{}, typ reflect.Type, r validator.Rule) bool {
+	switch {
+	case typ == timeType || typ.Kind() == reflect.Struct:
+		return false
+	case typ.Kind() == reflect.String:
+		return constrainString(schema, r)
+	case typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array:
+		if r.Tag == "unique" {
+			schema["uniqueItems"] = true
+			return true
+		}
+		return constrainLength(schema, r, "minItems", "maxItems")
+	case typ.Kind() == reflect.Map:
+		return constrainLength(schema, r, "minProperties", "maxProperties")
+	case schema["type"] == "integer" || schema["type"] == "number":
+		return constrainNumber(schema, r)
+	default:
+		return false
+	}
+}
+
+// constrainString adds the keyword of the rule r to the schema of strings.
+func constrainString(schema map[string]interface{}, r validator.Rule) bool {
+	switch r.Tag {
+	case "eq":
+		schema["const"] = r.Param
+		return true
+	case "oneof":
+		enum := []interface{}{}
+		for _, s := range splitParams.FindAllString(r.Param, -1) {
+			enum = append(enum, strings.ReplaceAll(s, "'", ""))
+		}
+		schema["enum"] = enum
+		return true
+	case "datetime":
+		format, ok := dateTimeFormats[r.Param]
+		return ok && setOnce(schema, "format", format)
+	case "startswith":
+		return setOnce(schema, "pattern", "^"+regexp.QuoteMeta(r.Param))
+	case "endswith":
+		return setOnce(schema, "pattern", regexp.QuoteMeta(r.Param)+"$")
+	case "contains":
+		return setOnce(schema, "pattern", regexp.QuoteMeta(r.Param))
+	}
+
+	if format, ok := formats[r.Tag]; ok {
+		return setOnce(schema, "format", format)
+	}
+
+	if pattern, ok := patterns[r.Tag]; ok {
+		return setOnce(schema, "pattern", pattern)
+	}
+	return constrainLength(schema, r, "minLength", "maxLength")
+}
+
+// constrainLength adds the keyword of the length rule r using the minimum and maximum length keywords.
+func constrainLength(schema map[string]interface{}, r validator.Rule, minKey, maxKey string) bool {
+	n, err := strconv.Atoi(r.Param)
+	if err != nil || n < 0 {
+		return false
+	}
+
+	switch r.Tag {
+	case "len":
+		schema[minKey], schema[maxKey] = n, n
+	case "min", "gte":
+		schema[minKey] = n
+	case "gt":
+		schema[minKey] = n + 1
+	case "max", "lte":
+		schema[maxKey] = n
+	case "lt":
+		if n == 0 {
+			return false
+		}
+		schema[maxKey] = n - 1
+	default:
+		return false
+	}
+	return true
+}
+
+// constrainNumber adds the keyword of the rule r to the schema of numbers.
+func constrainNumber(schema map[string]interface{}, r validator.Rule) bool {
+	if r.Tag == "oneof" {
+		enum := []interface{}{}
+		for _, s := range strings.Fields(r.Param) {
+			if _, err := strconv.ParseFloat(s, 64); err != nil {
+				return false
+			}
+			enum = append(enum, json.Number(s))
+		}
+		schema["enum"] = enum
+		return true
+	}
+
+	if _, err := strconv.ParseFloat(r.Param, 64); err != nil {
+		return false
+	}
+
+	n := json.Number(r.Param)
+	switch r.Tag {
+	case "eq", "len":
+		schema["const"] = n
+	case "min", "gte":
+		schema["minimum"] = n
+	case "max", "lte":
+		schema["maximum"] = n
+	case "gt":
+		schema["exclusiveMinimum"] = n
+	case "lt":
+		schema["exclusiveMaximum"] = n
+	case "multiple_of":
+		schema["multipleOf"] = n
+	default:
+		return false
+	}
+	return true
+}
+
+// setOnce sets the keyword of schema unless it's already set, e. g. a second pattern.
+func setOnce(schema map[string]interface{}, keyword, value string) bool {
+	if _, ok := schema[keyword]; ok {
+		return false
+	}
+	schema[keyword] = value
+	return true
+}
+
+// skip records the rule r of the property at ptr as skipped.
+func (exp *exporter) skip(ptr string, r validator.Rule) {
+	rule := r.Tag
+	if len(r.Param) > 0 {
+		rule += "=" + r.Param
+	}
+	exp.skipped = append(exp.skipped, ptr+": "+rule)
+}
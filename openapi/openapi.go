@@ -0,0 +1,368 @@
+// Package openapi imports the constraints of OpenAPI 3.1 and JSON Schema documents
+// as rules of Validate.ValidateMap, allowing services to enforce a contract at runtime
+// straight from its spec, e. g.
+//
+//	rules, skipped, err := openapi.ComponentRules(spec)
+//	// ...
+//	errs := validate.ValidateMap(body, rules["User"])
+//
+// Documents must be JSON, convert YAML documents first.
+//
+// Every property has a rule, 'omitempty' when it's optional and unconstrained, so validator.WithStrictMaps
+// rejects the undeclared keys, like additionalProperties: false. The required properties are checked
+// for presence only, the zero values, e. g. false or 0, being valid: their constraints apply without omitempty,
+// a missing key failing them, and WithStrictMaps reports the missing keys as 'missing_key' errors.
+//
+// The supported keywords are properties, required, items, $ref (local references only),
+// minLength, maxLength, minimum, maximum, exclusiveMinimum, exclusiveMaximum, multipleOf,
+// minItems, maxItems, uniqueItems, const and string enum,
+// and the formats email, idn-email, uri, uuid, ipv4, ipv6, hostname, date-time and date.
+// Other constraints can't be enforced by the rules,
+// their JSON pointers are returned as skipped, e. g. "#/components/schemas/User/properties/name/pattern".
+//
+// Conversely, Components exports the rules of struct types as the schemas of the components of a document.
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// formatTags are the tags of the supported string formats.
+var formatTags = map[string]string{
+	"email":     "email",
+	"idn-email": "email",
+	"uri":       "uri",
+	"uuid":      "uuid",
+	"ipv4":      "ipv4",
+	"ipv6":      "ipv6",
+	"hostname":  "hostname_rfc1123",
+	"date-time": "datetime=2006-01-02T15:04:05Z07:00",
+	"date":      "datetime=2006-01-02",
+}
+
+// ignoredKeywords are the keywords that don't constrain values or are enforced by the rules' structure.
+var ignoredKeywords = map[string]struct{}{
+	"type": {}, "title": {}, "description": {}, "default": {}, "example": {}, "examples": {},
+	"deprecated": {}, "readOnly": {}, "writeOnly": {}, "nullable": {}, "$schema": {}, "$id": {},
+	"$comment": {}, "$defs": {}, "definitions": {}, "xml": {}, "externalDocs": {}, "discriminator": {},
+	"properties": {}, "required": {}, "items": {}, "$ref": {}, "format": {}, "enum": {}, "const": {},
+	"minLength": {}, "maxLength": {}, "minimum": {}, "maximum": {}, "exclusiveMinimum": {},
+	"exclusiveMaximum": {}, "multipleOf": {}, "minItems": {}, "maxItems": {}, "uniqueItems": {},
+}
+
+// numericFormats are the OpenAPI formats describing the size of numbers rather than constraining them.
+var numericFormats = map[string]struct{}{
+	"int32": {}, "int64": {}, "float": {}, "double": {},
+}
+
+// importer converts the schemas of a document.
+type importer struct {
+	doc     map[string]interface{}
+	refs    []string
+	skipped []string
+}
+
+// SchemaRules returns the ValidateMap rules of the JSON Schema object schema,
+// and the sorted JSON pointers of the constraints that were skipped.
+func SchemaRules(schema []byte) (map[string]interface{}, []string, error) {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(schema, &doc); err != nil {
+		return nil, nil, err
+	}
+
+	imp := &importer{doc: doc, refs: []string{"#"}}
+	root, ptr, err := imp.resolve(doc, "#")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rules, err := imp.objectRules(root, ptr)
+	if err != nil {
+		return nil, nil, err
+	}
+	return rules, imp.skippedPointers(), nil
+}
+
+// ComponentRules returns the ValidateMap rules of each object schema of the components
+// of the OpenAPI document doc, keyed by schema name,
+// and the sorted JSON pointers of the constraints that were skipped.
+func ComponentRules(doc []byte) (map[string]map[string]interface{}, []string, error) {
+	var d map[string]interface{}
+	if err := json.Unmarshal(doc, &d); err != nil {
+		return nil, nil, err
+	}
+
+	components, _ := d["components"].(map[string]interface{})
+	schemas, _ := components["schemas"].(map[string]interface{})
+	if schemas == nil {
+		return nil, nil, errors.New("openapi: document has no components.schemas")
+	}
+
+	names := make([]string, 0, len(schemas))
+	for name := range schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	imp := &importer{doc: d}
+	rules := make(map[string]map[string]interface{}, len(schemas))
+	for _, name := range names {
+		ptr := "#/components/schemas/" + escapePointer(name)
+		schema, ok := schemas[name].(map[string]interface{})
+		if !ok {
+			return nil, nil, fmt.Errorf("openapi: schema '%s' is not an object", ptr)
+		}
+
+		imp.refs = append(imp.refs[:0], ptr)
+		schema, ptr, err := imp.resolve(schema, ptr)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if _, ok := schema["properties"]; !ok {
+			continue
+		}
+
+		r, err := imp.objectRules(schema, ptr)
+		if err != nil {
+			return nil, nil, err
+		}
+		rules[name] = r
+	}
+	return rules, imp.skippedPointers(), nil
+}
+
+// objectRules returns the rules of the properties of an object schema.
+func (imp *importer) objectRules(schema map[string]interface{}, ptr string) (map[string]interface{}, error) {
+	required := make(map[string]bool)
+	if req, ok := schema["required"].([]interface{}); ok {
+		for _, r := range req {
+			if name, ok := r.(string); ok {
+				required[name] = true
+			}
+		}
+	}
+
+	props, _ := schema["properties"].(map[string]interface{})
+	rules := make(map[string]interface{}, len(props))
+	for name, p := range props {
+		prop, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		rule, err := imp.propertyRule(prop, ptr+"/properties/"+escapePointer(name), required[name])
+		if err != nil {
+			return nil, err
+		}
+
+		if rule != nil {
+			rules[name] = rule
+		}
+	}
+	imp.skip(schema, ptr)
+	return rules, nil
+}
+
+// propertyRule returns the rule of a property, a tag string or the nested rules of an object,
+// empty when the property is required and unconstrained.
+func (imp *importer) propertyRule(schema map[string]interface{}, ptr string, required bool) (interface{}, error) {
+	depth := len(imp.refs)
+	defer func() { imp.refs = imp.refs[:depth] }()
+
+	schema, ptr, err := imp.resolve(schema, ptr)
+	if err != nil || schema == nil {
+		return nil, err
+	}
+
+	if _, ok := schema["properties"]; ok {
+		return imp.objectRules(schema, ptr)
+	}
+
+	items, itemsPtr := map[string]interface{}(nil), ptr+"/items"
+	if it, ok := schema["items"].(map[string]interface{}); ok {
+		if items, itemsPtr, err = imp.resolve(it, itemsPtr); err != nil {
+			return nil, err
+		}
+
+		if _, ok := items["properties"]; ok {
+			// the rules of an array of objects are the rules of its elements
+			for _, k := range []string{"minItems", "maxItems", "uniqueItems"} {
+				if _, ok := schema[k]; ok {
+					imp.skipped = append(imp.skipped, ptr+"/"+k)
+				}
+			}
+			imp.skip(schema, ptr)
+			return imp.objectRules(items, itemsPtr)
+		}
+	}
+
+	tags := imp.tags(schema, ptr)
+	if items != nil {
+		if itemTags := imp.tags(items, itemsPtr); len(itemTags) > 0 {
+			tags = append(append(tags, "dive"), itemTags...)
+		}
+	}
+
+	// required only means the key is present, the required tag would also reject
+	// the zero values, e. g. false or 0, the missing keys being reported by WithStrictMaps
+	if !required {
+		tags = append([]string{"omitempty"}, tags...)
+	}
+	return strings.Join(tags, ","), nil
+}
+
+// tags returns the tags of the value constraints of a schema.
+func (imp *importer) tags(schema map[string]interface{}, ptr string) []string {
+	var tags []string
+	add := func(keyword, tag string) {
+		if n, ok := schema[keyword].(float64); ok {
+			tags = append(tags, tag+"="+strconv.FormatFloat(n, 'f', -1, 64))
+		}
+	}
+
+	add("minLength", "min")
+	add("maxLength", "max")
+	add("minimum", "gte")
+	add("maximum", "lte")
+	add("exclusiveMinimum", "gt")
+	add("exclusiveMaximum", "lt")
+	add("multipleOf", "multiple_of")
+	add("minItems", "min")
+	add("maxItems", "max")
+	if unique, _ := schema["uniqueItems"].(bool); unique {
+		tags = append(tags, "unique")
+	}
+
+	if format, ok := schema["format"].(string); ok {
+		if tag, ok := formatTags[format]; ok {
+			tags = append(tags, tag)
+		} else if _, ok := numericFormats[format]; !ok {
+			imp.skipped = append(imp.skipped, ptr+"/format")
+		}
+	}
+
+	if c, ok := schema["const"]; ok {
+		if s, ok := c.(string); ok && isParamSafe(s) && !strings.ContainsAny(s, " '") {
+			tags = append(tags, "eq="+s)
+		} else {
+			imp.skipped = append(imp.skipped, ptr+"/const")
+		}
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		if param, ok := oneOfParam(enum); ok {
+			tags = append(tags, "oneof="+param)
+		} else {
+			imp.skipped = append(imp.skipped, ptr+"/enum")
+		}
+	}
+
+	imp.skip(schema, ptr)
+	return tags
+}
+
+// resolve follows the local $ref of schema, returning nil for a circular reference.
+func (imp *importer) resolve(schema map[string]interface{}, ptr string) (map[string]interface{}, string, error) {
+	for {
+		ref, ok := schema["$ref"].(string)
+		if !ok {
+			return schema, ptr, nil
+		}
+
+		for _, r := range imp.refs {
+			if r == ref {
+				imp.skipped = append(imp.skipped, ptr+"/$ref")
+				return nil, ptr, nil
+			}
+		}
+
+		target, err := imp.lookup(ref)
+		if err != nil {
+			return nil, ptr, err
+		}
+
+		imp.refs = append(imp.refs, ref)
+		schema, ptr = target, ref
+	}
+}
+
+// lookup returns the schema at the local reference ref, e. g. "#/components/schemas/User".
+func (imp *importer) lookup(ref string) (map[string]interface{}, error) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, fmt.Errorf("openapi: unsupported reference '%s', only local references are supported", ref)
+	}
+
+	var cur interface{} = imp.doc
+	for _, tok := range strings.Split(strings.TrimPrefix(ref, "#"), "/")[1:] {
+		obj, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("openapi: unresolvable reference '%s'", ref)
+		}
+
+		tok = strings.ReplaceAll(strings.ReplaceAll(tok, "~1", "/"), "~0", "~")
+		if cur, ok = obj[tok]; !ok {
+			return nil, fmt.Errorf("openapi: unresolvable reference '%s'", ref)
+		}
+	}
+
+	schema, ok := cur.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("openapi: reference '%s' is not a schema", ref)
+	}
+	return schema, nil
+}
+
+// skippedPointers returns the sorted JSON pointers of the skipped constraints,
+// a schema referenced more than once is converted more than once.
+func (imp *importer) skippedPointers() []string {
+	slices.Sort(imp.skipped)
+	return slices.Compact(imp.skipped)
+}
+
+// skip records the keywords of schema that are neither supported nor ignored.
+func (imp *importer) skip(schema map[string]interface{}, ptr string) {
+	keys := make([]string, 0, len(schema))
+	for k := range schema {
+		if _, ok := ignoredKeywords[k]; !ok && !strings.HasPrefix(k, "x-") {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		imp.skipped = append(imp.skipped, ptr+"/"+escapePointer(k))
+	}
+}
+
+// oneOfParam returns the oneof param of a string enum, quoting values containing spaces.
+func oneOfParam(enum []interface{}) (string, bool) {
+	vals := make([]string, len(enum))
+	for i, e := range enum {
+		s, ok := e.(string)
+		if !ok || len(s) == 0 || !isParamSafe(s) || strings.Contains(s, "'") {
+			return "", false
+		}
+
+		if strings.ContainsAny(s, " \t") {
+			s = "'" + s + "'"
+		}
+		vals[i] = s
+	}
+	return strings.Join(vals, " "), true
+}
+
+// isParamSafe reports whether s can be used within a tag param as is.
+func isParamSafe(s string) bool {
+	return !strings.ContainsAny(s, ",|")
+}
+
+// escapePointer escapes a JSON pointer token.
+func escapePointer(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1")
+}
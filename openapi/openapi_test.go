@@ -0,0 +1,191 @@
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/pchchv/go-assert"
+	"github.com/pchchv/validator"
+)
+
+const spec = `{
+	"openapi": "3.1.0",
+	"components": {
+		"schemas": {
+			"User": {
+				"type": "object",
+				"required": ["name", "email", "address"],
+				"properties": {
+					"name": {"type": "string", "minLength": 2, "maxLength": 50, "pattern": "^[a-z]+$"},
+					"email": {"type": "string", "format": "email"},
+					"age": {"type": "integer", "format": "int32", "minimum": 18, "exclusiveMaximum": 150},
+					"role": {"type": "string", "enum": ["admin", "read only"]},
+					"tags": {"type": "array", "uniqueItems": true, "maxItems": 3, "items": {"type": "string", "maxLength": 10}},
+					"address": {"$ref": "#/components/schemas/Address"},
+					"friend": {"$ref": "#/components/schemas/User"},
+					"note": {"type": "string"}
+				}
+			},
+			"Address": {
+				"type": "object",
+				"required": ["city"],
+				"properties": {
+					"city": {"type": "string", "minLength": 1},
+					"zip": {"type": "string", "x-internal": true, "anyOf": [{"maxLength": 5}]}
+				}
+			},
+			"Id": {"type": "string", "format": "uuid"}
+		}
+	}
+}`
+
+func TestComponentRules(t *testing.T) {
+	rules, skipped, err := ComponentRules([]byte(spec))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(rules))
+
+	user := rules["User"]
+	assert.Equal(t, "min=2,max=50", user["name"])
+	assert.Equal(t, "email", user["email"])
+	assert.Equal(t, "omitempty,gte=18,lt=150", user["age"])
+	assert.Equal(t, "omitempty,oneof=admin 'read only'", user["role"])
+	assert.Equal(t, "omitempty,max=3,unique,dive,max=10", user["tags"])
+	assert.Equal(t, map[string]interface{}{"city": "min=1", "zip": "omitempty"}, user["address"])
+	assert.Equal(t, nil, user["friend"])
+	assert.Equal(t, "omitempty", user["note"])
+	assert.Equal(t, map[string]interface{}{"city": "min=1", "zip": "omitempty"}, rules["Address"])
+
+	assert.Equal(t, []string{
+		"#/components/schemas/Address/properties/zip/anyOf",
+		"#/components/schemas/User/properties/friend/$ref",
+		"#/components/schemas/User/properties/name/pattern",
+	}, skipped)
+
+	v := validator.New()
+	errs := v.ValidateMap(map[string]interface{}{
+		"name":    "joey",
+		"email":   "joey@example.com",
+		"age":     30.0,
+		"role":    "read only",
+		"tags":    []interface{}{"a", "b"},
+		"address": map[string]interface{}{"city": "Berlin"},
+	}, user)
+	assert.Equal(t, 0, len(errs))
+
+	errs = v.ValidateMap(map[string]interface{}{
+		"name":    "j",
+		"email":   "joey@example.com",
+		"age":     12.0,
+		"tags":    []interface{}{"a", "a"},
+		"address": map[string]interface{}{},
+	}, user)
+	assert.Equal(t, 4, len(errs))
+	assert.NotEqual(t, nil, errs["name"])
+	assert.NotEqual(t, nil, errs["age"])
+	assert.NotEqual(t, nil, errs["tags"])
+	assert.NotEqual(t, nil, errs["address"])
+
+	_, _, err = ComponentRules([]byte(`{"openapi": "3.1.0"}`))
+	assert.NotEqual(t, nil, err)
+	_, _, err = ComponentRules([]byte(`{"components": {"schemas": {"A": {"properties": {"b": {"$ref": "other.json#/B"}}}}}}`))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestSchemaRules(t *testing.T) {
+	rules, skipped, err := SchemaRules([]byte(`{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"required": ["id"],
+		"properties": {
+			"id": {"$ref": "#/$defs/id"},
+			"items": {"type": "array", "minItems": 1, "items": {"type": "object", "properties": {"sku": {"type": "string", "const": "A1"}}}}
+		},
+		"$defs": {"id": {"type": "string", "format": "uuid"}}
+	}`))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "uuid", rules["id"])
+	assert.Equal(t, map[string]interface{}{"sku": "omitempty,eq=A1"}, rules["items"])
+	assert.Equal(t, []string{"#/properties/items/minItems"}, skipped)
+}
+
+func TestSchemaRulesRequired(t *testing.T) {
+	rules, _, err := SchemaRules([]byte(`{
+		"type": "object",
+		"required": ["active", "count", "name"],
+		"properties": {
+			"active": {"type": "boolean"},
+			"count": {"type": "integer", "minimum": 0},
+			"name": {"type": "string"},
+			"note": {"type": "string"}
+		}
+	}`))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, map[string]interface{}{"active": "", "count": "gte=0", "name": "", "note": "omitempty"}, rules)
+
+	// required keys may hold zero values
+	v := validator.New()
+	errs := v.ValidateMap(map[string]interface{}{"active": false, "count": 0.0, "name": ""}, rules)
+	assert.Equal(t, 0, len(errs))
+
+	strict := validator.New(validator.WithStrictMaps())
+	errs = strict.ValidateMap(map[string]interface{}{"active": false, "count": 0.0, "name": ""}, rules)
+	assert.Equal(t, 0, len(errs))
+
+	errs = strict.ValidateMap(map[string]interface{}{"count": 0.0, "name": "", "extra": 1.0}, rules)
+	assert.Equal(t, 2, len(errs))
+	assert.Equal(t, "missing_key", errs["active"].(validator.ValidationErrors)[0].Tag())
+	assert.Equal(t, "unknown_key", errs["extra"].(validator.ValidationErrors)[0].Tag())
+}
+
+type exportAddress struct {
+	City string `json:"city" validate:"required,alpha"`
+}
+
+type exportUser struct {
+	Name     string            `json:"name" validate:"required,min=2,max=50,startswith=j"`
+	Email    string            `json:"email" validate:"required,email"`
+	Age      int32             `json:"age" validate:"omitempty,gte=18,lt=150"`
+	Role     string            `json:"role" validate:"oneof=admin 'read only'"`
+	Level    int               `json:"level" validate:"oneof=1 2 3"`
+	Tags     []string          `json:"tags" validate:"max=3,unique,dive,max=10"`
+	Labels   map[string]string `json:"labels" validate:"dive,keys,lowercase,endkeys,uuid4"`
+	Address  exportAddress     `json:"address" validate:"required"`
+	Friend   *exportUser       `json:"friend"`
+	Born     time.Time         `json:"born" validate:"lt"`
+	Confirm  string            `json:"confirm" validate:"eqfield=Email|len=0"`
+	Internal string            `json:"-" validate:"-"`
+}
+
+func TestComponents(t *testing.T) {
+	v := validator.New(validator.WithFieldNameTags("json"))
+	schemas, skipped, err := Components(v, &exportUser{})
+	assert.Equal(t, nil, err)
+
+	b, err := json.Marshal(schemas)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, `{"exportAddress":{"properties":{"city":{"pattern":"^[a-zA-Z]+$","type":"string"}},"required":["city"],"type":"object"},`+
+		`"exportUser":{"properties":{`+
+		`"address":{"$ref":"#/components/schemas/exportAddress"},`+
+		`"age":{"exclusiveMaximum":150,"format":"int32","minimum":18,"type":"integer"},`+
+		`"born":{"format":"date-time","type":"string"},`+
+		`"confirm":{"type":"string"},`+
+		`"email":{"format":"email","type":"string"},`+
+		`"friend":{"$ref":"#/components/schemas/exportUser"},`+
+		`"labels":{"additionalProperties":{"format":"uuid","type":"string"},"propertyNames":{"type":"string"},"type":"object"},`+
+		`"level":{"enum":[1,2,3],"type":"integer"},`+
+		`"name":{"maxLength":50,"minLength":2,"pattern":"^j","type":"string"},`+
+		`"role":{"enum":["admin","read only"],"type":"string"},`+
+		`"tags":{"items":{"maxLength":10,"type":"string"},"maxItems":3,"type":"array","uniqueItems":true}},`+
+		`"required":["name","email","address"],"type":"object"}}`, string(b))
+
+	assert.Equal(t, []string{
+		"#/components/schemas/exportUser/properties/born: lt",
+		"#/components/schemas/exportUser/properties/confirm: eqfield=Email",
+		"#/components/schemas/exportUser/properties/confirm: len=0",
+		"#/components/schemas/exportUser/properties/labels/propertyNames: lowercase",
+	}, skipped)
+
+	_, _, err = Components(v, "user")
+	assert.NotEqual(t, nil, err)
+}
@@ -1,5 +1,13 @@
 package validator
 
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+)
+
 // Option represents a configurations option to
 // be applied to validator during initialization.
 type Option func(*Validate)
@@ -30,3 +38,334 @@ func WithRequiredStructEnabled() Option {
 		v.requiredStructEnabled = true
 	}
 }
+
+// WithPostcodeProvider sets the PostcodeProvider used by the
+// postcode_iso3166_alpha2 and postcode_iso3166_alpha2_field tags,
+// replacing the embedded postcode dataset.
+func WithPostcodeProvider(p PostcodeProvider) Option {
+	return func(v *Validate) {
+		v.postcodeProvider = p
+	}
+}
+
+// WithContextValueResolver sets the ContextValueResolver resolving the context values
+// the eqctx and oneof_ctx tags compare fields against, e. g.
+//
+//	validator.New(validator.WithContextValueResolver(validator.ContextKeys(map[string]interface{}{"tenant": tenantKey{}})))
+//
+// with `validate:"eqctx=tenant"` validating that a payload's TenantID is the authenticated tenant's
+// when validating using StructCtx.
+func WithContextValueResolver(r ContextValueResolver) Option {
+	return func(v *Validate) {
+		v.contextResolver = r
+	}
+}
+
+// WithRolesProvider sets the RolesProvider returning the roles of the caller of the validation context,
+// the required_role tag requiring a field for the callers having one of its roles only, e. g.
+//
+//	validator.New(validator.WithRolesProvider(func(ctx context.Context) []string {
+//		return claimsFrom(ctx).Roles
+//	}))
+//
+// with `validate:"required_role=admin"` requiring an AuditReason from the admins when validating using StructCtx,
+// so one payload type can serve callers with different requirements.
+func WithRolesProvider(p RolesProvider) Option {
+	return func(v *Validate) {
+		v.rolesProvider = p
+	}
+}
+
+// WithClock sets the clock returning the current time used by the time tags
+// comparing with now, e. g. future, past, future_within, past_within
+// and gt, gte, lt, lte on time.Time fields, defaults to time.Now.
+// It's the same as setting the Clock of WithEnvironment.
+func WithClock(clock func() time.Time) Option {
+	return func(v *Validate) {
+		v.env.Clock = clock
+	}
+}
+
+// WithEnvironment sets the environment providing the clock, randomness and hostname
+// used by the validations needing them, e. g. the time tags comparing with now,
+// WithSampling and RegisterValidationWithBreaker.
+// Unset funcs of env keep their defaults, see Env.
+func WithEnvironment(env Env) Option {
+	return func(v *Validate) {
+		v.env = env
+	}
+}
+
+// WithFieldNameTags makes the field names of errors the names of the fields' struct tags with the given keys,
+// the first key with a name taking precedence, e. g. WithFieldNameTags("json", "bson").
+// Fields without a name in any of the tags keep their name.
+// See RegisterTagNameFunc.
+func WithFieldNameTags(keys ...string) Option {
+	return func(v *Validate) {
+		fns := make([]TagNameFunc, len(keys))
+		for i, key := range keys {
+			fns[i] = StructTagNameFunc(key)
+		}
+		v.RegisterTagNameFunc(ChainTagNameFuncs(fns...))
+	}
+}
+
+// networkTags are the tags resolving network addresses.
+var networkTags = []string{
+	"tcp4_addr", "tcp6_addr", "tcp_addr",
+	"udp4_addr", "udp6_addr", "udp_addr",
+	"ip4_addr", "ip6_addr", "ip_addr",
+	"unix_addr",
+}
+
+// WithSampling makes the given tags run only on a random sample of
+// validation calls, at the given rate between 0 and 1,
+// while all other tags keep running on every call.
+// This allows gradually rolling out new or expensive rules on high traffic paths.
+// When no tags are given, the network address resolving tags are sampled
+// (tcp_addr, udp_addr, ip_addr, unix_addr and their variants).
+//
+// A sampled tag that is skipped is treated as passed.
+// The skipped rules of a call are returned by StructSampled.
+//
+// NOTE: sampled tags used within an 'or' are always run.
+func WithSampling(rate float64, tags ...string) Option {
+	return func(v *Validate) {
+		if len(tags) == 0 {
+			tags = networkTags
+		}
+
+		v.sampleRate = rate
+		v.sampledTags = make(map[string]struct{}, len(tags))
+		for _, tag := range tags {
+			v.sampledTags[tag] = struct{}{}
+		}
+	}
+}
+
+// TimeoutPolicy decides the outcome of a tag that can't
+// complete before the validation context is done, see WithTimeoutPolicy.
+type TimeoutPolicy uint8
+
+const (
+	// TimeoutFailClosed fails the tag as usual, it's the default.
+	TimeoutFailClosed TimeoutPolicy = iota
+	// TimeoutFailOpen treats the tag as passed.
+	TimeoutFailOpen
+	// TimeoutWarn fails the tag with the '(timeout)' suffix appended to the
+	// FieldError tag, e. g. 'tcp_addr(timeout)', so it can be told apart from
+	// a genuine failure and filtered out by the caller.
+	TimeoutWarn
+)
+
+const timeoutTagSuffix = "(timeout)"
+
+// MarkTimedOut marks the tag being validated by fl as interrupted because the validation context is done,
+// for WithTimeoutPolicy to apply to it. The validations registered using RegisterValidationCtx call it
+// before returning false when they can't complete, e. g.
+//
+//	select {
+//	case <-ctx.Done():
+//		validator.MarkTimedOut(fl)
+//		return false
+//	case ok := <-result:
+//		return ok
+//	}
+//
+// The validations registered using RegisterValidationWithBreaker are marked
+// when they return a context.Canceled or context.DeadlineExceeded error.
+func MarkTimedOut(fl FieldLevel) {
+	if v, ok := fl.(*validate); ok {
+		v.timedOut = true
+	}
+}
+
+// WithTimeoutPolicy sets the TimeoutPolicy applied when the given tags
+// can't complete because the validation context is done, e. g. its deadline passed.
+// When no tags are given, the policy applies to the network address resolving tags
+// (tcp_addr, udp_addr, ip_addr, unix_addr and their variants), which resolve using the context.
+//
+// The policy applies to the tags that are not run because the context is already done,
+// and to the tags interrupted by the context, see MarkTimedOut,
+// a tag failing on its own after the deadline failing as usual.
+func WithTimeoutPolicy(policy TimeoutPolicy, tags ...string) Option {
+	return func(v *Validate) {
+		if len(tags) == 0 {
+			tags = networkTags
+		}
+
+		if v.timeoutPolicies == nil {
+			v.timeoutPolicies = make(map[string]TimeoutPolicy, len(tags))
+		}
+
+		for _, tag := range tags {
+			v.timeoutPolicies[tag] = policy
+		}
+	}
+}
+
+// nanComparisonTags are the comparison tags WithRejectNaN applies to.
+var nanComparisonTags = []string{
+	"eq", "ne", "gt", "gte", "lt", "lte", "min", "max", "len",
+	"eqfield", "nefield", "gtfield", "gtefield", "ltfield", "ltefield",
+	"eqcsfield", "necsfield", "gtcsfield", "gtecsfield", "ltcsfield", "ltecsfield",
+}
+
+// WithRejectNaN makes the numeric comparison tags (eq, ne, gt, gte, lt, lte, min, max, len
+// and the field comparison tags) fail on a NaN float value,
+// NaN otherwise passes ne and nefield as it is unequal to everything.
+//
+// NOTE: registering a validation under one of these tags replaces its NaN check as well.
+func WithRejectNaN() Option {
+	return func(v *Validate) {
+		for _, tag := range nanComparisonTags {
+			wrapper, ok := v.validations[tag]
+			if !ok {
+				continue
+			}
+
+			fn := wrapper.fn
+			wrapper.fn = func(ctx context.Context, fl FieldLevel) bool {
+				field := fl.Field()
+				if (field.Kind() == reflect.Float32 || field.Kind() == reflect.Float64) && math.IsNaN(field.Float()) {
+					return false
+				}
+				return fn(ctx, fl)
+			}
+			v.validations[tag] = wrapper
+		}
+	}
+}
+
+// WithValuePolicy sets how values larger than the policy's Limit appear in FieldError.Value,
+// truncated with their length, hashed or omitted,
+// e. g. WithValuePolicy(ValuePolicy{Mode: ValueTruncate, Limit: 64})
+// stops a 5MB string failing max from being copied into errors and logs.
+// Values are kept as is by default.
+func WithValuePolicy(p ValuePolicy) Option {
+	return func(v *Validate) {
+		v.valuePolicy = p
+	}
+}
+
+// WithLeafTypes registers struct types that are validated as a single value, like time.Time,
+// rather than traversed, even without structonly,
+// e. g. WithLeafTypes(decimal.Decimal{}, uuid.UUID{}, timestamppb.Timestamp{}),
+// so the tags of their fields aren't run and their unexported internals never produce errors.
+// Tags on fields of a leaf type, e. g. required, apply to the struct value itself.
+// Pointer types are registered as their element type.
+func WithLeafTypes(types ...interface{}) Option {
+	return func(v *Validate) {
+		if v.leafTypes == nil {
+			v.leafTypes = make(map[reflect.Type]struct{}, len(types))
+		}
+
+		for _, t := range types {
+			typ := reflect.TypeOf(t)
+			for typ != nil && typ.Kind() == reflect.Ptr {
+				typ = typ.Elem()
+			}
+
+			if typ != nil {
+				v.leafTypes[typ] = struct{}{}
+			}
+		}
+	}
+}
+
+// WithMaxErrors makes validation calls stop after n errors, bounding the memory of e. g.
+// a large slice validated with 'dive,required'. The stopped calls return a *TruncatedValidationError
+// holding their errors.
+func WithMaxErrors(n int) Option {
+	if n < 1 {
+		panic(fmt.Sprintf("max errors must be positive, got %d", n))
+	}
+
+	return func(v *Validate) {
+		v.maxErrors = n
+	}
+}
+
+// WithParallelDive sets the number of workers validating the elements of the slices, arrays and maps
+// tagged with dive_parallel concurrently, e. g. `validate:"dive_parallel,required"` on a batch of 50k rows,
+// GOMAXPROCS by default. Their errors are merged in the order of the elements, the map elements
+// being ordered by key. The elements are validated in order, as with dive, by the calls
+// recording, auditing or collecting warnings, or capped by WithMaxErrors.
+func WithParallelDive(workers int) Option {
+	if workers < 1 {
+		panic(fmt.Sprintf("parallel dive workers must be positive, got %d", workers))
+	}
+
+	return func(v *Validate) {
+		v.diveWorkers = workers
+	}
+}
+
+// WithAllErrors makes the validation of a field go on after its first failing tag, reporting every failing tag
+// of the field, e. g. both min and alphanum, so a form can show all the violated constraints at once.
+// The elements and fields of a failing field still aren't validated.
+// Without it, only the fields having the allerrs modifier, e. g. `validate:"allerrs,min=8,alphanum"`, do so.
+func WithAllErrors() Option {
+	return func(v *Validate) {
+		v.allErrors = true
+	}
+}
+
+// WithContextAbort stops the validation calls once their context is done, e. g. its deadline passed,
+// checking it before each field and each element of a dive, so validating an enormous payload
+// can't run past the deadline of its request. The stopped calls return an *AbortedValidationError
+// holding the errors found until then.
+// Without it, the validation calls complete, the tags needing the context failing, see WithTimeoutPolicy.
+func WithContextAbort() Option {
+	return func(v *Validate) {
+		v.contextAbort = true
+	}
+}
+
+// WithStrictMaps makes ValidateMap reject the payloads whose keys don't match its rules,
+// e. g. like additionalProperties: false of JSON Schema, the keys having no rules being reported
+// as 'unknown_key' errors and the keys having rules missing from the data as 'missing_key' errors,
+// unless their rules start with omitempty, omitnil or omitzero.
+func WithStrictMaps() Option {
+	return func(v *Validate) {
+		v.strictMaps = true
+	}
+}
+
+// WithStrictErrors returns the panics of the validation calls returning an error,
+// e. g. Struct or Var, as an *InvalidValidationError describing them rather than panicking,
+// so a malformed tag, e. g. `validate:"required,"`, an undefined validation,
+// or a tag used on a type it doesn't support, e. g. validate.Var(6, "file"),
+// can't crash a server validating its requests.
+// Registering validations, aliases and options still panics on misuse.
+func WithStrictErrors() Option {
+	return func(v *Validate) {
+		v.strictErrors = true
+	}
+}
+
+// WithTracer passes every rule evaluated and short-circuit decision taken by the validation calls
+// to fn, e. g. to log why a field guarded by 'omitempty,required_if=Kind card' was skipped:
+//
+//	validator.New(validator.WithTracer(func(step validator.RecordedStep) {
+//		log.Printf("%s %s=%s: %s", step.Namespace, step.Tag, step.Param, step.Decision)
+//	}))
+//
+// fn is called by concurrent validation calls concurrently,
+// see NewRecordingContext and StructTrace for the steps of a single call.
+func WithTracer(fn func(step RecordedStep)) Option {
+	return func(v *Validate) {
+		v.tracer = fn
+	}
+}
+
+// WithHooks calls before and after around every validation call, e. g. Struct, StructPartial, Var
+// or Typed.Validate, either being optional, to trace or measure the cost of validating large payloads,
+// see the otelvalidator adapter. The context returned by before, e. g. holding a span,
+// is the context of the call and is passed to after.
+func WithHooks(before BeforeHook, after AfterHook) Option {
+	return func(v *Validate) {
+		v.beforeHook = before
+		v.afterHook = after
+	}
+}
@@ -0,0 +1,89 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// PairRule relates a field of the first value validated by Validate.Pair to a field of the second one.
+type PairRule struct {
+	Field string // namespace of the field of the first value, e. g. 'Version' or 'Address.City'
+	Other string // namespace of the field of the second value, "" for the second value itself
+	Tag   string // tags validating the field against the other one, e. g. 'eqfield', 'nefield' or 'gtefield'
+}
+
+// Pair validates the relations between the fields of two different struct values,
+// e. g. a request payload and the domain object it updates,
+// using the field comparison tags, e. g. for optimistic locking:
+//
+//	err := validate.Pair(ctx, req, current, validator.PairRule{Field: "Version", Other: "Version", Tag: "eqfield"})
+//
+// The namespaces of the errors are those of the fields of a, e. g. 'UpdateOrder.Version',
+// and the param of a tag without one is the namespace of the other field, e. g. 'Order.Version'.
+// A field that can't be reached, e. g. through a nil pointer, is validated as a nil value.
+//
+// It returns InvalidValidationError for bad values passed in and nil or ValidationErrors as error otherwise.
+func (v *Validate) Pair(ctx context.Context, a, b interface{}, rules ...PairRule) error {
+	topA, topB := reflect.ValueOf(a), reflect.ValueOf(b)
+	for topA.Kind() == reflect.Ptr && !topA.IsNil() {
+		topA = topA.Elem()
+	}
+
+	for topB.Kind() == reflect.Ptr && !topB.IsNil() {
+		topB = topB.Elem()
+	}
+
+	if topA.Kind() != reflect.Struct {
+		return &InvalidValidationError{Type: reflect.TypeOf(a)}
+	}
+
+	if topB.Kind() != reflect.Struct {
+		return &InvalidValidationError{Type: reflect.TypeOf(b)}
+	}
+
+	var errs ValidationErrors
+	for _, r := range rules {
+		var field, other interface{}
+		if current, ok := v.lookup(topA, r.Field); ok {
+			field = getValue(current)
+		}
+
+		if current, ok := v.lookup(topB, r.Other); ok {
+			other = getValue(current)
+		}
+
+		err := v.VarWithValueCtx(ctx, field, other, r.Tag)
+		if err == nil {
+			continue
+		}
+
+		var ve ValidationErrors
+		if !errors.As(err, &ve) {
+			return err
+		}
+
+		ns := topA.Type().Name() + namespaceSeparator + r.Field
+		otherNs := topB.Type().Name()
+		if len(r.Other) > 0 {
+			otherNs += namespaceSeparator + r.Other
+		}
+
+		name := r.Field[strings.LastIndex(r.Field, namespaceSeparator)+1:]
+		for _, e := range ve {
+			fe := e.(*fieldError)
+			fe.ns, fe.structNs = ns, ns
+			fe.fieldLen, fe.structfieldLen = uint8(len(name)), uint8(len(name))
+			if len(fe.param) == 0 {
+				fe.param = otherNs
+			}
+			errs = append(errs, fe)
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
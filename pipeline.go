@@ -0,0 +1,77 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Pipeline configures the validation stages of a struct type,
+// it's created using Validate.Pipeline.
+//
+// The stages of a struct run in the following order:
+// integrity check, Pre funcs, field rules, struct level validation and Post funcs.
+type Pipeline struct {
+	v      *Validate
+	typ    reflect.Type
+	stages *pipelineStages
+}
+
+type pipelineStages struct {
+	pre  []StructLevelFuncCtx
+	post []StructLevelFuncCtx
+}
+
+// Pipeline returns the Pipeline of the struct type of t, allowing normalization
+// before and derived field checks after the field rules are evaluated, e. g.
+//
+//	validate.Pipeline(User{}).
+//	    Pre(normalizeEmail).
+//	    Rules().
+//	    Post(checkDisplayName)
+//
+// NOTE: this method is not thread-safe it is intended that these all be registered prior to any validation.
+func (v *Validate) Pipeline(t interface{}) *Pipeline {
+	typ := reflect.TypeOf(t)
+	if typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ == nil || typ.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("Pipeline type '%v' is not a struct", typ))
+	}
+
+	if v.pipelines == nil {
+		v.pipelines = make(map[reflect.Type]*pipelineStages)
+	}
+
+	stages, ok := v.pipelines[typ]
+	if !ok {
+		stages = new(pipelineStages)
+		v.pipelines[typ] = stages
+	}
+	return &Pipeline{v: v, typ: typ, stages: stages}
+}
+
+// Pre adds a func run before the field rules of the struct are evaluated.
+// Current returns an addressable value when a pointer to the struct is validated,
+// allowing its fields to be normalized.
+func (p *Pipeline) Pre(fn StructLevelFuncCtx) *Pipeline {
+	p.stages.pre = append(p.stages.pre, fn)
+	return p
+}
+
+// Rules adds validate map rules, keyed by field name,
+// to the field rules of the struct, see RegisterStructValidationMapRules.
+// Without rules the struct tags are evaluated as is.
+func (p *Pipeline) Rules(rules ...map[string]string) *Pipeline {
+	for _, r := range rules {
+		p.v.RegisterStructValidationMapRules(r, reflect.New(p.typ).Interface())
+	}
+	return p
+}
+
+// Post adds a func run after the field rules and struct level validation of the struct.
+func (p *Pipeline) Post(fn StructLevelFuncCtx) *Pipeline {
+	p.stages.post = append(p.stages.post, fn)
+	return p
+}
@@ -6,9 +6,9 @@ import (
 )
 
 var (
-	postcodeRegexInit   sync.Once
-	postCodeRegexDict   = map[string]*regexp.Regexp{}
-	postCodePatternDict = map[string]string{
+	postCodeRegexDict       = map[string]*regexp.Regexp{}
+	postCodeRegexDictRWLock = sync.RWMutex{}
+	postCodePatternDict     = map[string]string{
 		"GB": `^GIR[ ]?0AA|((AB|AL|B|BA|BB|BD|BH|BL|BN|BR|BS|BT|CA|CB|CF|CH|CM|CO|CR|CT|CV|CW|DA|DD|DE|DG|DH|DL|DN|DT|DY|E|EC|EH|EN|EX|FK|FY|G|GL|GY|GU|HA|HD|HG|HP|HR|HS|HU|HX|IG|IM|IP|IV|JE|KA|KT|KW|KY|L|LA|LD|LE|LL|LN|LS|LU|M|ME|MK|ML|N|NE|NG|NN|NP|NR|NW|OL|OX|PA|PE|PH|PL|PO|PR|RG|RH|RM|S|SA|SE|SG|SK|SL|SM|SN|SO|SP|SR|SS|ST|SW|SY|TA|TD|TF|TN|TQ|TR|TS|TW|UB|W|WA|WC|WD|WF|WN|WR|WS|WV|YO|ZE)(\d[\dA-Z]?[ ]?\d[ABD-HJLN-UW-Z]{2}))|BFPO[ ]?\d{1,4}$`,
 		"JE": `^JE\d[\dA-Z]?[ ]?\d[ABD-HJLN-UW-Z]{2}$`,
 		"GG": `^GY\d[\dA-Z]?[ ]?\d[ABD-HJLN-UW-Z]{2}$`,
@@ -171,8 +171,116 @@ var (
 	}
 )
 
-func initPostcodes() {
+var _ PostcodeProvider = new(patternPostcodeProvider)
+
+// defaultPostcodeProvider is the PostcodeProvider backed by the embedded postcode dataset.
+var defaultPostcodeProvider PostcodeProvider = postcodeProviderFunc(postcodeRegex)
+
+// PostcodeProvider supplies the regexes used by the
+// postcode_iso3166_alpha2 and postcode_iso3166_alpha2_field tags.
+type PostcodeProvider interface {
+	// PostcodeRegex returns the postcode regex of the iso 3166 alpha 2 country code,
+	// or false when the country is not supported.
+	PostcodeRegex(countryCode string) (*regexp.Regexp, bool)
+}
+
+type postcodeProviderFunc func(countryCode string) (*regexp.Regexp, bool)
+
+func (fn postcodeProviderFunc) PostcodeRegex(countryCode string) (*regexp.Regexp, bool) {
+	return fn(countryCode)
+}
+
+// patternPostcodeProvider is a PostcodeProvider over a custom set of patterns,
+// each compiled on first use.
+type patternPostcodeProvider struct {
+	patterns map[string]string
+	regexes  map[string]*regexp.Regexp
+	lock     sync.RWMutex
+}
+
+// NewPostcodeProvider returns a PostcodeProvider for the given patterns,
+// keyed by iso 3166 alpha 2 country code.
+// Use DefaultPostcodePatterns as a starting point to trim or extend the embedded dataset.
+//
+// Patterns are compiled on first use and panic if invalid.
+func NewPostcodeProvider(patterns map[string]string) PostcodeProvider {
+	p := &patternPostcodeProvider{
+		patterns: make(map[string]string, len(patterns)),
+		regexes:  make(map[string]*regexp.Regexp),
+	}
+	for countryCode, pattern := range patterns {
+		p.patterns[countryCode] = pattern
+	}
+
+	return p
+}
+
+// PostcodeRegex returns the postcode regex of the iso 3166 alpha 2 country code.
+func (p *patternPostcodeProvider) PostcodeRegex(countryCode string) (*regexp.Regexp, bool) {
+	p.lock.RLock()
+	reg, ok := p.regexes[countryCode]
+	p.lock.RUnlock()
+	if ok {
+		return reg, true
+	}
+
+	pattern, ok := p.patterns[countryCode]
+	if !ok {
+		return nil, false
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if reg, ok = p.regexes[countryCode]; !ok {
+		reg = regexp.MustCompile(pattern)
+		p.regexes[countryCode] = reg
+	}
+
+	return reg, true
+}
+
+// DefaultPostcodePatterns returns a copy of the embedded postcode patterns,
+// keyed by iso 3166 alpha 2 country code.
+func DefaultPostcodePatterns() map[string]string {
+	patterns := make(map[string]string, len(postCodePatternDict))
 	for countryCode, pattern := range postCodePatternDict {
-		postCodeRegexDict[countryCode] = regexp.MustCompile(pattern)
+		patterns[countryCode] = pattern
 	}
+
+	return patterns
+}
+
+// postcodeProviderOf returns the PostcodeProvider configured on the validator, or the default one.
+func postcodeProviderOf(fl FieldLevel) PostcodeProvider {
+	if v, ok := fl.(*validate); ok && v.v.postcodeProvider != nil {
+		return v.v.postcodeProvider
+	}
+
+	return defaultPostcodeProvider
+}
+
+// postcodeRegex returns the postcode regex of the iso 3166 alpha 2 country code.
+// Each regex is compiled on first use,
+// so only the countries actually validated against are paid for.
+func postcodeRegex(countryCode string) (*regexp.Regexp, bool) {
+	postCodeRegexDictRWLock.RLock()
+	reg, ok := postCodeRegexDict[countryCode]
+	postCodeRegexDictRWLock.RUnlock()
+	if ok {
+		return reg, true
+	}
+
+	pattern, ok := postCodePatternDict[countryCode]
+	if !ok {
+		return nil, false
+	}
+
+	postCodeRegexDictRWLock.Lock()
+	defer postCodeRegexDictRWLock.Unlock()
+	if reg, ok = postCodeRegexDict[countryCode]; !ok {
+		reg = regexp.MustCompile(pattern)
+		postCodeRegexDict[countryCode] = reg
+	}
+
+	return reg, true
 }
@@ -0,0 +1,64 @@
+package validator
+
+import "strconv"
+
+// ProblemContentType is the media type of RFC 7807 problem details, see ValidationErrors.ToProblemDetails.
+const ProblemContentType = "application/problem+json"
+
+// ProblemDetails is an RFC 7807 problem details payload describing validation failures,
+// see ValidationErrors.ToProblemDetails.
+type ProblemDetails struct {
+	Type   string         `json:"type"`             // URI reference identifying the problem type, "about:blank" when unset
+	Title  string         `json:"title"`            // short summary of the problem type
+	Status int            `json:"status,omitempty"` // HTTP status code, 422 Unprocessable Entity by default
+	Detail string         `json:"detail,omitempty"` // explanation specific to this occurrence, e. g. "2 fields failed validation"
+	Errors []ProblemError `json:"errors"`           // field errors, extension member
+}
+
+// ProblemError is a field error of ProblemDetails.
+type ProblemError struct {
+	Namespace string `json:"namespace"`
+	Tag       string `json:"tag"`
+	Code      string `json:"code"`
+	Param     string `json:"param,omitempty"`
+	Message   string `json:"message"`
+}
+
+// ToProblemDetails returns the RFC 7807 problem details of the errors, to be written as
+// ProblemContentType, e. g.
+//
+//	problem := validationErrors.ToProblemDetails("Invalid request", "https://example.com/probs/validation")
+//	w.Header().Set("Content-Type", validator.ProblemContentType)
+//	w.WriteHeader(problem.Status)
+//	json.NewEncoder(w).Encode(problem)
+//
+// The messages of the field errors are translated in English, see FieldErrorDetails.Translate,
+// and typeURI defaults to "about:blank".
+func (ve ValidationErrors) ToProblemDetails(title, typeURI string) ProblemDetails {
+	if len(typeURI) == 0 {
+		typeURI = "about:blank"
+	}
+
+	detail := "1 field failed validation"
+	if len(ve) != 1 {
+		detail = strconv.Itoa(len(ve)) + " fields failed validation"
+	}
+
+	p := ProblemDetails{
+		Type:   typeURI,
+		Title:  title,
+		Status: 422,
+		Detail: detail,
+		Errors: make([]ProblemError, len(ve)),
+	}
+	for i, fe := range ve {
+		p.Errors[i] = ProblemError{
+			Namespace: fe.Namespace(),
+			Tag:       fe.Tag(),
+			Code:      Details(fe).Code(),
+			Param:     fe.Param(),
+			Message:   Details(fe).Translate("en"),
+		}
+	}
+	return p
+}
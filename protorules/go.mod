@@ -0,0 +1,21 @@
+module github.com/pchchv/validator/protorules
+
+go 1.24.1
+
+require (
+	github.com/envoyproxy/protoc-gen-validate v1.3.3
+	github.com/pchchv/go-assert v0.0.0-20250530192653-4d6a8ce5608f
+	github.com/pchchv/validator v1.1.0
+	google.golang.org/protobuf v1.36.11
+)
+
+require (
+	github.com/gabriel-vasile/mimetype v1.4.9 // indirect
+	github.com/leodido/go-urn v1.4.0 // indirect
+	golang.org/x/crypto v0.47.0 // indirect
+	golang.org/x/net v0.49.0 // indirect
+	golang.org/x/sys v0.40.0 // indirect
+	golang.org/x/text v0.33.0 // indirect
+)
+
+replace github.com/pchchv/validator => ../
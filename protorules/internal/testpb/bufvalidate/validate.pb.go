@@ -0,0 +1,1094 @@
+// A subset of the protovalidate constraints, see https://github.com/bufbuild/protovalidate.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: buf/validate/validate.proto
+
+package bufvalidate
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Ignore int32
+
+const (
+	Ignore_IGNORE_UNSPECIFIED      Ignore = 0
+	Ignore_IGNORE_IF_UNPOPULATED   Ignore = 1
+	Ignore_IGNORE_IF_DEFAULT_VALUE Ignore = 2
+	Ignore_IGNORE_ALWAYS           Ignore = 3
+)
+
+// Enum value maps for Ignore.
+var (
+	Ignore_name = map[int32]string{
+		0: "IGNORE_UNSPECIFIED",
+		1: "IGNORE_IF_UNPOPULATED",
+		2: "IGNORE_IF_DEFAULT_VALUE",
+		3: "IGNORE_ALWAYS",
+	}
+	Ignore_value = map[string]int32{
+		"IGNORE_UNSPECIFIED":      0,
+		"IGNORE_IF_UNPOPULATED":   1,
+		"IGNORE_IF_DEFAULT_VALUE": 2,
+		"IGNORE_ALWAYS":           3,
+	}
+)
+
+func (x Ignore) Enum() *Ignore {
+	p := new(Ignore)
+	*p = x
+	return p
+}
+
+func (x Ignore) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Ignore) Descriptor() protoreflect.EnumDescriptor {
+	return file_buf_validate_validate_proto_enumTypes[0].Descriptor()
+}
+
+func (Ignore) Type() protoreflect.EnumType {
+	return &file_buf_validate_validate_proto_enumTypes[0]
+}
+
+func (x Ignore) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Do not use.
+func (x *Ignore) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
+	if err != nil {
+		return err
+	}
+	*x = Ignore(num)
+	return nil
+}
+
+// Deprecated: Use Ignore.Descriptor instead.
+func (Ignore) EnumDescriptor() ([]byte, []int) {
+	return file_buf_validate_validate_proto_rawDescGZIP(), []int{0}
+}
+
+type FieldRules struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Required *bool                  `protobuf:"varint,25,opt,name=required" json:"required,omitempty"`
+	Ignore   *Ignore                `protobuf:"varint,27,opt,name=ignore,enum=buf.validate.Ignore" json:"ignore,omitempty"`
+	// Types that are valid to be assigned to Type:
+	//
+	//	*FieldRules_Double
+	//	*FieldRules_Uint32
+	//	*FieldRules_Bool
+	//	*FieldRules_String_
+	//	*FieldRules_Repeated
+	//	*FieldRules_Map
+	Type          isFieldRules_Type `protobuf_oneof:"type"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldRules) Reset() {
+	*x = FieldRules{}
+	mi := &file_buf_validate_validate_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldRules) ProtoMessage() {}
+
+func (x *FieldRules) ProtoReflect() protoreflect.Message {
+	mi := &file_buf_validate_validate_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldRules.ProtoReflect.Descriptor instead.
+func (*FieldRules) Descriptor() ([]byte, []int) {
+	return file_buf_validate_validate_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FieldRules) GetRequired() bool {
+	if x != nil && x.Required != nil {
+		return *x.Required
+	}
+	return false
+}
+
+func (x *FieldRules) GetIgnore() Ignore {
+	if x != nil && x.Ignore != nil {
+		return *x.Ignore
+	}
+	return Ignore_IGNORE_UNSPECIFIED
+}
+
+func (x *FieldRules) GetType() isFieldRules_Type {
+	if x != nil {
+		return x.Type
+	}
+	return nil
+}
+
+func (x *FieldRules) GetDouble() *DoubleRules {
+	if x != nil {
+		if x, ok := x.Type.(*FieldRules_Double); ok {
+			return x.Double
+		}
+	}
+	return nil
+}
+
+func (x *FieldRules) GetUint32() *UInt32Rules {
+	if x != nil {
+		if x, ok := x.Type.(*FieldRules_Uint32); ok {
+			return x.Uint32
+		}
+	}
+	return nil
+}
+
+func (x *FieldRules) GetBool() *BoolRules {
+	if x != nil {
+		if x, ok := x.Type.(*FieldRules_Bool); ok {
+			return x.Bool
+		}
+	}
+	return nil
+}
+
+func (x *FieldRules) GetString_() *StringRules {
+	if x != nil {
+		if x, ok := x.Type.(*FieldRules_String_); ok {
+			return x.String_
+		}
+	}
+	return nil
+}
+
+func (x *FieldRules) GetRepeated() *RepeatedRules {
+	if x != nil {
+		if x, ok := x.Type.(*FieldRules_Repeated); ok {
+			return x.Repeated
+		}
+	}
+	return nil
+}
+
+func (x *FieldRules) GetMap() *MapRules {
+	if x != nil {
+		if x, ok := x.Type.(*FieldRules_Map); ok {
+			return x.Map
+		}
+	}
+	return nil
+}
+
+type isFieldRules_Type interface {
+	isFieldRules_Type()
+}
+
+type FieldRules_Double struct {
+	Double *DoubleRules `protobuf:"bytes,2,opt,name=double,oneof"`
+}
+
+type FieldRules_Uint32 struct {
+	Uint32 *UInt32Rules `protobuf:"bytes,5,opt,name=uint32,oneof"`
+}
+
+type FieldRules_Bool struct {
+	Bool *BoolRules `protobuf:"bytes,13,opt,name=bool,oneof"`
+}
+
+type FieldRules_String_ struct {
+	String_ *StringRules `protobuf:"bytes,14,opt,name=string,oneof"`
+}
+
+type FieldRules_Repeated struct {
+	Repeated *RepeatedRules `protobuf:"bytes,18,opt,name=repeated,oneof"`
+}
+
+type FieldRules_Map struct {
+	Map *MapRules `protobuf:"bytes,19,opt,name=map,oneof"`
+}
+
+func (*FieldRules_Double) isFieldRules_Type() {}
+
+func (*FieldRules_Uint32) isFieldRules_Type() {}
+
+func (*FieldRules_Bool) isFieldRules_Type() {}
+
+func (*FieldRules_String_) isFieldRules_Type() {}
+
+func (*FieldRules_Repeated) isFieldRules_Type() {}
+
+func (*FieldRules_Map) isFieldRules_Type() {}
+
+type DoubleRules struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Const *float64               `protobuf:"fixed64,1,opt,name=const" json:"const,omitempty"`
+	// Types that are valid to be assigned to LessThan:
+	//
+	//	*DoubleRules_Lt
+	//	*DoubleRules_Lte
+	LessThan isDoubleRules_LessThan `protobuf_oneof:"less_than"`
+	// Types that are valid to be assigned to GreaterThan:
+	//
+	//	*DoubleRules_Gt
+	//	*DoubleRules_Gte
+	GreaterThan   isDoubleRules_GreaterThan `protobuf_oneof:"greater_than"`
+	In            []float64                 `protobuf:"fixed64,6,rep,name=in" json:"in,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DoubleRules) Reset() {
+	*x = DoubleRules{}
+	mi := &file_buf_validate_validate_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DoubleRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DoubleRules) ProtoMessage() {}
+
+func (x *DoubleRules) ProtoReflect() protoreflect.Message {
+	mi := &file_buf_validate_validate_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DoubleRules.ProtoReflect.Descriptor instead.
+func (*DoubleRules) Descriptor() ([]byte, []int) {
+	return file_buf_validate_validate_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DoubleRules) GetConst() float64 {
+	if x != nil && x.Const != nil {
+		return *x.Const
+	}
+	return 0
+}
+
+func (x *DoubleRules) GetLessThan() isDoubleRules_LessThan {
+	if x != nil {
+		return x.LessThan
+	}
+	return nil
+}
+
+func (x *DoubleRules) GetLt() float64 {
+	if x != nil {
+		if x, ok := x.LessThan.(*DoubleRules_Lt); ok {
+			return x.Lt
+		}
+	}
+	return 0
+}
+
+func (x *DoubleRules) GetLte() float64 {
+	if x != nil {
+		if x, ok := x.LessThan.(*DoubleRules_Lte); ok {
+			return x.Lte
+		}
+	}
+	return 0
+}
+
+func (x *DoubleRules) GetGreaterThan() isDoubleRules_GreaterThan {
+	if x != nil {
+		return x.GreaterThan
+	}
+	return nil
+}
+
+func (x *DoubleRules) GetGt() float64 {
+	if x != nil {
+		if x, ok := x.GreaterThan.(*DoubleRules_Gt); ok {
+			return x.Gt
+		}
+	}
+	return 0
+}
+
+func (x *DoubleRules) GetGte() float64 {
+	if x != nil {
+		if x, ok := x.GreaterThan.(*DoubleRules_Gte); ok {
+			return x.Gte
+		}
+	}
+	return 0
+}
+
+func (x *DoubleRules) GetIn() []float64 {
+	if x != nil {
+		return x.In
+	}
+	return nil
+}
+
+type isDoubleRules_LessThan interface {
+	isDoubleRules_LessThan()
+}
+
+type DoubleRules_Lt struct {
+	Lt float64 `protobuf:"fixed64,2,opt,name=lt,oneof"`
+}
+
+type DoubleRules_Lte struct {
+	Lte float64 `protobuf:"fixed64,3,opt,name=lte,oneof"`
+}
+
+func (*DoubleRules_Lt) isDoubleRules_LessThan() {}
+
+func (*DoubleRules_Lte) isDoubleRules_LessThan() {}
+
+type isDoubleRules_GreaterThan interface {
+	isDoubleRules_GreaterThan()
+}
+
+type DoubleRules_Gt struct {
+	Gt float64 `protobuf:"fixed64,4,opt,name=gt,oneof"`
+}
+
+type DoubleRules_Gte struct {
+	Gte float64 `protobuf:"fixed64,5,opt,name=gte,oneof"`
+}
+
+func (*DoubleRules_Gt) isDoubleRules_GreaterThan() {}
+
+func (*DoubleRules_Gte) isDoubleRules_GreaterThan() {}
+
+type UInt32Rules struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Const *uint32                `protobuf:"varint,1,opt,name=const" json:"const,omitempty"`
+	// Types that are valid to be assigned to LessThan:
+	//
+	//	*UInt32Rules_Lt
+	//	*UInt32Rules_Lte
+	LessThan isUInt32Rules_LessThan `protobuf_oneof:"less_than"`
+	// Types that are valid to be assigned to GreaterThan:
+	//
+	//	*UInt32Rules_Gt
+	//	*UInt32Rules_Gte
+	GreaterThan   isUInt32Rules_GreaterThan `protobuf_oneof:"greater_than"`
+	In            []uint32                  `protobuf:"varint,6,rep,name=in" json:"in,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UInt32Rules) Reset() {
+	*x = UInt32Rules{}
+	mi := &file_buf_validate_validate_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UInt32Rules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UInt32Rules) ProtoMessage() {}
+
+func (x *UInt32Rules) ProtoReflect() protoreflect.Message {
+	mi := &file_buf_validate_validate_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UInt32Rules.ProtoReflect.Descriptor instead.
+func (*UInt32Rules) Descriptor() ([]byte, []int) {
+	return file_buf_validate_validate_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *UInt32Rules) GetConst() uint32 {
+	if x != nil && x.Const != nil {
+		return *x.Const
+	}
+	return 0
+}
+
+func (x *UInt32Rules) GetLessThan() isUInt32Rules_LessThan {
+	if x != nil {
+		return x.LessThan
+	}
+	return nil
+}
+
+func (x *UInt32Rules) GetLt() uint32 {
+	if x != nil {
+		if x, ok := x.LessThan.(*UInt32Rules_Lt); ok {
+			return x.Lt
+		}
+	}
+	return 0
+}
+
+func (x *UInt32Rules) GetLte() uint32 {
+	if x != nil {
+		if x, ok := x.LessThan.(*UInt32Rules_Lte); ok {
+			return x.Lte
+		}
+	}
+	return 0
+}
+
+func (x *UInt32Rules) GetGreaterThan() isUInt32Rules_GreaterThan {
+	if x != nil {
+		return x.GreaterThan
+	}
+	return nil
+}
+
+func (x *UInt32Rules) GetGt() uint32 {
+	if x != nil {
+		if x, ok := x.GreaterThan.(*UInt32Rules_Gt); ok {
+			return x.Gt
+		}
+	}
+	return 0
+}
+
+func (x *UInt32Rules) GetGte() uint32 {
+	if x != nil {
+		if x, ok := x.GreaterThan.(*UInt32Rules_Gte); ok {
+			return x.Gte
+		}
+	}
+	return 0
+}
+
+func (x *UInt32Rules) GetIn() []uint32 {
+	if x != nil {
+		return x.In
+	}
+	return nil
+}
+
+type isUInt32Rules_LessThan interface {
+	isUInt32Rules_LessThan()
+}
+
+type UInt32Rules_Lt struct {
+	Lt uint32 `protobuf:"varint,2,opt,name=lt,oneof"`
+}
+
+type UInt32Rules_Lte struct {
+	Lte uint32 `protobuf:"varint,3,opt,name=lte,oneof"`
+}
+
+func (*UInt32Rules_Lt) isUInt32Rules_LessThan() {}
+
+func (*UInt32Rules_Lte) isUInt32Rules_LessThan() {}
+
+type isUInt32Rules_GreaterThan interface {
+	isUInt32Rules_GreaterThan()
+}
+
+type UInt32Rules_Gt struct {
+	Gt uint32 `protobuf:"varint,4,opt,name=gt,oneof"`
+}
+
+type UInt32Rules_Gte struct {
+	Gte uint32 `protobuf:"varint,5,opt,name=gte,oneof"`
+}
+
+func (*UInt32Rules_Gt) isUInt32Rules_GreaterThan() {}
+
+func (*UInt32Rules_Gte) isUInt32Rules_GreaterThan() {}
+
+type BoolRules struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Const         *bool                  `protobuf:"varint,1,opt,name=const" json:"const,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BoolRules) Reset() {
+	*x = BoolRules{}
+	mi := &file_buf_validate_validate_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BoolRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BoolRules) ProtoMessage() {}
+
+func (x *BoolRules) ProtoReflect() protoreflect.Message {
+	mi := &file_buf_validate_validate_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BoolRules.ProtoReflect.Descriptor instead.
+func (*BoolRules) Descriptor() ([]byte, []int) {
+	return file_buf_validate_validate_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BoolRules) GetConst() bool {
+	if x != nil && x.Const != nil {
+		return *x.Const
+	}
+	return false
+}
+
+type StringRules struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Const       *string                `protobuf:"bytes,1,opt,name=const" json:"const,omitempty"`
+	Len         *uint64                `protobuf:"varint,19,opt,name=len" json:"len,omitempty"`
+	MinLen      *uint64                `protobuf:"varint,2,opt,name=min_len,json=minLen" json:"min_len,omitempty"`
+	MaxLen      *uint64                `protobuf:"varint,3,opt,name=max_len,json=maxLen" json:"max_len,omitempty"`
+	Pattern     *string                `protobuf:"bytes,6,opt,name=pattern" json:"pattern,omitempty"`
+	Prefix      *string                `protobuf:"bytes,7,opt,name=prefix" json:"prefix,omitempty"`
+	Suffix      *string                `protobuf:"bytes,8,opt,name=suffix" json:"suffix,omitempty"`
+	Contains    *string                `protobuf:"bytes,9,opt,name=contains" json:"contains,omitempty"`
+	NotContains *string                `protobuf:"bytes,23,opt,name=not_contains,json=notContains" json:"not_contains,omitempty"`
+	In          []string               `protobuf:"bytes,10,rep,name=in" json:"in,omitempty"`
+	// Types that are valid to be assigned to WellKnown:
+	//
+	//	*StringRules_Email
+	//	*StringRules_Hostname
+	//	*StringRules_Ip
+	//	*StringRules_Uri
+	//	*StringRules_Uuid
+	WellKnown     isStringRules_WellKnown `protobuf_oneof:"well_known"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StringRules) Reset() {
+	*x = StringRules{}
+	mi := &file_buf_validate_validate_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StringRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StringRules) ProtoMessage() {}
+
+func (x *StringRules) ProtoReflect() protoreflect.Message {
+	mi := &file_buf_validate_validate_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StringRules.ProtoReflect.Descriptor instead.
+func (*StringRules) Descriptor() ([]byte, []int) {
+	return file_buf_validate_validate_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *StringRules) GetConst() string {
+	if x != nil && x.Const != nil {
+		return *x.Const
+	}
+	return ""
+}
+
+func (x *StringRules) GetLen() uint64 {
+	if x != nil && x.Len != nil {
+		return *x.Len
+	}
+	return 0
+}
+
+func (x *StringRules) GetMinLen() uint64 {
+	if x != nil && x.MinLen != nil {
+		return *x.MinLen
+	}
+	return 0
+}
+
+func (x *StringRules) GetMaxLen() uint64 {
+	if x != nil && x.MaxLen != nil {
+		return *x.MaxLen
+	}
+	return 0
+}
+
+func (x *StringRules) GetPattern() string {
+	if x != nil && x.Pattern != nil {
+		return *x.Pattern
+	}
+	return ""
+}
+
+func (x *StringRules) GetPrefix() string {
+	if x != nil && x.Prefix != nil {
+		return *x.Prefix
+	}
+	return ""
+}
+
+func (x *StringRules) GetSuffix() string {
+	if x != nil && x.Suffix != nil {
+		return *x.Suffix
+	}
+	return ""
+}
+
+func (x *StringRules) GetContains() string {
+	if x != nil && x.Contains != nil {
+		return *x.Contains
+	}
+	return ""
+}
+
+func (x *StringRules) GetNotContains() string {
+	if x != nil && x.NotContains != nil {
+		return *x.NotContains
+	}
+	return ""
+}
+
+func (x *StringRules) GetIn() []string {
+	if x != nil {
+		return x.In
+	}
+	return nil
+}
+
+func (x *StringRules) GetWellKnown() isStringRules_WellKnown {
+	if x != nil {
+		return x.WellKnown
+	}
+	return nil
+}
+
+func (x *StringRules) GetEmail() bool {
+	if x != nil {
+		if x, ok := x.WellKnown.(*StringRules_Email); ok {
+			return x.Email
+		}
+	}
+	return false
+}
+
+func (x *StringRules) GetHostname() bool {
+	if x != nil {
+		if x, ok := x.WellKnown.(*StringRules_Hostname); ok {
+			return x.Hostname
+		}
+	}
+	return false
+}
+
+func (x *StringRules) GetIp() bool {
+	if x != nil {
+		if x, ok := x.WellKnown.(*StringRules_Ip); ok {
+			return x.Ip
+		}
+	}
+	return false
+}
+
+func (x *StringRules) GetUri() bool {
+	if x != nil {
+		if x, ok := x.WellKnown.(*StringRules_Uri); ok {
+			return x.Uri
+		}
+	}
+	return false
+}
+
+func (x *StringRules) GetUuid() bool {
+	if x != nil {
+		if x, ok := x.WellKnown.(*StringRules_Uuid); ok {
+			return x.Uuid
+		}
+	}
+	return false
+}
+
+type isStringRules_WellKnown interface {
+	isStringRules_WellKnown()
+}
+
+type StringRules_Email struct {
+	Email bool `protobuf:"varint,12,opt,name=email,oneof"`
+}
+
+type StringRules_Hostname struct {
+	Hostname bool `protobuf:"varint,13,opt,name=hostname,oneof"`
+}
+
+type StringRules_Ip struct {
+	Ip bool `protobuf:"varint,14,opt,name=ip,oneof"`
+}
+
+type StringRules_Uri struct {
+	Uri bool `protobuf:"varint,17,opt,name=uri,oneof"`
+}
+
+type StringRules_Uuid struct {
+	Uuid bool `protobuf:"varint,22,opt,name=uuid,oneof"`
+}
+
+func (*StringRules_Email) isStringRules_WellKnown() {}
+
+func (*StringRules_Hostname) isStringRules_WellKnown() {}
+
+func (*StringRules_Ip) isStringRules_WellKnown() {}
+
+func (*StringRules_Uri) isStringRules_WellKnown() {}
+
+func (*StringRules_Uuid) isStringRules_WellKnown() {}
+
+type RepeatedRules struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MinItems      *uint64                `protobuf:"varint,1,opt,name=min_items,json=minItems" json:"min_items,omitempty"`
+	MaxItems      *uint64                `protobuf:"varint,2,opt,name=max_items,json=maxItems" json:"max_items,omitempty"`
+	Unique        *bool                  `protobuf:"varint,3,opt,name=unique" json:"unique,omitempty"`
+	Items         *FieldRules            `protobuf:"bytes,4,opt,name=items" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RepeatedRules) Reset() {
+	*x = RepeatedRules{}
+	mi := &file_buf_validate_validate_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RepeatedRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RepeatedRules) ProtoMessage() {}
+
+func (x *RepeatedRules) ProtoReflect() protoreflect.Message {
+	mi := &file_buf_validate_validate_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RepeatedRules.ProtoReflect.Descriptor instead.
+func (*RepeatedRules) Descriptor() ([]byte, []int) {
+	return file_buf_validate_validate_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RepeatedRules) GetMinItems() uint64 {
+	if x != nil && x.MinItems != nil {
+		return *x.MinItems
+	}
+	return 0
+}
+
+func (x *RepeatedRules) GetMaxItems() uint64 {
+	if x != nil && x.MaxItems != nil {
+		return *x.MaxItems
+	}
+	return 0
+}
+
+func (x *RepeatedRules) GetUnique() bool {
+	if x != nil && x.Unique != nil {
+		return *x.Unique
+	}
+	return false
+}
+
+func (x *RepeatedRules) GetItems() *FieldRules {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type MapRules struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MinPairs      *uint64                `protobuf:"varint,1,opt,name=min_pairs,json=minPairs" json:"min_pairs,omitempty"`
+	MaxPairs      *uint64                `protobuf:"varint,2,opt,name=max_pairs,json=maxPairs" json:"max_pairs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MapRules) Reset() {
+	*x = MapRules{}
+	mi := &file_buf_validate_validate_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MapRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MapRules) ProtoMessage() {}
+
+func (x *MapRules) ProtoReflect() protoreflect.Message {
+	mi := &file_buf_validate_validate_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MapRules.ProtoReflect.Descriptor instead.
+func (*MapRules) Descriptor() ([]byte, []int) {
+	return file_buf_validate_validate_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *MapRules) GetMinPairs() uint64 {
+	if x != nil && x.MinPairs != nil {
+		return *x.MinPairs
+	}
+	return 0
+}
+
+func (x *MapRules) GetMaxPairs() uint64 {
+	if x != nil && x.MaxPairs != nil {
+		return *x.MaxPairs
+	}
+	return 0
+}
+
+var file_buf_validate_validate_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*FieldRules)(nil),
+		Field:         1159,
+		Name:          "buf.validate.field",
+		Tag:           "bytes,1159,opt,name=field",
+		Filename:      "buf/validate/validate.proto",
+	},
+}
+
+// Extension fields to descriptorpb.FieldOptions.
+var (
+	// optional buf.validate.FieldRules field = 1159;
+	E_Field = &file_buf_validate_validate_proto_extTypes[0]
+)
+
+var File_buf_validate_validate_proto protoreflect.FileDescriptor
+
+const file_buf_validate_validate_proto_rawDesc = "" +
+	"\n" +
+	"\x1bbuf/validate/validate.proto\x12\fbuf.validate\x1a google/protobuf/descriptor.proto\"\x93\x03\n" +
+	"\n" +
+	"FieldRules\x12\x1a\n" +
+	"\brequired\x18\x19 \x01(\bR\brequired\x12,\n" +
+	"\x06ignore\x18\x1b \x01(\x0e2\x14.buf.validate.IgnoreR\x06ignore\x123\n" +
+	"\x06double\x18\x02 \x01(\v2\x19.buf.validate.DoubleRulesH\x00R\x06double\x123\n" +
+	"\x06uint32\x18\x05 \x01(\v2\x19.buf.validate.UInt32RulesH\x00R\x06uint32\x12-\n" +
+	"\x04bool\x18\r \x01(\v2\x17.buf.validate.BoolRulesH\x00R\x04bool\x123\n" +
+	"\x06string\x18\x0e \x01(\v2\x19.buf.validate.StringRulesH\x00R\x06string\x129\n" +
+	"\brepeated\x18\x12 \x01(\v2\x1b.buf.validate.RepeatedRulesH\x00R\brepeated\x12*\n" +
+	"\x03map\x18\x13 \x01(\v2\x16.buf.validate.MapRulesH\x00R\x03mapB\x06\n" +
+	"\x04type\"\x9c\x01\n" +
+	"\vDoubleRules\x12\x14\n" +
+	"\x05const\x18\x01 \x01(\x01R\x05const\x12\x10\n" +
+	"\x02lt\x18\x02 \x01(\x01H\x00R\x02lt\x12\x12\n" +
+	"\x03lte\x18\x03 \x01(\x01H\x00R\x03lte\x12\x10\n" +
+	"\x02gt\x18\x04 \x01(\x01H\x01R\x02gt\x12\x12\n" +
+	"\x03gte\x18\x05 \x01(\x01H\x01R\x03gte\x12\x0e\n" +
+	"\x02in\x18\x06 \x03(\x01R\x02inB\v\n" +
+	"\tless_thanB\x0e\n" +
+	"\fgreater_than\"\x9c\x01\n" +
+	"\vUInt32Rules\x12\x14\n" +
+	"\x05const\x18\x01 \x01(\rR\x05const\x12\x10\n" +
+	"\x02lt\x18\x02 \x01(\rH\x00R\x02lt\x12\x12\n" +
+	"\x03lte\x18\x03 \x01(\rH\x00R\x03lte\x12\x10\n" +
+	"\x02gt\x18\x04 \x01(\rH\x01R\x02gt\x12\x12\n" +
+	"\x03gte\x18\x05 \x01(\rH\x01R\x03gte\x12\x0e\n" +
+	"\x02in\x18\x06 \x03(\rR\x02inB\v\n" +
+	"\tless_thanB\x0e\n" +
+	"\fgreater_than\"!\n" +
+	"\tBoolRules\x12\x14\n" +
+	"\x05const\x18\x01 \x01(\bR\x05const\"\x80\x03\n" +
+	"\vStringRules\x12\x14\n" +
+	"\x05const\x18\x01 \x01(\tR\x05const\x12\x10\n" +
+	"\x03len\x18\x13 \x01(\x04R\x03len\x12\x17\n" +
+	"\amin_len\x18\x02 \x01(\x04R\x06minLen\x12\x17\n" +
+	"\amax_len\x18\x03 \x01(\x04R\x06maxLen\x12\x18\n" +
+	"\apattern\x18\x06 \x01(\tR\apattern\x12\x16\n" +
+	"\x06prefix\x18\a \x01(\tR\x06prefix\x12\x16\n" +
+	"\x06suffix\x18\b \x01(\tR\x06suffix\x12\x1a\n" +
+	"\bcontains\x18\t \x01(\tR\bcontains\x12!\n" +
+	"\fnot_contains\x18\x17 \x01(\tR\vnotContains\x12\x0e\n" +
+	"\x02in\x18\n" +
+	" \x03(\tR\x02in\x12\x16\n" +
+	"\x05email\x18\f \x01(\bH\x00R\x05email\x12\x1c\n" +
+	"\bhostname\x18\r \x01(\bH\x00R\bhostname\x12\x10\n" +
+	"\x02ip\x18\x0e \x01(\bH\x00R\x02ip\x12\x12\n" +
+	"\x03uri\x18\x11 \x01(\bH\x00R\x03uri\x12\x14\n" +
+	"\x04uuid\x18\x16 \x01(\bH\x00R\x04uuidB\f\n" +
+	"\n" +
+	"well_known\"\x91\x01\n" +
+	"\rRepeatedRules\x12\x1b\n" +
+	"\tmin_items\x18\x01 \x01(\x04R\bminItems\x12\x1b\n" +
+	"\tmax_items\x18\x02 \x01(\x04R\bmaxItems\x12\x16\n" +
+	"\x06unique\x18\x03 \x01(\bR\x06unique\x12.\n" +
+	"\x05items\x18\x04 \x01(\v2\x18.buf.validate.FieldRulesR\x05items\"D\n" +
+	"\bMapRules\x12\x1b\n" +
+	"\tmin_pairs\x18\x01 \x01(\x04R\bminPairs\x12\x1b\n" +
+	"\tmax_pairs\x18\x02 \x01(\x04R\bmaxPairs*k\n" +
+	"\x06Ignore\x12\x16\n" +
+	"\x12IGNORE_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15IGNORE_IF_UNPOPULATED\x10\x01\x12\x1b\n" +
+	"\x17IGNORE_IF_DEFAULT_VALUE\x10\x02\x12\x11\n" +
+	"\rIGNORE_ALWAYS\x10\x03:N\n" +
+	"\x05field\x12\x1d.google.protobuf.FieldOptions\x18\x87\t \x01(\v2\x18.buf.validate.FieldRulesR\x05fieldBDZBgithub.com/pchchv/validator/protorules/internal/testpb/bufvalidate"
+
+var (
+	file_buf_validate_validate_proto_rawDescOnce sync.Once
+	file_buf_validate_validate_proto_rawDescData []byte
+)
+
+func file_buf_validate_validate_proto_rawDescGZIP() []byte {
+	file_buf_validate_validate_proto_rawDescOnce.Do(func() {
+		file_buf_validate_validate_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_buf_validate_validate_proto_rawDesc), len(file_buf_validate_validate_proto_rawDesc)))
+	})
+	return file_buf_validate_validate_proto_rawDescData
+}
+
+var file_buf_validate_validate_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_buf_validate_validate_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_buf_validate_validate_proto_goTypes = []any{
+	(Ignore)(0),                       // 0: buf.validate.Ignore
+	(*FieldRules)(nil),                // 1: buf.validate.FieldRules
+	(*DoubleRules)(nil),               // 2: buf.validate.DoubleRules
+	(*UInt32Rules)(nil),               // 3: buf.validate.UInt32Rules
+	(*BoolRules)(nil),                 // 4: buf.validate.BoolRules
+	(*StringRules)(nil),               // 5: buf.validate.StringRules
+	(*RepeatedRules)(nil),             // 6: buf.validate.RepeatedRules
+	(*MapRules)(nil),                  // 7: buf.validate.MapRules
+	(*descriptorpb.FieldOptions)(nil), // 8: google.protobuf.FieldOptions
+}
+var file_buf_validate_validate_proto_depIdxs = []int32{
+	0,  // 0: buf.validate.FieldRules.ignore:type_name -> buf.validate.Ignore
+	2,  // 1: buf.validate.FieldRules.double:type_name -> buf.validate.DoubleRules
+	3,  // 2: buf.validate.FieldRules.uint32:type_name -> buf.validate.UInt32Rules
+	4,  // 3: buf.validate.FieldRules.bool:type_name -> buf.validate.BoolRules
+	5,  // 4: buf.validate.FieldRules.string:type_name -> buf.validate.StringRules
+	6,  // 5: buf.validate.FieldRules.repeated:type_name -> buf.validate.RepeatedRules
+	7,  // 6: buf.validate.FieldRules.map:type_name -> buf.validate.MapRules
+	1,  // 7: buf.validate.RepeatedRules.items:type_name -> buf.validate.FieldRules
+	8,  // 8: buf.validate.field:extendee -> google.protobuf.FieldOptions
+	1,  // 9: buf.validate.field:type_name -> buf.validate.FieldRules
+	10, // [10:10] is the sub-list for method output_type
+	10, // [10:10] is the sub-list for method input_type
+	9,  // [9:10] is the sub-list for extension type_name
+	8,  // [8:9] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_buf_validate_validate_proto_init() }
+func file_buf_validate_validate_proto_init() {
+	if File_buf_validate_validate_proto != nil {
+		return
+	}
+	file_buf_validate_validate_proto_msgTypes[0].OneofWrappers = []any{
+		(*FieldRules_Double)(nil),
+		(*FieldRules_Uint32)(nil),
+		(*FieldRules_Bool)(nil),
+		(*FieldRules_String_)(nil),
+		(*FieldRules_Repeated)(nil),
+		(*FieldRules_Map)(nil),
+	}
+	file_buf_validate_validate_proto_msgTypes[1].OneofWrappers = []any{
+		(*DoubleRules_Lt)(nil),
+		(*DoubleRules_Lte)(nil),
+		(*DoubleRules_Gt)(nil),
+		(*DoubleRules_Gte)(nil),
+	}
+	file_buf_validate_validate_proto_msgTypes[2].OneofWrappers = []any{
+		(*UInt32Rules_Lt)(nil),
+		(*UInt32Rules_Lte)(nil),
+		(*UInt32Rules_Gt)(nil),
+		(*UInt32Rules_Gte)(nil),
+	}
+	file_buf_validate_validate_proto_msgTypes[4].OneofWrappers = []any{
+		(*StringRules_Email)(nil),
+		(*StringRules_Hostname)(nil),
+		(*StringRules_Ip)(nil),
+		(*StringRules_Uri)(nil),
+		(*StringRules_Uuid)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_buf_validate_validate_proto_rawDesc), len(file_buf_validate_validate_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   7,
+			NumExtensions: 1,
+			NumServices:   0,
+		},
+		GoTypes:           file_buf_validate_validate_proto_goTypes,
+		DependencyIndexes: file_buf_validate_validate_proto_depIdxs,
+		EnumInfos:         file_buf_validate_validate_proto_enumTypes,
+		MessageInfos:      file_buf_validate_validate_proto_msgTypes,
+		ExtensionInfos:    file_buf_validate_validate_proto_extTypes,
+	}.Build()
+	File_buf_validate_validate_proto = out.File
+	file_buf_validate_validate_proto_goTypes = nil
+	file_buf_validate_validate_proto_depIdxs = nil
+}
@@ -0,0 +1,327 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: users.proto
+
+package testpb
+
+import (
+	_ "github.com/envoyproxy/protoc-gen-validate/validate"
+	_ "github.com/pchchv/validator/protorules/internal/testpb/bufvalidate"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type User struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	UserId   string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Email    string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Name     string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Age      uint32                 `protobuf:"varint,4,opt,name=age,proto3" json:"age,omitempty"`
+	Tags     []string               `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	Role     string                 `protobuf:"bytes,6,opt,name=role,proto3" json:"role,omitempty"`
+	Address  *User_Address          `protobuf:"bytes,7,opt,name=address,proto3" json:"address,omitempty"`
+	Labels   map[string]string      `protobuf:"bytes,8,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Score    float64                `protobuf:"fixed64,9,opt,name=score,proto3" json:"score,omitempty"`
+	Nickname string                 `protobuf:"bytes,10,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	Website  *string                `protobuf:"bytes,13,opt,name=website,proto3,oneof" json:"website,omitempty"`
+	// Types that are valid to be assigned to Contact:
+	//
+	//	*User_Phone
+	//	*User_Fax
+	Contact       isUser_Contact `protobuf_oneof:"contact"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_users_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *User) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *User) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *User) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *User) GetAge() uint32 {
+	if x != nil {
+		return x.Age
+	}
+	return 0
+}
+
+func (x *User) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *User) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *User) GetAddress() *User_Address {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *User) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *User) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *User) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+func (x *User) GetWebsite() string {
+	if x != nil && x.Website != nil {
+		return *x.Website
+	}
+	return ""
+}
+
+func (x *User) GetContact() isUser_Contact {
+	if x != nil {
+		return x.Contact
+	}
+	return nil
+}
+
+func (x *User) GetPhone() string {
+	if x != nil {
+		if x, ok := x.Contact.(*User_Phone); ok {
+			return x.Phone
+		}
+	}
+	return ""
+}
+
+func (x *User) GetFax() string {
+	if x != nil {
+		if x, ok := x.Contact.(*User_Fax); ok {
+			return x.Fax
+		}
+	}
+	return ""
+}
+
+type isUser_Contact interface {
+	isUser_Contact()
+}
+
+type User_Phone struct {
+	Phone string `protobuf:"bytes,11,opt,name=phone,proto3,oneof"`
+}
+
+type User_Fax struct {
+	Fax string `protobuf:"bytes,12,opt,name=fax,proto3,oneof"`
+}
+
+func (*User_Phone) isUser_Contact() {}
+
+func (*User_Fax) isUser_Contact() {}
+
+type User_Address struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	City          string                 `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *User_Address) Reset() {
+	*x = User_Address{}
+	mi := &file_users_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User_Address) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User_Address) ProtoMessage() {}
+
+func (x *User_Address) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User_Address.ProtoReflect.Descriptor instead.
+func (*User_Address) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{0, 1}
+}
+
+func (x *User_Address) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+var File_users_proto protoreflect.FileDescriptor
+
+const file_users_proto_rawDesc = "" +
+	"\n" +
+	"\vusers.proto\x12\busers.v1\x1a\x1bbuf/validate/validate.proto\x1a\x17validate/validate.proto\"\xff\x04\n" +
+	"\x04User\x12!\n" +
+	"\auser_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x06userId\x12 \n" +
+	"\x05email\x18\x02 \x01(\tB\n" +
+	"\xbaH\a\xc8\x01\x01r\x02`\x01R\x05email\x12'\n" +
+	"\x04name\x18\x03 \x01(\tB\x13\xbaH\x10r\x0e\x10\x02\x1822\b^[a-z]+$R\x04name\x12\x1c\n" +
+	"\x03age\x18\x04 \x01(\rB\n" +
+	"\xbaH\a*\x05\x10\x96\x01(\x12R\x03age\x12$\n" +
+	"\x04tags\x18\x05 \x03(\tB\x10\xbaH\r\x92\x01\n" +
+	"\x10\x03\x18\x01\"\x04r\x02\x10\x01R\x04tags\x12#\n" +
+	"\x04role\x18\x06 \x01(\tB\x0f\xbaH\f\xd8\x01\x01r\aR\x05adminR\x04role\x12:\n" +
+	"\aaddress\x18\a \x01(\v2\x16.users.v1.User.AddressB\b\xfaB\x05\x8a\x01\x02\x10\x01R\aaddress\x12<\n" +
+	"\x06labels\x18\b \x03(\v2\x1a.users.v1.User.LabelsEntryB\b\xbaH\x05\x9a\x01\x02\x10\x02R\x06labels\x12$\n" +
+	"\x05score\x18\t \x01(\x01B\x0e\xbaH\v\x12\t1\x00\x00\x00\x00\x00\x00\xf8?R\x05score\x12\x1a\n" +
+	"\bnickname\x18\n" +
+	" \x01(\tR\bnickname\x12'\n" +
+	"\awebsite\x18\r \x01(\tB\b\xbaH\x05r\x03\x88\x01\x01H\x01R\awebsite\x88\x01\x01\x12 \n" +
+	"\x05phone\x18\v \x01(\tB\b\xbaH\x05r\x03:\x01+H\x00R\x05phone\x12\x1c\n" +
+	"\x03fax\x18\f \x01(\tB\b\xfaB\x05r\x03:\x01+H\x00R\x03fax\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a)\n" +
+	"\aAddress\x12\x1e\n" +
+	"\x04city\x18\x01 \x01(\tB\n" +
+	"\xfaB\ar\x05\x10\x01\xd0\x01\x01R\x04cityB\t\n" +
+	"\acontactB\n" +
+	"\n" +
+	"\b_websiteB8Z6github.com/pchchv/validator/protorules/internal/testpbb\x06proto3"
+
+var (
+	file_users_proto_rawDescOnce sync.Once
+	file_users_proto_rawDescData []byte
+)
+
+func file_users_proto_rawDescGZIP() []byte {
+	file_users_proto_rawDescOnce.Do(func() {
+		file_users_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_users_proto_rawDesc), len(file_users_proto_rawDesc)))
+	})
+	return file_users_proto_rawDescData
+}
+
+var file_users_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_users_proto_goTypes = []any{
+	(*User)(nil),         // 0: users.v1.User
+	nil,                  // 1: users.v1.User.LabelsEntry
+	(*User_Address)(nil), // 2: users.v1.User.Address
+}
+var file_users_proto_depIdxs = []int32{
+	2, // 0: users.v1.User.address:type_name -> users.v1.User.Address
+	1, // 1: users.v1.User.labels:type_name -> users.v1.User.LabelsEntry
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_users_proto_init() }
+func file_users_proto_init() {
+	if File_users_proto != nil {
+		return
+	}
+	file_users_proto_msgTypes[0].OneofWrappers = []any{
+		(*User_Phone)(nil),
+		(*User_Fax)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_users_proto_rawDesc), len(file_users_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_users_proto_goTypes,
+		DependencyIndexes: file_users_proto_depIdxs,
+		MessageInfos:      file_users_proto_msgTypes,
+	}.Build()
+	File_users_proto = out.File
+	file_users_proto_goTypes = nil
+	file_users_proto_depIdxs = nil
+}
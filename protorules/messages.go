@@ -0,0 +1,241 @@
+package protorules
+
+import (
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pchchv/validator"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// optionNumbers are the field numbers of the field option extensions holding the constraints.
+var optionNumbers = map[protowire.Number]string{
+	1159: "(buf.validate.field)",
+	1071: "(validate.rules)",
+}
+
+// Register registers with v the rules of the generated messages msgs,
+// of the messages of their fields and of the wrappers of their oneofs,
+// and returns the constraints that were skipped, e. g. "users.v1.User.name: string.pattern".
+//
+// NOTE: this method is not thread-safe it is intended that these all be registered prior to any validation
+func Register(v *validator.Validate, msgs ...proto.Message) []string {
+	rules, skipped := Rules(msgs...)
+	for typ, r := range rules {
+		v.RegisterStructValidationMapRules(r, reflect.New(typ).Interface())
+	}
+	return skipped
+}
+
+// Rules returns the rules of the generated messages msgs,
+// of the messages of their fields and of the wrappers of their oneofs keyed by their Go struct type,
+// and the constraints that were skipped.
+func Rules(msgs ...proto.Message) (map[reflect.Type]map[string]string, []string) {
+	w := &walker{
+		builder: builder{rules: make(map[string]map[string]string)},
+		types:   make(map[string]reflect.Type),
+		seen:    make(map[protoreflect.FullName]struct{}),
+	}
+	for _, m := range msgs {
+		w.message(m)
+	}
+
+	rules := make(map[reflect.Type]map[string]string, len(w.rules))
+	for key, r := range w.rules {
+		rules[w.types[key]] = r
+	}
+
+	slices.Sort(w.skipped)
+	return rules, w.skipped
+}
+
+// walker records the rules of the fields of generated messages.
+type walker struct {
+	builder
+	types map[string]reflect.Type
+	seen  map[protoreflect.FullName]struct{}
+}
+
+// message records the rules of the fields of the generated message m and of the messages of its fields.
+func (w *walker) message(m proto.Message) {
+	md := m.ProtoReflect().Descriptor()
+	if _, ok := w.seen[md.FullName()]; ok {
+		return
+	}
+	w.seen[md.FullName()] = struct{}{}
+
+	typ := reflect.TypeOf(m)
+	if typ.Kind() != reflect.Ptr || typ.Elem().Kind() != reflect.Struct {
+		// not a generated message, e. g. a dynamicpb.Message
+		return
+	}
+
+	typ = typ.Elem()
+	names := goNames(typ)
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		constraints := w.constraints(fd)
+		if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() {
+			if field, ok := names[string(od.Name())]; ok {
+				wrapper := oneofWrapper(m, fd, field)
+				w.field(wrapper, goNames(wrapper)[string(fd.Name())], fd, constraints, false)
+			}
+		} else {
+			w.field(typ, names[string(fd.Name())], fd, constraints, fd.HasPresence() && fd.Message() == nil)
+		}
+
+		fmd := fd.Message()
+		if fd.IsMap() {
+			fmd = fd.MapValue().Message()
+		}
+
+		if fmd != nil {
+			if mt, err := protoregistry.GlobalTypes.FindMessageByName(fmd.FullName()); err == nil {
+				w.message(mt.New().Interface())
+			}
+		}
+	}
+}
+
+// field records the rules of the constraints of the field fd, the field goName of the Go struct type typ.
+func (w *walker) field(typ reflect.Type, goName string, fd protoreflect.FieldDescriptor, constraints []constraint, optional bool) {
+	if len(goName) == 0 {
+		return
+	}
+
+	key := typ.PkgPath() + "." + typ.Name()
+	w.types[key] = typ
+	w.add(key, goName, string(fd.FullName()), constraints, optional)
+}
+
+// constraints returns the constraints of the field options of fd,
+// recording the options of the extensions not linked into the binary as skipped.
+func (w *walker) constraints(fd protoreflect.FieldDescriptor) []constraint {
+	opts := fd.Options()
+	if opts == nil {
+		return nil
+	}
+
+	var constraints []constraint
+	m := opts.ProtoReflect()
+	m.Range(func(xd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if _, ok := optionNames["("+string(xd.FullName())+")"]; ok && xd.IsExtension() {
+			constraints = flattenValue(constraints, "", xd, v)
+		}
+		return true
+	})
+
+	for b := m.GetUnknown(); len(b) > 0; {
+		num, _, n := protowire.ConsumeField(b)
+		if n < 0 {
+			break
+		}
+
+		if opt, ok := optionNumbers[num]; ok {
+			w.skipped = append(w.skipped, string(fd.FullName())+": "+opt)
+		}
+		b = b[n:]
+	}
+	return constraints
+}
+
+// flattenValue appends the constraints of the value v of the field fd at path,
+// messages being flattened into dotted paths ordered by field name.
+func flattenValue(constraints []constraint, path string, fd protoreflect.FieldDescriptor, v protoreflect.Value) []constraint {
+	switch {
+	case fd.IsList():
+		list := v.List()
+		vals := make([]string, list.Len())
+		for i := range vals {
+			s, ok := scalar(fd, list.Get(i))
+			if !ok {
+				// e. g. the repeated CEL expressions
+				return append(constraints, constraint{path: path})
+			}
+			vals[i] = s
+		}
+		return append(constraints, constraint{path: path, value: vals})
+	case fd.Message() != nil && !fd.IsMap():
+		var fds []protoreflect.FieldDescriptor
+		m := v.Message()
+		m.Range(func(sub protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+			fds = append(fds, sub)
+			return true
+		})
+		slices.SortFunc(fds, func(a, b protoreflect.FieldDescriptor) int {
+			return strings.Compare(string(a.Name()), string(b.Name()))
+		})
+
+		for _, sub := range fds {
+			subPath := string(sub.Name())
+			if len(path) > 0 {
+				subPath = path + "." + subPath
+			}
+			constraints = flattenValue(constraints, subPath, sub, m.Get(sub))
+		}
+		return constraints
+	}
+
+	if s, ok := scalar(fd, v); ok {
+		return append(constraints, constraint{path: path, value: s})
+	}
+	return append(constraints, constraint{path: path})
+}
+
+// scalar returns the text of the scalar value v of the field fd, enums by value name as in the .proto source.
+func scalar(fd protoreflect.FieldDescriptor, v protoreflect.Value) (string, bool) {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return strconv.FormatBool(v.Bool()), true
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return string(ev.Name()), true
+		}
+		return strconv.Itoa(int(v.Enum())), true
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return strconv.FormatInt(v.Int(), 10), true
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return strconv.FormatUint(v.Uint(), 10), true
+	case protoreflect.FloatKind:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 32), true
+	case protoreflect.DoubleKind:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64), true
+	case protoreflect.StringKind:
+		return v.String(), true
+	}
+	return "", false
+}
+
+// goNames returns the Go names of the fields of the generated struct type typ keyed by their proto name,
+// the fields of oneofs keyed by the oneof name.
+func goNames(typ reflect.Type) map[string]string {
+	names := make(map[string]string, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if oneof, ok := f.Tag.Lookup("protobuf_oneof"); ok {
+			names[oneof] = f.Name
+			continue
+		}
+
+		for _, opt := range strings.Split(f.Tag.Get("protobuf"), ",") {
+			if name, ok := strings.CutPrefix(opt, "name="); ok {
+				names[name] = f.Name
+			}
+		}
+	}
+	return names
+}
+
+// oneofWrapper returns the Go struct type wrapping the field fd of the oneof field of the generated message m.
+func oneofWrapper(m proto.Message, fd protoreflect.FieldDescriptor, field string) reflect.Type {
+	x := m.ProtoReflect().New()
+	x.Set(fd, x.NewField(fd))
+	return reflect.ValueOf(x.Interface()).Elem().FieldByName(field).Elem().Type().Elem()
+}
@@ -0,0 +1,80 @@
+package protorules
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pchchv/go-assert"
+	"github.com/pchchv/validator"
+	"github.com/pchchv/validator/protorules/internal/testpb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestRules(t *testing.T) {
+	rules, skipped := Rules(&testpb.User{})
+	assert.Equal(t, map[reflect.Type]map[string]string{
+		reflect.TypeOf(testpb.User{}): {
+			"UserId":  "uuid",
+			"Email":   "required,email",
+			"Name":    "max=50,min=2",
+			"Age":     "gte=18,lt=150",
+			"Tags":    "max=3,unique,dive,min=1",
+			"Role":    "omitempty,oneof=admin",
+			"Address": "required",
+			"Labels":  "max=2",
+			"Website": "omitempty,uri",
+		},
+		reflect.TypeOf(testpb.User_Address{}): {
+			"City": "omitempty,min=1",
+		},
+		reflect.TypeOf(testpb.User_Phone{}): {
+			"Phone": "startswith=+",
+		},
+		reflect.TypeOf(testpb.User_Fax{}): {
+			"Fax": "startswith=+",
+		},
+	}, rules)
+	assert.Equal(t, []string{"users.v1.User.name: string.pattern", "users.v1.User.score: double.in"}, skipped)
+
+	// a message without constraints
+	rules, skipped = Rules(&structpb.Value{})
+	assert.Equal(t, 0, len(rules))
+	assert.Equal(t, 0, len(skipped))
+}
+
+func TestRegister(t *testing.T) {
+	v := validator.New()
+	assert.Equal(t, []string{"users.v1.User.name: string.pattern", "users.v1.User.score: double.in"}, Register(v, &testpb.User{}))
+
+	valid := &testpb.User{
+		UserId:  "a987fbc9-4bed-4078-8f07-9141ba07c9f3",
+		Email:   "joey@example.com",
+		Name:    "joey",
+		Age:     30,
+		Tags:    []string{"a", "b"},
+		Address: &testpb.User_Address{},
+		Contact: &testpb.User_Phone{Phone: "+49"},
+	}
+	assert.Equal(t, nil, v.Struct(valid))
+
+	// the unset optional field and oneof
+	valid.Contact = nil
+	assert.Equal(t, nil, v.Struct(valid))
+
+	invalid := proto.Clone(valid).(*testpb.User)
+	invalid.Age = 12
+	invalid.Website = proto.String("not a uri")
+	invalid.Contact = &testpb.User_Fax{Fax: "49"}
+	invalid.Address = nil
+	err := v.Struct(invalid)
+	assert.NotEqual(t, nil, err)
+
+	errs := err.(validator.ValidationErrors)
+	assert.Equal(t, 4, len(errs))
+	assert.Equal(t, "User.Age", errs[0].Namespace())
+	assert.Equal(t, "User.Address", errs[1].Namespace())
+	assert.Equal(t, "User.Website", errs[2].Namespace())
+	assert.Equal(t, "User.Contact.Fax", errs[3].Namespace())
+	assert.Equal(t, "startswith", errs[3].Tag())
+}
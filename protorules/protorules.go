@@ -0,0 +1,619 @@
+// Package protorules converts the protovalidate `(buf.validate.field)` and
+// PGV `(validate.rules)` field constraints of the messages generated by protoc-gen-go into rules of this package,
+// allowing services serving both REST and gRPC to rely on one validation engine and one error format, e. g.
+//
+//	skipped := protorules.Register(validate, &pb.User{})
+//
+// registers the rules of User, of the messages of its fields and of the wrappers of its oneofs.
+// The constraints are read from the descriptors of the generated types,
+// the Go packages of the constraint extensions being linked by the generated code.
+//
+// The constraints of .proto sources can be converted as well, e. g.
+//
+//	rules, skipped, err := protorules.Parse(src)
+//	// ...
+//	validate.RegisterStructValidationMapRules(rules["User"], &pb.User{})
+//	validate.RegisterStructValidationMapRules(rules["User_Phone"], &pb.User_Phone{})
+//
+// Parse keys the rules by message name, nested messages as 'Outer.Inner',
+// the fields of a oneof by the Go name of their wrapper type, e. g. 'User_Phone',
+// and by the Go name of the field generated by protoc-gen-go.
+//
+// The constraints of proto3 and proto2 optional fields, generated as pointers,
+// are prefixed with omitempty, applying when the field is set.
+//
+// The supported constraints are required, ignore (as omitempty), message.required,
+// string min_len, max_len, len, const, in, prefix, suffix, contains, not_contains,
+// email, uri, uuid, ip, ipv4, ipv6 and hostname,
+// bytes min_len, max_len and len, numeric const, in, gt, gte, lt and lte, bool const, enum const and in,
+// repeated min_items, max_items, unique and items, and map min_pairs and max_pairs.
+// Other constraints can't be enforced by the rules,
+// they are returned as skipped, e. g. "User.name: string.pattern".
+package protorules
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// optionNames are the field option names holding the constraints.
+var optionNames = map[string]struct{}{
+	"(buf.validate.field)": {},
+	"(validate.rules)":     {},
+}
+
+// stringTags are the tags of the string constraints.
+var stringTags = map[string]string{
+	"min_len":      "min",
+	"max_len":      "max",
+	"len":          "len",
+	"const":        "eq",
+	"prefix":       "startswith",
+	"suffix":       "endswith",
+	"contains":     "contains",
+	"not_contains": "excludes",
+	"in":           "oneof",
+}
+
+// stringFormatTags are the tags of the string format constraints.
+var stringFormatTags = map[string]string{
+	"email":    "email",
+	"uri":      "uri",
+	"uuid":     "uuid",
+	"ip":       "ip",
+	"ipv4":     "ipv4",
+	"ipv6":     "ipv6",
+	"hostname": "hostname_rfc1123",
+}
+
+// numericTags are the tags of the numeric constraints.
+var numericTags = map[string]string{
+	"const": "eq",
+	"gt":    "gt",
+	"gte":   "gte",
+	"lt":    "lt",
+	"lte":   "lte",
+	"in":    "oneof",
+}
+
+// numericTypes are the numeric constraint types, mapped to whether they are integers.
+var numericTypes = map[string]bool{
+	"int32": true, "int64": true, "uint32": true, "uint64": true,
+	"sint32": true, "sint64": true, "fixed32": true, "fixed64": true,
+	"sfixed32": true, "sfixed64": true, "float": false, "double": false,
+}
+
+// constraint is a single constraint of a field, e. g. path 'string.min_len' and value '1'.
+type constraint struct {
+	path  string
+	value interface{} // string or []string
+}
+
+// Parse returns the rules of the messages of the .proto source src,
+// and the constraints that were skipped.
+func Parse(src []byte) (map[string]map[string]string, []string, error) {
+	p := &parser{toks: tokenize(string(src)), builder: builder{rules: make(map[string]map[string]string)}}
+	if err := p.parseFile(); err != nil {
+		return nil, nil, err
+	}
+
+	slices.Sort(p.skipped)
+	return p.rules, p.skipped, nil
+}
+
+// parser parses the messages of a .proto source.
+type parser struct {
+	builder
+	toks []token
+	pos  int
+}
+
+// builder records the rules of the fields and the constraints that were skipped.
+type builder struct {
+	rules   map[string]map[string]string
+	skipped []string
+}
+
+// add records the rules of the constraints of field, e. g. 'User.name',
+// keyed by the key of its Go type and by its Go name.
+func (b *builder) add(key, goName, field string, constraints []constraint, optional bool) {
+	if len(constraints) == 0 {
+		return
+	}
+
+	if tag := b.fieldTag(field, constraints, optional); len(tag) > 0 {
+		if b.rules[key] == nil {
+			b.rules[key] = make(map[string]string)
+		}
+		b.rules[key][goName] = tag
+	}
+}
+
+func (p *parser) parseFile() error {
+	for !p.eof() {
+		switch p.next().text {
+		case "message":
+			if err := p.parseMessage(p.next().text); err != nil {
+				return err
+			}
+		case "enum", "service", "extend":
+			if err := p.skipBlock(); err != nil {
+				return err
+			}
+		case "syntax", "edition", "package", "import", "option":
+			p.skipStatement()
+		}
+	}
+	return nil
+}
+
+func (p *parser) parseMessage(name string) error {
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+
+	for {
+		if p.eof() {
+			return fmt.Errorf("protorules: unterminated message '%s'", name)
+		}
+
+		switch tok := p.peek(); tok.text {
+		case "}":
+			p.next()
+			return nil
+		case ";":
+			p.next()
+		case "message":
+			p.next()
+			if err := p.parseMessage(name + "." + p.next().text); err != nil {
+				return err
+			}
+		case "enum", "extend":
+			p.next()
+			if err := p.skipBlock(); err != nil {
+				return err
+			}
+		case "reserved", "extensions", "option":
+			p.skipStatement()
+		case "oneof":
+			// the fields of a oneof are the fields of their wrapper types
+			p.next()
+			p.next()
+			if err := p.expect("{"); err != nil {
+				return err
+			}
+
+			for !p.eof() && p.peek().text != "}" {
+				if p.peek().text == "option" || p.peek().text == ";" {
+					p.skipStatement()
+					continue
+				}
+
+				if err := p.parseField(name, true); err != nil {
+					return err
+				}
+			}
+			p.next()
+		default:
+			if err := p.parseField(name, false); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// parseField parses a field and records its rules, e. g.
+// 'repeated string tags = 3 [(buf.validate.field).repeated.max_items = 5];'.
+func (p *parser) parseField(message string, oneof bool) error {
+	var optional bool
+	if t := p.peek().text; t == "repeated" || t == "optional" || t == "required" {
+		optional = t == "optional"
+		p.next()
+	}
+
+	if p.next().text == "map" {
+		for !p.eof() && p.next().text != ">" {
+		}
+	}
+
+	name := p.next().text
+	if err := p.expect("="); err != nil {
+		return err
+	}
+	p.next()
+
+	var constraints []constraint
+	if p.peek().text == "[" {
+		p.next()
+		for {
+			optName := ""
+			for !p.eof() && p.peek().text != "=" {
+				optName += p.next().text
+			}
+
+			if err := p.expect("="); err != nil {
+				return err
+			}
+
+			value, err := p.parseValue()
+			if err != nil {
+				return err
+			}
+
+			for opt := range optionNames {
+				if rest, ok := strings.CutPrefix(optName, opt); ok {
+					constraints = flatten(constraints, strings.TrimPrefix(rest, "."), value)
+				}
+			}
+
+			if p.peek().text != "," {
+				break
+			}
+			p.next()
+		}
+
+		if err := p.expect("]"); err != nil {
+			return err
+		}
+	}
+
+	if err := p.expect(";"); err != nil {
+		return err
+	}
+
+	key := message
+	if oneof {
+		key = goMessageName(message) + "_" + goCamelCase(name)
+	}
+	p.add(key, goCamelCase(name), message+"."+name, constraints, optional)
+	return nil
+}
+
+// parseValue parses an option value, a scalar, a list or a message literal.
+func (p *parser) parseValue() (interface{}, error) {
+	tok := p.next()
+	switch tok.text {
+	case "{":
+		return p.parseMessageLiteral()
+	case "[":
+		var list []string
+		for !p.eof() && p.peek().text != "]" {
+			if p.peek().text == "," {
+				p.next()
+				continue
+			}
+			list = append(list, p.next().text)
+		}
+		return list, p.expect("]")
+	}
+
+	return tok.text, nil
+}
+
+// parseMessageLiteral parses the fields of a text format message literal, the '{' being consumed.
+func (p *parser) parseMessageLiteral() (map[string]interface{}, error) {
+	m := make(map[string]interface{})
+	for {
+		if p.eof() {
+			return nil, fmt.Errorf("protorules: unterminated message literal")
+		}
+
+		key := p.next().text
+		switch key {
+		case "}":
+			return m, nil
+		case ",", ";":
+			continue
+		}
+
+		if p.peek().text == ":" {
+			p.next()
+		}
+
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		m[key] = value
+	}
+}
+
+// fieldTag returns the tag of the constraints of a field, recording the skipped constraints,
+// the tag of an optional field applying when it is set.
+func (b *builder) fieldTag(field string, constraints []constraint, optional bool) string {
+	var pre, tags, items []string
+	for _, c := range constraints {
+		path := c.path
+		target := &tags
+		if rest, ok := strings.CutPrefix(path, "repeated.items."); ok {
+			path, target = rest, &items
+		}
+
+		switch {
+		case path == "required" || path == "message.required":
+			if c.value == "true" && target == &tags {
+				pre = append(pre, "required")
+				continue
+			}
+		case path == "ignore" || strings.HasSuffix(path, ".ignore_empty"):
+			if target == &tags && (c.value == "true" || c.value == "IGNORE_IF_UNPOPULATED" ||
+				c.value == "IGNORE_IF_ZERO_VALUE" || c.value == "IGNORE_EMPTY") {
+				pre = append(pre, "omitempty")
+				continue
+			}
+		default:
+			if tag, ok := constraintTag(path, c.value); ok {
+				*target = append(*target, tag)
+				continue
+			}
+		}
+		b.skipped = append(b.skipped, field+": "+c.path)
+	}
+
+	if len(items) > 0 {
+		tags = append(append(tags, "dive"), items...)
+	}
+
+	if optional && len(tags) > 0 && !slices.Contains(pre, "required") && !slices.Contains(pre, "omitempty") {
+		pre = append(pre, "omitempty")
+	}
+	return strings.Join(append(pre, tags...), ",")
+}
+
+// constraintTag returns the tag of a type constraint, e. g. 'string.min_len' with value '1' returns 'min=1'.
+func constraintTag(path string, value interface{}) (string, bool) {
+	typ, rule, ok := strings.Cut(path, ".")
+	if !ok {
+		return "", false
+	}
+
+	var tag string
+	switch typ {
+	case "string":
+		if t, ok := stringFormatTags[rule]; ok {
+			return t, value == "true"
+		}
+		tag, ok = stringTags[rule]
+	case "bytes":
+		if rule == "min_len" || rule == "max_len" || rule == "len" {
+			tag, ok = stringTags[rule], true
+		}
+	case "bool":
+		tag, ok = "eq", rule == "const"
+	case "enum":
+		tag, ok = numericTags[rule]
+		ok = ok && (rule == "const" || rule == "in")
+	case "repeated":
+		switch rule {
+		case "min_items":
+			tag, ok = "min", true
+		case "max_items":
+			tag, ok = "max", true
+		case "unique":
+			return "unique", value == "true"
+		}
+	case "map":
+		switch rule {
+		case "min_pairs":
+			tag, ok = "min", true
+		case "max_pairs":
+			tag, ok = "max", true
+		}
+	default:
+		integer, numeric := numericTypes[typ]
+		tag, ok = numericTags[rule]
+		// oneof supports integers only
+		ok = ok && numeric && (integer || rule != "in")
+	}
+
+	if !ok {
+		return "", false
+	}
+
+	param, ok := tagParam(value)
+	return tag + "=" + param, ok
+}
+
+// tagParam returns the tag param of a constraint value, quoting list values containing spaces.
+func tagParam(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, len(v) > 0 && !strings.ContainsAny(v, ",|")
+	case []string:
+		vals := make([]string, len(v))
+		for i, s := range v {
+			if len(s) == 0 || strings.ContainsAny(s, ",|'") {
+				return "", false
+			}
+
+			if strings.ContainsAny(s, " \t") {
+				s = "'" + s + "'"
+			}
+			vals[i] = s
+		}
+		return strings.Join(vals, " "), len(vals) > 0
+	}
+	return "", false
+}
+
+// flatten appends the constraints of an option value at path, message literals being flattened into dotted paths.
+func flatten(constraints []constraint, path string, value interface{}) []constraint {
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return append(constraints, constraint{path: path, value: value})
+	}
+
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		sub := k
+		if len(path) > 0 {
+			sub = path + "." + k
+		}
+		constraints = flatten(constraints, sub, m[k])
+	}
+	return constraints
+}
+
+// goCamelCase returns the Go name of a field generated by protoc-gen-go, e. g. 'user_id' returns 'UserId'.
+func goCamelCase(s string) string {
+	b := make([]byte, 0, len(s)+1)
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '_' && i == 0:
+			// an initial '_' is converted to ensure the name starts with a capital letter
+			b = append(b, 'X')
+		case c == '_' && i+1 < len(s) && isLower(s[i+1]):
+			// the '_' of '_{{lowercase}}' is skipped
+		case c >= '0' && c <= '9':
+			b = append(b, c)
+		default:
+			if isLower(c) {
+				c -= 'a' - 'A'
+			}
+			b = append(b, c)
+			for ; i+1 < len(s) && isLower(s[i+1]); i++ {
+				b = append(b, s[i+1])
+			}
+		}
+	}
+	return string(b)
+}
+
+// goMessageName returns the Go name of a message generated by protoc-gen-go, e. g. 'User.Address' returns 'User_Address'.
+func goMessageName(message string) string {
+	names := strings.Split(message, ".")
+	for i, name := range names {
+		names[i] = goCamelCase(name)
+	}
+	return strings.Join(names, "_")
+}
+
+func isLower(c byte) bool {
+	return c >= 'a' && c <= 'z'
+}
+
+func (p *parser) skipStatement() {
+	for !p.eof() && p.next().text != ";" {
+	}
+}
+
+// skipBlock skips a named block, e. g. 'enum Status { ... }', the keyword being consumed.
+func (p *parser) skipBlock() error {
+	for !p.eof() && p.peek().text != "{" {
+		p.next()
+	}
+
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+
+	for depth := 1; depth > 0; {
+		if p.eof() {
+			return fmt.Errorf("protorules: unterminated block")
+		}
+
+		switch p.next().text {
+		case "{":
+			depth++
+		case "}":
+			depth--
+		}
+	}
+	return nil
+}
+
+func (p *parser) expect(text string) error {
+	if tok := p.next(); tok.text != text || tok.str {
+		return fmt.Errorf("protorules: expected '%s' at line %d, found '%s'", text, tok.line, tok.text)
+	}
+	return nil
+}
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.toks)
+}
+
+func (p *parser) peek() token {
+	if p.eof() {
+		return token{}
+	}
+	return p.toks[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.peek()
+	p.pos++
+	return tok
+}
+
+// token is a token of a .proto source.
+type token struct {
+	text string
+	str  bool // text is the value of a string literal
+	line int
+}
+
+// tokenize splits a .proto source into tokens, skipping comments.
+func tokenize(src string) []token {
+	var toks []token
+	line := 1
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == '\n':
+			line++
+			i++
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		case strings.HasPrefix(src[i:], "//"):
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end == -1 {
+				end = len(src) - i - 4
+			}
+			line += strings.Count(src[i:i+end+4], "\n")
+			i += end + 4
+		case c == '"' || c == '\'':
+			var b strings.Builder
+			j := i + 1
+			for ; j < len(src) && src[j] != c; j++ {
+				if src[j] == '\\' && j+1 < len(src) {
+					j++
+				}
+				b.WriteByte(src[j])
+			}
+
+			// adjacent string literals are concatenated
+			if n := len(toks); n > 0 && toks[n-1].str {
+				toks[n-1].text += b.String()
+			} else {
+				toks = append(toks, token{text: b.String(), str: true, line: line})
+			}
+			i = j + 1
+		case isWordChar(c):
+			j := i
+			for j < len(src) && isWordChar(src[j]) {
+				j++
+			}
+			toks = append(toks, token{text: src[i:j], line: line})
+			i = j
+		default:
+			toks = append(toks, token{text: string(c), line: line})
+			i++
+		}
+	}
+	return toks
+}
+
+func isWordChar(c byte) bool {
+	return c == '_' || c == '.' || c == '-' || c == '+' ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,154 @@
+package protorules
+
+import (
+	"testing"
+
+	"github.com/pchchv/go-assert"
+	"github.com/pchchv/validator"
+)
+
+const protoSource = `
+syntax = "proto3";
+
+package users.v1;
+
+import "buf/validate/validate.proto";
+
+/* a user
+   of the service */
+message User {
+  string user_id = 1 [(buf.validate.field).string.uuid = true];
+  string email = 2 [(buf.validate.field).required = true, (buf.validate.field).string.email = true];
+  string name = 3 [(buf.validate.field).string = {min_len: 2, max_len: 50, pattern: "^[a-z]+$"}];
+  uint32 age = 4 [(buf.validate.field).uint32 = {gte: 18, lt: 150}];
+  repeated string tags = 5 [(buf.validate.field).repeated = {
+    max_items: 3
+    unique: true
+    items: {string: {min_len: 1}}
+  }];
+  string role = 6 [(buf.validate.field).string.in = "admin", (buf.validate.field).ignore = IGNORE_IF_UNPOPULATED];
+  Address address = 7 [(validate.rules).message.required = true];
+  map<string, string> labels = 8 [(buf.validate.field).map.max_pairs = 2];
+  double score = 9 [(buf.validate.field).double.in = 1.5];
+  string nickname = 10; // unconstrained
+  optional string website = 12 [(buf.validate.field).string.uri = true];
+
+  message Address {
+    reserved 2;
+    option deprecated = true;
+    string city = 1 [(validate.rules).string = {min_len: 1, ignore_empty: true}];
+  }
+
+  enum Status {
+    STATUS_UNSPECIFIED = 0;
+  }
+
+  oneof contact {
+    string phone = 11 [(buf.validate.field).string.prefix = "+"];
+  }
+}
+
+service Users {
+  rpc Get(User) returns (User);
+}
+`
+
+type User struct {
+	UserId   string
+	Email    string
+	Name     string
+	Age      uint32
+	Tags     []string
+	Role     string
+	Address  *User_Address
+	Labels   map[string]string
+	Score    float64
+	Nickname string
+	Website  *string
+	Contact  isUser_Contact
+}
+
+type isUser_Contact interface {
+	isUser_Contact()
+}
+
+type User_Phone struct {
+	Phone string
+}
+
+func (*User_Phone) isUser_Contact() {}
+
+type User_Address struct {
+	City string
+}
+
+func TestParse(t *testing.T) {
+	rules, skipped, err := Parse([]byte(protoSource))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, map[string]map[string]string{
+		"User": {
+			"UserId":  "uuid",
+			"Email":   "required,email",
+			"Name":    "max=50,min=2",
+			"Age":     "gte=18,lt=150",
+			"Tags":    "max=3,unique,dive,min=1",
+			"Role":    "omitempty,oneof=admin",
+			"Address": "required",
+			"Labels":  "max=2",
+			"Website": "omitempty,uri",
+		},
+		"User_Phone": {
+			"Phone": "startswith=+",
+		},
+		"User.Address": {
+			"City": "omitempty,min=1",
+		},
+	}, rules)
+	assert.Equal(t, []string{"User.name: string.pattern", "User.score: double.in"}, skipped)
+
+	v := validator.New()
+	v.RegisterStructValidationMapRules(rules["User"], User{})
+	v.RegisterStructValidationMapRules(rules["User.Address"], User_Address{})
+	v.RegisterStructValidationMapRules(rules["User_Phone"], User_Phone{})
+	valid := User{
+		UserId:  "a987fbc9-4bed-4078-8f07-9141ba07c9f3",
+		Email:   "joey@example.com",
+		Name:    "joey",
+		Age:     30,
+		Tags:    []string{"a", "b"},
+		Address: &User_Address{},
+		Contact: &User_Phone{Phone: "+49"},
+	}
+	assert.Equal(t, nil, v.Struct(valid))
+
+	invalid := valid
+	invalid.Age = 12
+	invalid.Tags = []string{"a", ""}
+	invalid.Role = "owner"
+	invalid.Address = nil
+	invalid.Website = new(string)
+	invalid.Contact = &User_Phone{Phone: "49"}
+	err = v.Struct(invalid)
+	assert.NotEqual(t, nil, err)
+	assert.Equal(t, 6, len(err.(validator.ValidationErrors)))
+
+	_, _, err = Parse([]byte(`message User { string name = 1 [(buf.validate.field).string.min_len = 1; }`))
+	assert.NotEqual(t, nil, err)
+	_, _, err = Parse([]byte(`message User { string name = 1;`))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestGoCamelCase(t *testing.T) {
+	tests := map[string]string{
+		"user_id":   "UserId",
+		"name":      "Name",
+		"_private":  "XPrivate",
+		"field_2b":  "Field_2B",
+		"ipv4_addr": "Ipv4Addr",
+		"HTTPCode":  "HTTPCode",
+	}
+
+	for in, out := range tests {
+		assert.Equal(t, out, goCamelCase(in))
+	}
+}
@@ -0,0 +1,80 @@
+package validator
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const pwnedTag = "pwned"
+
+// PwnedFunc reports whether password is known to be breached,
+// e. g. by querying a k-anonymity range API or a local bloom filter of breached passwords.
+// It returns an error when the lookup could not be completed.
+type PwnedFunc func(ctx context.Context, password string) (bool, error)
+
+// PwnedRangeFunc returns the range of breached password hashes starting with the
+// 5 characters uppercase hex prefix of their SHA-1, in the format of the
+// Have I Been Pwned range API, one 'SUFFIX:COUNT' line per hash, e. g. by requesting
+// 'https://api.pwnedpasswords.com/range/' + prefix.
+type PwnedRangeFunc func(ctx context.Context, prefix string) ([]byte, error)
+
+// PwnedRange returns the PwnedFunc looking up passwords using the k-anonymity range query fn,
+// only the first 5 characters of a password's SHA-1 leave the process.
+func PwnedRange(fn PwnedRangeFunc) PwnedFunc {
+	return func(ctx context.Context, password string) (bool, error) {
+		sum := sha1.Sum([]byte(password))
+		hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+		body, err := fn(ctx, hash[:5])
+		if err != nil {
+			return false, err
+		}
+
+		sc := bufio.NewScanner(bytes.NewReader(body))
+		for sc.Scan() {
+			suffix, count, _ := strings.Cut(strings.TrimSpace(sc.Text()), ":")
+			// padded ranges hold fake suffixes with a zero count
+			if strings.EqualFold(suffix, hash[5:]) && count != "0" {
+				return true, nil
+			}
+		}
+		return false, sc.Err()
+	}
+}
+
+// RegisterPwnedCheck adds the pwned tag validating that a string field isn't a breached password,
+// looked up using fn with the validation context, e. g.
+//
+//	validate.RegisterPwnedCheck(validator.PwnedRange(queryRange), validator.BreakerConfig{MaxFailures: 5, OpenDuration: time.Minute})
+//
+// and `validate:"required,min=12,pwned"`.
+// The lookups are rate limited and short-circuited as configured by cfg,
+// whose FailOpen decides whether passwords pass when the lookup fails, see RegisterValidationWithBreaker,
+// and WithTimeoutPolicy applies to the tag when the lookup honours the context.
+// Empty values pass, use required to reject them.
+//
+// NOTE: this method is not thread-safe it is intended that these all be registered prior to any validation.
+func (v *Validate) RegisterPwnedCheck(fn PwnedFunc, cfg BreakerConfig) {
+	if fn == nil {
+		panic("pwned lookup function cannot be nil")
+	}
+
+	_ = v.RegisterValidationWithBreaker(pwnedTag, func(ctx context.Context, fl FieldLevel) (bool, error) {
+		field := fl.Field()
+		if field.Kind() != reflect.String {
+			panic(fmt.Sprintf("Bad field type %T", field.Interface()))
+		}
+
+		if field.Len() == 0 {
+			return true, nil
+		}
+
+		pwned, err := fn(ctx, field.String())
+		return !pwned, err
+	}, cfg)
+}
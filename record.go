@@ -0,0 +1,143 @@
+package validator
+
+import (
+	"context"
+	"strings"
+)
+
+const (
+	DecisionPassed Decision = iota
+	DecisionFailed
+	DecisionSkippedOmitEmpty
+	DecisionSkippedOmitZero
+	DecisionSkippedOmitNil
+	DecisionSkippedSampled
+	DecisionOrBranchTaken
+	DecisionOrBranchFailed
+)
+
+var decisionNames = [...]string{
+	DecisionPassed:           "passed",
+	DecisionFailed:           "failed",
+	DecisionSkippedOmitEmpty: "skipped by omitempty",
+	DecisionSkippedOmitZero:  "skipped by omitzero",
+	DecisionSkippedOmitNil:   "skipped by omitnil",
+	DecisionSkippedSampled:   "skipped by sampling",
+	DecisionOrBranchTaken:    "or branch taken",
+	DecisionOrBranchFailed:   "or branch failed",
+}
+
+// Decision describes the outcome of evaluating a single rule.
+type Decision uint8
+
+// String returns the Decision's description.
+func (d Decision) String() string {
+	if int(d) < len(decisionNames) {
+		return decisionNames[d]
+	}
+
+	return "unknown"
+}
+
+// RecordedStep is a single rule evaluation recorded during a validation call.
+type RecordedStep struct {
+	// Namespace is the namespace of the field the rule was evaluated on,
+	// with the tag name taking precedence over the field's actual name.
+	Namespace string
+	// Tag is the evaluated tag, or the omitempty, omitzero and omitnil tags
+	// for the short-circuit decisions.
+	Tag string
+	// Param is the parameter of the evaluated tag.
+	Param string
+	// Decision is the outcome of the evaluation.
+	Decision Decision
+}
+
+// Recording holds the sequence of evaluated rules and short-circuit decisions of a validation call,
+// to help debug why a value passed or failed validation.
+//
+// NOTE: a Recording is not safe for concurrent validation calls.
+type Recording struct {
+	Steps []RecordedStep
+}
+
+// NewRecordingContext returns a context that records the evaluated rules and
+// short-circuit decisions of the validation calls it's passed to, e. g.
+//
+//	ctx, rec := validator.NewRecordingContext(ctx)
+//	err := validate.StructCtx(ctx, user)
+//	log.Println(rec)
+func NewRecordingContext(parent context.Context) (context.Context, *Recording) {
+	rec := new(Recording)
+	return withCallState(parent, func(cs *callState) { cs.rec = rec }), rec
+}
+
+// RecordingFromContext returns the Recording of the context, if any.
+func RecordingFromContext(ctx context.Context) (*Recording, bool) {
+	cs, _ := ctx.Value(callStateKey{}).(*callState)
+	if cs == nil || cs.rec == nil {
+		return nil, false
+	}
+	return cs.rec, true
+}
+
+// String returns one line per recorded step.
+func (r *Recording) String() string {
+	var b strings.Builder
+	for i, step := range r.Steps {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+
+		b.WriteString(step.Namespace)
+		b.WriteByte(' ')
+		b.WriteString(step.Tag)
+		if len(step.Param) > 0 {
+			b.WriteByte('=')
+			b.WriteString(step.Param)
+		}
+
+		b.WriteString(": ")
+		b.WriteString(step.Decision.String())
+	}
+
+	return b.String()
+}
+
+// StructTrace validates s as Struct does, returning the Recording of the evaluated rules
+// and short-circuit decisions along with the validation errors, e. g.
+//
+//	rec, err := validate.StructTrace(user)
+//	fmt.Println(rec)
+func (v *Validate) StructTrace(s interface{}) (*Recording, error) {
+	ctx, rec := NewRecordingContext(context.Background())
+	return rec, v.StructCtx(ctx, s)
+}
+
+// tracing reports whether the steps of the current validation call are recorded or traced.
+func (v *validate) tracing() bool {
+	return v.rec != nil || v.v.tracer != nil
+}
+
+// record appends a step to the Recording of the current validation call, if any,
+// and passes it to the tracer, see WithTracer.
+func (v *validate) record(ns []byte, cf *cField, tag, param string, d Decision) {
+	if !v.tracing() {
+		return
+	}
+
+	step := RecordedStep{
+		Namespace: string(append(ns, cf.altName...)),
+		Tag:       tag,
+		Param:     param,
+		Decision:  d,
+	}
+
+	if v.rec != nil {
+		v.rec.Steps = append(v.rec.Steps, step)
+	}
+
+	if v.v.tracer != nil {
+		v.v.tracer(step)
+	}
+}
@@ -78,88 +78,165 @@ const (
 	spicedbPermissionRegexString     = "^([a-z][a-z0-9_]{1,62}[a-z0-9])?$"
 	spicedbTypeRegexString           = "^([a-z][a-z0-9_]{1,61}[a-z0-9]/)?[a-z][a-z0-9_]{1,62}[a-z0-9]$"
 	einRegexString                   = "^(\\d{2}-\\d{7})$"
+	oAuthScopesRegexString           = `^[\x21\x23-\x5B\x5D-\x7E]+(?: [\x21\x23-\x5B\x5D-\x7E]+)*$` // space-delimited scope tokens https://www.rfc-editor.org/rfc/rfc6749#section-3.3
+	bearerTokenRegexString           = `^(?:[Bb][Ee][Aa][Rr][Ee][Rr] )?[A-Za-z0-9\-._~+/]+=*$`      // b64token, optionally prefixed by the scheme https://www.rfc-editor.org/rfc/rfc6750#section-2.1
 )
 
 var (
-	alphaRegex                 = lazyRegexCompile(alphaRegexString)
-	alphaNumericRegex          = lazyRegexCompile(alphaNumericRegexString)
-	alphaUnicodeRegex          = lazyRegexCompile(alphaUnicodeRegexString)
-	alphaUnicodeNumericRegex   = lazyRegexCompile(alphaUnicodeNumericRegexString)
-	numericRegex               = lazyRegexCompile(numericRegexString)
-	numberRegex                = lazyRegexCompile(numberRegexString)
-	hexadecimalRegex           = lazyRegexCompile(hexadecimalRegexString)
-	hexColorRegex              = lazyRegexCompile(hexColorRegexString)
-	rgbRegex                   = lazyRegexCompile(rgbRegexString)
-	rgbaRegex                  = lazyRegexCompile(rgbaRegexString)
-	hslRegex                   = lazyRegexCompile(hslRegexString)
-	hslaRegex                  = lazyRegexCompile(hslaRegexString)
-	e164Regex                  = lazyRegexCompile(e164RegexString)
-	emailRegex                 = lazyRegexCompile(emailRegexString)
-	base32Regex                = lazyRegexCompile(base32RegexString)
-	base64Regex                = lazyRegexCompile(base64RegexString)
-	base64URLRegex             = lazyRegexCompile(base64URLRegexString)
-	base64RawURLRegex          = lazyRegexCompile(base64RawURLRegexString)
-	iSBN10Regex                = lazyRegexCompile(iSBN10RegexString)
-	iSBN13Regex                = lazyRegexCompile(iSBN13RegexString)
-	iSSNRegex                  = lazyRegexCompile(iSSNRegexString)
-	uUID3Regex                 = lazyRegexCompile(uUID3RegexString)
-	uUID4Regex                 = lazyRegexCompile(uUID4RegexString)
-	uUID5Regex                 = lazyRegexCompile(uUID5RegexString)
-	uUIDRegex                  = lazyRegexCompile(uUIDRegexString)
-	uUID3RFC4122Regex          = lazyRegexCompile(uUID3RFC4122RegexString)
-	uUID4RFC4122Regex          = lazyRegexCompile(uUID4RFC4122RegexString)
-	uUID5RFC4122Regex          = lazyRegexCompile(uUID5RFC4122RegexString)
-	uUIDRFC4122Regex           = lazyRegexCompile(uUIDRFC4122RegexString)
-	uLIDRegex                  = lazyRegexCompile(uLIDRegexString)
-	md4Regex                   = lazyRegexCompile(md4RegexString)
-	md5Regex                   = lazyRegexCompile(md5RegexString)
-	sha256Regex                = lazyRegexCompile(sha256RegexString)
-	sha384Regex                = lazyRegexCompile(sha384RegexString)
-	sha512Regex                = lazyRegexCompile(sha512RegexString)
-	ripemd128Regex             = lazyRegexCompile(ripemd128RegexString)
-	ripemd160Regex             = lazyRegexCompile(ripemd160RegexString)
-	tiger128Regex              = lazyRegexCompile(tiger128RegexString)
-	tiger160Regex              = lazyRegexCompile(tiger160RegexString)
-	tiger192Regex              = lazyRegexCompile(tiger192RegexString)
-	aSCIIRegex                 = lazyRegexCompile(aSCIIRegexString)
-	printableASCIIRegex        = lazyRegexCompile(printableASCIIRegexString)
-	multibyteRegex             = lazyRegexCompile(multibyteRegexString)
-	dataURIRegex               = lazyRegexCompile(dataURIRegexString)
-	latitudeRegex              = lazyRegexCompile(latitudeRegexString)
-	longitudeRegex             = lazyRegexCompile(longitudeRegexString)
-	sSNRegex                   = lazyRegexCompile(sSNRegexString)
-	hostnameRegexRFC952        = lazyRegexCompile(hostnameRegexStringRFC952)
-	hostnameRegexRFC1123       = lazyRegexCompile(hostnameRegexStringRFC1123)
-	fqdnRegexRFC1123           = lazyRegexCompile(fqdnRegexStringRFC1123)
-	btcAddressRegex            = lazyRegexCompile(btcAddressRegexString)
-	btcUpperAddressRegexBech32 = lazyRegexCompile(btcAddressUpperRegexStringBech32)
-	btcLowerAddressRegexBech32 = lazyRegexCompile(btcAddressLowerRegexStringBech32)
-	ethAddressRegex            = lazyRegexCompile(ethAddressRegexString)
-	uRLEncodedRegex            = lazyRegexCompile(uRLEncodedRegexString)
-	hTMLEncodedRegex           = lazyRegexCompile(hTMLEncodedRegexString)
-	hTMLRegex                  = lazyRegexCompile(hTMLRegexString)
-	jWTRegex                   = lazyRegexCompile(jWTRegexString)
-	splitParamsRegex           = lazyRegexCompile(splitParamsRegexString)
-	bicRegex                   = lazyRegexCompile(bicRegexString)
-	semverRegex                = lazyRegexCompile(semverRegexString)
-	dnsRegexRFC1035Label       = lazyRegexCompile(dnsRegexStringRFC1035Label)
-	cveRegex                   = lazyRegexCompile(cveRegexString)
-	mongodbIdRegex             = lazyRegexCompile(mongodbIdRegexString)
-	mongodbConnectionRegex     = lazyRegexCompile(mongodbConnStringRegexString)
-	cronRegex                  = lazyRegexCompile(cronRegexString)
-	spicedbIDRegex             = lazyRegexCompile(spicedbIDRegexString)
-	spicedbPermissionRegex     = lazyRegexCompile(spicedbPermissionRegexString)
-	spicedbTypeRegex           = lazyRegexCompile(spicedbTypeRegexString)
-	einRegex                   = lazyRegexCompile(einRegexString)
+	alphaRegex                 = lazyRegexCompile(alphaRegexString, "alpha")
+	alphaNumericRegex          = lazyRegexCompile(alphaNumericRegexString, "alphanum")
+	alphaUnicodeRegex          = lazyRegexCompile(alphaUnicodeRegexString, "alphaunicode")
+	alphaUnicodeNumericRegex   = lazyRegexCompile(alphaUnicodeNumericRegexString, "alphanumunicode")
+	numericRegex               = lazyRegexCompile(numericRegexString, "numeric")
+	numberRegex                = lazyRegexCompile(numberRegexString, "number")
+	hexadecimalRegex           = lazyRegexCompile(hexadecimalRegexString, "hexadecimal")
+	hexColorRegex              = lazyRegexCompile(hexColorRegexString, "hexcolor")
+	rgbRegex                   = lazyRegexCompile(rgbRegexString, "rgb")
+	rgbaRegex                  = lazyRegexCompile(rgbaRegexString, "rgba")
+	hslRegex                   = lazyRegexCompile(hslRegexString, "hsl")
+	hslaRegex                  = lazyRegexCompile(hslaRegexString, "hsla")
+	e164Regex                  = lazyRegexCompile(e164RegexString, "e164")
+	emailRegex                 = lazyRegexCompile(emailRegexString, "email")
+	base32Regex                = lazyRegexCompile(base32RegexString, "base32")
+	base64Regex                = lazyRegexCompile(base64RegexString, "base64", "datauri")
+	base64URLRegex             = lazyRegexCompile(base64URLRegexString, "base64url")
+	base64RawURLRegex          = lazyRegexCompile(base64RawURLRegexString, "base64rawurl")
+	iSBN10Regex                = lazyRegexCompile(iSBN10RegexString, "isbn", "isbn10")
+	iSBN13Regex                = lazyRegexCompile(iSBN13RegexString, "isbn", "isbn13")
+	iSSNRegex                  = lazyRegexCompile(iSSNRegexString, "issn")
+	uUID3Regex                 = lazyRegexCompile(uUID3RegexString, "uuid3")
+	uUID4Regex                 = lazyRegexCompile(uUID4RegexString, "uuid4")
+	uUID5Regex                 = lazyRegexCompile(uUID5RegexString, "uuid5")
+	uUIDRegex                  = lazyRegexCompile(uUIDRegexString, "uuid")
+	uUID3RFC4122Regex          = lazyRegexCompile(uUID3RFC4122RegexString, "uuid3_rfc4122")
+	uUID4RFC4122Regex          = lazyRegexCompile(uUID4RFC4122RegexString, "uuid4_rfc4122")
+	uUID5RFC4122Regex          = lazyRegexCompile(uUID5RFC4122RegexString, "uuid5_rfc4122")
+	uUIDRFC4122Regex           = lazyRegexCompile(uUIDRFC4122RegexString, "uuid_rfc4122")
+	uLIDRegex                  = lazyRegexCompile(uLIDRegexString, "ulid")
+	md4Regex                   = lazyRegexCompile(md4RegexString, "md4")
+	md5Regex                   = lazyRegexCompile(md5RegexString, "md5")
+	sha256Regex                = lazyRegexCompile(sha256RegexString, "sha256")
+	sha384Regex                = lazyRegexCompile(sha384RegexString, "sha384")
+	sha512Regex                = lazyRegexCompile(sha512RegexString, "sha512")
+	ripemd128Regex             = lazyRegexCompile(ripemd128RegexString, "ripemd128")
+	ripemd160Regex             = lazyRegexCompile(ripemd160RegexString, "ripemd160")
+	tiger128Regex              = lazyRegexCompile(tiger128RegexString, "tiger128")
+	tiger160Regex              = lazyRegexCompile(tiger160RegexString, "tiger160")
+	tiger192Regex              = lazyRegexCompile(tiger192RegexString, "tiger192")
+	aSCIIRegex                 = lazyRegexCompile(aSCIIRegexString, "ascii")
+	printableASCIIRegex        = lazyRegexCompile(printableASCIIRegexString, "printascii")
+	multibyteRegex             = lazyRegexCompile(multibyteRegexString, "multibyte")
+	dataURIRegex               = lazyRegexCompile(dataURIRegexString, "datauri")
+	latitudeRegex              = lazyRegexCompile(latitudeRegexString, "latitude")
+	longitudeRegex             = lazyRegexCompile(longitudeRegexString, "longitude")
+	sSNRegex                   = lazyRegexCompile(sSNRegexString, "ssn")
+	hostnameRegexRFC952        = lazyRegexCompile(hostnameRegexStringRFC952, "hostname")
+	hostnameRegexRFC1123       = lazyRegexCompile(hostnameRegexStringRFC1123, "hostname_rfc1123", "hostname_port")
+	fqdnRegexRFC1123           = lazyRegexCompile(fqdnRegexStringRFC1123, "fqdn")
+	btcAddressRegex            = lazyRegexCompile(btcAddressRegexString, "btc_addr")
+	btcUpperAddressRegexBech32 = lazyRegexCompile(btcAddressUpperRegexStringBech32, "btc_addr_bech32")
+	btcLowerAddressRegexBech32 = lazyRegexCompile(btcAddressLowerRegexStringBech32, "btc_addr_bech32")
+	ethAddressRegex            = lazyRegexCompile(ethAddressRegexString, "eth_addr", "eth_addr_checksum")
+	uRLEncodedRegex            = lazyRegexCompile(uRLEncodedRegexString, "url_encoded")
+	hTMLEncodedRegex           = lazyRegexCompile(hTMLEncodedRegexString, "html_encoded")
+	hTMLRegex                  = lazyRegexCompile(hTMLRegexString, "html")
+	jWTRegex                   = lazyRegexCompile(jWTRegexString, "jwt")
+	splitParamsRegex           = lazyRegexCompile(splitParamsRegexString, "oneof")
+	bicRegex                   = lazyRegexCompile(bicRegexString, "bic")
+	semverRegex                = lazyRegexCompile(semverRegexString, "semver")
+	dnsRegexRFC1035Label       = lazyRegexCompile(dnsRegexStringRFC1035Label, "dns_rfc1035_label")
+	cveRegex                   = lazyRegexCompile(cveRegexString, "cve")
+	mongodbIdRegex             = lazyRegexCompile(mongodbIdRegexString, "mongodb")
+	mongodbConnectionRegex     = lazyRegexCompile(mongodbConnStringRegexString, "mongodb_connection_string")
+	cronRegex                  = lazyRegexCompile(cronRegexString, "cron")
+	spicedbIDRegex             = lazyRegexCompile(spicedbIDRegexString, "spicedb")
+	spicedbPermissionRegex     = lazyRegexCompile(spicedbPermissionRegexString, "spicedb")
+	spicedbTypeRegex           = lazyRegexCompile(spicedbTypeRegexString, "spicedb")
+	einRegex                   = lazyRegexCompile(einRegexString, "ein")
+	oAuthScopesRegex           = lazyRegexCompile(oAuthScopesRegexString, "oauth_scopes")
+	bearerTokenRegex           = lazyRegexCompile(bearerTokenRegexString, "bearer_token")
 )
 
-func lazyRegexCompile(str string) func() (regex *regexp.Regexp) {
+// lazyRegexes holds the lazily compiled regexes by their pattern.
+var lazyRegexes = map[string]func() *regexp.Regexp{}
+
+// tagRegexes maps the baked in tags to the patterns they compile on first use, see lazyRegexCompile.
+var tagRegexes = map[string][]string{}
+
+// lazyRegexCompile returns a func compiling str on its first call,
+// recording str as a pattern of the baked in tags using it.
+// Equal patterns share the same func, and so the same compiled regex.
+func lazyRegexCompile(str string, tags ...string) func() (regex *regexp.Regexp) {
+	for _, tag := range tags {
+		tagRegexes[tag] = append(tagRegexes[tag], str)
+	}
+
+	if fn, ok := lazyRegexes[str]; ok {
+		return fn
+	}
+
 	var regex *regexp.Regexp
 	var once sync.Once
-	return func() *regexp.Regexp {
+	fn := func() *regexp.Regexp {
 		once.Do(func() {
 			regex = regexp.MustCompile(str)
 		})
 		return regex
 	}
+
+	lazyRegexes[str] = fn
+	return fn
+}
+
+// RegexPatterns returns the regular expressions the baked in tag compiles on first use,
+// or nil if the tag doesn't use any.
+// The postcode tags return the pattern of every supported country.
+func RegexPatterns(tag string) []string {
+	switch tag {
+	case "postcode_iso3166_alpha2", "postcode_iso3166_alpha2_field":
+		patterns := make([]string, 0, len(postCodePatternDict))
+		for _, pattern := range postCodePatternDict {
+			patterns = append(patterns, pattern)
+		}
+
+		return patterns
+	}
+
+	patterns := tagRegexes[tag]
+	if len(patterns) == 0 {
+		return nil
+	}
+
+	return append([]string(nil), patterns...)
+}
+
+// WarmupRegexes compiles the regular expressions used by the given baked in tags ahead of time,
+// or every regular expression when no tags are given,
+// so that the cost is paid at startup rather than on the first validation.
+// Unknown tags and tags that don't use regular expressions are ignored.
+func WarmupRegexes(tags ...string) {
+	if len(tags) == 0 {
+		for _, fn := range lazyRegexes {
+			fn()
+		}
+
+		for countryCode := range postCodePatternDict {
+			postcodeRegex(countryCode)
+		}
+		return
+	}
+
+	for _, tag := range tags {
+		switch tag {
+		case "postcode_iso3166_alpha2", "postcode_iso3166_alpha2_field":
+			for countryCode := range postCodePatternDict {
+				postcodeRegex(countryCode)
+			}
+			continue
+		}
+
+		for _, pattern := range tagRegexes[tag] {
+			lazyRegexes[pattern]()
+		}
+	}
 }
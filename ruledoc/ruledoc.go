@@ -0,0 +1,208 @@
+// Package ruledoc renders the rules of struct types as reference tables, one per struct type,
+// listing the fields, their types, their constraints and their conditional rules, e. g.
+// to embed the validation docs of a service in a developer portal:
+//
+//	doc, err := ruledoc.Markdown(validate, User{}, Order{})
+//
+// The rules are described as validated by the Validate, see Validate.Rules, the field paths honouring
+// RegisterTagNameFunc and holding the fields of the nested struct types, e. g. 'address.city',
+// and the elements of the collections, e. g. 'emails[]'.
+package ruledoc
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"reflect"
+	"strings"
+
+	"github.com/pchchv/validator"
+)
+
+// conditionalTags are the tags whose rules depend on other fields.
+var conditionalTags = map[string]struct{}{
+	"required_if":          {},
+	"required_unless":      {},
+	"required_with":        {},
+	"required_with_all":    {},
+	"required_without":     {},
+	"required_without_all": {},
+	"excluded_if":          {},
+	"excluded_unless":      {},
+	"excluded_with":        {},
+	"excluded_with_all":    {},
+	"excluded_without":     {},
+	"excluded_without_all": {},
+	"skip_unless":          {},
+}
+
+// Table is the reference table of a struct type.
+type Table struct {
+	Name string // name of the struct type, e. g. 'User'
+	Rows []Row  // rows of the paths having rules
+}
+
+// Row is a path of a struct type and its rules.
+type Row struct {
+	Field        string   // path of the values, e. g. 'address.city', 'emails[]' or 'labels[] (keys)'
+	Type         string   // Go type of the values, e. g. 'string'
+	Constraints  []string // rules, e. g. 'required' or 'min=2', aliases being collapsed and 'or' rules joined by '|'
+	Conditionals []string // rules depending on other fields, e. g. 'required_if=Kind card'
+}
+
+// Tables returns the reference tables of the named struct types of types, in order.
+func Tables(v *validator.Validate, types ...interface{}) ([]Table, error) {
+	tables := make([]Table, 0, len(types))
+	for _, t := range types {
+		typ := reflect.TypeOf(t)
+		for typ != nil && typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+
+		if typ == nil || typ.Kind() != reflect.Struct || len(typ.Name()) == 0 {
+			return nil, fmt.Errorf("ruledoc: type '%v' is not a named struct", reflect.TypeOf(t))
+		}
+
+		fields, err := v.Rules(reflect.New(typ).Interface())
+		if err != nil {
+			return nil, err
+		}
+
+		table := Table{Name: typ.Name()}
+		for _, f := range fields {
+			row := Row{Field: f.Path, Type: f.Type.String()}
+			if f.Keys {
+				row.Field += " (keys)"
+			}
+
+			for _, group := range groups(f.Rules) {
+				if group.conditional {
+					row.Conditionals = append(row.Conditionals, group.rule)
+				} else {
+					row.Constraints = append(row.Constraints, group.rule)
+				}
+			}
+			table.Rows = append(table.Rows, row)
+		}
+		tables = append(tables, table)
+	}
+	return tables, nil
+}
+
+// Markdown returns the reference tables of the named struct types of types as Markdown,
+// a level 2 heading followed by a table per type.
+func Markdown(v *validator.Validate, types ...interface{}) ([]byte, error) {
+	tables, err := Tables(v, types...)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	for i, table := range tables {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+
+		fmt.Fprintf(&b, "## %s\n\n", table.Name)
+		b.WriteString("| Field | Type | Constraints | Conditional rules |\n")
+		b.WriteString("| --- | --- | --- | --- |\n")
+		for _, row := range table.Rows {
+			fmt.Fprintf(&b, "| %s | %s | %s | %s |\n", markdownCode(row.Field), markdownCode(row.Type),
+				markdownList(row.Constraints), markdownList(row.Conditionals))
+		}
+	}
+	return b.Bytes(), nil
+}
+
+// HTML returns the reference tables of the named struct types of types as an HTML fragment,
+// a level 2 heading identified by the type name followed by a table per type.
+func HTML(v *validator.Validate, types ...interface{}) ([]byte, error) {
+	tables, err := Tables(v, types...)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	for _, table := range tables {
+		name := html.EscapeString(table.Name)
+		fmt.Fprintf(&b, "<h2 id=\"%s\">%s</h2>\n<table>\n", name, name)
+		b.WriteString("<thead><tr><th>Field</th><th>Type</th><th>Constraints</th><th>Conditional rules</th></tr></thead>\n<tbody>\n")
+		for _, row := range table.Rows {
+			fmt.Fprintf(&b, "<tr><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>\n", htmlCode(row.Field), htmlCode(row.Type),
+				htmlList(row.Constraints), htmlList(row.Conditionals))
+		}
+		b.WriteString("</tbody>\n</table>\n")
+	}
+	return b.Bytes(), nil
+}
+
+// group is a rule as documented, the rules of an alias or of an 'or' making a single group.
+type group struct {
+	rule        string
+	conditional bool
+}
+
+// groups returns the documented groups of rules.
+func groups(rules []validator.Rule) []group {
+	var groups []group
+	for i := 0; i < len(rules); i++ {
+		var g group
+		var parts []string
+		for ; i < len(rules); i++ {
+			r := rules[i]
+			_, conditional := conditionalTags[r.Tag]
+			g.conditional = g.conditional || conditional
+			if len(r.Alias) > 0 {
+				// the rules expanded from an alias are documented as the alias
+				for i+1 < len(rules) && rules[i+1].Alias == r.Alias {
+					i++
+					r.Or = rules[i].Or
+				}
+				parts = append(parts, r.Alias)
+			} else if len(r.Param) > 0 {
+				parts = append(parts, r.Tag+"="+r.Param)
+			} else {
+				parts = append(parts, r.Tag)
+			}
+
+			if !r.Or {
+				break
+			}
+		}
+
+		g.rule = strings.Join(parts, "|")
+		if i < len(rules) && rules[i].Warn {
+			g.rule = "warn:" + g.rule
+		}
+		groups = append(groups, g)
+	}
+	return groups
+}
+
+// markdownCode returns s as a Markdown code span usable in a table cell.
+func markdownCode(s string) string {
+	return "`" + strings.ReplaceAll(s, "|", `\|`) + "`"
+}
+
+// markdownList returns the code spans of list separated by commas.
+func markdownList(list []string) string {
+	codes := make([]string, len(list))
+	for i, s := range list {
+		codes[i] = markdownCode(s)
+	}
+	return strings.Join(codes, ", ")
+}
+
+// htmlCode returns s as an HTML code element.
+func htmlCode(s string) string {
+	return "<code>" + html.EscapeString(s) + "</code>"
+}
+
+// htmlList returns the code elements of list separated by commas.
+func htmlList(list []string) string {
+	codes := make([]string, len(list))
+	for i, s := range list {
+		codes[i] = htmlCode(s)
+	}
+	return strings.Join(codes, ", ")
+}
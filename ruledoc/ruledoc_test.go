@@ -0,0 +1,81 @@
+package ruledoc
+
+import (
+	"testing"
+
+	"github.com/pchchv/go-assert"
+	"github.com/pchchv/validator"
+)
+
+type Address struct {
+	City string `json:"city" validate:"required"`
+	Zip  string `json:"zip" validate:"omitempty,len=5"`
+}
+
+type User struct {
+	Email   string            `json:"email" validate:"required,email"`
+	Phone   string            `json:"phone" validate:"required_without=Email,omitempty,e164"`
+	Color   string            `json:"color" validate:"iscolor"`
+	Kind    string            `json:"kind" validate:"oneof=card iban|len=0"`
+	Tags    []string          `json:"tags" validate:"max=3,dive,alpha"`
+	Labels  map[string]string `json:"labels" validate:"dive,keys,alpha,endkeys,max=10"`
+	Address Address           `json:"address"`
+	Note    string            `json:"note"`
+}
+
+func TestTables(t *testing.T) {
+	validate := validator.New(validator.WithFieldNameTags("json"))
+	tables, err := Tables(validate, &User{})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []Table{{Name: "User", Rows: []Row{
+		{Field: "email", Type: "string", Constraints: []string{"required", "email"}},
+		{Field: "phone", Type: "string", Constraints: []string{"omitempty", "e164"}, Conditionals: []string{"required_without=Email"}},
+		{Field: "color", Type: "string", Constraints: []string{"iscolor"}},
+		{Field: "kind", Type: "string", Constraints: []string{"oneof=card iban|len=0"}},
+		{Field: "tags", Type: "[]string", Constraints: []string{"max=3"}},
+		{Field: "tags[]", Type: "string", Constraints: []string{"alpha"}},
+		{Field: "labels[] (keys)", Type: "string", Constraints: []string{"alpha"}},
+		{Field: "labels[]", Type: "string", Constraints: []string{"max=10"}},
+		{Field: "address.city", Type: "string", Constraints: []string{"required"}},
+		{Field: "address.zip", Type: "string", Constraints: []string{"omitempty", "len=5"}},
+	}}}, tables)
+
+	_, err = Tables(validate, struct{}{})
+	assert.Equal(t, "ruledoc: type 'struct {}' is not a named struct", err.Error())
+}
+
+func TestMarkdown(t *testing.T) {
+	doc, err := Markdown(validator.New(), Address{}, User{})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "## Address\n\n"+
+		"| Field | Type | Constraints | Conditional rules |\n"+
+		"| --- | --- | --- | --- |\n"+
+		"| `City` | `string` | `required` |  |\n"+
+		"| `Zip` | `string` | `omitempty`, `len=5` |  |\n"+
+		"\n## User\n\n"+
+		"| Field | Type | Constraints | Conditional rules |\n"+
+		"| --- | --- | --- | --- |\n"+
+		"| `Email` | `string` | `required`, `email` |  |\n"+
+		"| `Phone` | `string` | `omitempty`, `e164` | `required_without=Email` |\n"+
+		"| `Color` | `string` | `iscolor` |  |\n"+
+		"| `Kind` | `string` | `oneof=card iban\\|len=0` |  |\n"+
+		"| `Tags` | `[]string` | `max=3` |  |\n"+
+		"| `Tags[]` | `string` | `alpha` |  |\n"+
+		"| `Labels[] (keys)` | `string` | `alpha` |  |\n"+
+		"| `Labels[]` | `string` | `max=10` |  |\n"+
+		"| `Address.City` | `string` | `required` |  |\n"+
+		"| `Address.Zip` | `string` | `omitempty`, `len=5` |  |\n", string(doc))
+}
+
+func TestHTML(t *testing.T) {
+	doc, err := HTML(validator.New(), Address{})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "<h2 id=\"Address\">Address</h2>\n<table>\n"+
+		"<thead><tr><th>Field</th><th>Type</th><th>Constraints</th><th>Conditional rules</th></tr></thead>\n<tbody>\n"+
+		"<tr><td><code>City</code></td><td><code>string</code></td><td><code>required</code></td><td></td></tr>\n"+
+		"<tr><td><code>Zip</code></td><td><code>string</code></td><td><code>omitempty</code>, <code>len=5</code></td><td></td></tr>\n"+
+		"</tbody>\n</table>\n", string(doc))
+
+	_, err = HTML(validator.New(), "User")
+	assert.Equal(t, "ruledoc: type 'string' is not a named struct", err.Error())
+}
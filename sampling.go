@@ -0,0 +1,37 @@
+package validator
+
+import "context"
+
+// SkippedRule is a rule that was not run because the validation call was not sampled, see WithSampling.
+type SkippedRule struct {
+	// Namespace is the namespace of the field of the rule,
+	// with the tag name taking precedence over the field's actual name.
+	Namespace string
+	// Tag is the sampled tag.
+	Tag string
+	// Param is the parameter of the tag.
+	Param string
+}
+
+// skips collects the rules skipped by sampling of a validation call.
+type skips struct {
+	rules []SkippedRule
+}
+
+// StructSampledCtx validates s like StructCtx, also returning the rules that were skipped
+// because the call was not sampled, see WithSampling.
+// A field whose rules are skipped passed validation only partially,
+// e. g. allowing the calls to be retried or logged.
+//
+// It returns the skipped rules, and InvalidValidationError for bad values passed in and nil or ValidationErrors as error otherwise.
+func (v *Validate) StructSampledCtx(ctx context.Context, s interface{}) (skipped []SkippedRule, err error) {
+	sk := new(skips)
+	err = v.StructCtx(withCallState(ctx, func(cs *callState) { cs.skips = sk }), s)
+	return sk.rules, err
+}
+
+// StructSampled validates s like Struct, also returning the rules skipped by sampling,
+// see StructSampledCtx.
+func (v *Validate) StructSampled(s interface{}) (skipped []SkippedRule, err error) {
+	return v.StructSampledCtx(context.Background(), s)
+}
@@ -3,9 +3,12 @@ package validator
 import (
 	"context"
 	"reflect"
+	"slices"
+	"strconv"
+	"strings"
 )
 
-var _ StructLevel = new(validate)
+var _ StructLevelDetails = new(validate)
 
 // StructLevel contains all the information and helper functions to validate the structure.
 type StructLevel interface {
@@ -37,6 +40,38 @@ type StructLevel interface {
 	ReportValidationErrors(relativeNamespace, relativeActualNamespace string, errs ValidationErrors)
 }
 
+// StructLevelDetails is implemented by the StructLevel passed to the struct level validation functions,
+// exposing the nested fields and the rules of the current struct, e. g.
+//
+//	if sd, ok := sl.(validator.StructLevelDetails); ok {
+//	    sd.ReportNamespaceError("Items[0].Price", "required", "", nil)
+//	    rules := sd.Rules("Color")
+//	}
+type StructLevelDetails interface {
+	StructLevel
+	// ReportNamespaceError reports an error on a nested field of the current struct.
+	//
+	// namespace uses the struct field names and is appended to the existing namespace that the validator resides on,
+	// for example 'Items[3].Price', the field names are translated using the registered tag name function
+	// so the error matches the one dive would produce.
+	ReportNamespaceError(namespace, tag, param string, value interface{})
+	// Rules returns the parsed validation rules declared on the current struct's field,
+	// in the order they are evaluated, or nil if the field has no rules.
+	//
+	// fieldName is the struct field name, e.g. 'Color', allowing decisions to be
+	// based on declared constraints such as a oneof list instead of duplicating them.
+	Rules(fieldName string) []Rule
+}
+
+// Rule is a single parsed validation of a field's tag.
+type Rule struct {
+	Tag   string // validation tag, e.g. 'oneof', 'omitempty' or 'dive'
+	Param string // tag parameter, e.g. 'red green' for 'oneof=red green'
+	Alias string // alias the rule was expanded from, if any
+	Or    bool   // true if the rule is joined with the next one by '|'
+	Warn  bool   // true if the rule's failures are warnings, see StructWithWarnings
+}
+
 // StructLevelFunc accepts all values needed for struct level validation.
 type StructLevelFunc func(sl StructLevel)
 
@@ -80,13 +115,12 @@ func (v *validate) ReportValidationErrors(relativeNamespace, relativeStructNames
 		err = errs[i].(*fieldError)
 		err.ns = string(append(append(v.ns, relativeNamespace...), err.ns...))
 		err.structNs = string(append(append(v.actualNs, relativeStructNamespace...), err.structNs...))
-		v.errs = append(v.errs, err)
+		v.appendErr(err)
 	}
 }
 
 // ReportError reports an error just by passing the field and tag information
 func (v *validate) ReportError(field interface{}, fieldName, structFieldName, tag, param string) {
-	fv, kind, _ := v.extractTypeInternal(reflect.ValueOf(field), false)
 	if len(structFieldName) == 0 {
 		structFieldName = fieldName
 	}
@@ -98,16 +132,32 @@ func (v *validate) ReportError(field interface{}, fieldName, structFieldName, ta
 		v.str2 = v.str1
 	}
 
+	v.reportError(field, uint8(len(fieldName)), uint8(len(structFieldName)), tag, param)
+}
+
+// ReportNamespaceError reports an error on the nested field at namespace relative to the current struct.
+func (v *validate) ReportNamespaceError(namespace, tag, param string, value interface{}) {
+	name := v.altNamespace(v.slCurrent.Type(), namespace)
+	v.str1 = string(append(v.ns, name...))
+	v.str2 = string(append(v.actualNs, namespace...))
+	fieldLen := len(name) - strings.LastIndexByte(name, '.') - 1
+	structFieldLen := len(namespace) - strings.LastIndexByte(namespace, '.') - 1
+	v.reportError(value, uint8(fieldLen), uint8(structFieldLen), tag, param)
+}
+
+// reportError appends the error for field using the namespaces stored in str1 and str2.
+func (v *validate) reportError(field interface{}, fieldLen, structFieldLen uint8, tag, param string) {
+	fv, kind, _ := v.extractTypeInternal(reflect.ValueOf(field), false)
 	if kind == reflect.Invalid {
-		v.errs = append(v.errs,
+		v.appendErr(
 			&fieldError{
 				v:              v.v,
 				tag:            tag,
 				actualTag:      tag,
 				ns:             v.str1,
 				structNs:       v.str2,
-				fieldLen:       uint8(len(fieldName)),
-				structfieldLen: uint8(len(structFieldName)),
+				fieldLen:       fieldLen,
+				structfieldLen: structFieldLen,
 				param:          param,
 				kind:           kind,
 			},
@@ -115,15 +165,15 @@ func (v *validate) ReportError(field interface{}, fieldName, structFieldName, ta
 		return
 	}
 
-	v.errs = append(v.errs,
+	v.appendErr(
 		&fieldError{
 			v:              v.v,
 			tag:            tag,
 			actualTag:      tag,
 			ns:             v.str1,
 			structNs:       v.str2,
-			fieldLen:       uint8(len(fieldName)),
-			structfieldLen: uint8(len(structFieldName)),
+			fieldLen:       fieldLen,
+			structfieldLen: structFieldLen,
 			value:          fv.Interface(),
 			param:          param,
 			kind:           kind,
@@ -132,6 +182,201 @@ func (v *validate) ReportError(field interface{}, fieldName, structFieldName, ta
 	)
 }
 
+// altNamespace translates the struct field names of namespace, relative to typ,
+// into the names returned by the tag name function.
+// Segments that can't be resolved are kept as is.
+func (v *validate) altNamespace(typ reflect.Type, namespace string) string {
+	if !v.v.hasTagNameFunc {
+		return namespace
+	}
+
+	segments := strings.Split(namespace, ".")
+	for i, seg := range segments {
+		for typ != nil && typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+
+		if typ == nil || typ.Kind() != reflect.Struct {
+			typ = nil
+			continue
+		}
+
+		name, index := seg, ""
+		if idx := strings.IndexByte(seg, '['); idx != -1 {
+			name, index = seg[:idx], seg[idx:]
+		}
+
+		structTyp := typ
+		cs, ok := v.structs().Get(structTyp)
+		if !ok {
+			cs = v.v.extractStructCacheOf(v.structs(), reflect.New(structTyp).Elem(), structTyp.Name())
+		}
+
+		typ = nil
+		for _, f := range cs.fields {
+			if f.name == name {
+				segments[i] = f.altName + index
+				typ = structTyp.Field(f.idx).Type
+				break
+			}
+		}
+
+		// each index steps into the element type of the slice, array or map
+		for typ != nil && strings.HasPrefix(index, "[") {
+			end := strings.IndexByte(index, ']')
+			if end == -1 {
+				break
+			}
+
+			index = index[end+1:]
+			for typ.Kind() == reflect.Ptr {
+				typ = typ.Elem()
+			}
+
+			switch typ.Kind() {
+			case reflect.Slice, reflect.Array, reflect.Map:
+				typ = typ.Elem()
+			default:
+				typ = nil
+			}
+		}
+	}
+	return strings.Join(segments, ".")
+}
+
+// Rules returns the parsed validation rules of the current struct's field.
+func (v *validate) Rules(fieldName string) []Rule {
+	cs, ok := v.structs().Get(v.slCurrent.Type())
+	if !ok {
+		return nil
+	}
+
+	for _, f := range cs.fields {
+		if f.name == fieldName {
+			return appendRules(nil, f.cTags)
+		}
+	}
+	return nil
+}
+
+// appendRules appends the rules of the ct chain to rules.
+func appendRules(rules []Rule, ct *cTag) []Rule {
+	for ; ct != nil; ct = ct.next {
+		var r Rule
+		switch ct.typeof {
+		case typeOmitEmpty:
+			r.Tag = omitempty
+		case typeOmitZero:
+			r.Tag = omitzero
+		case typeOmitNil:
+			r.Tag = omitnil
+		case typeStructOnly:
+			r.Tag = structOnlyTag
+		case typeNoStructLevel:
+			r.Tag = noStructLevelTag
+		case typeStopChildren:
+			r.Tag = stopChildrenTag
+		case typeAllErrs:
+			r.Tag = allErrsTag
+		case typeDive:
+			r.Tag = diveTag
+			if ct.maxErrs > 0 {
+				r.Tag = diveMaxErrsTag
+				r.Param = ct.param
+			} else if ct.parallel {
+				r.Tag = diveParallelTag
+			}
+		case typeKeys:
+			rules = append(rules, Rule{Tag: keysTag})
+			rules = appendRules(rules, ct.keys)
+			continue
+		case typeEndKeys:
+			r.Tag = endKeysTag
+		case typeItems:
+			// each position is a marker rule followed by the position's rules
+			for i, itemCt := range ct.items {
+				if itemCt != nil {
+					rules = append(rules, Rule{Tag: itemTag, Param: strconv.Itoa(i)})
+					rules = appendRules(rules, itemCt)
+				}
+			}
+
+			if ct.rest != nil {
+				rules = append(rules, Rule{Tag: restTag})
+				rules = appendRules(rules, ct.rest)
+			}
+			continue
+		case typeStrSplit:
+			r.Tag = strSplitTag
+			r.Param = ct.param
+		case typeJSONStruct:
+			r.Tag = jsonStructTag
+			r.Param = ct.param
+		default:
+			if !ct.hasTag {
+				continue
+			}
+
+			r.Tag = ct.tag
+			r.Param = ct.param
+			r.Or = ct.typeof == typeOr && !ct.isBlockEnd
+			r.Warn = ct.warn
+		}
+
+		if ct.hasAlias {
+			r.Alias = ct.aliasTag
+		}
+		rules = append(rules, r)
+	}
+	return rules
+}
+
+// appendRule appends the rules of ct, without the rest of its chain, to rules.
+func appendRule(rules []Rule, ct *cTag) []Rule {
+	single := *ct
+	single.next = nil
+	return appendRules(rules, &single)
+}
+
+// equalRules reports whether the rules a and b are equal, ignoring the aliases they were expanded from.
+func equalRules(a, b []Rule) bool {
+	return slices.EqualFunc(a, b, func(x, y Rule) bool {
+		return x.Tag == y.Tag && x.Param == y.Param && x.Or == y.Or && x.Warn == y.Warn
+	})
+}
+
+// formatRules returns the tag of rules.
+func formatRules(rules []Rule) string {
+	var b strings.Builder
+	for i, r := range rules {
+		if r.Warn && (i == 0 || !rules[i-1].Or) {
+			b.WriteString(warnTag + versionSep)
+		}
+
+		if r.Tag == itemTag || r.Tag == restTag {
+			// the positional rules marker, e. g. 'item0=' or 'rest=', is followed by the rules of its position
+			b.WriteString(r.Tag + r.Param + tagKeySeparator)
+			continue
+		}
+
+		b.WriteString(r.Tag)
+		if len(r.Param) > 0 {
+			b.WriteString(tagKeySeparator + r.Param)
+		}
+
+		switch {
+		case i == len(rules)-1:
+		case rules[i+1].Tag == itemTag || rules[i+1].Tag == restTag:
+			b.WriteString(versionSegmentSep)
+		case r.Or:
+			b.WriteString(orSeparator)
+		default:
+			b.WriteString(tagSeparator)
+		}
+	}
+	return b.String()
+}
+
 // ExtractType gets the actual underlying type of field value.
 func (v *validate) ExtractType(field reflect.Value) (reflect.Value, reflect.Kind, bool) {
 	return v.extractTypeInternal(field, false)
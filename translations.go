@@ -0,0 +1,292 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// defaultLocale is the locale of the built-in catalog and the fallback of other locales.
+const defaultLocale = "en"
+
+// TranslationFunc returns the message of fe, message being the registered template rendered for fe,
+// e. g. to pick the plural form or the wording depending on the field's kind.
+type TranslationFunc func(fe FieldError, message string) string
+
+// translation is the registered message of a tag in a locale.
+type translation struct {
+	template string
+	fn       TranslationFunc
+}
+
+// bakedInTranslations is the default English catalog,
+// templates hold the {field}, {param}, {value} and {tag} placeholders.
+var bakedInTranslations = map[string]translation{
+	"required":             {template: "{field} is a required field"},
+	"required_if":          {template: "{field} is a required field"},
+	"required_unless":      {template: "{field} is a required field"},
+	"required_with":        {template: "{field} is a required field"},
+	"required_with_all":    {template: "{field} is a required field"},
+	"required_without":     {template: "{field} is a required field"},
+	"required_without_all": {template: "{field} is a required field"},
+	"required_role":        {template: "{field} is a required field"},
+	"excluded_if":          {template: "{field} must not be set"},
+	"excluded_unless":      {template: "{field} must not be set"},
+	"excluded_with":        {template: "{field} must not be set"},
+	"excluded_with_all":    {template: "{field} must not be set"},
+	"excluded_without":     {template: "{field} must not be set"},
+	"excluded_without_all": {template: "{field} must not be set"},
+	"isdefault":            {template: "{field} must be empty"},
+	"len":                  {template: "{field} must be exactly {param}", fn: lengthUnit},
+	"min":                  {template: "{field} must be at least {param}", fn: lengthUnit},
+	"max":                  {template: "{field} must be at most {param}", fn: lengthUnit},
+	"eq":                   {template: "{field} must be equal to {param}"},
+	"ne":                   {template: "{field} must not be equal to {param}"},
+	"gt":                   {template: "{field} must be greater than {param}", fn: lengthUnit},
+	"gte":                  {template: "{field} must be greater than or equal to {param}", fn: lengthUnit},
+	"lt":                   {template: "{field} must be less than {param}", fn: lengthUnit},
+	"lte":                  {template: "{field} must be less than or equal to {param}", fn: lengthUnit},
+	"eqfield":              {template: "{field} must be equal to {param}"},
+	"eqfield_approx":       {template: "{field} must be approximately equal to {param}", fn: approxValue},
+	"nefield":              {template: "{field} must not be equal to {param}"},
+	"gtfield":              {template: "{field} must be greater than {param}"},
+	"gtefield":             {template: "{field} must be greater than or equal to {param}"},
+	"ltfield":              {template: "{field} must be less than {param}"},
+	"ltefield":             {template: "{field} must be less than or equal to {param}"},
+	"betweenfields":        {template: "{field} must be between {param}", fn: fieldRange},
+	"between_fields":       {template: "{field} must be between {param}", fn: fieldRange},
+	"eq_approx":            {template: "{field} must be approximately equal to {param}", fn: approxValue},
+	"same_host":            {template: "{field} must have the same host as {param}"},
+	"same_origin":          {template: "{field} must have the same origin as {param}"},
+	"subpath_of":           {template: "{field} must be a path below {param}"},
+	"oneof":                {template: "{field} must be one of [{param}]"},
+	"oneofci":              {template: "{field} must be one of [{param}]"},
+	"eqctx":                {template: "{field} must match the {param} of the context"},
+	"oneof_ctx":            {template: "{field} must be one of the {param} of the context"},
+	"unique":               {template: "{field} must contain unique values"},
+	"rows_eq":              {template: "{field} must have {param} rows"},
+	"cols_eq":              {template: "{field} rows must have {param} columns"},
+	"rectangular":          {template: "{field} rows must all have the same number of columns"},
+	"maxkeys_prefix":       {template: "{field} has too many keys with the prefix of '{param}'"},
+	"key_pattern":          {template: "{field} keys must match the pattern '{param}'"},
+	"contains":             {template: "{field} must contain the text '{param}'"},
+	"excludes":             {template: "{field} cannot contain the text '{param}'"},
+	"startswith":           {template: "{field} must start with '{param}'"},
+	"endswith":             {template: "{field} must end with '{param}'"},
+	"lowercase":            {template: "{field} must be a lowercase string"},
+	"uppercase":            {template: "{field} must be an uppercase string"},
+	"alpha":                {template: "{field} can only contain alphabetic characters"},
+	"alphanum":             {template: "{field} can only contain alphanumeric characters"},
+	"valid_utf8":           {template: "{field} must be valid UTF-8"},
+	"ascii_printable":      {template: "{field} can only contain printable ASCII characters"},
+	"latin1":               {template: "{field} can only contain Latin-1 characters"},
+	"numeric":              {template: "{field} must be a valid numeric value"},
+	"number":               {template: "{field} must be a valid number"},
+	"boolean":              {template: "{field} must be a valid boolean value"},
+	"hexadecimal":          {template: "{field} must be a valid hexadecimal"},
+	"email":                {template: "{field} must be a valid email address"},
+	"url":                  {template: "{field} must be a valid URL"},
+	"uri":                  {template: "{field} must be a valid URI"},
+	"uuid":                 {template: "{field} must be a valid UUID"},
+	"uuid4":                {template: "{field} must be a valid version 4 UUID"},
+	"ip":                   {template: "{field} must be a valid IP address"},
+	"ipv4":                 {template: "{field} must be a valid IPv4 address"},
+	"ipv6":                 {template: "{field} must be a valid IPv6 address"},
+	"hostname":             {template: "{field} must be a valid hostname"},
+	"e164":                 {template: "{field} must be a valid E.164 formatted phone number"},
+	"json":                 {template: "{field} must be a valid JSON string"},
+	"jsonstruct":           {template: "{field} must be a valid JSON {param}"},
+	"unknown_key":          {template: "{field} is not an allowed field"},
+	"missing_key":          {template: "{field} is missing"},
+	"oauth_scopes":         {template: "{field} must be a list of allowed OAuth scopes"},
+	"bearer_token":         {template: "{field} must be a valid bearer token"},
+	"base64":               {template: "{field} must be a valid Base64 string"},
+	"datetime":             {template: "{field} does not match the {param} format"},
+	"latitude":             {template: "{field} must contain valid latitude coordinates"},
+	"longitude":            {template: "{field} must contain valid longitude coordinates"},
+	"iscolor":              {template: "{field} must be a valid color"},
+	"country_code":         {template: "{field} must be a valid country code"},
+}
+
+// valueListTags are the tags whose param is a list of allowed values,
+// rendered in the {param} placeholder using the registered value translations.
+var valueListTags = map[string]struct{}{
+	"oneof":   {},
+	"oneofci": {},
+}
+
+// fieldRange names the bounds of a betweenfields message, e. g. 'Min and Max' for 'Min Max' or 'Min Max;2006-01-02'.
+func fieldRange(fe FieldError, message string) string {
+	names, _, _ := strings.Cut(fe.Param(), ";")
+	return strings.Replace(message, fe.Param(), strings.Join(strings.Fields(names), " and "), 1)
+}
+
+// approxValue drops the tolerance of an approximate comparison message, e. g. 'Total' for 'Total;eps=0.005'.
+func approxValue(fe FieldError, message string) string {
+	value, _, _ := strings.Cut(fe.Param(), ";")
+	return strings.Replace(message, fe.Param(), value, 1)
+}
+
+// lengthUnit is the TranslationFunc of the length and comparison tags,
+// appending the unit of the length of strings and collections.
+func lengthUnit(fe FieldError, message string) string {
+	switch fe.Kind() {
+	case reflect.String:
+		if fe.Param() == "1" {
+			return message + " character"
+		}
+		return message + " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if fe.Type() == byteSliceType {
+			return message
+		}
+
+		if fe.Param() == "1" {
+			return message + " item"
+		}
+		return message + " items"
+	default:
+		return message
+	}
+}
+
+// RegisterTranslation registers the message of the errors of the given tag or alias in locale,
+// e. g. "de" or "pt-BR", replacing the built-in English message for the "en" locale.
+// The template holds the {field}, {param}, {value} and {tag} placeholders,
+// e. g. "{field} muss mindestens {param} Zeichen lang sein",
+// and fn, when non-nil, computes the message from the rendered template.
+//
+// NOTE: this method is not thread-safe it is intended that these all be registered prior to any validation.
+func (v *Validate) RegisterTranslation(tag, locale, template string, fn TranslationFunc) {
+	if len(tag) == 0 || len(locale) == 0 {
+		panic("translation tag and locale cannot be empty")
+	}
+
+	locale = normalizeLocale(locale)
+	if v.translations == nil {
+		v.translations = make(map[string]map[string]translation)
+	}
+
+	if v.translations[locale] == nil {
+		v.translations[locale] = make(map[string]translation)
+	}
+	v.translations[locale][tag] = translation{template: template, fn: fn}
+}
+
+// RegisterValueTranslations registers the display translations of field values in locale,
+// e. g. "de" or "pt-BR", keyed by value, used to render the allowed values of the oneof and oneofci tags
+// in the {param} placeholder of their message, e. g.
+//
+//	validate.RegisterValueTranslations("de", map[string]string{"male": "männlich", "female": "weiblich"})
+//
+// renders 'oneof=male female' as "Gender must be one of [männlich, weiblich]" with a German template.
+// Values without a translation in the locale fall back to the locale's base language and then to English.
+//
+// NOTE: this method is not thread-safe it is intended that these all be registered prior to any validation.
+func (v *Validate) RegisterValueTranslations(locale string, values map[string]string) {
+	if len(locale) == 0 {
+		panic("value translation locale cannot be empty")
+	}
+
+	locale = normalizeLocale(locale)
+	if v.valueTranslations == nil {
+		v.valueTranslations = make(map[string]map[string]string)
+	}
+
+	if v.valueTranslations[locale] == nil {
+		v.valueTranslations[locale] = make(map[string]string, len(values))
+	}
+
+	for value, display := range values {
+		v.valueTranslations[locale][value] = display
+	}
+}
+
+// translate returns the message of fe in locale, falling back to the locale's base language
+// and then to English, and to the error's message when no translation is registered.
+func (v *Validate) translate(fe FieldError, locale string) string {
+	locale = normalizeLocale(locale)
+	base, _, _ := strings.Cut(locale, "-")
+	locales := []string{locale, base, defaultLocale}
+	for _, l := range locales {
+		tr, ok := v.lookupTranslation(l, fe.Tag())
+		if !ok {
+			tr, ok = v.lookupTranslation(l, fe.ActualTag())
+		}
+
+		if ok {
+			param := Details(fe).ParamString()
+			if isValueListTag(fe) {
+				param = v.translateValues(param, locales)
+			}
+
+			msg := strings.NewReplacer(
+				"{field}", fe.Field(),
+				"{param}", param,
+				"{value}", fmt.Sprint(fe.Value()),
+				"{tag}", fe.Tag(),
+			).Replace(tr.template)
+			// Var errors have no field name
+			msg = strings.TrimSpace(msg)
+			if tr.fn != nil {
+				msg = tr.fn(fe, msg)
+			}
+			return msg
+		}
+	}
+	return fe.Error()
+}
+
+// isValueListTag returns whether the tag of fe takes a list of allowed values, see valueListTags.
+func isValueListTag(fe FieldError) bool {
+	if _, ok := valueListTags[fe.Tag()]; ok {
+		return true
+	}
+
+	_, ok := valueListTags[fe.ActualTag()]
+	return ok
+}
+
+// translateValues returns the allowed values of param translated in the first of the locales having a translation,
+// joined by ", " as they may contain spaces, or param unchanged when none of them is translated.
+func (v *Validate) translateValues(param string, locales []string) string {
+	if len(v.valueTranslations) == 0 {
+		return param
+	}
+
+	var translated bool
+	vals := parseOneOfParam(param)
+	out := make([]string, len(vals))
+	for i, val := range vals {
+		out[i] = val
+		for _, l := range locales {
+			if display, ok := v.valueTranslations[l][val]; ok {
+				out[i], translated = display, true
+				break
+			}
+		}
+	}
+
+	if !translated {
+		return param
+	}
+	return strings.Join(out, ", ")
+}
+
+// lookupTranslation returns the translation of tag in locale, the built-in catalog backing "en".
+func (v *Validate) lookupTranslation(locale, tag string) (translation, bool) {
+	if tr, ok := v.translations[locale][tag]; ok {
+		return tr, true
+	}
+
+	if locale == defaultLocale {
+		tr, ok := bakedInTranslations[tag]
+		return tr, ok
+	}
+	return translation{}, false
+}
+
+// normalizeLocale returns locale lowercased with '-' separators, e. g. "pt_BR" returns "pt-br".
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+}
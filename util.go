@@ -2,6 +2,8 @@ package validator
 
 import (
 	"fmt"
+	"math"
+	"math/big"
 	"reflect"
 	"regexp"
 	"strconv"
@@ -68,7 +70,7 @@ BEGIN:
 		var ns string
 		typ := current.Type()
 		fld := namespace
-		if !typ.ConvertibleTo(timeType) {
+		if !v.v.isLeafType(typ) {
 			idx := strings.Index(namespace, namespaceSeparator)
 			if idx != -1 {
 				fld = namespace[:idx]
@@ -214,6 +216,15 @@ func asInt(param string) int64 {
 	return i
 }
 
+// asRat parses param as a decimal number and returns it as *big.Rat or panics on error.
+func asRat(param string) *big.Rat {
+	r, ok := new(big.Rat).SetString(param)
+	if !ok || !numericRegex().MatchString(param) {
+		panic(fmt.Sprintf("Bad param '%s', expected a decimal number", param))
+	}
+	return r
+}
+
 // asIntFromTimeDuration parses param as time.Duration and returns it as int64 or panics on error.
 func asIntFromTimeDuration(param string) int64 {
 	d, err := time.ParseDuration(param)
@@ -225,12 +236,93 @@ func asIntFromTimeDuration(param string) int64 {
 	return int64(d)
 }
 
+// asDuration parses param as time.Duration, also accepting a whole number of days e. g. '30d',
+// or panics on error.
+func asDuration(param string) time.Duration {
+	d, ok := parseDuration(param)
+	if !ok {
+		panic(fmt.Sprintf("Bad param '%s', expected a duration", param))
+	}
+	return d
+}
+
+// parseDuration parses a non-negative duration, also accepting a whole number of days e. g. '30d'.
+func parseDuration(s string) (time.Duration, bool) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		if n, err := strconv.ParseUint(days, 10, 16); err == nil {
+			return time.Duration(n) * 24 * time.Hour, true
+		}
+	}
+
+	d, err := time.ParseDuration(s)
+	return d, err == nil && d >= 0
+}
+
+// asIntFromByteSize parses param as a byte size, e. g. '10MiB' or '5KB', and returns it as int64 or panics on error.
+func asIntFromByteSize(param string) int64 {
+	if n, ok := parseByteSize(param); ok {
+		return n
+	}
+
+	// attempt parsing as an integer number of bytes
+	return asInt(param)
+}
+
+// byteSizeUnits are the units of byte sizes, binary units first.
+var byteSizeUnits = []struct {
+	suffix string
+	size   int64
+}{
+	{"TiB", 1 << 40}, {"GiB", 1 << 30}, {"MiB", 1 << 20}, {"KiB", 1 << 10},
+	{"TB", 1e12}, {"GB", 1e9}, {"MB", 1e6}, {"KB", 1e3}, {"B", 1},
+}
+
+// parseByteSize parses a byte size with a unit, e. g. '10MiB'.
+func parseByteSize(s string) (int64, bool) {
+	for _, u := range byteSizeUnits {
+		if num, ok := strings.CutSuffix(s, u.suffix); ok {
+			n, err := strconv.ParseInt(num, 10, 64)
+			if err != nil || n < 0 || n > math.MaxInt64/u.size {
+				return 0, false
+			}
+			return n * u.size, true
+		}
+	}
+	return 0, false
+}
+
+// formatByteSize returns n using the largest unit dividing it, e. g. 10485760 returns '10MiB'.
+func formatByteSize(n int64) string {
+	unit := byteSizeUnits[len(byteSizeUnits)-1]
+	for _, u := range byteSizeUnits {
+		if n != 0 && n%u.size == 0 && u.size > unit.size {
+			unit = u
+		}
+	}
+	return strconv.FormatInt(n/unit.size, 10) + unit.suffix
+}
+
+// formatDuration returns d without trailing zero units, e. g. 1h30m0s returns '1h30m'.
+func formatDuration(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = s[:len(s)-2]
+	}
+
+	if strings.HasSuffix(s, "h0m") {
+		s = s[:len(s)-2]
+	}
+	return s
+}
+
 // asIntFromType calls the proper function to parse param as int64,
 // given a field's Type t.
 func asIntFromType(t reflect.Type, param string) int64 {
-	switch t {
-	case timeDurationType:
+	switch {
+	case t == timeDurationType:
 		return asIntFromTimeDuration(param)
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		return asIntFromByteSize(param)
 	default:
 		return asInt(param)
 	}
@@ -265,3 +357,42 @@ func asFloat32(param string) float64 {
 	panicIf(err)
 	return i
 }
+
+// isIntKind reports whether k is a signed integer kind.
+func isIntKind(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Int64
+}
+
+// isUintKind reports whether k is an unsigned integer kind.
+func isUintKind(k reflect.Kind) bool {
+	return k >= reflect.Uint && k <= reflect.Uintptr
+}
+
+// isFloatKind reports whether k is a floating-point kind.
+func isFloatKind(k reflect.Kind) bool {
+	return k == reflect.Float32 || k == reflect.Float64
+}
+
+// levenshtein returns the Levenshtein distance between a and b in runes.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	row := make([]int, len(rb)+1)
+	for j := range row {
+		row[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		prev := row[0]
+		row[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			cur := min(row[j]+1, row[j-1]+1, prev+cost)
+			prev, row[j] = row[j], cur
+		}
+	}
+	return row[len(rb)]
+}
@@ -1,10 +1,14 @@
 package validator
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
 	"unsafe"
 )
 
@@ -14,6 +18,7 @@ type validate struct {
 	ns             []byte
 	actualNs       []byte
 	errs           ValidationErrors
+	integrityErrs  []error
 	includeExclude map[string]struct{} // reset only if StructPartial or StructExcept are called, no need otherwise
 	ffn            FilterFunc
 	slflParent     reflect.Value // StructLevel & FieldLevel
@@ -21,31 +26,103 @@ type validate struct {
 	flField        reflect.Value // StructLevel & FieldLevel
 	cf             *cField       // StructLevel & FieldLevel
 	ct             *cTag         // StructLevel & FieldLevel
+	elem           diveElem      // FieldLevel, the current dive element
 	misc           []byte        // misc reusable
+	orTags         []*cTag       // failed alternatives of the current 'or' group, see FieldErrorDetails.OrErrors
+	errParam       string        // param reported instead of the tag's by a failing validation, see validateList
+	timedOut       bool          // the current tag couldn't complete because the context is done, see MarkTimedOut
 	str1           string        // misc reusable
 	str2           string        // misc reusable
 	fldIsPointer   bool          // StructLevel & FieldLevel
+	rec            *Recording    // records the evaluated rules when set, see NewRecordingContext
+	audit          *Audit        // audits the traversed fields when set, see NewAuditContext
+	warns          *warnings     // collects the failures of warning tags when set, see StructWithWarnings
+	skips          *skips        // collects the rules skipped by sampling when set, see StructSampled
+	sampleHit      bool          // whether the sampled tags run for this validation call
+	truncated      bool          // whether errors were dropped or fields skipped past WithMaxErrors
+	aborted        error         // error of the done context stopping the call, see WithContextAbort
 	isPartial      bool
 	hasExcludes    bool
+	sc             *structCache // struct cache of the validated payload version, nil for the default one
+}
+
+// structs returns the struct cache of the validated payload version.
+func (v *validate) structs() *structCache {
+	if v.sc != nil {
+		return v.sc
+	}
+	return v.v.structCache
+}
+
+// orErrors returns the errors of the failed alternatives of the current 'or' group,
+// the namespaces being those stored in str1 and str2.
+func (v *validate) orErrors(current reflect.Value, kind reflect.Kind, typ reflect.Type) []FieldError {
+	errs := make([]FieldError, len(v.orTags))
+	for i, ct := range v.orTags {
+		errs[i] = &fieldError{
+			v:              v.v,
+			tag:            ct.tag,
+			actualTag:      ct.tag,
+			ns:             v.str1,
+			structNs:       v.str2,
+			fieldLen:       uint8(len(v.cf.altName)),
+			structfieldLen: uint8(len(v.cf.name)),
+			value:          getValue(current),
+			param:          ct.param,
+			kind:           kind,
+			typ:            typ,
+		}
+	}
+	return errs
+}
+
+// diveElem identifies the slice, array or map element being validated within a dive.
+type diveElem struct {
+	idx  int
+	key  reflect.Value
+	coll reflect.Value // the slice or array being dived into
+	ok   bool
 }
 
 // traverseField validates any field, be it a struct or single field,
 // ensures it's validity and passes it along to be validated via it's tag options.
 func (v *validate) traverseField(ctx context.Context, parent reflect.Value, current reflect.Value, ns []byte, structNs []byte, cf *cField, ct *cTag) {
+	if v.stopped(ctx) {
+		return
+	}
+
 	var typ reflect.Type
 	var kind reflect.Kind
 	var isNestedStruct bool
+	// with allerrs the failing field's remaining rules are checked, see WithAllErrors
+	allErrs, failed := v.v.allErrors, false
 	current, kind, v.fldIsPointer = v.extractTypeInternal(current, false)
 	switch kind {
 	case reflect.Ptr, reflect.Interface, reflect.Invalid:
+		// stopchildren and allerrs have no effect on nil values
+		for ct != nil && (ct.typeof == typeStopChildren || ct.typeof == typeAllErrs) {
+			ct = ct.next
+		}
+
 		if ct == nil || ct.typeof == typeOmitEmpty || ct.typeof == typeIsDefault ||
 			ct.typeof == typeOmitNil && (kind != reflect.Invalid && current.IsNil()) ||
 			ct.typeof == typeOmitZero {
+			if v.tracing() && ct != nil {
+				switch ct.typeof {
+				case typeOmitEmpty:
+					v.record(ns, cf, omitempty, "", DecisionSkippedOmitEmpty)
+				case typeOmitZero:
+					v.record(ns, cf, omitzero, "", DecisionSkippedOmitZero)
+				case typeOmitNil:
+					v.record(ns, cf, omitnil, "", DecisionSkippedOmitNil)
+				}
+			}
 			return
 		}
 
 		if ct.hasTag {
 			if kind == reflect.Invalid {
+				v.record(ns, cf, ct.tag, ct.param, DecisionFailed)
 				v.str1 = string(append(ns, cf.altName...))
 				if v.v.hasTagNameFunc {
 					v.str2 = string(append(structNs, cf.name...))
@@ -53,7 +130,7 @@ func (v *validate) traverseField(ctx context.Context, parent reflect.Value, curr
 					v.str2 = v.str1
 				}
 
-				v.errs = append(v.errs,
+				v.report(ct,
 					&fieldError{
 						v:              v.v,
 						tag:            ct.aliasTag,
@@ -77,7 +154,8 @@ func (v *validate) traverseField(ctx context.Context, parent reflect.Value, curr
 			}
 
 			if !ct.runValidationWhenNil {
-				v.errs = append(v.errs,
+				v.record(ns, cf, ct.tag, ct.param, DecisionFailed)
+				v.report(ct,
 					&fieldError{
 						v:              v.v,
 						tag:            ct.aliasTag,
@@ -100,13 +178,18 @@ func (v *validate) traverseField(ctx context.Context, parent reflect.Value, curr
 			return
 		}
 	case reflect.Struct:
-		isNestedStruct = !current.Type().ConvertibleTo(timeType)
+		isNestedStruct = !v.v.isLeafType(current.Type())
+		if ct != nil && ct.typeof == typeAllErrs {
+			allErrs = true
+			ct = ct.next
+		}
+
 		// For backward compatibility before struct level validation tags were supported as there
 		// were a number of projects relying on `required` not failing on non-pointer structs.
 		// Since it's basically nonsensical to use `required` with a non-pointer struct are
-		// explicitly skipping the required validation for it.
+		// explicitly skipping the required validation for it, unless stopchildren is used.
 		// This WILL be removed in the next major version.
-		if isNestedStruct && !v.v.requiredStructEnabled && ct != nil && ct.tag == requiredTag {
+		if isNestedStruct && !v.v.requiredStructEnabled && ct != nil && ct.tag == requiredTag && !ct.stopsChildren() {
 			ct = ct.next
 		}
 	}
@@ -114,6 +197,11 @@ func (v *validate) traverseField(ctx context.Context, parent reflect.Value, curr
 	typ = current.Type()
 OUTER:
 	for {
+		if failed && (ct == nil || ct.typeof != typeDefault && ct.typeof != typeOr && ct.typeof != typeAllErrs) {
+			// the elements and fields of a failing field aren't validated
+			return
+		}
+
 		if ct == nil || !ct.hasTag || (isNestedStruct && len(cf.name) == 0) {
 			// isNestedStruct check here
 			if isNestedStruct {
@@ -134,6 +222,14 @@ OUTER:
 		switch ct.typeof {
 		case typeNoStructLevel:
 			return
+		case typeStopChildren:
+			// failing tags already stop the traversal, see the required struct check above
+			ct = ct.next
+			continue
+		case typeAllErrs:
+			allErrs = true
+			ct = ct.next
+			continue
 		case typeStructOnly:
 			if isNestedStruct {
 				// if len == 0 then validating using 'Var' or 'VarWithValue'
@@ -155,9 +251,73 @@ OUTER:
 			v.cf = cf
 			v.ct = ct
 			if !hasValue(v) {
+				v.record(ns, cf, omitempty, "", DecisionSkippedOmitEmpty)
+				return
+			}
+
+			ct = ct.next
+			continue
+		case typeStrSplit:
+			if kind != reflect.String {
+				panic(fmt.Sprintf("'%s' can only be used on string fields, field '%s' is a %s", strSplitTag, cf.altName, kind))
+			}
+
+			// the string's whitespace trimmed elements become the current field for the following tags
+			elems := strings.Split(current.String(), ct.param)
+			for i := range elems {
+				elems[i] = strings.TrimSpace(elems[i])
+			}
+
+			current = reflect.ValueOf(elems)
+			kind = reflect.Slice
+			typ = current.Type()
+			ct = ct.next
+			continue
+		case typeJSONStruct:
+			var data []byte
+			switch {
+			case kind == reflect.String:
+				data = []byte(current.String())
+			case kind == reflect.Slice && typ.Elem().Kind() == reflect.Uint8:
+				data = current.Bytes()
+			default:
+				panic(fmt.Sprintf("'%s' can only be used on string and []byte fields, field '%s' is a %s", jsonStructTag, cf.altName, kind))
+			}
+
+			// the decoded struct becomes the current field, its fields being validated beneath it
+			decoded := reflect.New(ct.jsonType)
+			if err := json.Unmarshal(data, decoded.Interface()); err != nil {
+				v.record(ns, cf, ct.tag, ct.param, DecisionFailed)
+				v.str1 = string(append(ns, cf.altName...))
+				if v.v.hasTagNameFunc {
+					v.str2 = string(append(structNs, cf.name...))
+				} else {
+					v.str2 = v.str1
+				}
+
+				v.report(ct,
+					&fieldError{
+						v:              v.v,
+						tag:            ct.aliasTag,
+						actualTag:      ct.tag,
+						ns:             v.str1,
+						structNs:       v.str2,
+						fieldLen:       uint8(len(cf.altName)),
+						structfieldLen: uint8(len(cf.name)),
+						value:          getValue(current),
+						param:          ct.param,
+						kind:           kind,
+						typ:            typ,
+					},
+				)
 				return
 			}
 
+			v.record(ns, cf, ct.tag, ct.param, DecisionPassed)
+			current = decoded.Elem()
+			kind = reflect.Struct
+			typ = ct.jsonType
+			isNestedStruct = true
 			ct = ct.next
 			continue
 		case typeOmitZero:
@@ -166,6 +326,7 @@ OUTER:
 			v.cf = cf
 			v.ct = ct
 			if !hasNotZeroValue(v) {
+				v.record(ns, cf, omitzero, "", DecisionSkippedOmitZero)
 				return
 			}
 
@@ -179,10 +340,12 @@ OUTER:
 			switch field := v.Field(); field.Kind() {
 			case reflect.Slice, reflect.Map, reflect.Ptr, reflect.Interface, reflect.Chan, reflect.Func:
 				if field.IsNil() {
+					v.record(ns, cf, omitnil, "", DecisionSkippedOmitNil)
 					return
 				}
 			default:
 				if v.fldIsPointer && field.Interface() == nil {
+					v.record(ns, cf, omitnil, "", DecisionSkippedOmitNil)
 					return
 				}
 			}
@@ -191,36 +354,99 @@ OUTER:
 			continue
 		case typeEndKeys:
 			return
+		case typeItems:
+			if kind != reflect.Array && kind != reflect.Slice {
+				panic(fmt.Sprintf("positional rules can only be used on arrays and slices, field '%s' is a %s", cf.altName, kind))
+			}
+
+			// positions past the length of a slice aren't validated, see the len tag,
+			// the elements past the positions are validated by the rest rules
+			n := min(len(ct.items), current.Len())
+			if ct.rest != nil {
+				n = current.Len()
+			}
+
+			elem := v.elem
+			reusableCF := &cField{}
+			for i := 0; i < n; i++ {
+				itemCt := ct.rest
+				if i < len(ct.items) {
+					itemCt = ct.items[i]
+				}
+
+				if itemCt == nil {
+					continue
+				}
+
+				v.misc = append(v.misc[0:0], cf.name...)
+				v.misc = v.appendIndex(v.misc, i)
+				reusableCF.name = string(v.misc)
+				if cf.namesEqual {
+					reusableCF.altName = reusableCF.name
+				} else {
+					v.misc = append(v.misc[0:0], cf.altName...)
+					v.misc = v.appendIndex(v.misc, i)
+					reusableCF.altName = string(v.misc)
+				}
+
+				v.elem = diveElem{idx: i, coll: current, ok: true}
+				v.traverseField(ctx, parent, current.Index(i), ns, structNs, reusableCF, itemCt)
+			}
+
+			v.elem = elem
+			return
 		case typeDive:
+			// with dive_maxerrs the errors of the elements failing past maxErrs are dropped and counted instead
+			maxErrs, failed := ct.maxErrs, 0
+			diveCt := ct
 			ct = ct.next
+			elem := v.elem
+			if diveCt.parallel && kind != reflect.Invalid && v.parallelizable() {
+				switch kind {
+				case reflect.Slice, reflect.Array, reflect.Map:
+					v.diveParallel(ctx, parent, current, ns, structNs, cf, ct)
+					v.elem = elem
+					return
+				}
+			}
+
 			switch kind {
 			case reflect.Slice, reflect.Array:
-				var i64 int64
 				reusableCF := &cField{}
 				for i := 0; i < current.Len(); i++ {
-					i64 = int64(i)
+					if v.stopped(ctx) {
+						break
+					}
+
 					v.misc = append(v.misc[0:0], cf.name...)
-					v.misc = append(v.misc, '[')
-					v.misc = strconv.AppendInt(v.misc, i64, 10)
-					v.misc = append(v.misc, ']')
+					v.misc = v.appendIndex(v.misc, i)
 					reusableCF.name = string(v.misc)
 					if cf.namesEqual {
 						reusableCF.altName = reusableCF.name
 					} else {
 						v.misc = append(v.misc[0:0], cf.altName...)
-						v.misc = append(v.misc, '[')
-						v.misc = strconv.AppendInt(v.misc, i64, 10)
-						v.misc = append(v.misc, ']')
+						v.misc = v.appendIndex(v.misc, i)
 						reusableCF.altName = string(v.misc)
 					}
 
+					v.elem = diveElem{idx: i, coll: current, ok: true}
+					errsLen := len(v.errs)
 					v.traverseField(ctx, parent, current.Index(i), ns, structNs, reusableCF, ct)
+					if maxErrs > 0 && len(v.errs) > errsLen {
+						if failed++; failed > maxErrs {
+							v.errs = v.errs[:errsLen]
+						}
+					}
 				}
 			case reflect.Map:
 				var pv string
 				reusableCF := &cField{}
 				for _, key := range current.MapKeys() {
-					pv = fmt.Sprintf("%v", key.Interface())
+					if v.stopped(ctx) {
+						break
+					}
+
+					pv = v.formatKey(key)
 					v.misc = append(v.misc[0:0], cf.name...)
 					v.misc = append(v.misc, '[')
 					v.misc = append(v.misc, pv...)
@@ -236,6 +462,8 @@ OUTER:
 						reusableCF.altName = string(v.misc)
 					}
 
+					v.elem = diveElem{idx: -1, key: key, ok: true}
+					errsLen := len(v.errs)
 					if ct != nil && ct.typeof == typeKeys && ct.keys != nil {
 						v.traverseField(ctx, parent, key, ns, structNs, reusableCF, ct.keys)
 						// can be nil when just keys being validated
@@ -245,6 +473,12 @@ OUTER:
 					} else {
 						v.traverseField(ctx, parent, current.MapIndex(key), ns, structNs, reusableCF, ct)
 					}
+
+					if maxErrs > 0 && len(v.errs) > errsLen {
+						if failed++; failed > maxErrs {
+							v.errs = v.errs[:errsLen]
+						}
+					}
 				}
 
 			default:
@@ -253,9 +487,36 @@ OUTER:
 				panic("dive error! can't dive on a non slice or map")
 			}
 
+			v.elem = elem
+			if maxErrs > 0 && failed > maxErrs {
+				// report how many more elements failed
+				v.str1 = string(append(ns, cf.altName...))
+				if v.v.hasTagNameFunc {
+					v.str2 = string(append(structNs, cf.name...))
+				} else {
+					v.str2 = v.str1
+				}
+
+				v.appendErr(
+					&fieldError{
+						v:              v.v,
+						tag:            diveMaxErrsTag,
+						actualTag:      diveMaxErrsTag,
+						ns:             v.str1,
+						structNs:       v.str2,
+						fieldLen:       uint8(len(cf.altName)),
+						structfieldLen: uint8(len(cf.name)),
+						value:          failed - maxErrs,
+						param:          diveCt.param,
+						kind:           reflect.Int,
+						typ:            reflect.TypeOf(failed),
+					},
+				)
+			}
 			return
 		case typeOr:
 			v.misc = v.misc[0:0]
+			v.orTags = v.orTags[0:0]
 			for {
 				// set Field Level fields
 				v.slflParent = parent
@@ -263,6 +524,7 @@ OUTER:
 				v.cf = cf
 				v.ct = ct
 				if ct.fn(ctx, v) {
+					v.record(ns, cf, ct.tag, ct.param, DecisionOrBranchTaken)
 					if ct.isBlockEnd {
 						ct = ct.next
 						continue OUTER
@@ -282,6 +544,11 @@ OUTER:
 					}
 				}
 
+				if !ct.isBlockEnd && ct.next != nil {
+					v.record(ns, cf, ct.tag, ct.param, DecisionOrBranchFailed)
+				}
+
+				v.orTags = append(v.orTags, ct)
 				v.misc = append(v.misc, '|')
 				v.misc = append(v.misc, ct.tag...)
 				if ct.hasParam {
@@ -290,6 +557,7 @@ OUTER:
 				}
 
 				if ct.isBlockEnd || ct.next == nil {
+					v.record(ns, cf, string(v.misc)[1:], ct.param, DecisionFailed)
 					// if we get here, no valid 'or' value and no more tags
 					v.str1 = string(append(ns, cf.altName...))
 					if v.v.hasTagNameFunc {
@@ -299,7 +567,7 @@ OUTER:
 					}
 
 					if ct.hasAlias {
-						v.errs = append(v.errs,
+						v.report(ct,
 							&fieldError{
 								v:              v.v,
 								tag:            ct.aliasTag,
@@ -312,11 +580,12 @@ OUTER:
 								param:          ct.param,
 								kind:           kind,
 								typ:            typ,
+								orErrs:         v.orErrors(current, kind, typ),
 							},
 						)
 					} else {
 						tVal := string(v.misc)[1:]
-						v.errs = append(v.errs,
+						v.report(ct,
 							&fieldError{
 								v:              v.v,
 								tag:            tVal,
@@ -329,20 +598,67 @@ OUTER:
 								param:          ct.param,
 								kind:           kind,
 								typ:            typ,
+								orErrs:         v.orErrors(current, kind, typ),
 							},
 						)
 					}
+
+					if ct.warn {
+						ct = ct.next
+						continue OUTER
+					}
+
+					if allErrs {
+						failed = true
+						ct = ct.next
+						continue OUTER
+					}
 					return
 				}
 				ct = ct.next
 			}
 		default:
+			if ct.sampled && !v.sampleHit {
+				v.record(ns, cf, ct.tag, ct.param, DecisionSkippedSampled)
+				if v.skips != nil {
+					v.skips.rules = append(v.skips.rules, SkippedRule{
+						Namespace: string(append(ns, cf.altName...)),
+						Tag:       ct.tag,
+						Param:     ct.param,
+					})
+				}
+				ct = ct.next
+				continue
+			}
+
 			// set Field Level fields
 			v.slflParent = parent
 			v.flField = current
 			v.cf = cf
 			v.ct = ct
-			if !ct.fn(ctx, v) {
+			v.errParam = ""
+			v.timedOut = false
+			// a tag with a timeout policy can't complete once the context is done
+			timedOut := ct.timeoutPolicy != TimeoutFailClosed && ctx.Err() != nil
+			if timedOut || !ct.fn(ctx, v) {
+				timedOut = timedOut || (ct.timeoutPolicy != TimeoutFailClosed && v.timedOut)
+				if timedOut && ct.timeoutPolicy == TimeoutFailOpen {
+					v.record(ns, cf, ct.tag, ct.param, DecisionPassed)
+					ct = ct.next
+					continue
+				}
+
+				tag, actualTag, param := ct.aliasTag, ct.tag, ct.param
+				if len(v.errParam) > 0 {
+					param = v.errParam
+				}
+
+				if timedOut {
+					tag += timeoutTagSuffix
+					actualTag += timeoutTagSuffix
+				}
+
+				v.record(ns, cf, ct.tag, ct.param, DecisionFailed)
 				v.str1 = string(append(ns, cf.altName...))
 				if v.v.hasTagNameFunc {
 					v.str2 = string(append(structNs, cf.name...))
@@ -350,35 +666,89 @@ OUTER:
 					v.str2 = v.str1
 				}
 
-				v.errs = append(v.errs,
+				v.report(ct,
 					&fieldError{
 						v:              v.v,
-						tag:            ct.aliasTag,
-						actualTag:      ct.tag,
+						tag:            tag,
+						actualTag:      actualTag,
 						ns:             v.str1,
 						structNs:       v.str2,
 						fieldLen:       uint8(len(cf.altName)),
 						structfieldLen: uint8(len(cf.name)),
 						value:          getValue(current),
-						param:          ct.param,
+						param:          param,
 						kind:           kind,
 						typ:            typ,
 					},
 				)
+				if ct.warn {
+					ct = ct.next
+					continue
+				}
+
+				if allErrs {
+					failed = true
+					ct = ct.next
+					continue
+				}
 				return
 			}
+
+			v.record(ns, cf, ct.tag, ct.param, DecisionPassed)
 			ct = ct.next
 		}
 	}
 }
 
+// report appends the error of the failed tag ct to the errors,
+// or to the warnings of the current validation call, if any, when ct is a warning tag.
+func (v *validate) report(ct *cTag, fe *fieldError) {
+	if !ct.warn {
+		v.appendErr(fe)
+	} else if v.warns != nil {
+		v.warns.errs = append(v.warns.errs, fe)
+	}
+}
+
+// appendErr appends fe to the errors, unless they're full, see WithMaxErrors.
+func (v *validate) appendErr(fe FieldError) {
+	if v.full() {
+		v.truncated = true
+		return
+	}
+	v.errs = append(v.errs, fe)
+}
+
+// stopped reports whether the traversal stops, the errors having reached the cap of WithMaxErrors
+// or ctx being done with WithContextAbort.
+func (v *validate) stopped(ctx context.Context) bool {
+	if v.full() {
+		v.truncated = true
+		return true
+	}
+
+	if v.aborted == nil && v.v.contextAbort {
+		v.aborted = ctx.Err()
+	}
+	return v.aborted != nil
+}
+
+// full reports whether the errors reached the cap of WithMaxErrors.
+func (v *validate) full() bool {
+	return v.v.maxErrors > 0 && len(v.errs) >= v.v.maxErrors
+}
+
 // parent and current will be the same the first run of validateStruct
 func (v *validate) validateStruct(ctx context.Context, parent reflect.Value, current reflect.Value, typ reflect.Type, ns []byte, structNs []byte, ct *cTag) {
-	cs, ok := v.v.structCache.Get(typ)
+	cs, ok := v.structs().Get(typ)
 	if !ok {
-		cs = v.v.extractStructCache(current, typ.Name())
+		cs = v.v.extractStructCacheOf(v.structs(), current, typ.Name())
 	}
+	v.validateCStruct(ctx, cs, parent, current, typ, ns, structNs, ct)
+}
 
+// validateCStruct validates the struct current of the cached struct cs.
+func (v *validate) validateCStruct(ctx context.Context, cs *cStruct, parent reflect.Value, current reflect.Value, typ reflect.Type, ns []byte, structNs []byte, ct *cTag) {
 	if len(ns) == 0 && len(cs.name) != 0 {
 		ns = append(ns, cs.name...)
 		ns = append(ns, '.')
@@ -386,10 +756,33 @@ func (v *validate) validateStruct(ctx context.Context, parent reflect.Value, cur
 		structNs = append(structNs, '.')
 	}
 
+	if cs.integrity != nil && current.CanInterface() {
+		if err := cs.integrity.fn(ctx, current.Interface()); err != nil {
+			v.integrityErrs = append(v.integrityErrs, &IntegrityError{
+				Namespace: string(bytes.TrimSuffix(ns, []byte{'.'})),
+				Type:      typ,
+				Err:       err,
+			})
+
+			if cs.integrity.skipFields {
+				return
+			}
+		}
+	}
+
+	if cs.stages != nil {
+		v.runStages(ctx, parent, current, ns, structNs, cs.stages.pre)
+	}
+
+	// fields of a struct within a dive aren't dive elements themselves
+	elem := v.elem
+	v.elem = diveElem{}
+
 	// ct is nil on top level struct, and structs as fields that have no tag info
 	// so if nil or if not nil and the structonly tag isn't present
 	if ct == nil || ct.typeof != typeStructOnly {
 		var f *cField
+		var ok bool
 		for i := 0; i < len(cs.fields); i++ {
 			f = cs.fields[i]
 			if v.isPartial {
@@ -406,10 +799,15 @@ func (v *validate) validateStruct(ctx context.Context, parent reflect.Value, cur
 					}
 				}
 			}
+			if v.audit != nil {
+				v.auditField(ns, f, current.Field(f.idx))
+			}
 			v.traverseField(ctx, current, current.Field(f.idx), ns, structNs, f, f.cTags)
 		}
 	}
 
+	v.elem = elem
+
 	// check if any struct level validations, after all field validations already checked.
 	// first iteration will have no info about nostructlevel tag,
 	// and is checked prior to calling the next iteration of validateStruct called from traverseField.
@@ -420,6 +818,51 @@ func (v *validate) validateStruct(ctx context.Context, parent reflect.Value, cur
 		v.actualNs = structNs
 		cs.fn(ctx, v)
 	}
+
+	if cs.stages != nil {
+		v.runStages(ctx, parent, current, ns, structNs, cs.stages.post)
+	}
+}
+
+// runStages runs the pipeline stage funcs of the current struct, see Validate.Pipeline.
+func (v *validate) runStages(ctx context.Context, parent reflect.Value, current reflect.Value, ns []byte, structNs []byte, fns []StructLevelFuncCtx) {
+	for _, fn := range fns {
+		v.slflParent = parent
+		v.slCurrent = current
+		v.ns = ns
+		v.actualNs = structNs
+		fn(ctx, v)
+	}
+}
+
+// result returns the errors collected during the validation call and resets them,
+// integrity errors are joined with the ValidationErrors.
+func (v *validate) result() error {
+	if v.aborted != nil {
+		err := &AbortedValidationError{Err: v.aborted, Errors: v.errs}
+		v.aborted, v.truncated = nil, false
+		v.errs, v.integrityErrs = nil, nil
+		return err
+	}
+
+	var err error
+	if v.truncated {
+		err = &TruncatedValidationError{Max: v.v.maxErrors, Errors: v.errs}
+		v.errs, v.truncated = nil, false
+	} else if len(v.errs) > 0 {
+		err = v.errs
+		v.errs = nil
+	}
+
+	if len(v.integrityErrs) > 0 {
+		if err != nil {
+			v.integrityErrs = append(v.integrityErrs, err)
+		}
+
+		err = errors.Join(v.integrityErrs...)
+		v.integrityErrs = nil
+	}
+	return err
 }
 
 func getValue(val reflect.Value) interface{} {
@@ -444,3 +887,23 @@ func getValue(val reflect.Value) interface{} {
 		return val.String()
 	}
 }
+
+// appendIndex appends the namespace segment of the element i of a slice or array to b, e. g. '[0]',
+// see RegisterKeyFormatter.
+func (v *validate) appendIndex(b []byte, i int) []byte {
+	b = append(b, '[')
+	if v.v.keyFormatter != nil {
+		b = append(b, v.v.keyFormatter(i)...)
+	} else {
+		b = strconv.AppendInt(b, int64(i), 10)
+	}
+	return append(b, ']')
+}
+
+// formatKey returns the namespace segment of the map key, rendered between brackets, see RegisterKeyFormatter.
+func (v *validate) formatKey(key reflect.Value) string {
+	if v.v.keyFormatter != nil {
+		return v.v.keyFormatter(key.Interface())
+	}
+	return fmt.Sprintf("%v", key.Interface())
+}
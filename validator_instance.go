@@ -2,12 +2,16 @@ package validator
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
 )
 
 const (
@@ -19,6 +23,12 @@ const (
 	tagKeySeparator       = "="
 	structOnlyTag         = "structonly"
 	noStructLevelTag      = "nostructlevel"
+	stopChildrenTag       = "stopchildren"
+	allErrsTag            = "allerrs"
+	sensitiveTag          = "sensitive"
+	warnTag               = "warn"
+	versionSep            = ":"
+	versionSegmentSep     = ";"
 	omitzero              = "omitzero"
 	omitempty             = "omitempty"
 	omitnil               = "omitnil"
@@ -29,6 +39,7 @@ const (
 	requiredWithAllTag    = "required_with_all"
 	requiredIfTag         = "required_if"
 	requiredUnlessTag     = "required_unless"
+	requiredRoleTag       = "required_role"
 	skipUnlessTag         = "skip_unless"
 	excludedWithoutAllTag = "excluded_without_all"
 	excludedWithoutTag    = "excluded_without"
@@ -36,16 +47,33 @@ const (
 	excludedWithAllTag    = "excluded_with_all"
 	excludedIfTag         = "excluded_if"
 	excludedUnlessTag     = "excluded_unless"
+	xorTag                = "xor"
+	iffTag                = "iff"
+	minSetTag             = "min_set"
+	maxSetTag             = "max_set"
 	skipValidationTag     = "-"
 	diveTag               = "dive"
+	diveMaxErrsTag        = "dive_maxerrs"
+	diveParallelTag       = "dive_parallel"
+	unknownKeyTag         = "unknown_key"
+	missingKeyTag         = "missing_key"
+	strSplitTag           = "strsplit"
+	jsonStructTag         = "jsonstruct"
 	keysTag               = "keys"
 	endKeysTag            = "endkeys"
+	itemTag               = "item"
+	tupleTag              = "tuple"
+	restTag               = "rest"
 	requiredTag           = "required"
 	namespaceSeparator    = "."
 	leftBracket           = "["
 	rightBracket          = "]"
 	restrictedTagChars    = ".[],|=+()`~!@#$%^&*\\\"/?<>{}"
 	restrictedAliasErr    = "Alias '%s' either contains restricted characters or is the same as a restricted tag needed for normal operation"
+	maxSuggestionValues   = 100
+	maxSuggestionLen      = 64
+	similarDistance       = 2
+	minSimilarLen         = 3
 	restrictedTagErr      = "Tag '%s' either contains restricted characters or is the same as a restricted tag needed for normal operation"
 )
 
@@ -64,6 +92,20 @@ type TagNameFunc func(field reflect.StructField) string
 // see https://golang.org/src/database/sql/driver/types.go?s=1210:1293#L29
 type CustomTypeFunc func(field reflect.Value) interface{}
 
+// SuggestionFunc computes a hint fixing the failed value of fe,
+// e. g. the closest allowed value or the normalized form of an email,
+// returning "" when it has none.
+type SuggestionFunc func(fe FieldError) string
+
+// StructIntegrityFunc verifies a struct as a whole, e. g. an embedded signature or checksum,
+// before its fields are validated. A non-nil error is reported as an IntegrityError.
+type StructIntegrityFunc func(ctx context.Context, value interface{}) error
+
+// KeyFormatter returns the namespace segment of an element of a slice, array or map,
+// rendered between brackets, key being the int index of slice and array elements or the map key,
+// e. g. the key "a b" is rendered as 'Labels["a b"]' by a formatter quoting strings.
+type KeyFormatter func(key interface{}) string
+
 // FilterFunc is the type used to filter fields using the StructFiltered(...) function.
 // Return true causes the field to be filtered/skipped on validation.
 type FilterFunc func(ns []byte) bool
@@ -74,15 +116,46 @@ type Validate struct {
 	pool                   *sync.Pool
 	tagNameFunc            TagNameFunc
 	structLevelFuncs       map[reflect.Type]StructLevelFuncCtx
+	structIntegrity        map[reflect.Type]*structIntegrity
+	pipelines              map[reflect.Type]*pipelineStages
 	customFuncs            map[reflect.Type]CustomTypeFunc
 	aliases                map[string]string
 	validations            map[string]internalValidationFuncWrapper
 	rules                  map[reflect.Type]map[string]string
 	tagCache               *tagCache
 	structCache            *structCache
+	postcodeProvider       PostcodeProvider
+	countries              *codeSet
+	currencies             *codeSet
+	countryGroups          map[string]map[string]struct{}
+	jsonStructs            map[string]reflect.Type // types decoded by the 'jsonstruct' tag by name
+	sampledTags            map[string]struct{}
+	sampleRate             float64
+	maxErrors              int // errors after which validation calls stop, 0 when unlimited
+	diveWorkers            int // workers of dive_parallel, GOMAXPROCS when 0
+	timeoutPolicies        map[string]TimeoutPolicy
+	env                    Env
+	valuePolicy            ValuePolicy
+	suggestions            map[string]SuggestionFunc
+	errorCodes             map[string]string
+	leafTypes              map[reflect.Type]struct{}
+	translations           map[string]map[string]translation
+	valueTranslations      map[string]map[string]string
+	versionCaches          *sync.Map // struct caches by payload version, see StructVersion
+	tagVersions            *sync.Map // versions of the parsed versioned tags
+	contextResolver        ContextValueResolver
+	rolesProvider          RolesProvider
+	keyFormatter           KeyFormatter
+	tracer                 func(step RecordedStep)
+	beforeHook             BeforeHook
+	afterHook              AfterHook
 	hasCustomFuncs         bool
 	hasTagNameFunc         bool
 	requiredStructEnabled  bool
+	strictErrors           bool
+	contextAbort           bool
+	allErrors              bool
+	strictMaps             bool
 	privateFieldValidation bool
 }
 
@@ -110,6 +183,13 @@ func New(options ...Option) *Validate {
 		v.RegisterAlias(k, val)
 	}
 
+	v.versionCaches = new(sync.Map)
+	v.tagVersions = new(sync.Map)
+	v.suggestions = make(map[string]SuggestionFunc, len(bakedInSuggestions))
+	for k, fn := range bakedInSuggestions {
+		v.suggestions[k] = fn
+	}
+
 	// must copy validators for separate validations
 	// to be used in each instance
 	for k, val := range bakedInValidators {
@@ -118,7 +198,7 @@ func New(options ...Option) *Validate {
 		// omitempty still overrides this behaviour
 		case requiredIfTag, requiredUnlessTag, requiredWithTag, requiredWithAllTag, requiredWithoutTag,
 			requiredWithoutAllTag, excludedIfTag, excludedUnlessTag, excludedWithTag, excludedWithAllTag,
-			excludedWithoutTag, excludedWithoutAllTag, skipUnlessTag:
+			excludedWithoutTag, excludedWithoutAllTag, skipUnlessTag, xorTag, iffTag, minSetTag, maxSetTag:
 			_ = v.registerValidation(k, wrapFunc(val), true, true)
 		default:
 			// no need to error check here, baked in will always be valid
@@ -126,6 +206,11 @@ func New(options ...Option) *Validate {
 		}
 	}
 
+	for k, val := range bakedInCtxValidators {
+		// required_role runs on nil values as the other required tags
+		_ = v.registerValidation(k, val, true, k == requiredRoleTag)
+	}
+
 	v.pool = &sync.Pool{
 		New: func() interface{} {
 			return &validate{
@@ -156,6 +241,58 @@ func (v *Validate) RegisterAlias(alias, tags string) {
 	v.aliases[alias] = tags
 }
 
+// RegisterErrorCode registers the machine-readable code of the errors
+// of the given tag or alias, e. g. validate.RegisterErrorCode("is-awesome", "USR_NOT_AWESOME"),
+// overriding the default code derived from the tag, e. g. 'VAL_IS_AWESOME'.
+// The code of an error is returned by validator.Details(fe).Code(), see FieldErrorDetails.
+// An empty code restores the default code.
+//
+// NOTE: this method is not thread-safe it is intended that these all be registered prior to any validation.
+func (v *Validate) RegisterErrorCode(tag, code string) {
+	if len(tag) == 0 {
+		panic("error code tag cannot be empty")
+	}
+
+	if len(code) == 0 {
+		delete(v.errorCodes, tag)
+		return
+	}
+
+	if v.errorCodes == nil {
+		v.errorCodes = make(map[string]string)
+	}
+	v.errorCodes[tag] = code
+}
+
+// RegisterSuggestion registers the SuggestionFunc computing the suggestion
+// of the errors of the given tag or alias, e. g.
+//
+//	validate.RegisterSuggestion("email", func(fe validator.FieldError) string {
+//		return strings.ToLower(strings.TrimSpace(fmt.Sprint(fe.Value())))
+//	})
+//
+// The suggestion of an error is returned by validator.Details(fe).Suggestion(), see FieldErrorDetails.
+// Suggestions are computed when requested, not while validating.
+// oneof and oneofci suggest the allowed value closest to the failed value by default.
+// A nil fn removes the tag's SuggestionFunc.
+//
+// NOTE: this method is not thread-safe it is intended that these all be registered prior to any validation.
+func (v *Validate) RegisterSuggestion(tag string, fn SuggestionFunc) {
+	if len(tag) == 0 {
+		panic("suggestion tag cannot be empty")
+	}
+
+	if fn == nil {
+		delete(v.suggestions, tag)
+		return
+	}
+
+	if v.suggestions == nil {
+		v.suggestions = make(map[string]SuggestionFunc)
+	}
+	v.suggestions[tag] = fn
+}
+
 // RegisterValidation adds a validation with the given tag.
 //
 // NOTES:
@@ -202,8 +339,36 @@ func (v *Validate) RegisterStructValidationCtx(fn StructLevelFuncCtx, types ...i
 	}
 }
 
-// RegisterStructValidationMapRules registers validate map rules.
+// RegisterStructIntegrity registers a StructIntegrityFunc against a number of types,
+// it runs before the field and struct level validations of the type.
+// When skipFields is true and the integrity check fails the struct's fields
+// and struct level validations aren't run at all.
+//
+// Integrity failures are returned alongside any ValidationErrors, joined using errors.Join,
+// so use errors.As to retrieve either of them when integrity checks are registered.
+//
+// NOTE: this method is not thread-safe it is intended that these all be registered prior to any validation.
+func (v *Validate) RegisterStructIntegrity(fn StructIntegrityFunc, skipFields bool, types ...interface{}) {
+	if v.structIntegrity == nil {
+		v.structIntegrity = make(map[reflect.Type]*structIntegrity)
+	}
+
+	for _, t := range types {
+		typ := reflect.TypeOf(t)
+		if typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+
+		v.structIntegrity[typ] = &structIntegrity{fn: fn, skipFields: skipFields}
+	}
+}
+
+// RegisterStructValidationMapRules registers validate map rules, keyed by field name,
+// for struct types whose fields cannot be annotated with tags.
+// Rules are merged into the struct cache, registering rules for the same type
+// again adds to or replaces the previously registered field rules.
 // Be aware that map validation rules supersede those defined on a/the struct if present.
+// The rules of undefined fields are ignored, see RegisterStructValidationMapRulesStrict.
 //
 // NOTE: this method is not thread-safe it is intended that these all be registered prior to any validation
 func (v *Validate) RegisterStructValidationMapRules(rules map[string]string, types ...interface{}) {
@@ -211,22 +376,337 @@ func (v *Validate) RegisterStructValidationMapRules(rules map[string]string, typ
 		v.rules = make(map[reflect.Type]map[string]string)
 	}
 
-	deepCopyRules := make(map[string]string)
-	for i, rule := range rules {
-		deepCopyRules[i] = rule
+	for _, t := range types {
+		typ := reflect.TypeOf(t)
+		if typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+
+		if typ.Kind() != reflect.Struct {
+			continue
+		}
+
+		typeRules := make(map[string]string, len(v.rules[typ])+len(rules))
+		for field, rule := range v.rules[typ] {
+			typeRules[field] = rule
+		}
+
+		for field, rule := range rules {
+			typeRules[field] = rule
+		}
+
+		v.rules[typ] = typeRules
 	}
+}
 
+// RegisterStructValidationMapRulesStrict registers validate map rules as RegisterStructValidationMapRules,
+// catching misspelled field names, e. g. at startup. It returns an error naming the fields that aren't
+// direct fields of the struct types, e. g. undefined or promoted from an embedded struct,
+// registering no rules in that case.
+//
+// NOTE: this method is not thread-safe it is intended that these all be registered prior to any validation
+func (v *Validate) RegisterStructValidationMapRulesStrict(rules map[string]string, types ...interface{}) error {
+	var errs []error
 	for _, t := range types {
 		typ := reflect.TypeOf(t)
 		if typ.Kind() == reflect.Ptr {
 			typ = typ.Elem()
 		}
 
-		if typ.Kind() == reflect.Struct {
-			v.rules[typ] = deepCopyRules
+		if typ.Kind() != reflect.Struct {
+			continue
+		}
+
+		fields := make([]string, 0, len(rules))
+		for field := range rules {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+
+		for _, field := range fields {
+			if f, ok := typ.FieldByName(field); !ok || len(f.Index) != 1 {
+				errs = append(errs, fmt.Errorf("undefined field '%s' on struct '%s'", field, typ.Name()))
+			}
 		}
+	}
 
+	if len(errs) > 0 {
+		return errors.Join(errs...)
 	}
+
+	v.RegisterStructValidationMapRules(rules, types...)
+	return nil
+}
+
+// AssertRules reports whether the struct type of t still declares the expected rules, keyed by field name,
+// catching rules accidentally deleted or changed during refactors, e. g. in a test:
+//
+//	if err := validate.AssertRules(User{}, map[string]string{"Email": "required,email"}); err != nil {
+//	    t.Fatal(err)
+//	}
+//
+// The parsed rules are compared rather than the tags, so an alias and its expansion are equal.
+// The expected rule of a field skipped using the '-' tag is "-".
+// It returns an error describing each field whose rules don't match,
+// fields without expected rules aren't compared.
+func (v *Validate) AssertRules(t interface{}, expected map[string]string) error {
+	typ := reflect.TypeOf(t)
+	if typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return &InvalidValidationError{Type: reflect.TypeOf(t)}
+	}
+
+	cs, ok := v.structCache.Get(typ)
+	if !ok {
+		cs = v.extractStructCache(reflect.New(typ).Elem(), typ.Name())
+	}
+
+	fields := make([]string, 0, len(expected))
+	for field := range expected {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var errs []error
+	for _, field := range fields {
+		if f, ok := typ.FieldByName(field); !ok || len(f.Index) != 1 {
+			errs = append(errs, fmt.Errorf("undefined field '%s' on struct '%s'", field, typ.Name()))
+			continue
+		}
+
+		actual := skipValidationTag
+		var actualRules []Rule
+		for _, f := range cs.fields {
+			if f.name == field {
+				actualRules = appendRules(nil, f.cTags)
+				actual = formatRules(actualRules)
+				break
+			}
+		}
+
+		want := expected[field]
+		if want == skipValidationTag || actual == skipValidationTag {
+			if want != actual {
+				errs = append(errs, fmt.Errorf("field '%s' on struct '%s' has rules '%s', expected '%s'", field, typ.Name(), actual, want))
+			}
+			continue
+		}
+
+		var wantRules []Rule
+		if len(want) > 0 {
+			ct, _ := v.parseFieldTagsRecursive(want, field, "", false)
+			wantRules = appendRules(nil, ct)
+		}
+
+		if !equalRules(actualRules, wantRules) {
+			errs = append(errs, fmt.Errorf("field '%s' on struct '%s' has rules '%s', expected '%s'", field, typ.Name(), actual, formatRules(wantRules)))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// FieldDescription is a field of a struct type as validated, see Validate.Describe.
+type FieldDescription struct {
+	Name  string       // name of the field in errors, e. g. 'email' when using RegisterTagNameFunc
+	Field string       // name of the struct field, e. g. 'Email'
+	Type  reflect.Type // type of the struct field
+	Rules []Rule       // parsed rules of the field, aliases being expanded
+}
+
+// Describe returns the fields of the struct type of t as parsed into the struct cache,
+// allowing tools to export the rules, e. g. as OpenAPI schemas.
+// Fields skipped using the '-' tag aren't described.
+func (v *Validate) Describe(t interface{}) ([]FieldDescription, error) {
+	typ := reflect.TypeOf(t)
+	if typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return nil, &InvalidValidationError{Type: reflect.TypeOf(t)}
+	}
+
+	cs, ok := v.structCache.Get(typ)
+	if !ok {
+		cs = v.extractStructCache(reflect.New(typ).Elem(), typ.Name())
+	}
+
+	fields := make([]FieldDescription, len(cs.fields))
+	for i, f := range cs.fields {
+		fields[i] = FieldDescription{
+			Name:  f.altName,
+			Field: f.name,
+			Type:  typ.Field(f.idx).Type,
+			Rules: appendRules(nil, f.cTags),
+		}
+	}
+	return fields, nil
+}
+
+// FieldRules are the rules applied to the values at a path of a struct type, see Validate.Rules.
+type FieldRules struct {
+	Path  string       // path of the values using the names in errors, e. g. 'Address.City', 'Emails[]' for the elements of Emails or 'Pair[0]'
+	Field string       // name of the struct field, e. g. 'Emails'
+	Type  reflect.Type // type of the values
+	Depth int          // number of dives to reach the values, e. g. 1 for 'Emails[]'
+	Keys  bool         // true if the rules apply to the keys of the map at Path
+	Rules []Rule       // parsed rules, aliases being expanded
+}
+
+// Rules returns the rules applied when validating the struct type of t, once per path:
+// the fields of t, the elements of its collections reached by dive and positional rules,
+// and the fields of its nested struct types, allowing validation docs or form constraints
+// to be rendered from the rules of the backend.
+// Paths without rules, e. g. fields without tags, aren't returned.
+func (v *Validate) Rules(t interface{}) ([]FieldRules, error) {
+	typ := reflect.TypeOf(t)
+	if typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return nil, &InvalidValidationError{Type: reflect.TypeOf(t)}
+	}
+	return v.appendStructRules(nil, typ, "", 0, make(map[reflect.Type]struct{})), nil
+}
+
+// appendStructRules appends the rules of the fields of the struct type typ at prefix to rules.
+// The fields of recursive types aren't described again within themselves.
+func (v *Validate) appendStructRules(rules []FieldRules, typ reflect.Type, prefix string, depth int, seen map[reflect.Type]struct{}) []FieldRules {
+	if _, ok := seen[typ]; ok {
+		return rules
+	}
+	seen[typ] = struct{}{}
+	defer delete(seen, typ)
+
+	cs, ok := v.structCache.Get(typ)
+	if !ok {
+		cs = v.extractStructCache(reflect.New(typ).Elem(), typ.Name())
+	}
+
+	for _, f := range cs.fields {
+		rules = v.appendFieldRules(rules, f.cTags, typ.Field(f.idx).Type, prefix+f.altName, f.name, depth, seen)
+	}
+	return rules
+}
+
+// appendFieldRules appends the rules of the ct chain applied to the values of type typ at path to rules,
+// followed by the rules of the elements it dives into or of the fields of the struct type typ.
+func (v *Validate) appendFieldRules(rules []FieldRules, ct *cTag, typ reflect.Type, path, field string, depth int, seen map[reflect.Type]struct{}) []FieldRules {
+	fr := FieldRules{Path: path, Field: field, Type: typ, Depth: depth}
+	flush := func() {
+		if len(fr.Rules) > 0 {
+			rules = append(rules, fr)
+		}
+	}
+
+	for ; ct != nil; ct = ct.next {
+		switch ct.typeof {
+		case typeDive:
+			flush()
+			elem := derefType(typ)
+			if elem.Kind() == reflect.Map && ct.next != nil && ct.next.typeof == typeKeys {
+				ct = ct.next
+				keys := FieldRules{Path: path + "[]", Field: field, Type: elem.Key(), Depth: depth + 1, Keys: true}
+				for k := ct.keys; k != nil && k.typeof != typeEndKeys; k = k.next {
+					keys.Rules = appendRule(keys.Rules, k)
+				}
+				rules = append(rules, keys)
+			}
+
+			if kind := elem.Kind(); kind != reflect.Slice && kind != reflect.Array && kind != reflect.Map {
+				return rules
+			}
+			return v.appendFieldRules(rules, ct.next, elem.Elem(), path+"[]", field, depth+1, seen)
+		case typeItems:
+			flush()
+			elem := derefType(typ)
+			if kind := elem.Kind(); kind != reflect.Slice && kind != reflect.Array {
+				return rules
+			}
+
+			for i, itemCt := range ct.items {
+				if itemCt != nil {
+					rules = v.appendFieldRules(rules, itemCt, elem.Elem(), path+"["+strconv.Itoa(i)+"]", field, depth+1, seen)
+				}
+			}
+
+			if ct.rest != nil {
+				rules = v.appendFieldRules(rules, ct.rest, elem.Elem(), path+"[]", field, depth+1, seen)
+			}
+			return rules
+		case typeStructOnly:
+			// the fields of the struct aren't validated
+			fr.Rules = appendRule(fr.Rules, ct)
+			flush()
+			return rules
+		default:
+			fr.Rules = appendRule(fr.Rules, ct)
+		}
+	}
+
+	flush()
+	if elem := derefType(typ); elem.Kind() == reflect.Struct && !v.isLeafType(elem) {
+		if _, ok := v.customFuncs[elem]; !ok {
+			rules = v.appendStructRules(rules, elem, path+".", depth, seen)
+		}
+	}
+	return rules
+}
+
+// derefType returns the type pointed to by typ, dereferencing it as many times as needed.
+func derefType(typ reflect.Type) reflect.Type {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ
+}
+
+// HasRules reports whether the struct type of t has rules, i. e. whether validating its values can fail:
+// one of its fields has a tag or map rules, it has a struct level validation, an integrity check or a pipeline,
+// or one of its nested struct types, including the elements of its collections, has rules.
+// It allows skipping the validation of types without rules, e. g. in interceptors.
+func (v *Validate) HasRules(t interface{}) bool {
+	typ := reflect.TypeOf(t)
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return false
+	}
+	return v.hasRules(typ, make(map[reflect.Type]struct{}))
+}
+
+// hasRules reports whether the struct type typ has rules, traversing its nested struct types once.
+func (v *Validate) hasRules(typ reflect.Type, seen map[reflect.Type]struct{}) bool {
+	if _, ok := seen[typ]; ok {
+		return false
+	}
+	seen[typ] = struct{}{}
+
+	cs, ok := v.structCache.Get(typ)
+	if !ok {
+		cs = v.extractStructCache(reflect.New(typ).Elem(), typ.Name())
+	}
+
+	if cs.fn != nil || cs.integrity != nil || cs.stages != nil {
+		return true
+	}
+
+	for _, f := range cs.fields {
+		if f.cTags.hasTag || f.cTags.typeof != typeDefault || f.cTags.next != nil {
+			return true
+		}
+
+		if nested := v.coverStructType(typ.Field(f.idx).Type); nested != nil && v.hasRules(nested, seen) {
+			return true
+		}
+	}
+	return false
 }
 
 // RegisterTagNameFunc registers a function to get alternate names for StructFields.
@@ -241,11 +721,115 @@ func (v *Validate) RegisterStructValidationMapRules(rules map[string]string, typ
 //	    }
 //	    return name
 //	})
+//
+// The same is provided by JSONTagNameFunc, see also WithFieldNameTags.
 func (v *Validate) RegisterTagNameFunc(fn TagNameFunc) {
 	v.tagNameFunc = fn
 	v.hasTagNameFunc = true
 }
 
+// JSONTagNameFunc is a TagNameFunc returning the name of the field's `json` tag,
+// see StructTagNameFunc.
+func JSONTagNameFunc(fld reflect.StructField) string {
+	return structTagName(fld, "json")
+}
+
+// BSONTagNameFunc is a TagNameFunc returning the name of the field's `bson` tag,
+// see StructTagNameFunc.
+func BSONTagNameFunc(fld reflect.StructField) string {
+	return structTagName(fld, "bson")
+}
+
+// YAMLTagNameFunc is a TagNameFunc returning the name of the field's `yaml` tag,
+// see StructTagNameFunc.
+func YAMLTagNameFunc(fld reflect.StructField) string {
+	return structTagName(fld, "yaml")
+}
+
+// MapstructureTagNameFunc is a TagNameFunc returning the name of the field's `mapstructure` tag,
+// see StructTagNameFunc.
+func MapstructureTagNameFunc(fld reflect.StructField) string {
+	return structTagName(fld, "mapstructure")
+}
+
+// StructTagNameFunc returns a TagNameFunc returning the name of the field's struct tag with the given key,
+// i. e. the tag value up to the first comma.
+// A missing, empty or ignored ('-') name returns an empty string, keeping the field's name.
+func StructTagNameFunc(key string) TagNameFunc {
+	return func(fld reflect.StructField) string {
+		return structTagName(fld, key)
+	}
+}
+
+// ChainTagNameFuncs returns a TagNameFunc returning the first non-empty name
+// returned by fns, in order, e. g. falling back from the `json` to the `bson` tag:
+//
+//	validate.RegisterTagNameFunc(validator.ChainTagNameFuncs(validator.JSONTagNameFunc, validator.BSONTagNameFunc))
+func ChainTagNameFuncs(fns ...TagNameFunc) TagNameFunc {
+	return func(fld reflect.StructField) string {
+		for _, fn := range fns {
+			if name := fn(fld); len(name) > 0 {
+				return name
+			}
+		}
+		return ""
+	}
+}
+
+// structTagName returns the name of the field's struct tag with the given key.
+func structTagName(fld reflect.StructField, key string) string {
+	name, _, _ := strings.Cut(fld.Tag.Get(key), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+// RegisterKeyFormatter registers the KeyFormatter rendering the map keys and slice indices of the namespaces
+// of the errors, e. g. to quote string keys or shorten long or binary keys, see ReadableKeys.
+// The default renders them with fmt, e. g. 'Labels[a b]' or 'Items[0]'.
+//
+// NOTE: StructPartial, StructExcept and Lookup parse namespaces with the default rendering,
+// and this method is not thread-safe it is intended that it be registered prior to any validation.
+func (v *Validate) RegisterKeyFormatter(fn KeyFormatter) {
+	v.keyFormatter = fn
+}
+
+// ReadableKeys returns a KeyFormatter quoting string keys, e. g. 'Labels["a b"]',
+// encoding the byte array keys, e. g. of [32]byte hashes, in base64,
+// and shortening the keys longer than maxLen runes with an ellipsis, 0 keeping them whole.
+// Slice indices and other keys are rendered with fmt.
+func ReadableKeys(maxLen int) KeyFormatter {
+	return func(key interface{}) string {
+		rv := reflect.ValueOf(key)
+		switch {
+		case rv.Kind() == reflect.String:
+			return strconv.Quote(shortenKey(rv.String(), maxLen))
+		case rv.Kind() == reflect.Array && rv.Type().Elem().Kind() == reflect.Uint8:
+			b := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(b), rv)
+			return shortenKey(base64.StdEncoding.EncodeToString(b), maxLen)
+		default:
+			return shortenKey(fmt.Sprint(key), maxLen)
+		}
+	}
+}
+
+// shortenKey returns the first maxLen runes of s followed by an ellipsis when s is longer.
+func shortenKey(s string, maxLen int) string {
+	if maxLen <= 0 || utf8.RuneCountInString(s) <= maxLen {
+		return s
+	}
+
+	for i := range s {
+		if maxLen == 0 {
+			return s[:i] + "…"
+		}
+		maxLen--
+	}
+	return s
+}
+
 // RegisterCustomTypeFunc registers a CustomTypeFunc against a number of types.
 //
 // NOTE: this method is not thread-safe it is intended that these all be registered prior to any validation.
@@ -261,6 +845,36 @@ func (v *Validate) RegisterCustomTypeFunc(fn CustomTypeFunc, types ...interface{
 	v.hasCustomFuncs = true
 }
 
+// RegisterJSONStruct registers the struct type of t under name for use with the jsonstruct tag,
+// decoding the JSON of string and []byte fields into the type and validating it, e. g.
+//
+//	validate.RegisterJSONStruct("Settings", Settings{})
+//
+// and `validate:"jsonstruct=Settings"`, the errors of the decoded struct being reported
+// beneath the field, e. g. 'Account.Settings.Theme'.
+// Registering a type of an existing name replaces it.
+//
+// NOTE: this method is not thread-safe it is intended that these all be registered prior to any validation.
+func (v *Validate) RegisterJSONStruct(name string, t interface{}) {
+	if len(name) == 0 {
+		panic("JSON struct name cannot be empty")
+	}
+
+	typ := reflect.TypeOf(t)
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ == nil || typ.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("JSON struct '%s' must be a struct, not %v", name, reflect.TypeOf(t)))
+	}
+
+	if v.jsonStructs == nil {
+		v.jsonStructs = make(map[string]reflect.Type)
+	}
+	v.jsonStructs[name] = typ
+}
+
 // SetTagName allows for changing of the default tag name of 'validate'.
 func (v *Validate) SetTagName(name string) {
 	v.tagName = name
@@ -273,6 +887,55 @@ func (v *Validate) SetTagName(name string) {
 // It returns InvalidValidationError for bad values passed in and nil or ValidationErrors as error otherwise.
 // To access the error array, assert the error unless it is nil, e. g. err.(validator.ValidationErrors).
 func (v *Validate) StructCtx(ctx context.Context, s interface{}) (err error) {
+	return v.structCtx(ctx, s, nil)
+}
+
+// StructVersion validates a structs exposed fields, like StructCtx, using the rules of the given payload version
+// of the versioned tags, e. g. `validate:"v1:required;v2:omitempty,uuid4"`,
+// so an evolving API can keep one struct with versioned constraints.
+// A leading segment without a version applies to the versions without a segment,
+// e. g. `validate:"required;v3:omitempty"`, and fields without a segment applying to the version aren't validated.
+// Struct and the other methods use the segment without a version.
+//
+// Versions are 'v' followed by dot separated numbers, e. g. 'v2' or 'v2.1',
+// other 'word:' prefixes being kept as part of the tags.
+func (v *Validate) StructVersion(ctx context.Context, s interface{}, version string) error {
+	if len(version) == 0 {
+		return v.structCtx(ctx, s, nil)
+	}
+	return v.structCtx(ctx, s, v.versionCache(version))
+}
+
+// versionCache returns the struct cache of the given payload version.
+// Only the caches of the versions of the parsed versioned tags are kept,
+// so the versions supplied by callers can't grow the caches without bound.
+func (v *Validate) versionCache(version string) *structCache {
+	if sc, ok := v.versionCaches.Load(version); ok {
+		return sc.(*structCache)
+	}
+
+	sc := &structCache{version: version}
+	sc.m.Store(make(map[reflect.Type]*cStruct))
+	if _, ok := v.tagVersions.Load(version); !ok {
+		return sc
+	}
+
+	actual, _ := v.versionCaches.LoadOrStore(version, sc)
+	return actual.(*structCache)
+}
+
+// structCtx validates s using the struct cache sc, nil being the default one.
+func (v *Validate) structCtx(ctx context.Context, s interface{}, sc *structCache) (err error) {
+	if v.hooked() {
+		var call *ValidationCall
+		ctx, call = v.startCall(ctx, "Struct", reflect.TypeOf(s))
+		defer v.endCall(ctx, call, &err)
+	}
+
+	if v.strictErrors {
+		defer recoverStrict(&err, reflect.TypeOf(s))
+	}
+
 	val := reflect.ValueOf(s)
 	top := val
 	if val.Kind() == reflect.Ptr && !val.IsNil() {
@@ -286,14 +949,16 @@ func (v *Validate) StructCtx(ctx context.Context, s interface{}) (err error) {
 	// good to validate
 	vd := v.pool.Get().(*validate)
 	vd.top = top
+	vd.sampleHit = v.sample()
+	vd.loadCallState(ctx)
 	vd.isPartial = false
+	vd.sc = sc
 	// vd.hasExcludes = false // only need to reset in StructPartial and StructExcept
-	vd.validateStruct(ctx, top, val, val.Type(), vd.ns[0:0], vd.actualNs[0:0], nil)
-	if len(vd.errs) > 0 {
-		err = vd.errs
-		vd.errs = nil
-	}
+	typ := val.Type()
+	vd.validateStruct(ctx, top, val, typ, vd.ns[0:0], vd.actualNs[0:0], nil)
+	err = vd.result()
 
+	vd.sc = nil
 	v.pool.Put(vd)
 	return
 }
@@ -307,6 +972,80 @@ func (v *Validate) Struct(s interface{}) error {
 	return v.StructCtx(context.Background(), s)
 }
 
+// StructPassedCtx validates s like StructCtx and also returns the namespaces of the struct fields that passed,
+// e. g. 'User.Email', in traversal order, allowing to accept the valid part of partially invalid data
+// without validating each field again. A nested struct field passes when all of its fields pass,
+// and the fields of a struct failing its integrity check don't pass, see RegisterStructIntegrity.
+// The fields are collected using the Audit of ctx, a new one being used when ctx has none, see NewAuditContext.
+//
+// It returns InvalidValidationError for bad values passed in and nil or ValidationErrors as error otherwise.
+func (v *Validate) StructPassedCtx(ctx context.Context, s interface{}) (passed []string, err error) {
+	audit, ok := AuditFromContext(ctx)
+	if !ok {
+		ctx, audit = NewAuditContext(ctx, nil)
+	}
+
+	start := len(audit.Fields)
+	if err = v.StructCtx(ctx, s); err != nil {
+		var invalid *InvalidValidationError
+		if errors.As(err, &invalid) {
+			return nil, err
+		}
+	}
+
+	var failed, failedStructs []string
+	errs := []error{err}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joined.Unwrap()
+	}
+
+	for _, e := range errs {
+		switch e := e.(type) {
+		case ValidationErrors:
+			for _, fe := range e {
+				failed = append(failed, fe.Namespace())
+			}
+		case *TruncatedValidationError:
+			for _, fe := range e.Errors {
+				failed = append(failed, fe.Namespace())
+			}
+		case *IntegrityError:
+			failedStructs = append(failedStructs, e.Namespace)
+		}
+	}
+
+	passed = make([]string, 0, len(audit.Fields)-start)
+OUTER:
+	for _, f := range audit.Fields[start:] {
+		for _, ns := range failed {
+			if withinNamespace(ns, f.Namespace) {
+				continue OUTER
+			}
+		}
+
+		for _, ns := range failedStructs {
+			if withinNamespace(f.Namespace, ns) {
+				continue OUTER
+			}
+		}
+		passed = append(passed, f.Namespace)
+	}
+	return passed, err
+}
+
+// StructPassed validates s like Struct and also returns the namespaces of the struct fields that passed,
+// see StructPassedCtx.
+func (v *Validate) StructPassed(s interface{}) ([]string, error) {
+	return v.StructPassedCtx(context.Background(), s)
+}
+
+// withinNamespace reports whether the namespace ns is parent or one of its fields or elements,
+// e. g. 'User.Tags[1]' is within 'User.Tags'.
+func withinNamespace(ns, parent string) bool {
+	rest, ok := strings.CutPrefix(ns, parent)
+	return ok && (len(rest) == 0 || strings.HasPrefix(rest, namespaceSeparator) || strings.HasPrefix(rest, leftBracket))
+}
+
 // StructPartialCtx validates the fields passed in only,
 // ignoring all others and allows passing of contextual
 // validation information vis context.Context.
@@ -316,6 +1055,16 @@ func (v *Validate) Struct(s interface{}) error {
 // It returns InvalidValidationError for bad values passed in and nil or ValidationErrors as error otherwise.
 // To access the error array, assert the error unless it is nil, e. g. err.(validator.ValidationErrors).
 func (v *Validate) StructPartialCtx(ctx context.Context, s interface{}, fields ...string) (err error) {
+	if v.hooked() {
+		var call *ValidationCall
+		ctx, call = v.startCall(ctx, "StructPartial", reflect.TypeOf(s))
+		defer v.endCall(ctx, call, &err)
+	}
+
+	if v.strictErrors {
+		defer recoverStrict(&err, reflect.TypeOf(s))
+	}
+
 	val := reflect.ValueOf(s)
 	top := val
 	if val.Kind() == reflect.Ptr && !val.IsNil() {
@@ -329,6 +1078,8 @@ func (v *Validate) StructPartialCtx(ctx context.Context, s interface{}, fields .
 	// good to validate
 	vd := v.pool.Get().(*validate)
 	vd.top = top
+	vd.sampleHit = v.sample()
+	vd.loadCallState(ctx)
 	vd.isPartial = true
 	vd.ffn = nil
 	vd.hasExcludes = false
@@ -367,10 +1118,7 @@ func (v *Validate) StructPartialCtx(ctx context.Context, s interface{}, fields .
 	}
 
 	vd.validateStruct(ctx, top, val, typ, vd.ns[0:0], vd.actualNs[0:0], nil)
-	if len(vd.errs) > 0 {
-		err = vd.errs
-		vd.errs = nil
-	}
+	err = vd.result()
 
 	v.pool.Put(vd)
 	return
@@ -393,6 +1141,16 @@ func (v *Validate) StructPartial(s interface{}, fields ...string) error {
 // It returns InvalidValidationError for bad values passed in and nil or ValidationErrors as error otherwise.
 // To access the error array, assert the error unless it is nil, e. g. err.(validator.ValidationErrors).
 func (v *Validate) StructFilteredCtx(ctx context.Context, s interface{}, fn FilterFunc) (err error) {
+	if v.hooked() {
+		var call *ValidationCall
+		ctx, call = v.startCall(ctx, "StructFiltered", reflect.TypeOf(s))
+		defer v.endCall(ctx, call, &err)
+	}
+
+	if v.strictErrors {
+		defer recoverStrict(&err, reflect.TypeOf(s))
+	}
+
 	val := reflect.ValueOf(s)
 	top := val
 
@@ -407,16 +1165,16 @@ func (v *Validate) StructFilteredCtx(ctx context.Context, s interface{}, fn Filt
 	// good to validate
 	vd := v.pool.Get().(*validate)
 	vd.top = top
+	vd.sampleHit = v.sample()
+	vd.loadCallState(ctx)
 	vd.isPartial = true
 	vd.ffn = fn
 	// vd.hasExcludes = false // only need to reset in StructPartial and StructExcept
 
-	vd.validateStruct(ctx, top, val, val.Type(), vd.ns[0:0], vd.actualNs[0:0], nil)
+	typ := val.Type()
+	vd.validateStruct(ctx, top, val, typ, vd.ns[0:0], vd.actualNs[0:0], nil)
 
-	if len(vd.errs) > 0 {
-		err = vd.errs
-		vd.errs = nil
-	}
+	err = vd.result()
 
 	v.pool.Put(vd)
 
@@ -444,6 +1202,16 @@ func (v *Validate) StructFiltered(s interface{}, fn FilterFunc) error {
 // It returns InvalidValidationError for bad values passed in and nil or ValidationErrors as error otherwise.
 // To access the error array, assert the error unless it is nil, e. g. err.(validator.ValidationErrors).
 func (v *Validate) StructExceptCtx(ctx context.Context, s interface{}, fields ...string) (err error) {
+	if v.hooked() {
+		var call *ValidationCall
+		ctx, call = v.startCall(ctx, "StructExcept", reflect.TypeOf(s))
+		defer v.endCall(ctx, call, &err)
+	}
+
+	if v.strictErrors {
+		defer recoverStrict(&err, reflect.TypeOf(s))
+	}
+
 	val := reflect.ValueOf(s)
 	top := val
 	if val.Kind() == reflect.Ptr && !val.IsNil() {
@@ -457,6 +1225,8 @@ func (v *Validate) StructExceptCtx(ctx context.Context, s interface{}, fields ..
 	// good to validate
 	vd := v.pool.Get().(*validate)
 	vd.top = top
+	vd.sampleHit = v.sample()
+	vd.loadCallState(ctx)
 	vd.isPartial = true
 	vd.ffn = nil
 	vd.hasExcludes = true
@@ -475,10 +1245,7 @@ func (v *Validate) StructExceptCtx(ctx context.Context, s interface{}, fields ..
 	}
 
 	vd.validateStruct(ctx, top, val, typ, vd.ns[0:0], vd.actualNs[0:0], nil)
-	if len(vd.errs) > 0 {
-		err = vd.errs
-		vd.errs = nil
-	}
+	err = vd.result()
 
 	v.pool.Put(vd)
 	return
@@ -511,6 +1278,16 @@ func (v *Validate) StructExcept(s interface{}, fields ...string) error {
 // e. g. err.(validator.ValidationErrors).
 // Validate Array, Slice and maps fields which may contain more than one error.
 func (v *Validate) VarCtx(ctx context.Context, field interface{}, tag string) (err error) {
+	if v.hooked() {
+		var call *ValidationCall
+		ctx, call = v.startCall(ctx, "Var", reflect.TypeOf(field))
+		defer v.endCall(ctx, call, &err)
+	}
+
+	if v.strictErrors {
+		defer recoverStrict(&err, reflect.TypeOf(field))
+	}
+
 	if len(tag) == 0 || tag == skipValidationTag {
 		return nil
 	}
@@ -519,12 +1296,11 @@ func (v *Validate) VarCtx(ctx context.Context, field interface{}, tag string) (e
 	val := reflect.ValueOf(field)
 	vd := v.pool.Get().(*validate)
 	vd.top = val
+	vd.sampleHit = v.sample()
+	vd.loadCallState(ctx)
 	vd.isPartial = false
 	vd.traverseField(ctx, val, val, vd.ns[0:0], vd.actualNs[0:0], defaultCField, ctag)
-	if len(vd.errs) > 0 {
-		err = vd.errs
-		vd.errs = nil
-	}
+	err = vd.result()
 
 	v.pool.Put(vd)
 	return
@@ -568,6 +1344,16 @@ func (v *Validate) Var(field interface{}, tag string) error {
 // e. g. err.(validator.ValidationErrors).
 // Validate Array, Slice and maps fields which may contain more than one error
 func (v *Validate) VarWithValueCtx(ctx context.Context, field interface{}, other interface{}, tag string) (err error) {
+	if v.hooked() {
+		var call *ValidationCall
+		ctx, call = v.startCall(ctx, "VarWithValue", reflect.TypeOf(field))
+		defer v.endCall(ctx, call, &err)
+	}
+
+	if v.strictErrors {
+		defer recoverStrict(&err, reflect.TypeOf(field))
+	}
+
 	if len(tag) == 0 || tag == skipValidationTag {
 		return nil
 	}
@@ -576,12 +1362,11 @@ func (v *Validate) VarWithValueCtx(ctx context.Context, field interface{}, other
 	otherVal := reflect.ValueOf(other)
 	vd := v.pool.Get().(*validate)
 	vd.top = otherVal
+	vd.sampleHit = v.sample()
+	vd.loadCallState(ctx)
 	vd.isPartial = false
 	vd.traverseField(ctx, otherVal, reflect.ValueOf(field), vd.ns[0:0], vd.actualNs[0:0], defaultCField, ctag)
-	if len(vd.errs) > 0 {
-		err = vd.errs
-		vd.errs = nil
-	}
+	err = vd.result()
 
 	v.pool.Put(vd)
 	return
@@ -610,9 +1395,20 @@ func (v *Validate) VarWithValue(field interface{}, other interface{}, tag string
 // ValidateMapCtx validates a map using a map of
 // validation rules and allows passing of
 // contextual validation information vis context.Context.
+//
+// With WithStrictMaps, the keys of data and of its nested maps having no rules are reported
+// as 'unknown_key' errors and the keys having rules missing from data as 'missing_key' errors,
+// unless their rules start with omitempty, omitnil or omitzero.
 func (v Validate) ValidateMapCtx(ctx context.Context, data map[string]interface{}, rules map[string]interface{}) map[string]interface{} {
 	errs := make(map[string]interface{})
 	for field, rule := range rules {
+		if v.strictMaps {
+			if _, ok := data[field]; !ok && !optionalMapRule(rule) {
+				errs[field] = v.mapKeyError(missingKeyTag, field, nil)
+				continue
+			}
+		}
+
 		if ruleObj, ok := rule.(map[string]interface{}); ok {
 			if dataObj, ok := data[field].(map[string]interface{}); ok {
 				if err := v.ValidateMapCtx(ctx, dataObj, ruleObj); len(err) > 0 {
@@ -633,14 +1429,146 @@ func (v Validate) ValidateMapCtx(ctx context.Context, data map[string]interface{
 			}
 		}
 	}
+
+	if v.strictMaps {
+		for field, value := range data {
+			if _, ok := rules[field]; !ok {
+				errs[field] = v.mapKeyError(unknownKeyTag, field, value)
+			}
+		}
+	}
 	return errs
 }
 
+// optionalMapRule reports whether the key of rule may be missing from the data, see WithStrictMaps.
+func optionalMapRule(rule interface{}) bool {
+	ruleStr, ok := rule.(string)
+	if !ok {
+		return false
+	}
+
+	first, _, _ := strings.Cut(ruleStr, tagSeparator)
+	return first == omitempty || first == omitnil || first == omitzero
+}
+
+// mapKeyError returns the ValidationErrors of the key field of a map failing the strict tag, see WithStrictMaps.
+func (v Validate) mapKeyError(tag, field string, value interface{}) ValidationErrors {
+	fe := &fieldError{
+		v:              &v,
+		tag:            tag,
+		actualTag:      tag,
+		ns:             field,
+		structNs:       field,
+		fieldLen:       uint8(len(field)),
+		structfieldLen: uint8(len(field)),
+		value:          value,
+	}
+
+	if value != nil {
+		fe.typ = reflect.TypeOf(value)
+		fe.kind = fe.typ.Kind()
+	}
+	return ValidationErrors{fe}
+}
+
 // ValidateMap validates map data from a map of tags.
 func (v *Validate) ValidateMap(data map[string]interface{}, rules map[string]interface{}) map[string]interface{} {
 	return v.ValidateMapCtx(context.Background(), data, rules)
 }
 
+// Lookup returns the value of the field of s at the given struct namespace,
+// as returned by FieldError.StructNamespace, e. g. 'User.Addresses[0].City',
+// and whether it was found, allowing to fetch the offending values of errors.
+// Pointers and interfaces along the path are dereferenced and custom types are converted
+// by their CustomTypeFunc, the same as when validating.
+//
+// NOTE: found is false when the namespace doesn't start with the struct's name, is malformed,
+// goes deeper than a leaf value, e. g. a time.Time, or a nil pointer, missing index or missing map key is on the path.
+func (v *Validate) Lookup(s interface{}, namespace string) (current reflect.Value, found bool) {
+	val := reflect.ValueOf(s)
+	top := val
+	for top.Kind() == reflect.Ptr && !top.IsNil() {
+		top = top.Elem()
+	}
+
+	if top.Kind() == reflect.Struct && len(namespace) > 0 {
+		rest, ok := strings.CutPrefix(namespace, top.Type().Name())
+		if !ok || (len(rest) > 0 && !strings.HasPrefix(rest, namespaceSeparator)) {
+			return reflect.Value{}, false
+		}
+		namespace = strings.TrimPrefix(rest, namespaceSeparator)
+	}
+
+	return v.lookup(val, namespace)
+}
+
+// lookup returns the field of val at the namespace relative to val, e. g. 'Address.City'.
+func (v *Validate) lookup(val reflect.Value, namespace string) (current reflect.Value, found bool) {
+	vd := v.pool.Get().(*validate)
+	defer v.pool.Put(vd)
+	defer func() {
+		// a namespace going deeper than the value or malformed isn't found
+		if recover() != nil {
+			current, found = reflect.Value{}, false
+		}
+	}()
+
+	current, _, _, found = vd.getStructFieldOKInternal(val, namespace)
+	return current, found
+}
+
+// Set assigns value to the field of s at the given struct namespace, see Lookup,
+// allowing to apply fixes to the offending values of errors.
+// s must be a pointer for the field to be addressable, and value must be assignable
+// or convertible, keeping its kind, to the field's type, integers and floats are converted to
+// the field's size when they fit, e. g. an int can set an int64 field. A nil value sets the field's zero value.
+//
+// It returns an error if the field isn't found, can't be set, e. g. it's unexported or a map element,
+// or value has the wrong type.
+func (v *Validate) Set(s interface{}, namespace string, value interface{}) error {
+	field, ok := v.Lookup(s, namespace)
+	if !ok {
+		return fmt.Errorf("validator: field '%s' not found", namespace)
+	}
+
+	if !field.CanSet() {
+		return fmt.Errorf("validator: field '%s' can't be set", namespace)
+	}
+
+	if value == nil {
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+
+	val := reflect.ValueOf(value)
+	switch {
+	case val.Type().AssignableTo(field.Type()):
+		field.Set(val)
+	case val.Kind() == field.Kind() && val.Type().ConvertibleTo(field.Type()):
+		field.Set(val.Convert(field.Type()))
+	case isIntKind(val.Kind()) && isIntKind(field.Kind()) && !field.OverflowInt(val.Int()):
+		field.SetInt(val.Int())
+	case isUintKind(val.Kind()) && isUintKind(field.Kind()) && !field.OverflowUint(val.Uint()):
+		field.SetUint(val.Uint())
+	case isFloatKind(val.Kind()) && isFloatKind(field.Kind()) && !field.OverflowFloat(val.Float()):
+		field.SetFloat(val.Float())
+	default:
+		return fmt.Errorf("validator: can't set field '%s' of type %s to a value of type %s", namespace, field.Type(), val.Type())
+	}
+	return nil
+}
+
+// isLeafType reports whether the struct type typ is validated as a single value rather than traversed,
+// i.e. it's convertible to time.Time or registered using WithLeafTypes.
+func (v *Validate) isLeafType(typ reflect.Type) bool {
+	if typ.ConvertibleTo(timeType) {
+		return true
+	}
+
+	_, ok := v.leafTypes[typ]
+	return ok
+}
+
 func (v *Validate) registerValidation(tag string, fn FuncCtx, bakedIn bool, nilCheckable bool) error {
 	if len(tag) == 0 {
 		return errors.New("function Key cannot be empty")
@@ -658,7 +1586,21 @@ func (v *Validate) registerValidation(tag string, fn FuncCtx, bakedIn bool, nilC
 	return nil
 }
 
+type structIntegrity struct {
+	fn         StructIntegrityFunc
+	skipFields bool
+}
+
 type internalValidationFuncWrapper struct {
 	fn                 FuncCtx
 	runValidationOnNil bool
 }
+
+// sample reports whether the sampled tags should run for a validation call, see WithSampling.
+func (v *Validate) sample() bool {
+	if len(v.sampledTags) == 0 || v.sampleRate >= 1 {
+		return true
+	}
+
+	return v.sampleRate > 0 && v.rand() < v.sampleRate
+}
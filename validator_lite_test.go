@@ -0,0 +1,20 @@
+//go:build validator_lite
+
+package validator
+
+import (
+	"testing"
+
+	. "github.com/pchchv/go-assert"
+)
+
+func TestLiteModeExcludesValidators(t *testing.T) {
+	validate := New()
+	for _, tag := range []string{"file", "filepath", "image", "dir", "dirpath", "validateFn"} {
+		_, ok := validate.validations[tag]
+		Equal(t, ok, false)
+	}
+
+	PanicMatches(t, func() { _ = validate.Var("/tmp", "dir") }, "Undefined validation function 'dir' on field ''")
+	Equal(t, validate.Var("abc", "alpha"), nil)
+}
@@ -3,15 +3,19 @@ package validator
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
 	"database/sql"
 	"database/sql/driver"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"image"
 	"image/jpeg"
 	"image/png"
+	"math"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -1419,6 +1423,50 @@ func TestPostCodeByIso3166Alpha2Field_InvalidKind(t *testing.T) {
 	t.Errorf("Didn't panic as expected")
 }
 
+func TestStructValidationMapRules(t *testing.T) {
+	type Account struct {
+		Name  string
+		Email string
+		Age   int `validate:"gte=18"`
+	}
+
+	validate := New()
+	validate.RegisterStructValidationMapRules(map[string]string{"Name": "required"}, Account{})
+	validate.RegisterStructValidationMapRules(map[string]string{"Email": "required,email", "Age": "gte=21"}, &Account{})
+
+	errs := validate.Struct(Account{Email: "joey", Age: 20})
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 3)
+	AssertError(t, errs, "Account.Name", "Account.Name", "Name", "Name", "required")
+	AssertError(t, errs, "Account.Email", "Account.Email", "Email", "Email", "email")
+	AssertError(t, errs, "Account.Age", "Account.Age", "Age", "Age", "gte")
+
+	errs = validate.Struct(Account{Name: "joey", Email: "joey@bloggs.com", Age: 21})
+	Equal(t, errs, nil)
+
+	// the rules of undefined fields are ignored
+	validate.RegisterStructValidationMapRules(map[string]string{"Nmae": "required"}, Account{})
+	Equal(t, validate.Struct(Account{Name: "joey", Email: "joey@bloggs.com", Age: 21}), nil)
+
+	type Embedded struct {
+		Account
+		Note string
+	}
+
+	validate = New()
+	err := validate.RegisterStructValidationMapRulesStrict(map[string]string{"Nmae": "required", "Note": "required"}, Account{})
+	NotEqual(t, err, nil)
+	Equal(t, err.Error(), "undefined field 'Nmae' on struct 'Account'\nundefined field 'Note' on struct 'Account'")
+	Equal(t, validate.Struct(Account{Email: "joey@bloggs.com", Age: 21}), nil)
+
+	err = validate.RegisterStructValidationMapRulesStrict(map[string]string{"Name": "required"}, Embedded{})
+	Equal(t, err.Error(), "undefined field 'Name' on struct 'Embedded'")
+
+	err = validate.RegisterStructValidationMapRulesStrict(map[string]string{"Name": "required"}, &Account{})
+	Equal(t, err, nil)
+	NotEqual(t, validate.Struct(Account{}), nil)
+}
+
 func TestValidate_ValidateMapCtx(t *testing.T) {
 	type args struct {
 		data  map[string]interface{}
@@ -1510,6 +1558,47 @@ func TestValidate_ValidateMapCtx(t *testing.T) {
 	}
 }
 
+func TestValidateMapStrict(t *testing.T) {
+	rules := map[string]interface{}{
+		"name":  "required",
+		"email": "omitempty,email",
+		"address": map[string]interface{}{
+			"city": "required",
+		},
+	}
+
+	validate := New(WithStrictMaps())
+	errs := validate.ValidateMap(map[string]interface{}{
+		"name":    "joey",
+		"address": map[string]interface{}{"city": "Rome"},
+	}, rules)
+	Equal(t, len(errs), 0)
+
+	errs = validate.ValidateMap(map[string]interface{}{
+		"admin":   true,
+		"address": map[string]interface{}{"city": "Rome", "zip": "00100"},
+	}, rules)
+	Equal(t, len(errs), 3)
+
+	fe := errs["admin"].(ValidationErrors)[0]
+	Equal(t, fe.Tag(), "unknown_key")
+	Equal(t, fe.Field(), "admin")
+	Equal(t, fe.Value(), true)
+	Equal(t, fe.Kind(), reflect.Bool)
+	Equal(t, errs["name"].(ValidationErrors)[0].Tag(), "missing_key")
+	Equal(t, errs["name"].(ValidationErrors)[0].Value(), nil)
+	Equal(t, errs["address"].(map[string]interface{})["zip"].(ValidationErrors)[0].Tag(), "unknown_key")
+	Equal(t, Details(errs["name"].(ValidationErrors)[0]).Translate("en"), "name is missing")
+
+	errs = validate.ValidateMap(map[string]interface{}{"name": "joey"}, rules)
+	Equal(t, len(errs), 1)
+	Equal(t, errs["address"].(ValidationErrors)[0].Tag(), "missing_key")
+
+	errs = New().ValidateMap(map[string]interface{}{"name": "joey", "admin": true, "address": map[string]interface{}{}}, rules)
+	Equal(t, len(errs), 1)
+	Equal(t, errs["address"].(map[string]interface{})["city"].(ValidationErrors)[0].Tag(), "required")
+}
+
 func TestEINStringValidation(t *testing.T) {
 	tests := []struct {
 		value    string `validate:"ein"`
@@ -8139,486 +8228,2987 @@ func Test_port_validator(t *testing.T) {
 	}
 }
 
-func TestDurationType(t *testing.T) {
-	tests := []struct {
-		name    string
-		s       interface{} // struct
-		success bool
-	}{
-		{
-			name: "valid duration string pass",
-			s: struct {
-				Value time.Duration `validate:"gte=500ns"`
-			}{
-				Value: time.Second,
-			},
-			success: true,
-		},
-		{
-			name: "valid duration int pass",
-			s: struct {
-				Value time.Duration `validate:"gte=500"`
-			}{
-				Value: time.Second,
-			},
-			success: true,
-		},
-	}
-
+func TestPortClassesAndRanges(t *testing.T) {
 	validate := New()
-	for _, tc := range tests {
-		tc := tc
-		t.Run(tc.name, func(t *testing.T) {
-			t.Parallel()
-			errs := validate.Struct(tc.s)
-			if tc.success {
-				Equal(t, errs, nil)
-				return
-			}
-
-			NotEqual(t, errs, nil)
-		})
-	}
-}
-
-func TestMultiOrOperatorGroup(t *testing.T) {
 	tests := []struct {
-		Value    int `validate:"eq=1|gte=5,eq=1|lt=7"`
+		value    interface{}
+		tag      string
 		expected bool
 	}{
-		{1, true}, {2, false}, {5, true}, {6, true}, {8, false},
+		{80, "port", true},
+		{-1, "port", false},
+		{"8080", "port", true},
+		{"80a", "port", false},
+		{uint16(443), "port=well_known", true},
+		{1024, "port=well_known", false},
+		{8080, "port=registered", true},
+		{49152, "port=registered", false},
+		{49152, "port=ephemeral", true},
+		{8500, "port=range=8000-9000", true},
+		{9001, "port=range=8000-9000", false},
+		{"8000-9000", "port_range", true},
+		{"9000-8000", "port_range", false},
+		{"8000-8000", "port_range", false},
+		{"0-10", "port_range", false},
+		{"8000-70000", "port_range", false},
+		{"8000", "port_range", false},
+		{"50000-60000", "port_range=ephemeral", true},
+		{"8000-60000", "port_range=registered", false},
+		{"8100-8200", "port_range=range=8000-9000", true},
 	}
 
-	validate := New()
 	for i, test := range tests {
-		errs := validate.Struct(test)
+		errs := validate.Var(test.value, test.tag)
 		if test.expected {
 			if !IsEqual(errs, nil) {
-				t.Fatalf("Index: %d multi_group_of_OR_operators failed Error: %s", i, errs)
+				t.Fatalf("Index: %d %s failed Error: %s", i, test.tag, errs)
 			}
 		} else {
 			if IsEqual(errs, nil) {
-				t.Fatalf("Index: %d multi_group_of_OR_operators should have errs", i)
+				t.Fatalf("Index: %d %s failed Error: %s", i, test.tag, errs)
 			}
 		}
 	}
+
+	PanicMatches(t, func() { _ = validate.Var(80, "port=private") }, "Bad param 'private' for 'port'")
+	PanicMatches(t, func() { _ = validate.Var(80, "port=range=9000-8000") }, "Bad param 'range=9000-8000' for 'port'")
 }
 
-func TestCronExpressionValidation(t *testing.T) {
-	tests := []struct {
-		value    string `validate:"cron"`
-		tag      string
-		expected bool
-	}{
-		{"0 0 12 * * ?", "cron", true},
-		{"0 15 10 ? * *", "cron", true},
-		{"0 15 10 * * ?", "cron", true},
-		{"0 15 10 * * ? 2005", "cron", true},
-		{"0 15 10 ? * 6L", "cron", true},
-		{"0 15 10 ? * 6L 2002-2005", "cron", true},
-		{"*/20 * * * *", "cron", true},
-		{"0 15 10 ? * MON-FRI", "cron", true},
-		{"0 15 10 ? * 6#3", "cron", true},
-		{"0 */15 * * *", "cron", true},
-		{"wrong", "cron", false},
+func TestListValidation(t *testing.T) {
+	type Config struct {
+		Brokers string `validate:"hostport_list"`
+		Mirrors string `validate:"omitempty,url_list=sep=;"`
 	}
 
 	validate := New()
-	for i, test := range tests {
-		errs := validate.Var(test.value, test.tag)
-		if test.expected {
-			if !IsEqual(errs, nil) {
-				t.Fatalf(`Index: %d cron "%s" failed Error: %s`, i, test.value, errs)
-			}
-		} else {
-			if IsEqual(errs, nil) {
-				t.Fatalf(`Index: %d cron "%s" should have errs`, i, test.value)
-			}
-		}
+	errs := validate.Struct(Config{Brokers: "kafka-1:9092, kafka-2:9092", Mirrors: "https://a.example.com;https://b.example.com"})
+	Equal(t, errs, nil)
+
+	errs = validate.Struct(Config{Brokers: "kafka-1:9092,kafka-2,kafka-3:0", Mirrors: "https://a.example.com;example.com"})
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 2)
+	AssertError(t, errs, "Config.Brokers", "Config.Brokers", "Brokers", "Brokers", "hostport_list")
+	AssertError(t, errs, "Config.Mirrors", "Config.Mirrors", "Mirrors", "Mirrors", "url_list")
+	Equal(t, getError(errs, "Config.Brokers", "Config.Brokers").Param(), "1")
+	Equal(t, getError(errs, "Config.Mirrors", "Config.Mirrors").Param(), "1")
+
+	errs = validate.Var("", "hostport_list")
+	NotEqual(t, errs, nil)
+	Equal(t, errs.(ValidationErrors)[0].Param(), "0")
+	Equal(t, validate.Var("http://a.example.com|http://b.example.com", "url_list=sep=0x7C"), nil)
+
+	PanicMatches(t, func() { _ = validate.Var("a:1", "hostport_list=;") }, "Bad param ';' for 'hostport_list', expected 'sep=<separator>'")
+	PanicMatches(t, func() { _ = validate.Var(1, "url_list") }, "Bad field type int")
+}
+
+func TestStrSplitValidation(t *testing.T) {
+	type Config struct {
+		IDs    string `validate:"strsplit=,,dive,uuid4"`
+		Names  string `validate:"omitempty,strsplit=;,min=2,dive,alpha"`
+		Scopes string `validate:"strsplit,unique"`
 	}
+
+	validate := New()
+	errs := validate.Struct(Config{
+		IDs:    "a987fbc9-4bed-4078-8f07-9141ba07c9f3, 57b73598-8764-4ad0-a76a-679bb6640eb1",
+		Names:  "joey;bloggs",
+		Scopes: "read,write",
+	})
+	Equal(t, errs, nil)
+
+	errs = validate.Struct(Config{
+		IDs:    "a987fbc9-4bed-4078-8f07-9141ba07c9f3,nope",
+		Names:  "joey",
+		Scopes: "read,read",
+	})
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 3)
+	AssertError(t, errs, "Config.IDs[1]", "Config.IDs[1]", "IDs[1]", "IDs[1]", "uuid4")
+	AssertError(t, errs, "Config.Names", "Config.Names", "Names", "Names", "min")
+	AssertError(t, errs, "Config.Scopes", "Config.Scopes", "Scopes", "Scopes", "unique")
+
+	errs = validate.Struct(Config{IDs: "a987fbc9-4bed-4078-8f07-9141ba07c9f3", Names: "joey;b0ggs", Scopes: "read"})
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "Config.Names[1]", "Config.Names[1]", "Names[1]", "Names[1]", "alpha")
+
+	Equal(t, validate.Var("1|2|3", "strsplit=0x7C,len=3,dive,numeric"), nil)
+	PanicMatches(t, func() { _ = validate.Var(1, "strsplit,dive,required") }, "'strsplit' can only be used on string fields, field '' is a int")
 }
 
-func TestNestedStructValidation(t *testing.T) {
-	validator := New(WithRequiredStructEnabled())
-	t.Run("required", func(t *testing.T) {
-		type (
-			value struct {
-				Field string
-			}
-			topLevel struct {
-				Nested value `validate:"required"`
-			}
-		)
+func TestJSONStructValidation(t *testing.T) {
+	type Settings struct {
+		Theme  string `json:"theme" validate:"required,oneof=light dark"`
+		Volume int    `json:"volume" validate:"lte=10"`
+	}
 
-		var validationErrs ValidationErrors
-		if errs := validator.Struct(topLevel{}); errs != nil {
-			validationErrs = errs.(ValidationErrors)
-		}
+	type Account struct {
+		Settings string `validate:"omitempty,jsonstruct=Settings"`
+		Raw      []byte `validate:"jsonstruct=Settings"`
+	}
 
-		Equal(t, 1, len(validationErrs))
-		AssertError(t, validationErrs, "topLevel.Nested", "topLevel.Nested", "Nested", "Nested", "required")
+	validate := New()
+	validate.RegisterJSONStruct("Settings", &Settings{})
 
-		Equal(t, validator.Struct(topLevel{value{"potato"}}), nil)
-	})
+	errs := validate.Struct(Account{Settings: `{"theme":"dark","volume":3}`, Raw: []byte(`{"theme":"light"}`)})
+	Equal(t, errs, nil)
 
-	t.Run("omitempty", func(t *testing.T) {
-		type (
-			value struct {
-				Field string
-			}
-			topLevel struct {
-				Nested value `validate:"omitempty,required"`
-			}
-		)
+	errs = validate.Struct(Account{Settings: `{"theme":"blue","volume":11}`, Raw: []byte(`{"volume":1}`)})
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 3)
+	AssertError(t, errs, "Account.Settings.Theme", "Account.Settings.Theme", "Theme", "Theme", "oneof")
+	AssertError(t, errs, "Account.Settings.Volume", "Account.Settings.Volume", "Volume", "Volume", "lte")
+	AssertError(t, errs, "Account.Raw.Theme", "Account.Raw.Theme", "Theme", "Theme", "required")
 
-		errs := validator.Struct(topLevel{})
-		Equal(t, errs, nil)
-	})
+	errs = validate.Struct(Account{Settings: `{"theme":`, Raw: []byte(`{"theme":"dark"}`)})
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 1)
+	AssertError(t, errs, "Account.Settings", "Account.Settings", "Settings", "Settings", "jsonstruct")
+	Equal(t, errs.(ValidationErrors)[0].Param(), "Settings")
 
-	t.Run("excluded_if", func(t *testing.T) {
-		type (
-			value struct {
-				Field string
-			}
-			topLevel struct {
-				Field  string
-				Nested value `validate:"excluded_if=Field potato"`
-			}
-		)
+	errs = validate.Struct(Account{Raw: []byte(`{"theme":"dark"}`)})
+	Equal(t, errs, nil)
 
-		errs := validator.Struct(topLevel{Field: "test", Nested: value{"potato"}})
-		Equal(t, errs, nil)
+	Equal(t, validate.Var(`{"theme":"dark"}`, "jsonstruct=Settings"), nil)
+	NotEqual(t, validate.Var(`{"theme":"red"}`, "jsonstruct=Settings"), nil)
 
-		errs = validator.Struct(topLevel{Field: "potato"})
-		Equal(t, errs, nil)
+	PanicMatches(t, func() { _ = validate.Var(`{}`, "jsonstruct=Other") }, "Undefined JSON struct 'Other' on field '', see RegisterJSONStruct")
+	PanicMatches(t, func() { _ = validate.Var(1, "jsonstruct=Settings") }, "'jsonstruct' can only be used on string and []byte fields, field '' is a int")
+	PanicMatches(t, func() { validate.RegisterJSONStruct("", Settings{}) }, "JSON struct name cannot be empty")
+	PanicMatches(t, func() { validate.RegisterJSONStruct("Name", "") }, "JSON struct 'Name' must be a struct, not string")
+}
 
-		errs = validator.Struct(topLevel{Field: "potato", Nested: value{"potato"}})
-		AssertError(t, errs, "topLevel.Nested", "topLevel.Nested", "Nested", "Nested", "excluded_if")
-	})
+func TestValidateCSV(t *testing.T) {
+	validate := New()
+	data := "name,email,age\njoey,joey@example.com,30\n,nope,abc\nbob,bob@example.com,7\n"
 
-	t.Run("excluded_unless", func(t *testing.T) {
-		type (
-			value struct {
-				Field string
-			}
-			topLevel struct {
-				Field  string
-				Nested value `validate:"excluded_unless=Field potato"`
-			}
-		)
+	errs, err := validate.ValidateCSV(strings.NewReader(data), []string{"required", "email", "numeric,gte_numstr=18"})
+	Equal(t, err, nil)
+	Equal(t, len(errs), 4)
+	Equal(t, errs[0].Row, 3)
+	Equal(t, errs[0].Column, "name")
+	Equal(t, errs[0].Err.(FieldError).Tag(), "required")
+	Equal(t, errs[1].Column, "email")
+	Equal(t, errs[2].Column, "age")
+	Equal(t, errs[2].Err.(FieldError).Tag(), "numeric")
+	Equal(t, errs[3].Row, 4)
+	Equal(t, errs[3].Err.(FieldError).Tag(), "gte_numstr")
+
+	type Person struct {
+		Name  string `csv:"name" validate:"required"`
+		Email string `csv:"email" validate:"email"`
+		Age   int    `csv:"age" validate:"gte=18"`
+	}
+
+	errs, err = validate.ValidateCSVStruct(strings.NewReader(data), Person{})
+	Equal(t, err, nil)
+	Equal(t, len(errs), 2)
+	Equal(t, errs[0].Row, 3)
+	Equal(t, errs[0].Column, "age")
+	NotEqual(t, errs[0].Err, nil)
+	Equal(t, errs[1].Row, 4)
+	Equal(t, errs[1].Column, "age")
+	Equal(t, errs[1].Err.(FieldError).Tag(), "gte")
+	Equal(t, errs.Error(), "row 3 column 'age': strconv.ParseInt: parsing \"abc\": invalid syntax\nrow 4 column 'age': "+errs[1].Err.Error())
+
+	_, err = validate.ValidateCSV(strings.NewReader(""), nil)
+	NotEqual(t, err, nil)
 
-		errs := validator.Struct(topLevel{Field: "test"})
-		Equal(t, errs, nil)
+	_, err = validate.ValidateCSV(strings.NewReader("a,b\n1\n"), []string{"required"})
+	NotEqual(t, err, nil)
 
-		errs = validator.Struct(topLevel{Field: "potato", Nested: value{"potato"}})
-		Equal(t, errs, nil)
+	_, err = validate.ValidateCSVStruct(strings.NewReader(data), 1)
+	NotEqual(t, err, nil)
+}
 
-		errs = validator.Struct(topLevel{Field: "test", Nested: value{"potato"}})
-		AssertError(t, errs, "topLevel.Nested", "topLevel.Nested", "Nested", "Nested", "excluded_unless")
-	})
+func TestXorIffValidation(t *testing.T) {
+	type Contact struct {
+		Email    string  `validate:"xor=Phone"`
+		Phone    *string `validate:"xor=Email"`
+		Password string  `validate:"iff=Confirm"`
+		Confirm  string
+	}
 
-	t.Run("nonComparableField", func(t *testing.T) {
-		type (
-			value struct {
-				Field []string
-			}
-			topLevel struct {
-				Nested value `validate:"required"`
-			}
-		)
+	validate := New()
+	Equal(t, validate.Struct(Contact{Email: "joeybloggs@gmail.com"}), nil)
+	Equal(t, validate.Struct(Contact{Phone: stringPtr("555"), Password: "secret", Confirm: "secret"}), nil)
 
-		errs := validator.Struct(topLevel{value{[]string{}}})
-		Equal(t, errs, nil)
-	})
+	errs := validate.Struct(Contact{})
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 2)
+	AssertError(t, errs, "Contact.Email", "Contact.Email", "Email", "Email", "xor")
+	AssertError(t, errs, "Contact.Phone", "Contact.Phone", "Phone", "Phone", "xor")
 
-	type (
-		veggyBasket struct {
-			Root   string
-			Squash string `validate:"required"`
-		}
-		testErr struct {
-			path string
-			tag  string
-		}
-		test struct {
-			name  string
-			err   testErr
-			value veggyBasket
-		}
-	)
+	errs = validate.Struct(Contact{Email: "joeybloggs@gmail.com", Phone: stringPtr("555"), Password: "secret"})
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 3)
+	AssertError(t, errs, "Contact.Password", "Contact.Password", "Password", "Password", "iff")
 
-	if err := validator.RegisterValidation("veggy", func(f FieldLevel) bool {
-		v, ok := f.Field().Interface().(veggyBasket)
-		if !ok || v.Root != "potato" {
-			return false
-		}
-		return true
-	}); err != nil {
-		t.Fatal(fmt.Errorf("failed to register potato tag: %w", err))
+	errs = validate.Struct(Contact{Email: "joeybloggs@gmail.com", Confirm: "secret"})
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "Contact.Password", "Contact.Password", "Password", "Password", "iff")
+}
+
+func TestTemporalFieldValidation(t *testing.T) {
+	type Booking struct {
+		Start   time.Time
+		End     time.Time `validate:"after=Start"`
+		Checked time.Time `validate:"betweenfields=Start End"`
+		Due     string    `validate:"after_eq=Opened;2006-01-02,before=Expiry;2006-01-02"`
+		Opened  string
+		Expiry  string
+		Until   *time.Time `validate:"omitempty,before_eq=End"`
 	}
 
-	tests := []test{
-		{
-			name:  "valid",
-			value: veggyBasket{"potato", "zucchini"},
-		}, {
-			name:  "failedCustomTag",
-			value: veggyBasket{"zucchini", "potato"},
-			err:   testErr{"topLevel.VeggyBasket", "veggy"},
-		}, {
-			name:  "failedInnerField",
-			value: veggyBasket{"potato", ""},
-			err:   testErr{"topLevel.VeggyBasket.Squash", "required"},
-		}, {
-			name:  "customTagFailurePriorityCheck",
-			value: veggyBasket{"zucchini", ""},
-			err:   testErr{"topLevel.VeggyBasket", "veggy"},
-		},
-	}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(48 * time.Hour)
+	validate := New()
 
-	evaluateTest := func(tt test, errs error) {
-		if tt.err != (testErr{}) && errs != nil {
-			Equal(t, len(errs.(ValidationErrors)), 1)
+	b := Booking{Start: start, End: end, Checked: start, Due: "2024-01-01", Opened: "2024-01-01", Expiry: "2024-02-01", Until: &end}
+	Equal(t, validate.Struct(b), nil)
 
-			segments := strings.Split(tt.err.path, ".")
-			fieldName := segments[len(segments)-1]
-			AssertError(t, errs, tt.err.path, tt.err.path, fieldName, fieldName, tt.err.tag)
-		}
+	b.End = start
+	b.Checked = end.Add(time.Second)
+	b.Due = "2024-02-01"
+	until := end.Add(time.Hour)
+	b.Until = &until
+	errs := validate.Struct(b)
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 4)
+	AssertError(t, errs, "Booking.End", "Booking.End", "End", "End", "after")
+	AssertError(t, errs, "Booking.Checked", "Booking.Checked", "Checked", "Checked", "betweenfields")
+	AssertError(t, errs, "Booking.Due", "Booking.Due", "Due", "Due", "before")
+	AssertError(t, errs, "Booking.Until", "Booking.Until", "Until", "Until", "before_eq")
 
-		shouldFail := tt.err != (testErr{})
-		hasFailed := errs != nil
-		if shouldFail != hasFailed {
-			t.Fatalf("expected failure %v, got: %v with errs: %v", shouldFail, hasFailed, errs)
-		}
+	b = Booking{Start: start, End: end, Checked: end, Due: "01/15/2024", Opened: "2024-01-01", Expiry: "2024-02-01"}
+	errs = validate.Struct(b)
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "Booking.Due", "Booking.Due", "Due", "Due", "after_eq")
+
+	type RFC3339 struct {
+		From string
+		To   string `validate:"after=From"`
 	}
 
-	for _, tt := range tests {
-		type topLevel struct {
-			VeggyBasket veggyBasket `validate:"veggy"`
-		}
+	Equal(t, validate.Struct(RFC3339{From: "2024-01-01T00:00:00Z", To: "2024-01-01T00:00:01Z"}), nil)
+	NotEqual(t, validate.Struct(RFC3339{From: "2024-01-01T00:00:00Z", To: "2024-01-01T00:00:00Z"}), nil)
 
-		t.Run(tt.name, func(t *testing.T) {
-			evaluateTest(tt, validator.Struct(topLevel{tt.value}))
-		})
+	type Bad struct {
+		A int `validate:"after=B"`
+		B int
 	}
 
-	// Also test on struct pointers
-	for _, tt := range tests {
-		type topLevel struct {
-			VeggyBasket *veggyBasket `validate:"veggy"`
-		}
+	PanicMatches(t, func() { _ = validate.Struct(Bad{}) }, "Bad field type int")
 
-		t.Run(tt.name+"Ptr", func(t *testing.T) {
-			evaluateTest(tt, validator.Struct(topLevel{&tt.value}))
-		})
+	type BadParam struct {
+		A time.Time `validate:"betweenfields=B"`
+		B time.Time
 	}
+
+	PanicMatches(t, func() { _ = validate.Struct(BadParam{}) }, "Bad param 'B' for 'betweenfields'")
 }
 
-func TestTimeRequired(t *testing.T) {
+func TestApproxValidation(t *testing.T) {
+	type Invoice struct {
+		Total  float64
+		Sum    float64 `validate:"eqfield_approx=Total;eps=0.005"`
+		Ratio  float64 `validate:"eq_approx=0.3"`
+		Rate   float32 `validate:"eq_approx=0.1;ulp=1"`
+		Weight float64 `validate:"eqfield_approx=Total;ulp=2"`
+	}
+
 	validate := New()
-	validate.RegisterTagNameFunc(func(fld reflect.StructField) string {
-		if name := strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]; name != "-" {
-			return name
-		}
+	tenth := 0.1
+	sum := tenth + 0.2
+	errs := validate.Struct(Invoice{Total: 10.004, Sum: 10, Ratio: sum, Rate: 0.1, Weight: 10.004})
+	Equal(t, errs, nil)
 
-		return ""
-	})
+	errs = validate.Struct(Invoice{Total: 10.01, Sum: 10, Ratio: 0.31, Rate: 0.1000001, Weight: math.Nextafter(math.Nextafter(math.Nextafter(10.01, 11), 11), 11)})
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 4)
+	AssertError(t, errs, "Invoice.Sum", "Invoice.Sum", "Sum", "Sum", "eqfield_approx")
+	AssertError(t, errs, "Invoice.Ratio", "Invoice.Ratio", "Ratio", "Ratio", "eq_approx")
+	AssertError(t, errs, "Invoice.Rate", "Invoice.Rate", "Rate", "Rate", "eq_approx")
+	AssertError(t, errs, "Invoice.Weight", "Invoice.Weight", "Weight", "Weight", "eqfield_approx")
+	Equal(t, Details(getError(errs, "Invoice.Sum", "Invoice.Sum")).Translate("en"), "Sum must be approximately equal to Total")
+
+	NotEqual(t, validate.Var(sum, "eq=0.3"), nil)
+	Equal(t, validate.Var(sum, "eq_approx=0.3;eps=1e-9"), nil)
+	Equal(t, validate.Var(-0.0, "eq_approx=0"), nil)
+	Equal(t, validate.Var(math.Nextafter(0, -1), "eq_approx=0;ulp=1"), nil)
+	NotEqual(t, validate.Var(math.NaN(), "eq_approx=0;eps=1"), nil)
+	NotEqual(t, validate.Var(math.Inf(1), "eq_approx=1e308;ulp=100"), nil)
+	Equal(t, validate.Var(math.Inf(1), "eq_approx=+Inf"), nil)
+	Equal(t, validate.VarWithValue(1.0, 1.0001, "eqfield_approx=;eps=0.001"), nil)
+	NotEqual(t, validate.VarWithValue(1.0, "1.0", "eqfield_approx=;eps=0.001"), nil)
+
+	PanicMatches(t, func() { _ = validate.Var(1, "eq_approx=1") }, "Bad field type int")
+	PanicMatches(t, func() { _ = validate.Var(1.0, "eq_approx=1;eps=-1") }, "Bad param '1;eps=-1' for 'eq_approx'")
+	PanicMatches(t, func() { _ = validate.Var(1.0, "eq_approx=1;ulp=1.5") }, "Bad param '1;ulp=1.5' for 'eq_approx'")
+	PanicMatches(t, func() { _ = validate.Var(1.0, "eq_approx=1;tol=1") }, "Bad param '1;tol=1' for 'eq_approx', expected 'eq_approx=<value>;eps=<epsilon>' or 'eq_approx=<value>;ulp=<units>'")
+}
+
+func TestBetweenFieldsValidation(t *testing.T) {
+	type Range struct {
+		Min   int `validate:"ltefield=Max"`
+		Max   int
+		Value int `validate:"betweenfields=Min Max"`
+		Lo    float64
+		Hi    float64
+		Ratio float64 `validate:"betweenfields=Lo Hi"`
+		Start time.Time
+		End   time.Time
+		At    time.Time `validate:"betweenfields=Start End"`
+	}
 
-	type TestTime struct {
-		Time time.Time `validate:"required"`
+	now := time.Now()
+	valid := Range{Min: 1, Max: 10, Value: 10, Lo: 0, Hi: 1, Ratio: 0.5, Start: now, End: now.Add(time.Hour), At: now}
+	validate := New()
+	Equal(t, validate.Struct(valid), nil)
+
+	r := valid
+	r.Value, r.Ratio, r.At = 0, 1.5, now.Add(-time.Second)
+	errs := validate.Struct(r).(ValidationErrors)
+	Equal(t, len(errs), 3)
+	AssertError(t, errs, "Range.Value", "Range.Value", "Value", "Value", "betweenfields")
+	AssertError(t, errs, "Range.Ratio", "Range.Ratio", "Ratio", "Ratio", "betweenfields")
+	AssertError(t, errs, "Range.At", "Range.At", "At", "At", "betweenfields")
+	Equal(t, Details(errs[0]).Translate("en"), "Value must be between Min and Max")
+
+	// inverted bounds fail even for a value equal to both
+	r = valid
+	r.Min, r.Max, r.Value = 5, 5, 5
+	Equal(t, validate.Struct(r), nil)
+	r.Lo, r.Hi, r.Ratio = 1, 0, 0.5
+	errs = validate.Struct(r).(ValidationErrors)
+	Equal(t, len(errs), 1)
+	AssertError(t, errs, "Range.Ratio", "Range.Ratio", "Ratio", "Ratio", "betweenfields")
+
+	r = valid
+	r.Ratio = math.NaN()
+	Equal(t, len(validate.Struct(r).(ValidationErrors)), 1)
+	r = valid
+	r.Hi = math.NaN()
+	Equal(t, len(validate.Struct(r).(ValidationErrors)), 1)
+
+	type Mixed struct {
+		Min   int64
+		Max   int
+		Value int  `validate:"betweenfields=Min Max"`
+		Flag  bool `validate:"betweenfields=Min Max"`
+		Odd   int  `validate:"betweenfields=Min"`
+		Gone  int  `validate:"betweenfields=Missing Max"`
+	}
+
+	PanicMatches(t, func() { _ = validate.StructPartial(Mixed{}, "Flag") }, "Bad field type bool")
+	PanicMatches(t, func() { _ = validate.StructPartial(Mixed{}, "Odd") }, "Bad param 'Min' for 'betweenfields'")
+	errs = validate.StructPartial(Mixed{}, "Value", "Gone").(ValidationErrors)
+	Equal(t, len(errs), 2)
+	AssertError(t, errs, "Mixed.Value", "Mixed.Value", "Value", "Value", "betweenfields")
+	AssertError(t, errs, "Mixed.Gone", "Mixed.Gone", "Gone", "Gone", "betweenfields")
+
+	// between_fields is kept as an alias
+	type Legacy struct {
+		Min   int
+		Max   int
+		Value int `validate:"between_fields=Min Max"`
 	}
 
-	var testTime TestTime
-	err := validate.Struct(&testTime)
-	NotEqual(t, err, nil)
-	AssertError(t, err.(ValidationErrors), "TestTime.Time", "TestTime.Time", "Time", "Time", "required")
+	Equal(t, validate.Struct(Legacy{Min: 1, Max: 3, Value: 2}), nil)
+	errs = validate.Struct(Legacy{Min: 1, Max: 3, Value: 4}).(ValidationErrors)
+	Equal(t, len(errs), 1)
+	AssertError(t, errs, "Legacy.Value", "Legacy.Value", "Value", "Value", "between_fields")
+	Equal(t, Details(errs[0]).Translate("en"), "Value must be between Min and Max")
 }
 
-func TestOmitNilAndRequired(t *testing.T) {
-	type (
-		OmitEmpty struct {
-			Str    string  `validate:"omitempty,required,min=10"`
-			StrPtr *string `validate:"omitempty,required,min=10"`
-			Inner  *OmitEmpty
-		}
-		OmitNil struct {
-			Str    string  `validate:"omitnil,required,min=10"`
-			StrPtr *string `validate:"omitnil,required,min=10"`
-			Inner  *OmitNil
-		}
-	)
-
-	var (
-		validate = New(WithRequiredStructEnabled())
-		valid    = "this is the long string to pass the validation rule"
-	)
+func TestRelativeTimeValidation(t *testing.T) {
+	type Token struct {
+		Expires  time.Time `validate:"future,future_within=72h"`
+		IssuedAt time.Time `validate:"past_within=30d"`
+		Created  time.Time `validate:"past,lt"`
+	}
 
-	t.Run("compare using valid data", func(t *testing.T) {
-		err1 := validate.Struct(OmitEmpty{Str: valid, StrPtr: &valid, Inner: &OmitEmpty{Str: valid, StrPtr: &valid}})
-		err2 := validate.Struct(OmitNil{Str: valid, StrPtr: &valid, Inner: &OmitNil{Str: valid, StrPtr: &valid}})
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	validate := New(WithClock(func() time.Time { return now }))
 
-		Equal(t, err1, nil)
-		Equal(t, err2, nil)
-	})
+	tok := Token{Expires: now.Add(72 * time.Hour), IssuedAt: now.Add(-30 * 24 * time.Hour), Created: now.Add(-time.Second)}
+	Equal(t, validate.Struct(tok), nil)
 
-	t.Run("compare fully empty omitempty and omitnil", func(t *testing.T) {
-		err1 := validate.Struct(OmitEmpty{})
-		err2 := validate.Struct(OmitNil{})
+	tok = Token{Expires: now.Add(73 * time.Hour), IssuedAt: now.Add(-31 * 24 * time.Hour), Created: now}
+	errs := validate.Struct(tok)
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 3)
+	AssertError(t, errs, "Token.Expires", "Token.Expires", "Expires", "Expires", "future_within")
+	AssertError(t, errs, "Token.IssuedAt", "Token.IssuedAt", "IssuedAt", "IssuedAt", "past_within")
+	AssertError(t, errs, "Token.Created", "Token.Created", "Created", "Created", "past")
 
-		Equal(t, err1, nil)
-		AssertError(t, err2, "OmitNil.Str", "OmitNil.Str", "Str", "Str", "required")
-	})
+	errs = validate.Struct(Token{Expires: now, IssuedAt: now.Add(time.Hour), Created: now.Add(-time.Hour)})
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "Token.Expires", "Token.Expires", "Expires", "Expires", "future")
+	AssertError(t, errs, "Token.IssuedAt", "Token.IssuedAt", "IssuedAt", "IssuedAt", "past_within")
 
-	t.Run("validate in deep", func(t *testing.T) {
-		err1 := validate.Struct(OmitEmpty{Str: valid, Inner: &OmitEmpty{}})
-		err2 := validate.Struct(OmitNil{Str: valid, Inner: &OmitNil{}})
+	Equal(t, validate.Var(now.Add(-time.Hour), "past"), nil)
+	NotEqual(t, validate.Var(now.Add(time.Hour), "past"), nil)
+	Equal(t, New().Var(time.Now().Add(time.Hour), "future"), nil)
 
-		Equal(t, err1, nil)
-		AssertError(t, err2, "OmitNil.Inner.Str", "OmitNil.Inner.Str", "Str", "Str", "required")
-	})
+	PanicMatches(t, func() { _ = validate.Var(now, "future_within=soon") }, "Bad param 'soon', expected a duration")
+	PanicMatches(t, func() { _ = validate.Var("2024-06-01", "future") }, "Bad field type string")
 }
 
-func TestOmitZero(t *testing.T) {
-	type (
-		OmitEmpty struct {
-			Str    string  `validate:"omitempty,min=10"`
-			StrPtr *string `validate:"omitempty,min=10"`
-		}
-		OmitZero struct {
-			Str    string  `validate:"omitzero,min=10"`
-			StrPtr *string `validate:"omitzero,min=10"`
-		}
-	)
+func TestFieldNameTags(t *testing.T) {
+	type Doc struct {
+		ID    string `bson:"_id" validate:"required"`
+		Name  string `json:"name,omitempty" bson:"full_name" validate:"required"`
+		Email string `json:"-" yaml:"mail" mapstructure:"email_address" validate:"required"`
+		Age   int    `validate:"required"`
+	}
 
-	var (
-		validate = New()
-		valid    = "this is the long string to pass the validation rule"
-		empty    = ""
-	)
+	validate := New(WithFieldNameTags("json", "bson"))
+	errs := validate.Struct(Doc{})
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 4)
+	AssertError(t, errs, "Doc._id", "Doc.ID", "_id", "ID", "required")
+	AssertError(t, errs, "Doc.name", "Doc.Name", "name", "Name", "required")
+	AssertError(t, errs, "Doc.Email", "Doc.Email", "Email", "Email", "required")
+	AssertError(t, errs, "Doc.Age", "Doc.Age", "Age", "Age", "required")
+
+	typ := reflect.TypeOf(Doc{})
+	Equal(t, JSONTagNameFunc(typ.Field(1)), "name")
+	Equal(t, JSONTagNameFunc(typ.Field(2)), "")
+	Equal(t, BSONTagNameFunc(typ.Field(1)), "full_name")
+	Equal(t, YAMLTagNameFunc(typ.Field(2)), "mail")
+	Equal(t, MapstructureTagNameFunc(typ.Field(2)), "email_address")
+	Equal(t, StructTagNameFunc("bson")(typ.Field(0)), "_id")
+
+	chain := ChainTagNameFuncs(JSONTagNameFunc, YAMLTagNameFunc, BSONTagNameFunc)
+	Equal(t, chain(typ.Field(0)), "_id")
+	Equal(t, chain(typ.Field(1)), "name")
+	Equal(t, chain(typ.Field(2)), "mail")
+	Equal(t, chain(typ.Field(3)), "")
+}
+
+func TestCoverage(t *testing.T) {
+	type Address struct {
+		City string `validate:"required"`
+		Zip  string
+	}
 
-	t.Run("compare using valid data", func(t *testing.T) {
-		err1 := validate.Struct(OmitEmpty{Str: valid, StrPtr: &valid})
-		err2 := validate.Struct(OmitZero{Str: valid, StrPtr: &valid})
+	type Order struct {
+		ID       string    `validate:"required,uuid"`
+		Note     string    `validate:"omitempty"`
+		Email    string    `validate:"omitempty,required,email"`
+		Tags     []string  `validate:"max=3,dive,min=1,min=1"`
+		Internal string    `validate:"-"`
+		Created  time.Time `validate:"required"`
+		Updated  time.Time
+		Address  *Address
+		Previous []Address `validate:"max=2"`
+		secret   string
+	}
 
-		Equal(t, err1, nil)
-		Equal(t, err2, nil)
+	validate := New()
+	report := validate.Coverage(Order{})
+	Equal(t, report.Fields, 8)
+	Equal(t, report.Covered, 5)
+	Equal(t, report.Issues, []CoverageIssue{
+		{Type: "validator.Address", Field: "Zip", Kind: CoverageMissingRule},
+		{Type: "validator.Order", Field: "Email", Kind: CoverageDeadTag, Tag: "required"},
+		{Type: "validator.Order", Field: "Note", Kind: CoverageOmitOnly},
+		{Type: "validator.Order", Field: "Tags", Kind: CoverageDeadTag, Tag: "min=1"},
+		{Type: "validator.Order", Field: "Updated", Kind: CoverageMissingRule},
 	})
 
-	t.Run("compare fully empty omitempty and omitzero", func(t *testing.T) {
-		err1 := validate.Struct(OmitEmpty{})
-		err2 := validate.Struct(OmitZero{})
+	b, err := json.Marshal(validate.Coverage(Address{}))
+	Equal(t, err, nil)
+	Equal(t, string(b), `{"fields":2,"covered":1,"issues":[{"type":"validator.Address","field":"Zip","kind":"missing_rule"}]}`)
 
-		Equal(t, err1, nil)
-		Equal(t, err2, nil)
-	})
+	// registered types are reported by default, map rules cover fields
+	Equal(t, len(validate.Coverage().Issues), 0)
+	validate.RegisterStructValidationMapRules(map[string]string{"Zip": "numeric"}, Address{})
+	report = validate.Coverage()
+	Equal(t, report.Fields, 2)
+	Equal(t, report.Covered, 2)
+	Equal(t, len(report.Issues), 0)
+	Equal(t, report.Covered, report.Fields)
+}
 
-	t.Run("compare with zero value", func(t *testing.T) {
-		err1 := validate.Struct(OmitEmpty{Str: "", StrPtr: nil})
-		err2 := validate.Struct(OmitZero{Str: "", StrPtr: nil})
+func TestAssertRules(t *testing.T) {
+	type User struct {
+		Email    string            `validate:"required,email"`
+		Color    string            `validate:"iscolor"`
+		Tags     []string          `validate:"max=3,dive,min=1"`
+		Labels   map[string]string `validate:"dive,keys,alpha,endkeys,required"`
+		Internal string            `validate:"-"`
+		Note     string
+	}
 
-		Equal(t, err1, nil)
-		Equal(t, err2, nil)
+	validate := New()
+	Equal(t, validate.AssertRules(User{}, map[string]string{
+		"Email":    "required,email",
+		"Color":    "hexcolor|rgb|rgba|hsl|hsla",
+		"Tags":     "max=3,dive,min=1",
+		"Labels":   "dive,keys,alpha,endkeys,required",
+		"Internal": "-",
+		"Note":     "",
+	}), nil)
+	Equal(t, validate.AssertRules(&User{}, map[string]string{"Color": "iscolor"}), nil)
+
+	err := validate.AssertRules(User{}, map[string]string{
+		"Email":    "required,email,max=255",
+		"Tags":     "max=3",
+		"Internal": "required",
+		"Note":     "omitempty",
+		"Missing":  "required",
 	})
+	NotEqual(t, err, nil)
+	Equal(t, err.Error(), strings.Join([]string{
+		"field 'Email' on struct 'User' has rules 'required,email', expected 'required,email,max=255'",
+		"field 'Internal' on struct 'User' has rules '-', expected 'required'",
+		"undefined field 'Missing' on struct 'User'",
+		"field 'Note' on struct 'User' has rules '', expected 'omitempty'",
+		"field 'Tags' on struct 'User' has rules 'max=3,dive,min=1', expected 'max=3'",
+	}, "\n"))
+
+	// map rules are the declared rules
+	validate = New()
+	validate.RegisterStructValidationMapRules(map[string]string{"Note": "max=10"}, User{})
+	Equal(t, validate.AssertRules(User{}, map[string]string{"Note": "max=10"}), nil)
 
-	t.Run("compare with empty value", func(t *testing.T) {
-		err1 := validate.Struct(OmitEmpty{Str: empty, StrPtr: &empty})
-		err2 := validate.Struct(OmitZero{Str: empty, StrPtr: &empty})
-
-		AssertError(t, err1, "OmitEmpty.StrPtr", "OmitEmpty.StrPtr", "StrPtr", "StrPtr", "min")
-		Equal(t, err2, nil)
-	})
+	err = validate.AssertRules(1, nil)
+	NotEqual(t, err, nil)
 }
 
-func TestAlphaUnicodeValidation(t *testing.T) {
-	tests := []struct {
-		param    string
-		expected bool
-	}{
-		{"", false},
-		{"abc", true},
-		{"this is a test string", false},
-		{"这是一个测试字符串", true},
-		{"123", false},
-		{"<>@;.-=", false},
-		{"ひらがな・カタカナ、．漢字", false},
-		{"あいうえおfoobar", true},
-		{"test＠example.com", false},
-		{"1234abcDE", false},
-		{"ｶﾀｶﾅ", true},
+func TestOrErrors(t *testing.T) {
+	type Theme struct {
+		Color  string `validate:"iscolor"`
+		Width  int    `validate:"eq=0|gte=10,lte=100"`
+		Border string `validate:"required"`
 	}
 
 	validate := New()
-	for i, test := range tests {
-		errs := validate.Var(test.param, "alphaunicode")
-		if test.expected {
-			if !IsEqual(errs, nil) {
-				t.Fatalf("Index: %d Alpha Unicode failed Error: %s", i, errs)
-			}
-		} else {
-			if IsEqual(errs, nil) {
-				t.Fatalf("Index: %d Alpha Unicode failed Error: %s", i, errs)
-			} else {
-				val := getError(errs, "", "")
-				if val.Tag() != "alphaunicode" {
-					t.Fatalf("Index: %d Alpha Unicode failed Error: %s", i, errs)
-				}
-			}
-		}
-	}
+	errs := validate.Struct(Theme{Color: "blue", Width: 5, Border: "solid"})
+	NotEqual(t, errs, nil)
+	ve := errs.(ValidationErrors)
+	Equal(t, len(ve), 2)
+
+	fe := getError(ve, "Theme.Color", "Theme.Color")
+	Equal(t, fe.Tag(), "iscolor")
+	orErrs := Details(fe).OrErrors()
+	Equal(t, len(orErrs), 5)
+	for i, tag := range []string{"hexcolor", "rgb", "rgba", "hsl", "hsla"} {
+		Equal(t, orErrs[i].Tag(), tag)
+		Equal(t, orErrs[i].Namespace(), "Theme.Color")
+		Equal(t, orErrs[i].Field(), "Color")
+		Equal(t, orErrs[i].Value(), "blue")
+		Equal(t, Details(orErrs[i]).OrErrors() == nil, true)
+	}
+
+	fe = getError(ve, "Theme.Width", "Theme.Width")
+	Equal(t, fe.Tag(), "eq=0|gte=10")
+	orErrs = Details(fe).OrErrors()
+	Equal(t, len(orErrs), 2)
+	Equal(t, orErrs[0].Tag(), "eq")
+	Equal(t, orErrs[0].Param(), "0")
+	Equal(t, orErrs[1].Tag(), "gte")
+	Equal(t, orErrs[1].Param(), "10")
+	Equal(t, orErrs[1].Kind(), reflect.Int)
+
+	errs = validate.Struct(Theme{Color: "#fff", Width: 200})
+	NotEqual(t, errs, nil)
+	ve = errs.(ValidationErrors)
+	Equal(t, len(ve), 2)
+	Equal(t, Details(getError(ve, "Theme.Width", "Theme.Width")).OrErrors() == nil, true)
+	Equal(t, Details(getError(ve, "Theme.Border", "Theme.Border")).OrErrors() == nil, true)
 }
 
-func TestAlphanumericUnicodeValidation(t *testing.T) {
-	tests := []struct {
-		param    string
-		expected bool
-	}{
-		{"", false},
-		{"abc", true},
-		{"this is a test string", false},
-		{"这是一个测试字符串", true},
-		{"\u0031\u0032\u0033", true}, // unicode 5
-		{"123", true},
-		{"<>@;.-=", false},
-		{"ひらがな・カタカナ、．漢字", false},
-		{"あいうえおfoobar", true},
-		{"test＠example.com", false},
-		{"1234abcDE", true},
-		{"ｶﾀｶﾅ", true},
+func TestParamUnits(t *testing.T) {
+	validate := New()
+
+	type Upload struct {
+		Data    []byte        `validate:"max=10MiB"`
+		Small   []byte        `validate:"min=1024"`
+		Timeout time.Duration `validate:"lte=5400s"`
+		Nanos   time.Duration `validate:"gte=1000"`
+		Name    string        `validate:"max=3"`
 	}
 
-	validate := New()
-	for i, test := range tests {
-		errs := validate.Var(test.param, "alphanumunicode")
-		if test.expected {
-			if !IsEqual(errs, nil) {
-				t.Fatalf("Index: %d Alphanum Unicode failed Error: %s", i, errs)
-			}
-		} else {
-			if IsEqual(errs, nil) {
-				t.Fatalf("Index: %d Alphanum Unicode failed Error: %s", i, errs)
-			} else {
-				val := getError(errs, "", "")
-				if val.Tag() != "alphanumunicode" {
-					t.Fatalf("Index: %d Alphanum Unicode failed Error: %s", i, errs)
-				}
-			}
-		}
+	u := Upload{
+		Data:    make([]byte, 10<<20+1),
+		Small:   []byte("x"),
+		Timeout: 2 * time.Hour,
+		Name:    "abcd",
 	}
-}
 
-func TestHostnameRFC952Validation(t *testing.T) {
-	tests := []struct {
-		param    string
-		expected bool
-	}{
-		{"test.example.com", true},
-		{"example.com", true},
-		{"example24.com", true},
-		{"test.example24.com", true},
-		{"test24.example24.com", true},
-		{"example", true},
-		{"EXAMPLE", true},
-		{"1.foo.com", false},
+	errs := validate.Struct(u)
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 5)
+
+	fe := getError(errs, "Upload.Data", "Upload.Data")
+	Equal(t, fe.Param(), "10MiB")
+	Equal(t, Details(fe).ParamString(), "10MiB")
+	Equal(t, Details(fe).ParamValue(), int64(10<<20))
+
+	fe = getError(errs, "Upload.Small", "Upload.Small")
+	Equal(t, fe.Param(), "1024")
+	Equal(t, Details(fe).ParamString(), "1KiB")
+	Equal(t, Details(fe).ParamValue(), int64(1024))
+
+	fe = getError(errs, "Upload.Timeout", "Upload.Timeout")
+	Equal(t, fe.Param(), "5400s")
+	Equal(t, Details(fe).ParamString(), "1h30m")
+	Equal(t, Details(fe).ParamValue(), 90*time.Minute)
+	Equal(t, Details(fe).Translate("en"), "Timeout must be less than or equal to 1h30m")
+
+	fe = getError(errs, "Upload.Nanos", "Upload.Nanos")
+	Equal(t, fe.Param(), "1000")
+	Equal(t, Details(fe).ParamString(), "1µs")
+	Equal(t, Details(fe).ParamValue(), time.Microsecond)
+
+	fe = getError(errs, "Upload.Name", "Upload.Name")
+	Equal(t, fe.Param(), "3")
+	Equal(t, Details(fe).ParamString(), "3")
+	Equal(t, Details(fe).ParamValue(), "3")
+
+	errs = validate.Var([]byte("abcdef"), "len=5KB")
+	NotEqual(t, errs, nil)
+	fe = errs.(ValidationErrors)[0]
+	Equal(t, fe.Param(), "5KB")
+	Equal(t, Details(fe).ParamValue(), int64(5000))
+
+	errs = validate.Var(make([]byte, 6), "max=5B")
+	NotEqual(t, errs, nil)
+	Equal(t, errs.(ValidationErrors)[0].Param(), "5B")
+
+	errs = validate.Var(time.Now().Add(48*time.Hour), "future_within=1d")
+	NotEqual(t, errs, nil)
+	fe = errs.(ValidationErrors)[0]
+	Equal(t, fe.Param(), "1d")
+	Equal(t, Details(fe).ParamString(), "24h")
+	Equal(t, Details(fe).ParamValue(), 24*time.Hour)
+
+	errs = validate.Var(make([]byte, 10<<20+1), "max=10485760")
+	NotEqual(t, errs, nil)
+	fe = errs.(ValidationErrors)[0]
+	Equal(t, fe.Param(), "10485760")
+	Equal(t, Details(fe).ParamString(), "10MiB")
+	Equal(t, Details(fe).Translate("en"), "must be at most 10MiB")
+}
+
+func TestValuePolicy(t *testing.T) {
+	type Payload struct {
+		Body  string   `validate:"max=4"`
+		Data  []byte   `validate:"max=2"`
+		Items []int    `validate:"max=2"`
+		Tags  []string `validate:"max=1"`
+		Name  string   `validate:"eq=x"`
+	}
+
+	p := Payload{
+		Body:  "héllo world",
+		Data:  []byte("abcdef"),
+		Items: []int{1, 2, 3, 4},
+		Tags:  []string{"a", "b"},
+		Name:  "ab",
+	}
+
+	validate := New(WithValuePolicy(ValuePolicy{Mode: ValueTruncate, Limit: 2}))
+	errs := validate.Struct(p)
+	NotEqual(t, errs, nil)
+	Equal(t, getError(errs, "Payload.Body", "Payload.Body").Value(), "h…(12 bytes)")
+	Equal(t, getError(errs, "Payload.Data", "Payload.Data").Value(), "ab…(6 bytes)")
+	Equal(t, getError(errs, "Payload.Items", "Payload.Items").Value(), "[1 2]…(4 elements)")
+	Equal(t, getError(errs, "Payload.Tags", "Payload.Tags").Value(), []string{"a", "b"})
+	Equal(t, getError(errs, "Payload.Name", "Payload.Name").Value(), "ab")
+
+	errs = validate.Var(map[string]int{"a": 1, "b": 2, "c": 3}, "max=1")
+	NotEqual(t, errs, nil)
+	Equal(t, errs.(ValidationErrors)[0].Value(), "map[…](3 entries)")
+
+	validate = New(WithValuePolicy(ValuePolicy{Mode: ValueHash, Limit: 2}))
+	errs = validate.Struct(p)
+	NotEqual(t, errs, nil)
+	Equal(t, getError(errs, "Payload.Data", "Payload.Data").Value(), "sha256:bef57ec7f53a6d40beb640a780a639c83bc29ac8a9816f1fc6c5c6dcd93c4721")
+	Equal(t, getError(errs, "Payload.Items", "Payload.Items").Value(), "sha256:89731f5e21db11bcfe7012f8b8d493141337ccee87316fd8614379c152a49aa4")
+	Equal(t, getError(errs, "Payload.Name", "Payload.Name").Value(), "ab")
+
+	validate = New(WithValuePolicy(ValuePolicy{Mode: ValueOmit, Limit: 2}))
+	errs = validate.Struct(p)
+	NotEqual(t, errs, nil)
+	Equal(t, getError(errs, "Payload.Body", "Payload.Body").Value(), nil)
+	Equal(t, getError(errs, "Payload.Name", "Payload.Name").Value(), "ab")
+
+	errs = New().Struct(p)
+	NotEqual(t, errs, nil)
+	Equal(t, getError(errs, "Payload.Body", "Payload.Body").Value(), "héllo world")
+}
+
+func TestLookupSet(t *testing.T) {
+	type Address struct {
+		City string `validate:"required"`
+		Zip  *string
+	}
+
+	type User struct {
+		Name      string `validate:"required"`
+		Age       int64
+		Addresses []Address `validate:"dive"`
+		Labels    map[string]string
+		Inner     *Address
+		secret    string
+	}
+
+	validate := New()
+	zip := "12345"
+	u := &User{
+		Age:       20,
+		Addresses: []Address{{City: "Paris", Zip: &zip}, {}},
+		Labels:    map[string]string{"env": "prod"},
+		secret:    "s",
+	}
+
+	errs := validate.Struct(u)
+	NotEqual(t, errs, nil)
+	for _, fe := range errs.(ValidationErrors) {
+		val, ok := validate.Lookup(u, fe.StructNamespace())
+		Equal(t, ok, true)
+		Equal(t, val.Interface(), fe.Value())
+	}
+
+	val, ok := validate.Lookup(u, "User.Addresses[0].City")
+	Equal(t, ok, true)
+	Equal(t, val.String(), "Paris")
+
+	val, ok = validate.Lookup(*u, "User.Addresses[0].Zip")
+	Equal(t, ok, true)
+	Equal(t, val.String(), "12345")
+
+	val, ok = validate.Lookup(u, "User.Labels[env]")
+	Equal(t, ok, true)
+	Equal(t, val.String(), "prod")
+
+	val, ok = validate.Lookup(u, "User")
+	Equal(t, ok, true)
+	Equal(t, val.Type(), reflect.TypeOf(User{}))
+
+	_, ok = validate.Lookup(u, "User.Addresses[5].City")
+	Equal(t, ok, false)
+
+	_, ok = validate.Lookup(u, "User.Inner.City")
+	Equal(t, ok, false)
+
+	_, ok = validate.Lookup(u, "Account.Name")
+	Equal(t, ok, false)
+
+	_, ok = validate.Lookup(u, "UserName")
+	Equal(t, ok, false)
+
+	Equal(t, validate.Set(u, "User.Name", "Joeybloggs"), nil)
+	Equal(t, u.Name, "Joeybloggs")
+
+	Equal(t, validate.Set(u, "User.Addresses[1].City", "Berlin"), nil)
+	Equal(t, u.Addresses[1].City, "Berlin")
+
+	Equal(t, validate.Set(u, "User.Addresses[0].Zip", "54321"), nil)
+	Equal(t, zip, "54321")
+
+	Equal(t, validate.Set(u, "User.Age", 30), nil)
+	Equal(t, u.Age, int64(30))
+
+	Equal(t, validate.Set(u, "User.Name", nil), nil)
+	Equal(t, u.Name, "")
+
+	Equal(t, validate.Set(u, "User.Age", uint8(1)).Error(), "validator: can't set field 'User.Age' of type int64 to a value of type uint8")
+	Equal(t, validate.Set(u, "User.Age", "old").Error(), "validator: can't set field 'User.Age' of type int64 to a value of type string")
+	Equal(t, validate.Set(u, "User.Labels[env]", "dev").Error(), "validator: field 'User.Labels[env]' can't be set")
+	Equal(t, validate.Set(u, "User.secret", "x").Error(), "validator: field 'User.secret' can't be set")
+	Equal(t, validate.Set(*u, "User.Name", "x").Error(), "validator: field 'User.Name' can't be set")
+	Equal(t, validate.Set(u, "User.Missing", "x").Error(), "validator: field 'User.Missing' not found")
+	Equal(t, validate.Struct(u) != nil, true)
+}
+
+func TestSuggestion(t *testing.T) {
+	type Account struct {
+		Email string `validate:"email"`
+		Color string `validate:"iscolor"`
+		Name  string `validate:"required"`
+	}
+
+	validate := New()
+	validate.RegisterSuggestion("email", func(fe FieldError) string {
+		s := strings.ToLower(strings.ReplaceAll(fe.Value().(string), " ", ""))
+		if New().Var(s, "email") != nil {
+			return ""
+		}
+		return s
+	})
+	validate.RegisterSuggestion("hexcolor|rgb|rgba|hsl|hsla", func(fe FieldError) string {
+		return "#" + fe.Value().(string)
+	})
+
+	errs := validate.Struct(Account{Email: "Joey @Example.com", Color: "ffffff"})
+	NotEqual(t, errs, nil)
+	Equal(t, Details(getError(errs, "Account.Email", "Account.Email")).Suggestion(), "joey@example.com")
+	Equal(t, Details(getError(errs, "Account.Color", "Account.Color")).Suggestion(), "#ffffff")
+	Equal(t, Details(getError(errs, "Account.Name", "Account.Name")).Suggestion(), "")
+
+	errs = validate.Struct(Account{Email: "joey", Color: "#fff", Name: "joey"})
+	NotEqual(t, errs, nil)
+	Equal(t, Details(getError(errs, "Account.Email", "Account.Email")).Suggestion(), "")
+
+	validate.RegisterSuggestion("email", nil)
+	errs = validate.Struct(Account{Email: "Joey @Example.com", Color: "#fff", Name: "joey"})
+	NotEqual(t, errs, nil)
+	Equal(t, Details(getError(errs, "Account.Email", "Account.Email")).Suggestion(), "")
+
+	PanicMatches(t, func() { validate.RegisterSuggestion("", nil) }, "suggestion tag cannot be empty")
+}
+
+func TestOneOfSuggestion(t *testing.T) {
+	type Paint struct {
+		Color  string `validate:"oneof=red green blue"`
+		Finish string `validate:"oneofci=Matte Gloss 'Semi Gloss'"`
+		Level  int    `validate:"oneof=1 2 3"`
+	}
+
+	validate := New()
+	errs := validate.Struct(Paint{Color: "gren", Finish: "semi glos", Level: 4})
+	NotEqual(t, errs, nil)
+	Equal(t, Details(getError(errs, "Paint.Color", "Paint.Color")).Suggestion(), "green")
+	Equal(t, Details(getError(errs, "Paint.Finish", "Paint.Finish")).Suggestion(), "Semi Gloss")
+	Equal(t, Details(getError(errs, "Paint.Level", "Paint.Level")).Suggestion(), "")
+
+	errs = validate.Struct(Paint{Color: "RED", Finish: "xyz", Level: 1})
+	NotEqual(t, errs, nil)
+	Equal(t, Details(getError(errs, "Paint.Color", "Paint.Color")).Suggestion(), "red")
+	Equal(t, Details(getError(errs, "Paint.Finish", "Paint.Finish")).Suggestion(), "")
+
+	errs = validate.Var("purple", "oneof=red green blue")
+	NotEqual(t, errs, nil)
+	Equal(t, Details(errs.(ValidationErrors)[0]).Suggestion(), "")
+
+	errs = validate.Var(strings.Repeat("a", maxSuggestionLen+1), "oneof="+strings.Repeat("a", maxSuggestionLen))
+	NotEqual(t, errs, nil)
+	Equal(t, Details(errs.(ValidationErrors)[0]).Suggestion(), "")
+
+	validate.RegisterSuggestion("oneof", nil)
+	errs = validate.Var("gren", "oneof=red green blue")
+	NotEqual(t, errs, nil)
+	Equal(t, Details(errs.(ValidationErrors)[0]).Suggestion(), "")
+
+	Equal(t, levenshtein("kitten", "sitting"), 3)
+	Equal(t, levenshtein("", "abc"), 3)
+	Equal(t, levenshtein("héllo", "hello"), 1)
+}
+
+func TestSimilarityValidation(t *testing.T) {
+	type Signup struct {
+		Username string
+		Password string `validate:"not_similar_to=Username"`
+		Secret   string `validate:"not_similar_to=Username 4"`
+	}
+
+	validate := New()
+	errs := validate.Struct(Signup{Username: "joeybloggs", Password: "correct horse", Secret: "battery staple"})
+	Equal(t, errs, nil)
+
+	errs = validate.Struct(Signup{Username: "JoeyBloggs", Password: "joeybloggs12", Secret: "joeybl"})
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "Signup.Password", "Signup.Password", "Password", "Password", "not_similar_to")
+	AssertError(t, errs, "Signup.Secret", "Signup.Secret", "Secret", "Secret", "not_similar_to")
+
+	errs = validate.Struct(Signup{Username: "joey", Password: "j0ey", Secret: "my-joey-secret"})
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "Signup.Password", "Signup.Password", "Password", "Password", "not_similar_to")
+	AssertError(t, errs, "Signup.Secret", "Signup.Secret", "Secret", "Secret", "not_similar_to")
+
+	errs = validate.Struct(Signup{Username: "al", Password: "always here", Secret: "salted hash"})
+	Equal(t, errs, nil)
+
+	errs = validate.Struct(Signup{Password: "x", Secret: "y"})
+	Equal(t, errs, nil)
+
+	errs = validate.Var("kitten", "levenshtein_lte=3 sitting")
+	Equal(t, errs, nil)
+
+	errs = validate.Var("kitten", "levenshtein_lte=2 sitting")
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "", "", "", "", "levenshtein_lte")
+
+	errs = validate.Var("passw0rd", "levenshtein_gt=2 password")
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "", "", "", "", "levenshtein_gt")
+
+	errs = validate.Var("hunter2", "levenshtein_gt=2 password")
+	Equal(t, errs, nil)
+
+	errs = validate.Var("a b", "levenshtein_lte=0 a b")
+	Equal(t, errs, nil)
+
+	PanicMatches(t, func() { _ = validate.Var("kitten", "levenshtein_lte=sitting") }, "Bad param 'sitting' for 'levenshtein_lte'")
+	PanicMatches(t, func() { _ = validate.Var("kitten", "levenshtein_gt=-1 sitting") }, "Bad param '-1 sitting' for 'levenshtein_gt'")
+	PanicMatches(t, func() { _ = validate.Var(1, "levenshtein_lte=1 sitting") }, "Bad field type int")
+
+	type BadParam struct {
+		Username string
+		Password string `validate:"not_similar_to=Username x"`
+	}
+	PanicMatches(t, func() { _ = validate.Struct(BadParam{}) }, "Bad param 'Username x' for 'not_similar_to'")
+
+	type BadType struct {
+		Username string
+		Pin      int `validate:"not_similar_to=Username"`
+	}
+	PanicMatches(t, func() { _ = validate.Struct(BadType{}) }, "Bad field type int")
+}
+
+func TestLeafTypes(t *testing.T) {
+	type Money struct {
+		Amount   int64 `validate:"gt=0"`
+		currency string
+	}
+
+	type Order struct {
+		Total    Money  `validate:"required"`
+		Discount *Money `validate:"omitempty"`
+		Refund   Money
+	}
+
+	validate := New()
+	errs := validate.Struct(Order{Total: Money{Amount: -1}, Discount: &Money{}})
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 3)
+	AssertError(t, errs, "Order.Total.Amount", "Order.Total.Amount", "Amount", "Amount", "gt")
+	AssertError(t, errs, "Order.Discount.Amount", "Order.Discount.Amount", "Amount", "Amount", "gt")
+	AssertError(t, errs, "Order.Refund.Amount", "Order.Refund.Amount", "Amount", "Amount", "gt")
+
+	validate = New(WithLeafTypes(&Money{}))
+	Equal(t, validate.Struct(Order{Total: Money{Amount: -1}, Discount: &Money{}}), nil)
+
+	errs = validate.Struct(Order{})
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 1)
+	AssertError(t, errs, "Order.Total", "Order.Total", "Total", "Total", "required")
+
+	_, ok := validate.Lookup(Order{}, "Order.Total.Amount")
+	Equal(t, ok, false)
+	val, ok := validate.Lookup(Order{Total: Money{Amount: 5}}, "Order.Total")
+	Equal(t, ok, true)
+	Equal(t, val.Interface(), Money{Amount: 5})
+
+	report := validate.Coverage(Order{})
+	Equal(t, report.Fields, 3)
+	Equal(t, report.Covered, 1)
+}
+
+func TestStopChildren(t *testing.T) {
+	type Address struct {
+		Street string `validate:"required"`
+		City   string `validate:"required"`
+		Zip    string `validate:"required,len=5"`
+	}
+
+	type Customer struct {
+		Billing  Address            `validate:"required,stopchildren"`
+		Shipping Address            `validate:"required"`
+		Previous []Address          `validate:"dive,required,stopchildren"`
+		Mailing  *Address           `validate:"stopchildren,required"`
+		Other    *Address           `validate:"omitempty,stopchildren"`
+		Keyed    map[string]Address `validate:"dive,stopchildren"`
+	}
+
+	validate := New()
+	errs := validate.Struct(Customer{Previous: []Address{{}, {Street: "1 Main St", City: "Springfield", Zip: "12345"}}})
+	NotEqual(t, errs, nil)
+	ve := errs.(ValidationErrors)
+	Equal(t, len(ve), 6)
+	AssertError(t, errs, "Customer.Billing", "Customer.Billing", "Billing", "Billing", "required")
+	AssertError(t, errs, "Customer.Shipping.Street", "Customer.Shipping.Street", "Street", "Street", "required")
+	AssertError(t, errs, "Customer.Shipping.City", "Customer.Shipping.City", "City", "City", "required")
+	AssertError(t, errs, "Customer.Shipping.Zip", "Customer.Shipping.Zip", "Zip", "Zip", "required")
+	AssertError(t, errs, "Customer.Previous[0]", "Customer.Previous[0]", "Previous[0]", "Previous[0]", "required")
+	AssertError(t, errs, "Customer.Mailing", "Customer.Mailing", "Mailing", "Mailing", "required")
+
+	// a struct passing its own tags is traversed
+	errs = validate.Struct(Customer{
+		Billing:  Address{Street: "1 Main St", City: "Springfield", Zip: "1"},
+		Shipping: Address{Street: "1 Main St", City: "Springfield", Zip: "12345"},
+		Mailing:  &Address{Street: "1 Main St", City: "Springfield", Zip: "12345"},
+		Keyed:    map[string]Address{"home": {}},
+	})
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 4)
+	AssertError(t, errs, "Customer.Billing.Zip", "Customer.Billing.Zip", "Zip", "Zip", "len")
+	AssertError(t, errs, "Customer.Keyed[home].Street", "Customer.Keyed[home].Street", "Street", "Street", "required")
+
+	Equal(t, validate.AssertRules(Customer{}, map[string]string{"Billing": "required,stopchildren", "Mailing": "stopchildren,required"}), nil)
+
+	PanicMatches(t, func() { validate.RegisterAlias("stopchildren", "required") }, fmt.Sprintf(restrictedAliasErr, "stopchildren"))
+}
+
+func TestTranslations(t *testing.T) {
+	type User struct {
+		Name   string   `validate:"required"`
+		Bio    string   `validate:"min=10"`
+		Tags   []string `validate:"max=1"`
+		Age    int      `validate:"gte=18"`
+		Color  string   `validate:"iscolor"`
+		Handle string   `validate:"alphaunicode"`
+		Role   string   `validate:"oneof=admin user"`
+	}
+
+	validate := New()
+	errs := validate.Struct(User{Bio: "short", Tags: []string{"a", "b"}, Age: 16, Color: "nope", Handle: "a-b", Role: "root"})
+	NotEqual(t, errs, nil)
+	Equal(t, errs.(ValidationErrors).Translate("en"), map[string]string{
+		"User.Name":   "Name is a required field",
+		"User.Bio":    "Bio must be at least 10 characters",
+		"User.Tags":   "Tags must be at most 1 item",
+		"User.Age":    "Age must be greater than or equal to 18",
+		"User.Color":  "Color must be a valid color",
+		"User.Handle": "Key: 'User.Handle' Error:Field validation for 'Handle' failed on the 'alphaunicode' tag",
+		"User.Role":   "Role must be one of [admin user]",
+	})
+
+	validate.RegisterTranslation("required", "pt", "{field} é obrigatório", nil)
+	validate.RegisterTranslation("min", "pt_BR", "{field} deve ter pelo menos {param} caracteres", nil)
+	validate.RegisterTranslation("oneof", "pt-br", "{field} não aceita '{value}'", func(fe FieldError, message string) string {
+		if s := Details(fe).Suggestion(); len(s) > 0 {
+			return message + ", você quis dizer '" + s + "'?"
+		}
+		return message
+	})
+	validate.RegisterTranslation("required", "en", "{field} is missing", nil)
+
+	errs = validate.Struct(User{Bio: "short", Age: 18, Color: "#fff", Handle: "ab", Role: "admn"})
+	NotEqual(t, errs, nil)
+	Equal(t, errs.(ValidationErrors).Translate("pt-BR"), map[string]string{
+		"User.Name": "Name é obrigatório",
+		"User.Bio":  "Bio deve ter pelo menos 10 caracteres",
+		"User.Role": "Role não aceita 'admn', você quis dizer 'admin'?",
+	})
+	Equal(t, errs.(ValidationErrors).Translate("pt"), map[string]string{
+		"User.Name": "Name é obrigatório",
+		"User.Bio":  "Bio must be at least 10 characters",
+		"User.Role": "Role must be one of [admin user]",
+	})
+	Equal(t, errs.(ValidationErrors).Translate("de")["User.Name"], "Name is missing")
+	Equal(t, Details(New().Var("", "required").(ValidationErrors)[0]).Translate("EN"), "is a required field")
+
+	PanicMatches(t, func() { validate.RegisterTranslation("", "en", "", nil) }, "translation tag and locale cannot be empty")
+	PanicMatches(t, func() { validate.RegisterTranslation("required", "", "", nil) }, "translation tag and locale cannot be empty")
+}
+
+func TestValueTranslations(t *testing.T) {
+	type User struct {
+		Gender string `validate:"oneof=male female 'not specified'"`
+		Role   string `validate:"oneofci=admin user"`
+	}
+
+	validate := New()
+	validate.RegisterTranslation("oneof", "de", "{field} muss eines von [{param}] sein", nil)
+	validate.RegisterTranslation("oneofci", "de", "{field} muss eines von [{param}] sein", nil)
+	validate.RegisterValueTranslations("de", map[string]string{"male": "männlich", "female": "weiblich", "not specified": "keine Angabe"})
+	validate.RegisterValueTranslations("de_AT", map[string]string{"female": "weiblich (AT)"})
+	validate.RegisterValueTranslations("en", map[string]string{"admin": "Administrator"})
+
+	errs := validate.Struct(User{Gender: "x", Role: "root"})
+	NotEqual(t, errs, nil)
+	Equal(t, errs.(ValidationErrors).Translate("de"), map[string]string{
+		"User.Gender": "Gender muss eines von [männlich, weiblich, keine Angabe] sein",
+		"User.Role":   "Role muss eines von [Administrator, user] sein",
+	})
+	Equal(t, errs.(ValidationErrors).Translate("de-AT")["User.Gender"], "Gender muss eines von [männlich, weiblich (AT), keine Angabe] sein")
+	Equal(t, errs.(ValidationErrors).Translate("en"), map[string]string{
+		"User.Gender": "Gender must be one of [male female 'not specified']",
+		"User.Role":   "Role must be one of [Administrator, user]",
+	})
+
+	PanicMatches(t, func() { validate.RegisterValueTranslations("", nil) }, "value translation locale cannot be empty")
+}
+
+func TestNewFieldError(t *testing.T) {
+	fe := NewFieldError("User.Address.City", "User.Address.City", "min", "3", "Ro")
+	Equal(t, fe.Namespace(), "User.Address.City")
+	Equal(t, fe.StructNamespace(), "User.Address.City")
+	Equal(t, fe.Field(), "City")
+	Equal(t, fe.StructField(), "City")
+	Equal(t, fe.Tag(), "min")
+	Equal(t, fe.ActualTag(), "min")
+	Equal(t, fe.Param(), "3")
+	Equal(t, fe.Value(), "Ro")
+	Equal(t, fe.Kind(), reflect.String)
+	Equal(t, fe.Type() == reflect.TypeOf(""), true)
+	Equal(t, Details(fe).Code(), "VAL_MIN")
+	Equal(t, Details(fe).Translate("en"), "City must be at least 3 characters")
+	Equal(t, fe.Error(), "Key: 'User.Address.City' Error:Field validation for 'City' failed on the 'min' tag")
+
+	type User struct {
+		Name string `validate:"required"`
+	}
+
+	expected := New().Struct(User{}).(ValidationErrors)[0]
+	fe = NewFieldError("User.Name", "User.Name", "required", "", "")
+	Equal(t, fe.Error(), expected.Error())
+	Equal(t, fe.Kind(), expected.Kind())
+	Equal(t, fe.Type() == expected.Type(), true)
+
+	fe = NewFieldError("User.Age", "User.Age", "required", "", nil)
+	Equal(t, fe.Kind(), reflect.Invalid)
+	Equal(t, fe.Type(), nil)
+}
+
+func TestStrictErrors(t *testing.T) {
+	type User struct {
+		Name string `validate:"required,"`
+	}
+
+	type Account struct {
+		Active bool `validate:"min=1"`
+	}
+
+	validate := New(WithStrictErrors())
+	err := validate.Struct(User{Name: "joey"})
+	var ive *InvalidValidationError
+	Equal(t, errors.As(err, &ive), true)
+	Equal(t, ive.Type == reflect.TypeOf(User{}), true)
+	Equal(t, ive.Reason, "Invalid validation tag on field 'Name'")
+	Equal(t, err.Error(), "validator: Invalid validation tag on field 'Name' validating validator.User")
+
+	err = validate.StructPartial(&Account{}, "Active")
+	Equal(t, err.Error(), "validator: Bad field type bool validating *validator.Account")
+	err = validate.StructExcept(Account{}, "Name")
+	Equal(t, err.Error(), "validator: Bad field type bool validating validator.Account")
+	err = validate.StructFiltered(Account{}, func(ns []byte) bool { return false })
+	Equal(t, err.Error(), "validator: Bad field type bool validating validator.Account")
+
+	err = validate.Var(true, "min=1")
+	Equal(t, err.Error(), "validator: Bad field type bool validating bool")
+	err = validate.Var("joey", "undefined")
+	Equal(t, err.Error(), "validator: Undefined validation function 'undefined' on field '' validating string")
+	err = validate.VarWithValue(true, false, "min=1")
+	Equal(t, err.Error(), "validator: Bad field type bool validating bool")
+
+	typed, err := CompileWith[Account](validate)
+	Equal(t, err, nil)
+	err = typed.Validate(context.Background(), &Account{})
+	Equal(t, err.Error(), "validator: Bad field type bool validating validator.Account")
+
+	// validation keeps working once a call failed
+	Equal(t, validate.Var("joey", "required,min=2"), nil)
+	err = validate.Var("", "required")
+	AssertError(t, err.(ValidationErrors), "", "", "", "", "required")
+	Equal(t, validate.Struct(5).Error(), "validator: (nil int)")
+
+	PanicMatches(t, func() { _ = New().Var(true, "min=1") }, "Bad field type bool")
+}
+
+func TestKeyFormatter(t *testing.T) {
+	type Config struct {
+		Labels map[string]string   `validate:"dive,required"`
+		Hashes map[[4]byte]string  `validate:"dive,required"`
+		Items  []string            `validate:"dive,required"`
+		Limits map[int]int         `validate:"dive,gte=1"`
+		Keys   map[string][]string `validate:"dive,dive,required"`
+	}
+
+	cfg := Config{
+		Labels: map[string]string{"a b": "", "a very long label key": ""},
+		Hashes: map[[4]byte]string{{1, 2, 3, 4}: ""},
+		Items:  []string{"", "b"},
+		Limits: map[int]int{7: 0},
+		Keys:   map[string][]string{"k": {""}},
+	}
+
+	validate := New()
+	errs := validate.Struct(cfg).(ValidationErrors)
+	Equal(t, len(errs), 6)
+	AssertError(t, errs, "Config.Labels[a b]", "Config.Labels[a b]", "Labels[a b]", "Labels[a b]", "required")
+	AssertError(t, errs, "Config.Hashes[[1 2 3 4]]", "Config.Hashes[[1 2 3 4]]", "Hashes[[1 2 3 4]]", "Hashes[[1 2 3 4]]", "required")
+	AssertError(t, errs, "Config.Items[0]", "Config.Items[0]", "Items[0]", "Items[0]", "required")
+
+	validate.RegisterKeyFormatter(ReadableKeys(8))
+	errs = validate.Struct(cfg).(ValidationErrors)
+	Equal(t, len(errs), 6)
+	AssertError(t, errs, `Config.Labels["a b"]`, `Config.Labels["a b"]`, `Labels["a b"]`, `Labels["a b"]`, "required")
+	AssertError(t, errs, `Config.Labels["a very l…"]`, `Config.Labels["a very l…"]`, `Labels["a very l…"]`, `Labels["a very l…"]`, "required")
+	AssertError(t, errs, "Config.Hashes[AQIDBA==]", "Config.Hashes[AQIDBA==]", "Hashes[AQIDBA==]", "Hashes[AQIDBA==]", "required")
+	AssertError(t, errs, "Config.Items[0]", "Config.Items[0]", "Items[0]", "Items[0]", "required")
+	AssertError(t, errs, "Config.Limits[7]", "Config.Limits[7]", "Limits[7]", "Limits[7]", "gte")
+	AssertError(t, errs, `Config.Keys["k"][0]`, `Config.Keys["k"][0]`, `Keys["k"][0]`, `Keys["k"][0]`, "required")
+
+	validate = New()
+	validate.RegisterKeyFormatter(func(key interface{}) string {
+		return fmt.Sprintf("#%v", key)
+	})
+	validate.RegisterTagNameFunc(func(fld reflect.StructField) string {
+		return strings.ToLower(fld.Name)
+	})
+	errs = validate.Struct(Config{Items: []string{""}}).(ValidationErrors)
+	AssertError(t, errs, "Config.items[#0]", "Config.Items[#0]", "items[#0]", "Items[#0]", "required")
+
+	Equal(t, ReadableKeys(0)("a very long label key"), `"a very long label key"`)
+	Equal(t, ReadableKeys(3)(12345), "123…")
+	Equal(t, ReadableKeys(3)("äöüß"), `"äöü…"`)
+}
+
+func TestItemsValidation(t *testing.T) {
+	type Pair struct {
+		Owner  [2]string   `validate:"item0=required,uuid4;item1=required,email"`
+		Range  []int       `validate:"len=2;item0=gte=0;item1=gte=0,lte=100"`
+		Window [3]string   `validate:"item2=omitempty,after=Start;2006-01-02"`
+		Ptr    *[2]string  `validate:"omitempty;item1=required"`
+		Nested [2][]string `validate:"item1=dive,required"`
+	}
+
+	validate := New()
+	err := validate.Struct(Pair{
+		Owner: [2]string{"a987fbc9-4bed-4078-8f07-9141ba07c9f3", "joey@example.com"},
+		Range: []int{1, 100},
+	})
+	Equal(t, err, nil)
+
+	errs := validate.Struct(Pair{
+		Owner:  [2]string{"joey", ""},
+		Range:  []int{-1, 101},
+		Ptr:    &[2]string{"a", ""},
+		Nested: [2][]string{{""}, {"a", ""}},
+	}).(ValidationErrors)
+	Equal(t, len(errs), 6)
+	AssertError(t, errs, "Pair.Owner[0]", "Pair.Owner[0]", "Owner[0]", "Owner[0]", "uuid4")
+	AssertError(t, errs, "Pair.Owner[1]", "Pair.Owner[1]", "Owner[1]", "Owner[1]", "required")
+	AssertError(t, errs, "Pair.Range[0]", "Pair.Range[0]", "Range[0]", "Range[0]", "gte")
+	AssertError(t, errs, "Pair.Range[1]", "Pair.Range[1]", "Range[1]", "Range[1]", "lte")
+	AssertError(t, errs, "Pair.Ptr[1]", "Pair.Ptr[1]", "Ptr[1]", "Ptr[1]", "required")
+	AssertError(t, errs, "Pair.Nested[1][1]", "Pair.Nested[1][1]", "Nested[1][1]", "Nested[1][1]", "required")
+
+	// the collection's own tags fail first, positions past the length of a slice aren't validated
+	errs = validate.Struct(Pair{Owner: [2]string{"a987fbc9-4bed-4078-8f07-9141ba07c9f3", "joey@example.com"}, Range: []int{-1}}).(ValidationErrors)
+	Equal(t, len(errs), 1)
+	AssertError(t, errs, "Pair.Range", "Pair.Range", "Range", "Range", "len")
+	Equal(t, validate.Var([]int{-1}, "item0=gte=0;item1=gte=0").(ValidationErrors)[0].Namespace(), "[0]")
+	Equal(t, validate.Var([]int{1}, "item0=gte=0;item1=gte=0"), nil)
+
+	fields, err := validate.Describe(Pair{})
+	Equal(t, err, nil)
+	Equal(t, fields[2].Rules, []Rule{{Tag: "item", Param: "2"}, {Tag: "omitempty"}, {Tag: "after", Param: "Start;2006-01-02"}})
+	Equal(t, validate.AssertRules(Pair{}, map[string]string{
+		"Owner": "item0=required,uuid4;item1=required,email",
+		"Range": "len=2;item0=gte=0;item1=gte=0,lte=100",
+	}), nil)
+
+	PanicMatches(t, func() { _ = validate.Var("ab", "item0=required") }, "positional rules can only be used on arrays and slices, field '' is a string")
+	PanicMatches(t, func() { _ = validate.Var([]int{1}, "item0=required;item0=gte=1") }, "Duplicate positional rules 'item0' on field ''")
+
+	// positional rules only start at a segment boundary
+	PanicMatches(t, func() { _ = validate.Var([]int{1}, "required,item0=min=1") }, "Positional rules 'item0' must start a ';' separated segment on field ''")
+	PanicMatches(t, func() { _ = validate.Var([]int{1}, "item0=required,item1=min=1") }, "Positional rules 'item1' must start a ';' separated segment on field ''")
+	PanicMatches(t, func() { _ = validate.Var([]int{1}, "min=1,rest=gte=1") }, "Positional rules 'rest' must start a ';' separated segment on field ''")
+
+	// tags merely containing 'item' or 'rest' aren't positional rules
+	validate.RegisterValidation("interest", func(fl FieldLevel) bool { return fl.Field().Int() > 0 })
+	validate.RegisterValidation("itemized", func(fl FieldLevel) bool { return fl.Field().Len() > 0 })
+	Equal(t, validate.Var(1, "required,interest"), nil)
+	NotEqual(t, validate.Var(0, "interest"), nil)
+	Equal(t, validate.Var([]int{1}, "itemized;item0=interest"), nil)
+	Equal(t, validate.Var([]int{0}, "itemized;item0=interest").(ValidationErrors)[0].Tag(), "interest")
+	Equal(t, validate.Var([]int{}, "itemized").(ValidationErrors)[0].Tag(), "itemized")
+}
+
+func TestTupleValidation(t *testing.T) {
+	type Row struct {
+		Record []string `validate:"min=2,tuple=uuid4;email;rest=numeric"`
+		Fixed  [3]int   `validate:"tuple=gte=1;;lte=10"`
+	}
+
+	validate := New()
+	err := validate.Struct(Row{
+		Record: []string{"a987fbc9-4bed-4078-8f07-9141ba07c9f3", "joey@example.com", "1", "2"},
+		Fixed:  [3]int{1, 100, 10},
+	})
+	Equal(t, err, nil)
+
+	errs := validate.Struct(Row{
+		Record: []string{"joey", "joey", "1", "a"},
+		Fixed:  [3]int{0, 0, 11},
+	}).(ValidationErrors)
+	Equal(t, len(errs), 5)
+	AssertError(t, errs, "Row.Record[0]", "Row.Record[0]", "Record[0]", "Record[0]", "uuid4")
+	AssertError(t, errs, "Row.Record[1]", "Row.Record[1]", "Record[1]", "Record[1]", "email")
+	AssertError(t, errs, "Row.Record[3]", "Row.Record[3]", "Record[3]", "Record[3]", "numeric")
+	AssertError(t, errs, "Row.Fixed[0]", "Row.Fixed[0]", "Fixed[0]", "Fixed[0]", "gte")
+	AssertError(t, errs, "Row.Fixed[2]", "Row.Fixed[2]", "Fixed[2]", "Fixed[2]", "lte")
+
+	// the collection's own tags fail first
+	errs = validate.Struct(Row{Record: []string{"a987fbc9-4bed-4078-8f07-9141ba07c9f3"}, Fixed: [3]int{1}}).(ValidationErrors)
+	Equal(t, len(errs), 1)
+	AssertError(t, errs, "Row.Record", "Row.Record", "Record", "Record", "min")
+
+	Equal(t, validate.Var([]string{"1", "2", "x"}, "rest=numeric").(ValidationErrors)[0].Namespace(), "[2]")
+	Equal(t, validate.Var([]string{"x", "2"}, "item0=alpha;rest=numeric"), nil)
+
+	fields, err := validate.Describe(Row{})
+	Equal(t, err, nil)
+	Equal(t, fields[0].Rules, []Rule{
+		{Tag: "min", Param: "2"},
+		{Tag: "item", Param: "0"}, {Tag: "uuid4"},
+		{Tag: "item", Param: "1"}, {Tag: "email"},
+		{Tag: "rest"}, {Tag: "numeric"},
+	})
+	Equal(t, validate.AssertRules(Row{}, map[string]string{
+		"Record": "min=2;item0=uuid4;item1=email;rest=numeric",
+		"Fixed":  "item0=gte=1;item2=lte=10",
+	}), nil)
+
+	PanicMatches(t, func() { _ = validate.Var([]int{1}, "tuple=rest=gte=1;lte=1") }, "'rest' must be the last position of the 'tuple' tag on field ''")
+	PanicMatches(t, func() { _ = validate.Var([]int{1}, "required,tuple=") }, "'tuple' tag has no positions on field ''")
+	PanicMatches(t, func() { _ = validate.Var([]int{1}, "rest=gte=1;rest=lte=1") }, "Duplicate positional rules 'rest' on field ''")
+}
+
+func TestCheckStruct(t *testing.T) {
+	type Inner struct {
+		Code string `validate:"len=b"`
+	}
+
+	type Test struct {
+		Name  string            `validate:"required,undefinedtag"`
+		Size  string            `validate:"len=a"`
+		Tags  string            `validate:"dive,required"`
+		Flags map[string]string `validate:"dive,keys,min=x,endkeys,required"`
+		Age   int               `validate:"gte=1,lte=130"`
+		Skip  string            `validate:"-"`
+		Inner Inner
+	}
+
+	validate := New()
+	err := validate.CheckStruct(Test{})
+	NotEqual(t, err, nil)
+
+	msg := err.Error()
+	for _, s := range []string{
+		"field 'Name' on struct 'Test'",
+		"undefinedtag",
+		"field 'Size' on struct 'Test': 'len=a' on string",
+		"field 'Tags' on struct 'Test': 'dive' can only be used on slices, arrays and maps, not string",
+		"field 'Flags' on struct 'Test': 'min=x' on string",
+		"field 'Code' on struct 'Inner': 'len=b' on string",
+	} {
+		Equal(t, strings.Contains(msg, s), true)
+	}
+	Equal(t, strings.Contains(msg, "'Age'"), false)
+	Equal(t, len(strings.Split(msg, "\n")), 5)
+
+	type Valid struct {
+		Name  string   `validate:"required,max=10"`
+		Items []string `validate:"required,dive,min=1"`
+		CSV   string   `validate:"strsplit=;,dive,alpha"`
+		Inner *Inner   `validate:"-"`
+	}
+
+	Equal(t, validate.CheckStruct(&Valid{}), nil)
+
+	var invalid *InvalidValidationError
+	Equal(t, errors.As(validate.CheckStruct(1), &invalid), true)
+	Equal(t, errors.As(validate.CheckStruct(nil), &invalid), true)
+}
+
+func TestStructVersion(t *testing.T) {
+	type Item struct {
+		SKU string `validate:"v1:required;v2:required,uuid4"`
+	}
+
+	type Order struct {
+		ID      string    `validate:"v1:required;v2:omitempty,uuid4"`
+		Note    string    `validate:"max=5;v3:omitempty"`
+		Legacy  string    `validate:"v1:required"`
+		At      string    `validate:"omitempty,datetime=15:04"`
+		Start   time.Time `validate:"after=At;15:04;v2:-"`
+		Items   []Item    `validate:"v1:omitempty;v2:required,dive"`
+		Comment string    `validate:"required"`
+	}
+
+	validate := New()
+	ctx := context.Background()
+	o := Order{Note: "too long", At: "12:00", Start: time.Now(), Comment: "c"}
+
+	errs := validate.StructVersion(ctx, o, "v1")
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 3)
+	AssertError(t, errs, "Order.ID", "Order.ID", "ID", "ID", "required")
+	AssertError(t, errs, "Order.Note", "Order.Note", "Note", "Note", "max")
+	AssertError(t, errs, "Order.Legacy", "Order.Legacy", "Legacy", "Legacy", "required")
+
+	errs = validate.StructVersion(ctx, o, "v2")
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 2)
+	AssertError(t, errs, "Order.Note", "Order.Note", "Note", "Note", "max")
+	AssertError(t, errs, "Order.Items", "Order.Items", "Items", "Items", "required")
+
+	o.ID, o.Items = "not-a-uuid", []Item{{SKU: "abc"}}
+	errs = validate.StructVersion(ctx, o, "v2")
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 3)
+	AssertError(t, errs, "Order.ID", "Order.ID", "ID", "ID", "uuid4")
+	AssertError(t, errs, "Order.Items[0].SKU", "Order.Items[0].SKU", "SKU", "SKU", "uuid4")
+
+	Equal(t, validate.StructVersion(ctx, &o, "v3"), nil)
+
+	// the segment without a version applies to Struct
+	errs = validate.Struct(o)
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 1)
+	AssertError(t, errs, "Order.Note", "Order.Note", "Note", "Note", "max")
+	Equal(t, validate.StructVersion(ctx, o, ""), validate.Struct(o))
+
+	o.Start = time.Date(0, 1, 1, 8, 0, 0, 0, time.UTC)
+	errs = validate.Struct(o)
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "Order.Start", "Order.Start", "Start", "Start", "after")
+
+	Equal(t, versionedTag("required,datetime=15:04", "v1"), "required,datetime=15:04")
+	Equal(t, versionedTag("after=Start;2006-01-02", "v1"), "after=Start;2006-01-02")
+	Equal(t, versionedTag("v1:after=Start;15:04;v2.1:required", "v1"), "after=Start;15:04")
+	Equal(t, versionedTag("v1:required;v2.1:uuid", "v2.1"), "uuid")
+	Equal(t, versionedTag("v1:required", "v2"), "")
+
+	// only 'v<N>' prefixes are versions
+	Equal(t, versionedTag("after=Start;layout:15:04", "v1"), "after=Start;layout:15:04")
+	Equal(t, versionedTag("v1:after=Start;beta:15:04;v2:required", "v1"), "after=Start;beta:15:04")
+	Equal(t, versionedTag("required;v:min=1;v2.:max=1;v1a:uuid", "v1"), "required;v:min=1;v2.:max=1;v1a:uuid")
+	validate.recordTagVersions("required;beta:min=1;v1.0:max=1")
+	_, ok := validate.tagVersions.Load("beta")
+	Equal(t, ok, false)
+	_, ok = validate.tagVersions.Load("v1.0")
+	Equal(t, ok, true)
+
+	_, ok = validate.versionCaches.Load("v2")
+	Equal(t, ok, true)
+	_, ok = validate.versionCaches.Load("")
+	Equal(t, ok, false)
+
+	// the versions not used by the tags aren't cached
+	Equal(t, validate.StructVersion(ctx, o, "v9"), validate.Struct(o))
+	_, ok = validate.versionCaches.Load("v3")
+	Equal(t, ok, true)
+	_, ok = validate.versionCaches.Load("v9")
+	Equal(t, ok, false)
+}
+
+type tenantCtxKey struct{}
+
+type projectsCtxKey struct{}
+
+type ctxTenantID string
+
+func (id ctxTenantID) String() string { return string(id) }
+
+func TestContextValueValidation(t *testing.T) {
+	type Payload struct {
+		TenantID  string `validate:"eqctx=tenant"`
+		ProjectID int64  `validate:"oneof_ctx=projects"`
+	}
+
+	validate := New(WithContextValueResolver(ContextKeys(map[string]interface{}{
+		"tenant":   tenantCtxKey{},
+		"projects": projectsCtxKey{},
+	})))
+
+	ctx := context.WithValue(context.Background(), tenantCtxKey{}, "acme")
+	ctx = context.WithValue(ctx, projectsCtxKey{}, []int{1, 2, 3})
+
+	errs := validate.StructCtx(ctx, Payload{TenantID: "acme", ProjectID: 2})
+	Equal(t, errs, nil)
+
+	errs = validate.StructCtx(ctx, Payload{TenantID: "globex", ProjectID: 4})
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "Payload.TenantID", "Payload.TenantID", "TenantID", "TenantID", "eqctx")
+	AssertError(t, errs, "Payload.ProjectID", "Payload.ProjectID", "ProjectID", "ProjectID", "oneof_ctx")
+
+	fe := getError(errs, "Payload.TenantID", "Payload.TenantID")
+	Equal(t, fe.Param(), "tenant")
+	Equal(t, Details(fe).Translate("en"), "TenantID must match the tenant of the context")
+
+	// values missing from the context fail
+	errs = validate.StructCtx(context.Background(), Payload{TenantID: "acme", ProjectID: 2})
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 2)
+
+	errs = validate.Struct(Payload{TenantID: "acme", ProjectID: 2})
+	NotEqual(t, errs, nil)
+
+	// stringers and map keys
+	ctx = context.WithValue(context.Background(), tenantCtxKey{}, ctxTenantID("acme"))
+	ctx = context.WithValue(ctx, projectsCtxKey{}, map[uint8]struct{}{7: {}})
+
+	errs = validate.StructCtx(ctx, Payload{TenantID: "acme", ProjectID: 7})
+	Equal(t, errs, nil)
+
+	errs = validate.StructCtx(ctx, Payload{TenantID: "acme", ProjectID: 8})
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "Payload.ProjectID", "Payload.ProjectID", "ProjectID", "ProjectID", "oneof_ctx")
+
+	errs = validate.VarCtx(ctx, "acme", "eqctx=tenant")
+	Equal(t, errs, nil)
+
+	errs = validate.VarCtx(ctx, "acme", "eqctx=unknown")
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "", "", "", "", "eqctx")
+
+	PanicMatches(t, func() { _ = New().VarCtx(ctx, "acme", "eqctx=tenant") },
+		"no ContextValueResolver set for 'eqctx', see WithContextValueResolver")
+}
+
+func TestRequiredRoleValidation(t *testing.T) {
+	type rolesCtxKey struct{}
+	type Payload struct {
+		Name        string  `validate:"required"`
+		AuditReason string  `validate:"required_role=admin auditor"`
+		Approver    *string `validate:"required_role=admin,omitempty,min=2"`
+	}
+
+	validate := New(WithRolesProvider(func(ctx context.Context) []string {
+		roles, _ := ctx.Value(rolesCtxKey{}).([]string)
+		return roles
+	}))
+
+	user := context.WithValue(context.Background(), rolesCtxKey{}, []string{"user"})
+	admin := context.WithValue(context.Background(), rolesCtxKey{}, []string{"user", "admin"})
+	auditor := context.WithValue(context.Background(), rolesCtxKey{}, []string{"auditor"})
+
+	Equal(t, validate.StructCtx(user, Payload{Name: "a"}), nil)
+	Equal(t, validate.Struct(Payload{Name: "a"}), nil)
+
+	errs := validate.StructCtx(admin, Payload{Name: "a"}).(ValidationErrors)
+	Equal(t, len(errs), 2)
+	AssertError(t, errs, "Payload.AuditReason", "Payload.AuditReason", "AuditReason", "AuditReason", "required_role")
+	AssertError(t, errs, "Payload.Approver", "Payload.Approver", "Approver", "Approver", "required_role")
+	Equal(t, Details(errs[0]).Translate("en"), "AuditReason is a required field")
+
+	errs = validate.StructCtx(auditor, Payload{Name: "a"}).(ValidationErrors)
+	Equal(t, len(errs), 1)
+	AssertError(t, errs, "Payload.AuditReason", "Payload.AuditReason", "AuditReason", "AuditReason", "required_role")
+
+	approver := "b"
+	errs = validate.StructCtx(admin, Payload{Name: "a", AuditReason: "review", Approver: &approver}).(ValidationErrors)
+	Equal(t, len(errs), 1)
+	AssertError(t, errs, "Payload.Approver", "Payload.Approver", "Approver", "Approver", "min")
+
+	Equal(t, validate.VarCtx(user, "", "required_role=admin"), nil)
+	NotEqual(t, validate.VarCtx(admin, "", "required_role=admin"), nil)
+
+	PanicMatches(t, func() { _ = New().VarCtx(admin, "", "required_role=admin") },
+		"no RolesProvider set for 'required_role', see WithRolesProvider")
+}
+
+func TestDescribe(t *testing.T) {
+	type Inner struct {
+		Name string `validate:"required"`
+	}
+
+	type Test struct {
+		Email   string   `json:"email" validate:"required,email"`
+		Tags    []string `json:"tags" validate:"dive,iscolor"`
+		Inner   Inner    `json:"inner"`
+		Skipped string   `validate:"-"`
+	}
+
+	validate := New(WithFieldNameTags("json"))
+	fields, err := validate.Describe(&Test{})
+	Equal(t, err, nil)
+	Equal(t, len(fields), 3)
+	Equal(t, fields[0].Name, "email")
+	Equal(t, fields[0].Field, "Email")
+	Equal(t, fields[0].Type == reflect.TypeOf(""), true)
+	Equal(t, fields[0].Rules, []Rule{{Tag: "required"}, {Tag: "email"}})
+	Equal(t, fields[1].Rules, []Rule{
+		{Tag: "dive"},
+		{Tag: "hexcolor", Alias: "iscolor", Or: true},
+		{Tag: "rgb", Alias: "iscolor", Or: true},
+		{Tag: "rgba", Alias: "iscolor", Or: true},
+		{Tag: "hsl", Alias: "iscolor", Or: true},
+		{Tag: "hsla", Alias: "iscolor"},
+	})
+	Equal(t, fields[2].Name, "inner")
+	Equal(t, len(fields[2].Rules), 0)
+
+	_, err = validate.Describe("test")
+	NotEqual(t, err, nil)
+	Equal(t, err.Error(), "validator: (nil string)")
+}
+
+func TestRules(t *testing.T) {
+	type Address struct {
+		City string `json:"city" validate:"required"`
+		Zip  string `json:"zip"`
+	}
+
+	type Node struct {
+		Name     string  `json:"name" validate:"required"`
+		Children []*Node `json:"children" validate:"dive"`
+	}
+
+	type Test struct {
+		Email     string            `json:"email" validate:"required,email"`
+		Emails    []string          `json:"emails" validate:"max=3,dive,email"`
+		Labels    map[string]string `json:"labels" validate:"dive,keys,alpha,endkeys,max=10"`
+		Pair      []int             `json:"pair" validate:"len=2;item0=min=1;item1=max=5"`
+		Address   *Address          `json:"address"`
+		Addresses []Address         `json:"addresses" validate:"dive"`
+		Tree      Node              `json:"tree"`
+		Skipped   string            `validate:"-"`
+	}
+
+	validate := New(WithFieldNameTags("json"))
+	rules, err := validate.Rules(&Test{})
+	Equal(t, err, nil)
+
+	var paths []string
+	for _, r := range rules {
+		paths = append(paths, r.Path)
+	}
+	Equal(t, paths, []string{
+		"email", "emails", "emails[]", "labels[]", "labels[]", "pair", "pair[0]", "pair[1]",
+		"address.city", "addresses[].city", "tree.name",
+	})
+
+	Equal(t, rules[0].Field, "Email")
+	Equal(t, rules[0].Type == reflect.TypeOf(""), true)
+	Equal(t, rules[0].Rules, []Rule{{Tag: "required"}, {Tag: "email"}})
+	Equal(t, rules[1].Rules, []Rule{{Tag: "max", Param: "3"}})
+	Equal(t, rules[2].Depth, 1)
+	Equal(t, rules[2].Field, "Emails")
+	Equal(t, rules[2].Rules, []Rule{{Tag: "email"}})
+	Equal(t, rules[3].Keys, true)
+	Equal(t, rules[3].Rules, []Rule{{Tag: "alpha"}})
+	Equal(t, rules[4].Keys, false)
+	Equal(t, rules[4].Rules, []Rule{{Tag: "max", Param: "10"}})
+	Equal(t, rules[6].Depth, 1)
+	Equal(t, rules[6].Type == reflect.TypeOf(0), true)
+	Equal(t, rules[6].Rules, []Rule{{Tag: "min", Param: "1"}})
+	Equal(t, rules[9].Depth, 1)
+
+	_, err = validate.Rules("test")
+	NotEqual(t, err, nil)
+}
+
+func TestAudit(t *testing.T) {
+	type Address struct {
+		City string `validate:"required"`
+	}
+
+	type User struct {
+		Email    string   `json:"email" validate:"sensitive,required,email"`
+		Password []byte   `json:"password" validate:"required,sensitive"`
+		Name     string   `json:"name"`
+		Age      *int     `json:"age"`
+		Tags     []string `json:"tags" validate:"dive,required"`
+		Address  Address  `json:"address"`
+		Skipped  string   `validate:"-"`
+	}
+
+	validate := New(WithFieldNameTags("json"))
+	salt := []byte("salt")
+	ctx, audit := NewAuditContext(context.Background(), salt)
+	errs := validate.StructCtx(ctx, User{Email: "joey@example.com", Password: []byte("secret"), Tags: []string{"a"}})
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "User.address.City", "User.Address.City", "City", "City", "required")
+
+	emailHash := hmac.New(sha256.New, salt)
+	emailHash.Write([]byte("joey@example.com"))
+	passwordHash := hmac.New(sha256.New, salt)
+	passwordHash.Write([]byte("secret"))
+
+	Equal(t, audit.Fields, []AuditedField{
+		{Namespace: "User.email", Present: true, Hash: "hmac-sha256:" + hex.EncodeToString(emailHash.Sum(nil))},
+		{Namespace: "User.password", Present: true, Hash: "hmac-sha256:" + hex.EncodeToString(passwordHash.Sum(nil))},
+		{Namespace: "User.name"},
+		{Namespace: "User.age"},
+		{Namespace: "User.tags", Present: true},
+		{Namespace: "User.address"},
+		{Namespace: "User.address.City"},
+	})
+	Equal(t, strings.Contains(audit.String(), "joey"), false)
+	Equal(t, strings.Split(audit.String(), "\n")[2], "User.name: absent")
+
+	a, ok := AuditFromContext(ctx)
+	Equal(t, ok, true)
+	Equal(t, a, audit)
+
+	// audits and recordings share the context of a call
+	actx, a := NewAuditContext(context.Background(), salt)
+	rctx, rec := NewRecordingContext(actx)
+	Equal(t, validate.StructCtx(rctx, User{Email: "joey@example.com", Password: []byte("secret"), Address: Address{City: "Berlin"}}), nil)
+	Equal(t, len(a.Fields), 7)
+	Equal(t, len(rec.Steps) > 0, true)
+	_, ok = RecordingFromContext(actx)
+	Equal(t, ok, false)
+	_, ok = AuditFromContext(context.Background())
+	Equal(t, ok, false)
+
+	// the sensitive modifier isn't a rule
+	Equal(t, validate.AssertRules(User{}, map[string]string{"Email": "required,email", "Password": "required"}), nil)
+
+	errs = validate.Struct(User{Email: "joey@example.com", Password: []byte("secret"), Address: Address{City: "Berlin"}})
+	Equal(t, errs, nil)
+	Equal(t, len(audit.Fields), 7)
+
+	PanicMatches(t, func() { _ = validate.RegisterValidation(sensitiveTag, func(FieldLevel) bool { return true }) },
+		"Tag 'sensitive' either contains restricted characters or is the same as a restricted tag needed for normal operation")
+}
+
+func TestPair(t *testing.T) {
+	type Owner struct {
+		ID string
+	}
+
+	type Order struct {
+		Version int
+		Total   float64
+		Owner   *Owner
+	}
+
+	type UpdateOrder struct {
+		Version int
+		Total   float64
+		OwnerID string
+	}
+
+	validate := New()
+	rules := []PairRule{
+		{Field: "Version", Other: "Version", Tag: "eqfield"},
+		{Field: "Total", Other: "Total", Tag: "ltefield"},
+		{Field: "OwnerID", Other: "Owner.ID", Tag: "required,eqfield"},
+	}
+
+	current := &Order{Version: 3, Total: 10, Owner: &Owner{ID: "joey"}}
+	errs := validate.Pair(context.Background(), UpdateOrder{Version: 3, Total: 5, OwnerID: "joey"}, current, rules...)
+	Equal(t, errs, nil)
+
+	errs = validate.Pair(context.Background(), &UpdateOrder{Version: 2, Total: 15, OwnerID: "joey"}, current, rules...)
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 2)
+	AssertError(t, errs, "UpdateOrder.Version", "UpdateOrder.Version", "Version", "Version", "eqfield")
+	AssertError(t, errs, "UpdateOrder.Total", "UpdateOrder.Total", "Total", "Total", "ltefield")
+
+	fe := getError(errs, "UpdateOrder.Version", "UpdateOrder.Version")
+	Equal(t, fe.Param(), "Order.Version")
+	Equal(t, fe.Value(), 2)
+	Equal(t, fe.Error(), "Key: 'UpdateOrder.Version' Error:Field validation for 'Version' failed on the 'eqfield' tag")
+	Equal(t, Details(fe).Translate("en"), "Version must be equal to Order.Version")
+
+	// unreachable fields are nil
+	errs = validate.Pair(context.Background(), UpdateOrder{Version: 3, OwnerID: "joey"}, Order{Version: 3}, rules...)
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 1)
+	AssertError(t, errs, "UpdateOrder.OwnerID", "UpdateOrder.OwnerID", "OwnerID", "OwnerID", "eqfield")
+	Equal(t, getError(errs, "UpdateOrder.OwnerID", "UpdateOrder.OwnerID").Param(), "Order.Owner.ID")
+
+	errs = validate.Pair(context.Background(), UpdateOrder{}, Order{}, PairRule{Field: "Missing", Other: "Version", Tag: "required"})
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "UpdateOrder.Missing", "UpdateOrder.Missing", "Missing", "Missing", "required")
+
+	errs = validate.Pair(context.Background(), "order", current, rules...)
+	NotEqual(t, errs, nil)
+	Equal(t, errs.Error(), "validator: (nil string)")
+
+	errs = validate.Pair(context.Background(), current, nil, rules...)
+	NotEqual(t, errs, nil)
+	Equal(t, errs.Error(), "validator: (nil)")
+}
+
+func TestSetCardinalityValidation(t *testing.T) {
+	type Contact struct {
+		Contact struct{} `validate:"min_set=2 of=Email Phone Address"`
+		Email   string
+		Phone   *string
+		Address []string
+		Payment *int `validate:"max_set=1 Card IBAN"`
+		Card    string
+		IBAN    string
+	}
+
+	phone := "+15555555555"
+	validate := New()
+	errs := validate.Struct(Contact{Email: "joey@example.com", Phone: &phone, Card: "4242"})
+	Equal(t, errs, nil)
+
+	errs = validate.Struct(Contact{Email: "joey@example.com", Address: []string{}})
+	Equal(t, errs, nil)
+
+	errs = validate.Struct(Contact{Email: "joey@example.com", Card: "4242", IBAN: "DE89"})
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 2)
+	AssertError(t, errs, "Contact.Contact", "Contact.Contact", "Contact", "Contact", "min_set")
+	AssertError(t, errs, "Contact.Payment", "Contact.Payment", "Payment", "Payment", "max_set")
+	Equal(t, getError(errs, "Contact.Contact", "Contact.Contact").Param(), "2 of=Email Phone Address")
+
+	type Bad struct {
+		Field string `validate:"min_set=two of=Email"`
+	}
+	PanicMatches(t, func() { _ = validate.Struct(Bad{}) }, "Bad param 'two of=Email' for 'min_set'")
+
+	type Short struct {
+		Field string `validate:"max_set=1"`
+	}
+	PanicMatches(t, func() { _ = validate.Struct(Short{}) }, "Bad param '1' for 'max_set'")
+}
+
+func TestForm(t *testing.T) {
+	type Address struct {
+		PostalCode string `json:"postal_code" validate:"required,numeric,len=5"`
+	}
+
+	type Account struct {
+		Email    string     `json:"email" label:"E-mail address" validate:"required,email"`
+		UserID   int        `json:"user_id" validate:"gt=0,lt=100"`
+		Ratio    float64    `json:"ratio" validate:"gt=0"`
+		Role     string     `json:"role" validate:"oneof=admin 'read only'"`
+		Tags     []string   `json:"tags" validate:"max=3,dive,oneof=a b"`
+		Active   bool       `json:"active"`
+		Born     string     `json:"born" validate:"omitempty,datetime=2006-01-02"`
+		Created  time.Time  `json:"created"`
+		Address  *Address   `json:"address"`
+		Parent   *Account   `json:"parent"`
+		Internal string     `json:"-" validate:"-"`
+		Code     string     `json:"code" validate:"alpha|numeric"`
+		Extra    []*Address `json:"extra"`
+	}
+
+	validate := New(WithFieldNameTags("json"))
+	fields, err := validate.Form(&Account{})
+	Equal(t, err, nil)
+
+	f := func(n float64) *float64 { return &n }
+	Equal(t, fields, []FormField{
+		{Name: "email", Label: "E-mail address", Type: "email", Required: true},
+		{Name: "user_id", Label: "User ID", Type: "number", Min: f(1), Max: f(99)},
+		{Name: "ratio", Label: "Ratio", Type: "number", Min: f(0)},
+		{Name: "role", Label: "Role", Type: "select", Options: []string{"admin", "read only"}},
+		{Name: "tags", Label: "Tags", Type: "multiselect", Max: f(3), Options: []string{"a", "b"}},
+		{Name: "active", Label: "Active", Type: "checkbox"},
+		{Name: "born", Label: "Born", Type: "date"},
+		{Name: "created", Label: "Created", Type: "datetime"},
+		{Name: "address.postal_code", Label: "Postal Code", Type: "text", Required: true, Min: f(5), Max: f(5), Pattern: numericRegexString},
+		{Name: "code", Label: "Code", Type: "text"},
+		{Name: "extra", Label: "Extra", Type: "list"},
+	})
+
+	_, err = validate.Form("account")
+	NotEqual(t, err, nil)
+}
+
+func TestErrorFamilies(t *testing.T) {
+	type Test struct {
+		Age     int           `validate:"gte=18"`
+		Ratio   float64       `validate:"lt=0.5"`
+		Count   uint          `validate:"eq=3"`
+		Timeout time.Duration `validate:"max=1s"`
+		Name    string        `validate:"min=3"`
+		Tags    []string      `validate:"max=1"`
+		Data    []byte        `validate:"max=1KiB"`
+		Email   string        `validate:"email"`
+	}
+
+	validate := New(WithValuePolicy(ValuePolicy{Mode: ValueTruncate, Limit: 4}))
+	errs := validate.Struct(Test{
+		Age:     12,
+		Ratio:   0.75,
+		Count:   2,
+		Timeout: time.Minute,
+		Name:    "jö",
+		Tags:    []string{"a", "b"},
+		Data:    make([]byte, 2048),
+		Email:   "joey",
+	})
+	NotEqual(t, errs, nil)
+	ve := errs.(ValidationErrors)
+	Equal(t, len(ve), 8)
+
+	var numeric []string
+	for e := range ve.Numeric() {
+		numeric = append(numeric, fmt.Sprintf("%s %s %v %v", e.Field(), e.Tag(), e.Bound, e.Actual))
+	}
+	Equal(t, numeric, []string{"Age gte 18 12", "Ratio lt 0.5 0.75", "Count eq 3 2"})
+
+	var lengths []string
+	for e := range ve.Lengths() {
+		lengths = append(lengths, fmt.Sprintf("%s %s %d %d", e.Field(), e.Tag(), e.Bound, e.Actual))
+	}
+	Equal(t, lengths, []string{"Name min 3 2", "Tags max 1 2", "Data max 1024 2048"})
+
+	// iteration stops early
+	for e := range ve.Lengths() {
+		Equal(t, e.Field(), "Name")
+		break
+	}
+}
+
+func TestStructPassed(t *testing.T) {
+	type Address struct {
+		City string `json:"city" validate:"required"`
+		Zip  string `json:"zip" validate:"omitempty,numeric"`
+	}
+
+	type Row struct {
+		Name     string    `json:"name" validate:"required"`
+		Email    string    `json:"email" validate:"required,email"`
+		Tags     []string  `json:"tags" validate:"dive,alpha"`
+		Address  Address   `json:"address"`
+		Billing  Address   `json:"billing"`
+		Checksum *Address  `json:"checksum"`
+		Skipped  string    `validate:"-"`
+		Seen     time.Time `json:"seen"`
+	}
+
+	validate := New(WithFieldNameTags("json"))
+	validate.RegisterStructIntegrity(func(ctx context.Context, value interface{}) error {
+		if value.(Address).Zip == "00000" {
+			return errors.New("bad zip")
+		}
+		return nil
+	}, false, Address{})
+
+	passed, err := validate.StructPassed(Row{
+		Name:     "joey",
+		Email:    "joey",
+		Tags:     []string{"a", "1"},
+		Address:  Address{City: "Berlin", Zip: "10115"},
+		Billing:  Address{City: "Berlin", Zip: "00000"},
+		Checksum: &Address{Zip: "1"},
+	})
+	NotEqual(t, err, nil)
+	Equal(t, passed, []string{"Row.name", "Row.address", "Row.address.city", "Row.address.zip", "Row.checksum.zip", "Row.seen"})
+
+	var ve ValidationErrors
+	Equal(t, errors.As(err, &ve), true)
+	Equal(t, len(ve), 3)
+
+	// the fields are collected using the audit of the context
+	ctx, audit := NewAuditContext(context.Background(), nil)
+	passed, err = validate.StructPassedCtx(ctx, Row{Name: "joey", Email: "joey@example.com", Address: Address{City: "Berlin"}, Billing: Address{City: "Berlin"}})
+	Equal(t, err, nil)
+	Equal(t, len(passed), 11)
+	Equal(t, len(audit.Fields), 11)
+
+	passed, err = validate.StructPassed("row")
+	NotEqual(t, err, nil)
+	Equal(t, len(passed), 0)
+}
+
+func TestHasRules(t *testing.T) {
+	type Plain struct {
+		Name string
+		When time.Time
+	}
+
+	type Inner struct {
+		Code string `validate:"required"`
+	}
+
+	type Nested struct {
+		Items []*Inner
+	}
+
+	type Recursive struct {
+		Next *Recursive
+	}
+
+	type Level struct {
+		Name string
+	}
+
+	validate := New()
+	validate.RegisterStructValidation(func(sl StructLevel) {}, Level{})
+
+	Equal(t, validate.HasRules(Plain{}), false)
+	Equal(t, validate.HasRules(&Inner{}), true)
+	Equal(t, validate.HasRules(Nested{}), true)
+	Equal(t, validate.HasRules(Recursive{}), false)
+	Equal(t, validate.HasRules(Level{}), true)
+	Equal(t, validate.HasRules("plain"), false)
+
+	validate = New()
+	validate.RegisterStructValidationMapRules(map[string]string{"Name": "required"}, Plain{})
+	Equal(t, validate.HasRules(Plain{}), true)
+}
+
+func TestProblemDetails(t *testing.T) {
+	type User struct {
+		Name string `json:"name" validate:"required"`
+		Age  int    `json:"age" validate:"gte=18"`
+	}
+
+	validate := New(WithFieldNameTags("json"))
+	err := validate.Struct(User{Age: 3})
+	NotEqual(t, err, nil)
+
+	problem := err.(ValidationErrors).ToProblemDetails("Invalid user", "https://example.com/probs/validation")
+	Equal(t, problem.Type, "https://example.com/probs/validation")
+	Equal(t, problem.Title, "Invalid user")
+	Equal(t, problem.Status, 422)
+	Equal(t, problem.Detail, "2 fields failed validation")
+	Equal(t, problem.Errors, []ProblemError{
+		{Namespace: "User.name", Tag: "required", Code: "VAL_REQUIRED", Message: "name is a required field"},
+		{Namespace: "User.age", Tag: "gte", Code: "VAL_GTE", Param: "18", Message: "age must be greater than or equal to 18"},
+	})
+
+	b, _ := json.Marshal(ValidationErrors{err.(ValidationErrors)[0]}.ToProblemDetails("Invalid user", ""))
+	Equal(t, string(b), `{"type":"about:blank","title":"Invalid user","status":422,"detail":"1 field failed validation","errors":[{"namespace":"User.name","tag":"required","code":"VAL_REQUIRED","message":"name is a required field"}]}`)
+}
+
+func TestMapKeyValidation(t *testing.T) {
+	validate := New()
+
+	labels := map[string]string{"label.app": "api", "label.tier": "web", "owner": "ops"}
+	errs := validate.Var(labels, "maxkeys_prefix=label.=2")
+	Equal(t, errs, nil)
+
+	errs = validate.Var(labels, "maxkeys_prefix=label.=1")
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "", "", "", "", "maxkeys_prefix")
+
+	errs = validate.Var(map[string]int{}, "maxkeys_prefix=label.=0")
+	Equal(t, errs, nil)
+
+	errs = validate.Var(labels, "key_pattern=^[a-z0-9.-]+$")
+	Equal(t, errs, nil)
+
+	errs = validate.Var(map[string]string{"label.App": "api"}, "key_pattern=^[a-z0-9.-]+$")
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "", "", "", "", "key_pattern")
+
+	type Pod struct {
+		Labels map[string]string `validate:"key_pattern=^[a-z0-9.-]+$,maxkeys_prefix=app.=1,dive,required"`
+	}
+
+	errs = validate.Struct(Pod{Labels: map[string]string{"app.name": "api", "app.tier": "web"}})
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "Pod.Labels", "Pod.Labels", "Labels", "Labels", "maxkeys_prefix")
+
+	PanicMatches(t, func() { _ = validate.Var(labels, "maxkeys_prefix=label.") }, "Bad param 'label.' for 'maxkeys_prefix'")
+	PanicMatches(t, func() { _ = validate.Var(labels, "key_pattern=[a-z") }, "Bad param '[a-z' for 'key_pattern'")
+	PanicMatches(t, func() { _ = validate.Var(map[int]string{}, "key_pattern=^[a-z]+$") }, "Bad field type map[int]string")
+}
+
+func TestValidationErrorsMarshalJSON(t *testing.T) {
+	type User struct {
+		Name string   `json:"name" validate:"required"`
+		Age  int      `json:"age" validate:"gte=18"`
+		Tags []string `json:"tags" validate:"max=1"`
+	}
+
+	validate := New(WithFieldNameTags("json"))
+	err := validate.Struct(User{Age: 3, Tags: []string{"a", "b"}})
+	NotEqual(t, err, nil)
+
+	b, e := json.Marshal(err)
+	Equal(t, e, nil)
+	Equal(t, string(b), `[{"namespace":"User.name","field":"name","tag":"required","code":"VAL_REQUIRED","value":""},{"namespace":"User.age","field":"age","tag":"gte","code":"VAL_GTE","param":"18","value":3},{"namespace":"User.tags","field":"tags","tag":"max","code":"VAL_MAX","param":"1","value":["a","b"]}]`)
+
+	b, e = json.Marshal(err.(ValidationErrors)[1])
+	Equal(t, e, nil)
+	Equal(t, string(b), `{"namespace":"User.age","field":"age","tag":"gte","code":"VAL_GTE","param":"18","value":3}`)
+
+	validate.RegisterValidation("never", func(fl FieldLevel) bool { return false })
+	b, e = json.Marshal(validate.Var(make(chan int), "never"))
+	Equal(t, e, nil)
+	Equal(t, strings.HasPrefix(string(b), `[{"namespace":"","field":"","tag":"never","code":"VAL_NEVER","value":"0x`), true)
+}
+
+func TestEncodingValidation(t *testing.T) {
+	validate := New()
+
+	tests := []struct {
+		value    interface{}
+		tag      string
+		expected bool
+	}{
+		{"héllo, 世界", "valid_utf8", true},
+		{"", "valid_utf8", true},
+		{"caf\xe9", "valid_utf8", false},
+		{[]byte("héllo"), "valid_utf8", true},
+		{[]byte{0xff, 0xfe}, "valid_utf8", false},
+		{"Hello, World!", "ascii_printable", true},
+		{"tab\there", "ascii_printable", false},
+		{"héllo", "ascii_printable", false},
+		{[]byte("~ok~"), "ascii_printable", true},
+		{[]byte{0x7f}, "ascii_printable", false},
+		{"Ça va, naïve façade", "latin1", true},
+		{"price: 5€", "latin1", false},
+		{"caf\xe9", "latin1", false},
+		{[]byte("Müller"), "latin1", true},
+	}
+
+	for i, test := range tests {
+		errs := validate.Var(test.value, test.tag)
+		if test.expected {
+			if !IsEqual(errs, nil) {
+				t.Fatalf("Index: %d %s failed Error: %s", i, test.tag, errs)
+			}
+		} else {
+			if IsEqual(errs, nil) {
+				t.Fatalf("Index: %d %s failed Error: %s", i, test.tag, errs)
+			} else {
+				val := getError(errs, "", "")
+				if val.Tag() != test.tag {
+					t.Fatalf("Index: %d %s failed Error: %s", i, test.tag, errs)
+				}
+			}
+		}
+	}
+
+	PanicMatches(t, func() { _ = validate.Var(1, "valid_utf8") }, "Bad field type int")
+	PanicMatches(t, func() { _ = validate.Var([]int{1}, "latin1") }, "Bad field type []int")
+}
+
+func TestErrorCode(t *testing.T) {
+	type User struct {
+		Name  string `validate:"required_if=Admin true"`
+		Age   int    `validate:"gte=18"`
+		Admin bool
+		Color string `validate:"iscolor"`
+		Nick  string `validate:"is-awesome"`
+	}
+
+	validate := New()
+	validate.RegisterValidation("is-awesome", func(fl FieldLevel) bool { return fl.Field().String() == "awesome" })
+
+	err := validate.Struct(User{Admin: true, Color: "nope", Nick: "joey"})
+	NotEqual(t, err, nil)
+	errs := err.(ValidationErrors)
+	Equal(t, len(errs), 4)
+	Equal(t, Details(errs[0]).Code(), "VAL_REQUIRED_IF")
+	Equal(t, Details(errs[1]).Code(), "VAL_GTE")
+	Equal(t, Details(errs[2]).Code(), "VAL_ISCOLOR")
+	Equal(t, Details(errs[3]).Code(), "VAL_IS_AWESOME")
+
+	validate.RegisterErrorCode("is-awesome", "USR_NOT_AWESOME")
+	validate.RegisterErrorCode("hexcolor|rgb|rgba|hsl|hsla", "VAL_COLOR")
+	validate.RegisterErrorCode("gte", "AGE_TOO_LOW")
+	errs = validate.Struct(User{Admin: true, Color: "nope", Nick: "joey"}).(ValidationErrors)
+	Equal(t, Details(errs[1]).Code(), "AGE_TOO_LOW")
+	Equal(t, Details(errs[2]).Code(), "VAL_COLOR")
+	Equal(t, Details(errs[3]).Code(), "USR_NOT_AWESOME")
+
+	validate.RegisterErrorCode("gte", "")
+	Equal(t, Details(errs[1]).Code(), "VAL_GTE")
+
+	PanicMatches(t, func() { validate.RegisterErrorCode("", "VAL_EMPTY") }, "error code tag cannot be empty")
+}
+
+func TestURLRelationshipValidation(t *testing.T) {
+	type Client struct {
+		SiteURL     string
+		RedirectURI string `validate:"same_origin=SiteURL"`
+		LogoURL     string `validate:"same_host=SiteURL"`
+		CallbackURL string `validate:"subpath_of=SiteURL"`
+	}
+
+	validate := New()
+
+	tests := []struct {
+		client   Client
+		expected []string
+	}{
+		{Client{"https://app.example.com/oauth", "https://app.example.com:443/cb", "http://APP.example.com:8080/logo.png", "https://app.example.com/oauth/cb?x=1"}, nil},
+		{Client{"https://app.example.com/oauth", "https://app.example.com/oauth", "https://app.example.com", "https://app.example.com/oauth"}, nil},
+		{Client{"https://app.example.com/oauth", "http://app.example.com/cb", "https://evil.com/logo.png", "https://app.example.com/oauthx"}, []string{"same_origin", "same_host", "subpath_of"}},
+		{Client{"https://app.example.com/oauth/", "https://app.example.com:8443/cb", "https://app.example.com.evil.com", "https://app.example.com/oauth/../admin"}, []string{"same_origin", "same_host", "subpath_of"}},
+		{Client{"https://app.example.com", "/cb", "not a url", "https://evil.com/"}, []string{"same_origin", "same_host", "subpath_of"}},
+		{Client{"", "https://app.example.com", "https://app.example.com", "https://app.example.com"}, []string{"same_origin", "same_host", "subpath_of"}},
+		{Client{"https://app.example.com", "https://app.example.com/a", "https://app.example.com/b", "https://app.example.com/c"}, nil},
+	}
+
+	for i, test := range tests {
+		errs := validate.Struct(test.client)
+		if len(test.expected) == 0 {
+			if !IsEqual(errs, nil) {
+				t.Fatalf("Index: %d failed Error: %s", i, errs)
+			}
+			continue
+		}
+
+		NotEqual(t, errs, nil)
+		ve := errs.(ValidationErrors)
+		Equal(t, len(ve), len(test.expected))
+		for j, tag := range test.expected {
+			Equal(t, ve[j].Tag(), tag)
+		}
+	}
+
+	type Bad struct {
+		Port  int `validate:"same_host=Other"`
+		Other string
+	}
+	PanicMatches(t, func() { _ = validate.Struct(Bad{}) }, "Bad field type int")
+}
+
+func TestStructWithWarnings(t *testing.T) {
+	type Profile struct {
+		Bio      string   `validate:"warn:max=10,required"`
+		Nick     string   `validate:"warn:required,max=5"`
+		Color    string   `validate:"warn:iscolor"`
+		Tags     []string `validate:"warn:max=1,dive,warn:lowercase"`
+		Website  *string  `validate:"warn:required,omitempty,url"`
+		Fallback string   `validate:"warn:email|url"`
+	}
+
+	validate := New()
+	p := Profile{Bio: "a very long biography", Nick: "joey", Color: "#000", Tags: []string{"a"}, Fallback: "joey@example.com"}
+	warns, err := validate.StructWithWarnings(p)
+	Equal(t, err, nil)
+	Equal(t, len(warns), 2)
+	AssertError(t, warns, "Profile.Bio", "Profile.Bio", "Bio", "Bio", "max")
+	AssertError(t, warns, "Profile.Website", "Profile.Website", "Website", "Website", "required")
+
+	p = Profile{Nick: "joseph", Color: "nope", Tags: []string{"A", "b"}, Fallback: "nope"}
+	warns, err = validate.StructWithWarnings(&p)
+	NotEqual(t, err, nil)
+	errs := err.(ValidationErrors)
+	Equal(t, len(errs), 2)
+	AssertError(t, errs, "Profile.Bio", "Profile.Bio", "Bio", "Bio", "required")
+	AssertError(t, errs, "Profile.Nick", "Profile.Nick", "Nick", "Nick", "max")
+	Equal(t, len(warns), 5)
+	AssertError(t, warns, "Profile.Color", "Profile.Color", "Color", "Color", "iscolor")
+	AssertError(t, warns, "Profile.Tags", "Profile.Tags", "Tags", "Tags", "max")
+	AssertError(t, warns, "Profile.Tags[0]", "Profile.Tags[0]", "Tags[0]", "Tags[0]", "lowercase")
+	AssertError(t, warns, "Profile.Website", "Profile.Website", "Website", "Website", "required")
+	AssertError(t, warns, "Profile.Fallback", "Profile.Fallback", "Fallback", "Fallback", "email|url")
+
+	// other validation calls ignore the warning tags
+	err = validate.Struct(p)
+	Equal(t, len(err.(ValidationErrors)), 2)
+
+	_, err = validate.StructWithWarnings(1)
+	NotEqual(t, err, nil)
+	Equal(t, err.Error(), "validator: (nil int)")
+
+	type Versioned struct {
+		Name string `validate:"warn:max=3;v2:required"`
+	}
+	warns, err = validate.StructWithWarnings(Versioned{Name: "joseph"})
+	Equal(t, err, nil)
+	Equal(t, len(warns), 1)
+
+	descs, err := validate.Describe(Profile{})
+	Equal(t, err, nil)
+	Equal(t, descs[0].Rules, []Rule{{Tag: "max", Param: "10", Warn: true}, {Tag: "required"}})
+
+	type Bad struct {
+		Items []string `validate:"warn:dive"`
+	}
+	PanicMatches(t, func() { _ = validate.Struct(Bad{}) }, "Invalid warning tag 'warn:dive' on field 'Items'")
+}
+
+func TestOAuthValidation(t *testing.T) {
+	validate := New()
+
+	tests := []struct {
+		value    string
+		tag      string
+		expected bool
+	}{
+		{"openid", "oauth_scopes", true},
+		{"openid profile https://api.example.com/read repo:status", "oauth_scopes", true},
+		{"", "oauth_scopes", false},
+		{"openid  profile", "oauth_scopes", false},
+		{" openid", "oauth_scopes", false},
+		{`openid "profile"`, "oauth_scopes", false},
+		{`back\slash`, "oauth_scopes", false},
+		{"openid email", "oauth_scopes=openid profile email", true},
+		{"openid admin", "oauth_scopes=openid profile email", false},
+		{"mF_9.B5f-4.1JqM", "bearer_token", true},
+		{"Bearer mF_9.B5f-4.1JqM", "bearer_token", true},
+		{"bearer dGVzdA==", "bearer_token", true},
+		{"Bearer", "bearer_token", true},
+		{"Bearer ", "bearer_token", false},
+		{"Basic dGVzdA==", "bearer_token", false},
+		{"to=ken", "bearer_token", false},
+		{"", "bearer_token", false},
+	}
+
+	for i, test := range tests {
+		errs := validate.Var(test.value, test.tag)
+		if test.expected {
+			if !IsEqual(errs, nil) {
+				t.Fatalf("Index: %d %s failed Error: %s", i, test.tag, errs)
+			}
+		} else {
+			if IsEqual(errs, nil) {
+				t.Fatalf("Index: %d %s failed Error: %s", i, test.tag, errs)
+			} else {
+				val := getError(errs, "", "")
+				if tag, _, _ := strings.Cut(test.tag, "="); val.Tag() != tag {
+					t.Fatalf("Index: %d %s failed Error: %s", i, test.tag, errs)
+				}
+			}
+		}
+	}
+}
+
+func TestMaxErrors(t *testing.T) {
+	type Item struct {
+		Name string `validate:"required"`
+	}
+
+	type Batch struct {
+		IDs   []string        `validate:"dive,required"`
+		Items map[string]Item `validate:"dive"`
+		Owner string          `validate:"required"`
+	}
+
+	validate := New(WithMaxErrors(3))
+	err := validate.Struct(Batch{IDs: make([]string, 100000), Items: map[string]Item{"a": {}}})
+	NotEqual(t, err, nil)
+	var truncated *TruncatedValidationError
+	Equal(t, errors.As(err, &truncated), true)
+	Equal(t, truncated.Max, 3)
+	Equal(t, len(truncated.Errors), 3)
+	AssertError(t, truncated.Errors, "Batch.IDs[2]", "Batch.IDs[2]", "IDs[2]", "IDs[2]", "required")
+	var errs ValidationErrors
+	Equal(t, errors.As(err, &errs), true)
+	Equal(t, errs, truncated.Errors)
+	Equal(t, strings.HasSuffix(err.Error(), "failed on the 'required' tag\nvalidator: validation stopped after 3 errors"), true)
+
+	// the errors below the cap are returned as is
+	err = validate.Struct(Batch{IDs: []string{"1", ""}, Owner: "joey"})
+	NotEqual(t, err, nil)
+	errs = err.(ValidationErrors)
+	Equal(t, len(errs), 1)
+	Equal(t, errors.As(err, &truncated), false)
+
+	err = validate.Var(make([]string, 10), "dive,required")
+	NotEqual(t, err, nil)
+	Equal(t, errors.As(err, &truncated), true)
+	Equal(t, len(truncated.Errors), 3)
+
+	// the cap counts the errors kept by dive_maxerrs
+	err = validate.Var(make([]string, 10), "dive_maxerrs=2,required")
+	NotEqual(t, err, nil)
+	errs = err.(ValidationErrors)
+	Equal(t, len(errs), 3)
+	Equal(t, errs[2].Tag(), "dive_maxerrs")
+
+	// exactly reaching the cap doesn't truncate
+	err = validate.Var(make([]string, 3), "dive,required")
+	NotEqual(t, err, nil)
+	Equal(t, len(err.(ValidationErrors)), 3)
+
+	PanicMatches(t, func() { New(WithMaxErrors(0)) }, "max errors must be positive, got 0")
+}
+
+func TestCompile(t *testing.T) {
+	type Address struct {
+		City string `validate:"required"`
+	}
+
+	type User struct {
+		Name      string     `validate:"required"`
+		Age       int        `validate:"gte=18"`
+		Addresses []*Address `validate:"dive"`
+	}
+
+	users, err := Compile[User]()
+	Equal(t, err, nil)
+
+	err = users.Validate(context.Background(), &User{Name: "joey", Age: 30, Addresses: []*Address{{City: "Oslo"}}})
+	Equal(t, err, nil)
+
+	u := User{Age: 3, Addresses: []*Address{{}}}
+	err = users.Validate(context.Background(), &u)
+	NotEqual(t, err, nil)
+	errs := err.(ValidationErrors)
+	Equal(t, len(errs), 3)
+	AssertError(t, errs, "User.Name", "User.Name", "Name", "Name", "required")
+	AssertError(t, errs, "User.Age", "User.Age", "Age", "Age", "gte")
+	AssertError(t, errs, "User.Addresses[0].City", "User.Addresses[0].City", "City", "City", "required")
+	Equal(t, err.Error(), users.Validator().Struct(u).Error())
+
+	type BadAddress struct {
+		City string `validate:"requird"`
+	}
+
+	type BadUser struct {
+		Name    string `validate:"required"`
+		Address BadAddress
+	}
+
+	_, err = Compile[BadUser]()
+	NotEqual(t, err, nil)
+	Equal(t, err.Error(), "validator: compiling validator.BadUser: Undefined validation function 'requird' on field 'City'")
+
+	_, err = Compile[string]()
+	NotEqual(t, err, nil)
+	Equal(t, err.Error(), "validator: (nil string)")
+
+	_, err = Compile[time.Time]()
+	NotEqual(t, err, nil)
+
+	validate := New()
+	validate.RegisterValidation("is-awesome", func(fl FieldLevel) bool { return fl.Field().String() == "awesome" })
+	type Custom struct {
+		Nick string `validate:"is-awesome"`
+	}
+
+	custom, err := CompileWith[Custom](validate)
+	Equal(t, err, nil)
+	Equal(t, custom.Validate(context.Background(), &Custom{Nick: "awesome"}), nil)
+	NotEqual(t, custom.Validate(context.Background(), &Custom{Nick: "joey"}), nil)
+
+	PanicMatches(t, func() { MustCompile[Custom]() }, "validator: compiling validator.Custom: Undefined validation function 'is-awesome' on field 'Nick'")
+
+	err = users.Validate(context.Background(), nil)
+	NotEqual(t, err, nil)
+	Equal(t, err.Error(), "validator: (nil *validator.User)")
+}
+
+func TestTypedAllocs(t *testing.T) {
+	type Address struct {
+		City string `validate:"required"`
+	}
+
+	type User struct {
+		Name    string `validate:"required,min=3"`
+		Age     int    `validate:"gte=18"`
+		Address Address
+	}
+
+	u := User{Name: "joey", Age: 30, Address: Address{City: "Oslo"}}
+	users := MustCompile[User]()
+	ctx := context.Background()
+	structAllocs := testing.AllocsPerRun(100, func() { _ = users.Validator().StructCtx(ctx, &u) })
+	typedAllocs := testing.AllocsPerRun(100, func() { _ = users.Validate(ctx, &u) })
+	Equal(t, typedAllocs <= structAllocs, true)
+	Equal(t, typedAllocs, float64(0))
+}
+
+func TestDecodeJSONArray(t *testing.T) {
+	type User struct {
+		Name string `json:"name" validate:"required"`
+		Age  int    `json:"age" validate:"gte=18"`
+	}
+
+	validate := New()
+	var names []string
+	var invalid []int
+	err := DecodeJSONArray(context.Background(), validate, strings.NewReader(`[{"name":"joey","age":20},{"age":12},{"name":"zoey","age":30}]`), func(i int, u *User, err error) error {
+		if err != nil {
+			invalid = append(invalid, i)
+			return nil
+		}
+		names = append(names, u.Name)
+		return nil
+	})
+	Equal(t, err, nil)
+	Equal(t, names, []string{"joey", "zoey"})
+	Equal(t, invalid, []int{1})
+
+	// early rejection, the elements after the first invalid one aren't decoded
+	var decoded int
+	err = DecodeJSONArray(context.Background(), validate, strings.NewReader(`[{"name":"joey","age":20},{"age":12},{"name":`), func(i int, u *User, err error) error {
+		decoded++
+		return err
+	})
+	NotEqual(t, err, nil)
+	Equal(t, decoded, 2)
+	AssertError(t, err.(ValidationErrors), "User.Name", "User.Name", "Name", "Name", "required")
+	AssertError(t, err.(ValidationErrors), "User.Age", "User.Age", "Age", "Age", "gte")
+
+	err = DecodeJSONArray(context.Background(), validate, strings.NewReader(`[{"name":"joey","age":"20"}]`), func(i int, u *User, err error) error {
+		return err
+	})
+	NotEqual(t, err, nil)
+	Equal(t, strings.HasPrefix(err.Error(), "validator: decoding JSON array element 0: "), true)
+
+	err = DecodeJSONArray(context.Background(), validate, strings.NewReader(`{"name":"joey"}`), func(i int, u *User, err error) error {
+		return err
+	})
+	Equal(t, err.Error(), "validator: expected JSON array, got {")
+
+	err = DecodeJSONArray(context.Background(), validate, strings.NewReader(`[1]`), func(i int, n *int, err error) error {
+		return err
+	})
+	Equal(t, err.Error(), "validator: (nil *int)")
+}
+
+func TestDurationType(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       interface{} // struct
+		success bool
+	}{
+		{
+			name: "valid duration string pass",
+			s: struct {
+				Value time.Duration `validate:"gte=500ns"`
+			}{
+				Value: time.Second,
+			},
+			success: true,
+		},
+		{
+			name: "valid duration int pass",
+			s: struct {
+				Value time.Duration `validate:"gte=500"`
+			}{
+				Value: time.Second,
+			},
+			success: true,
+		},
+	}
+
+	validate := New()
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			errs := validate.Struct(tc.s)
+			if tc.success {
+				Equal(t, errs, nil)
+				return
+			}
+
+			NotEqual(t, errs, nil)
+		})
+	}
+}
+
+func TestMultiOrOperatorGroup(t *testing.T) {
+	tests := []struct {
+		Value    int `validate:"eq=1|gte=5,eq=1|lt=7"`
+		expected bool
+	}{
+		{1, true}, {2, false}, {5, true}, {6, true}, {8, false},
+	}
+
+	validate := New()
+	for i, test := range tests {
+		errs := validate.Struct(test)
+		if test.expected {
+			if !IsEqual(errs, nil) {
+				t.Fatalf("Index: %d multi_group_of_OR_operators failed Error: %s", i, errs)
+			}
+		} else {
+			if IsEqual(errs, nil) {
+				t.Fatalf("Index: %d multi_group_of_OR_operators should have errs", i)
+			}
+		}
+	}
+}
+
+func TestCronExpressionValidation(t *testing.T) {
+	tests := []struct {
+		value    string `validate:"cron"`
+		tag      string
+		expected bool
+	}{
+		{"0 0 12 * * ?", "cron", true},
+		{"0 15 10 ? * *", "cron", true},
+		{"0 15 10 * * ?", "cron", true},
+		{"0 15 10 * * ? 2005", "cron", true},
+		{"0 15 10 ? * 6L", "cron", true},
+		{"0 15 10 ? * 6L 2002-2005", "cron", true},
+		{"*/20 * * * *", "cron", true},
+		{"0 15 10 ? * MON-FRI", "cron", true},
+		{"0 15 10 ? * 6#3", "cron", true},
+		{"0 */15 * * *", "cron", true},
+		{"wrong", "cron", false},
+	}
+
+	validate := New()
+	for i, test := range tests {
+		errs := validate.Var(test.value, test.tag)
+		if test.expected {
+			if !IsEqual(errs, nil) {
+				t.Fatalf(`Index: %d cron "%s" failed Error: %s`, i, test.value, errs)
+			}
+		} else {
+			if IsEqual(errs, nil) {
+				t.Fatalf(`Index: %d cron "%s" should have errs`, i, test.value)
+			}
+		}
+	}
+}
+
+func TestNestedStructValidation(t *testing.T) {
+	validator := New(WithRequiredStructEnabled())
+	t.Run("required", func(t *testing.T) {
+		type (
+			value struct {
+				Field string
+			}
+			topLevel struct {
+				Nested value `validate:"required"`
+			}
+		)
+
+		var validationErrs ValidationErrors
+		if errs := validator.Struct(topLevel{}); errs != nil {
+			validationErrs = errs.(ValidationErrors)
+		}
+
+		Equal(t, 1, len(validationErrs))
+		AssertError(t, validationErrs, "topLevel.Nested", "topLevel.Nested", "Nested", "Nested", "required")
+
+		Equal(t, validator.Struct(topLevel{value{"potato"}}), nil)
+	})
+
+	t.Run("omitempty", func(t *testing.T) {
+		type (
+			value struct {
+				Field string
+			}
+			topLevel struct {
+				Nested value `validate:"omitempty,required"`
+			}
+		)
+
+		errs := validator.Struct(topLevel{})
+		Equal(t, errs, nil)
+	})
+
+	t.Run("excluded_if", func(t *testing.T) {
+		type (
+			value struct {
+				Field string
+			}
+			topLevel struct {
+				Field  string
+				Nested value `validate:"excluded_if=Field potato"`
+			}
+		)
+
+		errs := validator.Struct(topLevel{Field: "test", Nested: value{"potato"}})
+		Equal(t, errs, nil)
+
+		errs = validator.Struct(topLevel{Field: "potato"})
+		Equal(t, errs, nil)
+
+		errs = validator.Struct(topLevel{Field: "potato", Nested: value{"potato"}})
+		AssertError(t, errs, "topLevel.Nested", "topLevel.Nested", "Nested", "Nested", "excluded_if")
+	})
+
+	t.Run("excluded_unless", func(t *testing.T) {
+		type (
+			value struct {
+				Field string
+			}
+			topLevel struct {
+				Field  string
+				Nested value `validate:"excluded_unless=Field potato"`
+			}
+		)
+
+		errs := validator.Struct(topLevel{Field: "test"})
+		Equal(t, errs, nil)
+
+		errs = validator.Struct(topLevel{Field: "potato", Nested: value{"potato"}})
+		Equal(t, errs, nil)
+
+		errs = validator.Struct(topLevel{Field: "test", Nested: value{"potato"}})
+		AssertError(t, errs, "topLevel.Nested", "topLevel.Nested", "Nested", "Nested", "excluded_unless")
+	})
+
+	t.Run("nonComparableField", func(t *testing.T) {
+		type (
+			value struct {
+				Field []string
+			}
+			topLevel struct {
+				Nested value `validate:"required"`
+			}
+		)
+
+		errs := validator.Struct(topLevel{value{[]string{}}})
+		Equal(t, errs, nil)
+	})
+
+	type (
+		veggyBasket struct {
+			Root   string
+			Squash string `validate:"required"`
+		}
+		testErr struct {
+			path string
+			tag  string
+		}
+		test struct {
+			name  string
+			err   testErr
+			value veggyBasket
+		}
+	)
+
+	if err := validator.RegisterValidation("veggy", func(f FieldLevel) bool {
+		v, ok := f.Field().Interface().(veggyBasket)
+		if !ok || v.Root != "potato" {
+			return false
+		}
+		return true
+	}); err != nil {
+		t.Fatal(fmt.Errorf("failed to register potato tag: %w", err))
+	}
+
+	tests := []test{
+		{
+			name:  "valid",
+			value: veggyBasket{"potato", "zucchini"},
+		}, {
+			name:  "failedCustomTag",
+			value: veggyBasket{"zucchini", "potato"},
+			err:   testErr{"topLevel.VeggyBasket", "veggy"},
+		}, {
+			name:  "failedInnerField",
+			value: veggyBasket{"potato", ""},
+			err:   testErr{"topLevel.VeggyBasket.Squash", "required"},
+		}, {
+			name:  "customTagFailurePriorityCheck",
+			value: veggyBasket{"zucchini", ""},
+			err:   testErr{"topLevel.VeggyBasket", "veggy"},
+		},
+	}
+
+	evaluateTest := func(tt test, errs error) {
+		if tt.err != (testErr{}) && errs != nil {
+			Equal(t, len(errs.(ValidationErrors)), 1)
+
+			segments := strings.Split(tt.err.path, ".")
+			fieldName := segments[len(segments)-1]
+			AssertError(t, errs, tt.err.path, tt.err.path, fieldName, fieldName, tt.err.tag)
+		}
+
+		shouldFail := tt.err != (testErr{})
+		hasFailed := errs != nil
+		if shouldFail != hasFailed {
+			t.Fatalf("expected failure %v, got: %v with errs: %v", shouldFail, hasFailed, errs)
+		}
+	}
+
+	for _, tt := range tests {
+		type topLevel struct {
+			VeggyBasket veggyBasket `validate:"veggy"`
+		}
+
+		t.Run(tt.name, func(t *testing.T) {
+			evaluateTest(tt, validator.Struct(topLevel{tt.value}))
+		})
+	}
+
+	// Also test on struct pointers
+	for _, tt := range tests {
+		type topLevel struct {
+			VeggyBasket *veggyBasket `validate:"veggy"`
+		}
+
+		t.Run(tt.name+"Ptr", func(t *testing.T) {
+			evaluateTest(tt, validator.Struct(topLevel{&tt.value}))
+		})
+	}
+}
+
+func TestTimeRequired(t *testing.T) {
+	validate := New()
+	validate.RegisterTagNameFunc(func(fld reflect.StructField) string {
+		if name := strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]; name != "-" {
+			return name
+		}
+
+		return ""
+	})
+
+	type TestTime struct {
+		Time time.Time `validate:"required"`
+	}
+
+	var testTime TestTime
+	err := validate.Struct(&testTime)
+	NotEqual(t, err, nil)
+	AssertError(t, err.(ValidationErrors), "TestTime.Time", "TestTime.Time", "Time", "Time", "required")
+}
+
+func TestOmitNilAndRequired(t *testing.T) {
+	type (
+		OmitEmpty struct {
+			Str    string  `validate:"omitempty,required,min=10"`
+			StrPtr *string `validate:"omitempty,required,min=10"`
+			Inner  *OmitEmpty
+		}
+		OmitNil struct {
+			Str    string  `validate:"omitnil,required,min=10"`
+			StrPtr *string `validate:"omitnil,required,min=10"`
+			Inner  *OmitNil
+		}
+	)
+
+	var (
+		validate = New(WithRequiredStructEnabled())
+		valid    = "this is the long string to pass the validation rule"
+	)
+
+	t.Run("compare using valid data", func(t *testing.T) {
+		err1 := validate.Struct(OmitEmpty{Str: valid, StrPtr: &valid, Inner: &OmitEmpty{Str: valid, StrPtr: &valid}})
+		err2 := validate.Struct(OmitNil{Str: valid, StrPtr: &valid, Inner: &OmitNil{Str: valid, StrPtr: &valid}})
+
+		Equal(t, err1, nil)
+		Equal(t, err2, nil)
+	})
+
+	t.Run("compare fully empty omitempty and omitnil", func(t *testing.T) {
+		err1 := validate.Struct(OmitEmpty{})
+		err2 := validate.Struct(OmitNil{})
+
+		Equal(t, err1, nil)
+		AssertError(t, err2, "OmitNil.Str", "OmitNil.Str", "Str", "Str", "required")
+	})
+
+	t.Run("validate in deep", func(t *testing.T) {
+		err1 := validate.Struct(OmitEmpty{Str: valid, Inner: &OmitEmpty{}})
+		err2 := validate.Struct(OmitNil{Str: valid, Inner: &OmitNil{}})
+
+		Equal(t, err1, nil)
+		AssertError(t, err2, "OmitNil.Inner.Str", "OmitNil.Inner.Str", "Str", "Str", "required")
+	})
+}
+
+func TestOmitZero(t *testing.T) {
+	type (
+		OmitEmpty struct {
+			Str    string  `validate:"omitempty,min=10"`
+			StrPtr *string `validate:"omitempty,min=10"`
+		}
+		OmitZero struct {
+			Str    string  `validate:"omitzero,min=10"`
+			StrPtr *string `validate:"omitzero,min=10"`
+		}
+	)
+
+	var (
+		validate = New()
+		valid    = "this is the long string to pass the validation rule"
+		empty    = ""
+	)
+
+	t.Run("compare using valid data", func(t *testing.T) {
+		err1 := validate.Struct(OmitEmpty{Str: valid, StrPtr: &valid})
+		err2 := validate.Struct(OmitZero{Str: valid, StrPtr: &valid})
+
+		Equal(t, err1, nil)
+		Equal(t, err2, nil)
+	})
+
+	t.Run("compare fully empty omitempty and omitzero", func(t *testing.T) {
+		err1 := validate.Struct(OmitEmpty{})
+		err2 := validate.Struct(OmitZero{})
+
+		Equal(t, err1, nil)
+		Equal(t, err2, nil)
+	})
+
+	t.Run("compare with zero value", func(t *testing.T) {
+		err1 := validate.Struct(OmitEmpty{Str: "", StrPtr: nil})
+		err2 := validate.Struct(OmitZero{Str: "", StrPtr: nil})
+
+		Equal(t, err1, nil)
+		Equal(t, err2, nil)
+	})
+
+	t.Run("compare with empty value", func(t *testing.T) {
+		err1 := validate.Struct(OmitEmpty{Str: empty, StrPtr: &empty})
+		err2 := validate.Struct(OmitZero{Str: empty, StrPtr: &empty})
+
+		AssertError(t, err1, "OmitEmpty.StrPtr", "OmitEmpty.StrPtr", "StrPtr", "StrPtr", "min")
+		Equal(t, err2, nil)
+	})
+}
+
+func TestAlphaUnicodeValidation(t *testing.T) {
+	tests := []struct {
+		param    string
+		expected bool
+	}{
+		{"", false},
+		{"abc", true},
+		{"this is a test string", false},
+		{"这是一个测试字符串", true},
+		{"123", false},
+		{"<>@;.-=", false},
+		{"ひらがな・カタカナ、．漢字", false},
+		{"あいうえおfoobar", true},
+		{"test＠example.com", false},
+		{"1234abcDE", false},
+		{"ｶﾀｶﾅ", true},
+	}
+
+	validate := New()
+	for i, test := range tests {
+		errs := validate.Var(test.param, "alphaunicode")
+		if test.expected {
+			if !IsEqual(errs, nil) {
+				t.Fatalf("Index: %d Alpha Unicode failed Error: %s", i, errs)
+			}
+		} else {
+			if IsEqual(errs, nil) {
+				t.Fatalf("Index: %d Alpha Unicode failed Error: %s", i, errs)
+			} else {
+				val := getError(errs, "", "")
+				if val.Tag() != "alphaunicode" {
+					t.Fatalf("Index: %d Alpha Unicode failed Error: %s", i, errs)
+				}
+			}
+		}
+	}
+}
+
+func TestAlphanumericUnicodeValidation(t *testing.T) {
+	tests := []struct {
+		param    string
+		expected bool
+	}{
+		{"", false},
+		{"abc", true},
+		{"this is a test string", false},
+		{"这是一个测试字符串", true},
+		{"\u0031\u0032\u0033", true}, // unicode 5
+		{"123", true},
+		{"<>@;.-=", false},
+		{"ひらがな・カタカナ、．漢字", false},
+		{"あいうえおfoobar", true},
+		{"test＠example.com", false},
+		{"1234abcDE", true},
+		{"ｶﾀｶﾅ", true},
+	}
+
+	validate := New()
+	for i, test := range tests {
+		errs := validate.Var(test.param, "alphanumunicode")
+		if test.expected {
+			if !IsEqual(errs, nil) {
+				t.Fatalf("Index: %d Alphanum Unicode failed Error: %s", i, errs)
+			}
+		} else {
+			if IsEqual(errs, nil) {
+				t.Fatalf("Index: %d Alphanum Unicode failed Error: %s", i, errs)
+			} else {
+				val := getError(errs, "", "")
+				if val.Tag() != "alphanumunicode" {
+					t.Fatalf("Index: %d Alphanum Unicode failed Error: %s", i, errs)
+				}
+			}
+		}
+	}
+}
+
+func TestHostnameRFC952Validation(t *testing.T) {
+	tests := []struct {
+		param    string
+		expected bool
+	}{
+		{"test.example.com", true},
+		{"example.com", true},
+		{"example24.com", true},
+		{"test.example24.com", true},
+		{"test24.example24.com", true},
+		{"example", true},
+		{"EXAMPLE", true},
+		{"1.foo.com", false},
 		{"test.example.com.", false},
 		{"example.com.", false},
 		{"example24.com.", false},
@@ -9811,55 +12401,237 @@ func TestStructLevelReturnValidationErrors(t *testing.T) {
 	AssertError(t, errs, "TestStructReturnValidationErrors.Inner1.TestStructReturnValidationErrorsInner2.String", "TestStructReturnValidationErrors.Inner1.TestStructReturnValidationErrorsInner2.String", "String", "String", "required")
 }
 
-func TestStructLevelReturnValidationErrorsWithJSON(t *testing.T) {
-	validate := New()
-	validate.RegisterTagNameFunc(func(fld reflect.StructField) string {
-		name := strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
+func TestStructLevelReturnValidationErrorsWithJSON(t *testing.T) {
+	validate := New()
+	validate.RegisterTagNameFunc(func(fld reflect.StructField) string {
+		name := strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
+
+		if name == "-" {
+			return ""
+		}
+
+		return name
+	})
+	validate.RegisterStructValidation(StructValidationTestStructReturnValidationErrors2, TestStructReturnValidationErrors{})
+	inner2 := &TestStructReturnValidationErrorsInner2{
+		String: "I'm HERE",
+	}
+	inner1 := &TestStructReturnValidationErrorsInner1{
+		Inner2: inner2,
+	}
+	val := &TestStructReturnValidationErrors{
+		Inner1: inner1,
+	}
+	errs := validate.Struct(val)
+	Equal(t, errs, nil)
+
+	inner2.String = ""
+	errs = validate.Struct(val)
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 2)
+	AssertError(t, errs, "TestStructReturnValidationErrors.Inner1JSON.Inner2.JSONString", "TestStructReturnValidationErrors.Inner1.Inner2.String", "JSONString", "String", "required")
+	// this is an extra error reported from struct validation, it's a badly formatted one, but on purpose
+	AssertError(t, errs, "TestStructReturnValidationErrors.Inner1JSON.TestStructReturnValidationErrorsInner2.JSONString", "TestStructReturnValidationErrors.Inner1.TestStructReturnValidationErrorsInner2.String", "JSONString", "String", "required")
+
+	fe := getError(errs, "TestStructReturnValidationErrors.Inner1JSON.Inner2.JSONString", "TestStructReturnValidationErrors.Inner1.Inner2.String")
+	NotEqual(t, fe, nil)
+
+	// check for proper JSON namespace
+	Equal(t, fe.Field(), "JSONString")
+	Equal(t, fe.StructField(), "String")
+	Equal(t, fe.Namespace(), "TestStructReturnValidationErrors.Inner1JSON.Inner2.JSONString")
+	Equal(t, fe.StructNamespace(), "TestStructReturnValidationErrors.Inner1.Inner2.String")
+
+	fe = getError(errs, "TestStructReturnValidationErrors.Inner1JSON.TestStructReturnValidationErrorsInner2.JSONString", "TestStructReturnValidationErrors.Inner1.TestStructReturnValidationErrorsInner2.String")
+	NotEqual(t, fe, nil)
+
+	// check for proper JSON namespace
+	Equal(t, fe.Field(), "JSONString")
+	Equal(t, fe.StructField(), "String")
+	Equal(t, fe.Namespace(), "TestStructReturnValidationErrors.Inner1JSON.TestStructReturnValidationErrorsInner2.JSONString")
+	Equal(t, fe.StructNamespace(), "TestStructReturnValidationErrors.Inner1.TestStructReturnValidationErrorsInner2.String")
+}
+
+func TestStructLevelReportNamespaceError(t *testing.T) {
+	type Item struct {
+		Price int `json:"price" validate:"gte=0"`
+	}
+
+	type Order struct {
+		Limit int     `json:"limit"`
+		Items []*Item `json:"items" validate:"dive"`
+	}
+
+	validate := New()
+	validate.RegisterTagNameFunc(func(fld reflect.StructField) string {
+		return strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
+	})
+	validate.RegisterStructValidation(func(sl StructLevel) {
+		order := sl.Current().Interface().(Order)
+		for i, item := range order.Items {
+			if item.Price > order.Limit {
+				sl.(StructLevelDetails).ReportNamespaceError(fmt.Sprintf("Items[%d].Price", i), "ltefield", "Limit", item.Price)
+			}
+		}
+	}, Order{})
+
+	errs := validate.Struct(Order{Limit: 10, Items: []*Item{{Price: 5}, {Price: -1}, {Price: 15}}})
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 2)
+	AssertError(t, errs, "Order.items[1].price", "Order.Items[1].Price", "price", "Price", "gte")
+	AssertError(t, errs, "Order.items[2].price", "Order.Items[2].Price", "price", "Price", "ltefield")
+
+	fe := getError(errs, "Order.items[2].price", "Order.Items[2].Price")
+	Equal(t, fe.Param(), "Limit")
+	Equal(t, fe.Value(), 15)
+	Equal(t, fe.Kind(), reflect.Int)
+
+	validate = New()
+	validate.RegisterStructValidation(func(sl StructLevel) {
+		sl.(StructLevelDetails).ReportNamespaceError("Items[0].Price", "required", "", nil)
+	}, Order{})
+
+	errs = validate.Struct(Order{})
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "Order.Items[0].Price", "Order.Items[0].Price", "Price", "Price", "required")
+}
+
+func TestStructLevelRules(t *testing.T) {
+	type Palette struct {
+		Color   string            `validate:"omitempty,oneof=red green,iscolor,alpha|numeric"`
+		Colors  []string          `validate:"dive,oneof=red green"`
+		Labels  map[string]string `validate:"dive,keys,alpha,endkeys,required"`
+		Comment string
+	}
+
+	var rules map[string][]Rule
+	validate := New()
+	validate.RegisterStructValidation(func(sl StructLevel) {
+		rules = map[string][]Rule{}
+		for _, name := range []string{"Color", "Colors", "Labels", "Comment", "Missing"} {
+			rules[name] = sl.(StructLevelDetails).Rules(name)
+		}
+	}, Palette{})
+
+	Equal(t, validate.Struct(Palette{}), nil)
+	Equal(t, rules["Color"], []Rule{
+		{Tag: "omitempty"},
+		{Tag: "oneof", Param: "red green"},
+		{Tag: "hexcolor", Alias: "iscolor", Or: true},
+		{Tag: "rgb", Alias: "iscolor", Or: true},
+		{Tag: "rgba", Alias: "iscolor", Or: true},
+		{Tag: "hsl", Alias: "iscolor", Or: true},
+		{Tag: "hsla", Alias: "iscolor"},
+		{Tag: "alpha", Or: true},
+		{Tag: "numeric"},
+	})
+	Equal(t, rules["Colors"], []Rule{{Tag: "dive"}, {Tag: "oneof", Param: "red green"}})
+	Equal(t, rules["Labels"], []Rule{{Tag: "dive"}, {Tag: "keys"}, {Tag: "alpha"}, {Tag: "endkeys"}, {Tag: "required"}})
+	Equal(t, rules["Comment"] == nil, true)
+	Equal(t, rules["Missing"] == nil, true)
+}
+
+func TestStructIntegrity(t *testing.T) {
+	type Body struct {
+		Amount int `validate:"gt=0"`
+	}
+
+	type Envelope struct {
+		Body      Body
+		Signature string `validate:"required"`
+	}
+
+	type Payload struct {
+		Envelope Envelope
+	}
+
+	errBadSignature := errors.New("bad signature")
+	verify := func(ctx context.Context, value interface{}) error {
+		if value.(Envelope).Signature != "ok" {
+			return errBadSignature
+		}
+		return nil
+	}
+
+	validate := New()
+	validate.RegisterStructIntegrity(verify, false, &Envelope{})
+
+	errs := validate.Struct(Payload{Envelope: Envelope{Body: Body{Amount: 1}, Signature: "ok"}})
+	Equal(t, errs, nil)
+
+	errs = validate.Struct(Payload{Envelope: Envelope{Signature: "bad"}})
+	NotEqual(t, errs, nil)
+
+	var ie *IntegrityError
+	Equal(t, errors.As(errs, &ie), true)
+	Equal(t, ie.Namespace, "Payload.Envelope")
+	Equal(t, ie.Type == reflect.TypeOf(Envelope{}), true)
+	Equal(t, errors.Is(errs, errBadSignature), true)
+	Equal(t, ie.Error(), "validator: integrity check failed for 'Payload.Envelope': bad signature")
+
+	var ve ValidationErrors
+	Equal(t, errors.As(errs, &ve), true)
+	Equal(t, len(ve), 1)
+	AssertError(t, ve, "Payload.Envelope.Body.Amount", "Payload.Envelope.Body.Amount", "Amount", "Amount", "gt")
+
+	// field validation skipped when the integrity check fails
+	validate = New()
+	validate.RegisterStructIntegrity(verify, true, Envelope{})
+
+	errs = validate.Struct(Envelope{})
+	NotEqual(t, errs, nil)
+	Equal(t, errors.As(errs, &ie), true)
+	Equal(t, ie.Namespace, "Envelope")
+	Equal(t, errors.As(errs, &ve), false)
+
+	errs = validate.Struct(Envelope{Signature: "ok"})
+	NotEqual(t, errs, nil)
+	Equal(t, errors.As(errs, &ie), false)
+	_, ok := errs.(ValidationErrors)
+	Equal(t, ok, true)
+}
+
+func TestPipeline(t *testing.T) {
+	type Account struct {
+		Email       string `validate:"required,email"`
+		First, Last string
+		DisplayName string
+	}
 
-		if name == "-" {
-			return ""
-		}
+	var order []string
+	validate := New()
+	validate.RegisterStructValidation(func(sl StructLevel) {
+		order = append(order, "struct")
+	}, Account{})
+	validate.Pipeline(&Account{}).
+		Pre(func(ctx context.Context, sl StructLevel) {
+			order = append(order, "pre")
+			if email := sl.Current().FieldByName("Email"); email.CanSet() {
+				email.SetString(strings.ToLower(strings.TrimSpace(email.String())))
+			}
+		}).
+		Rules(map[string]string{"First": "required"}).
+		Post(func(ctx context.Context, sl StructLevel) {
+			order = append(order, "post")
+			account := sl.Current().Interface().(Account)
+			if account.DisplayName != account.First+" "+account.Last {
+				sl.ReportError(account.DisplayName, "DisplayName", "DisplayName", "displayname", "")
+			}
+		})
 
-		return name
-	})
-	validate.RegisterStructValidation(StructValidationTestStructReturnValidationErrors2, TestStructReturnValidationErrors{})
-	inner2 := &TestStructReturnValidationErrorsInner2{
-		String: "I'm HERE",
-	}
-	inner1 := &TestStructReturnValidationErrorsInner1{
-		Inner2: inner2,
-	}
-	val := &TestStructReturnValidationErrors{
-		Inner1: inner1,
-	}
-	errs := validate.Struct(val)
+	account := &Account{Email: "  Joey@Bloggs.COM ", First: "Joey", Last: "Bloggs", DisplayName: "Joey Bloggs"}
+	errs := validate.Struct(account)
 	Equal(t, errs, nil)
+	Equal(t, account.Email, "joey@bloggs.com")
+	Equal(t, order, []string{"pre", "struct", "post"})
 
-	inner2.String = ""
-	errs = validate.Struct(val)
+	errs = validate.Struct(Account{Email: "joey@bloggs.com", DisplayName: "Joey"})
 	NotEqual(t, errs, nil)
 	Equal(t, len(errs.(ValidationErrors)), 2)
-	AssertError(t, errs, "TestStructReturnValidationErrors.Inner1JSON.Inner2.JSONString", "TestStructReturnValidationErrors.Inner1.Inner2.String", "JSONString", "String", "required")
-	// this is an extra error reported from struct validation, it's a badly formatted one, but on purpose
-	AssertError(t, errs, "TestStructReturnValidationErrors.Inner1JSON.TestStructReturnValidationErrorsInner2.JSONString", "TestStructReturnValidationErrors.Inner1.TestStructReturnValidationErrorsInner2.String", "JSONString", "String", "required")
-
-	fe := getError(errs, "TestStructReturnValidationErrors.Inner1JSON.Inner2.JSONString", "TestStructReturnValidationErrors.Inner1.Inner2.String")
-	NotEqual(t, fe, nil)
-
-	// check for proper JSON namespace
-	Equal(t, fe.Field(), "JSONString")
-	Equal(t, fe.StructField(), "String")
-	Equal(t, fe.Namespace(), "TestStructReturnValidationErrors.Inner1JSON.Inner2.JSONString")
-	Equal(t, fe.StructNamespace(), "TestStructReturnValidationErrors.Inner1.Inner2.String")
-
-	fe = getError(errs, "TestStructReturnValidationErrors.Inner1JSON.TestStructReturnValidationErrorsInner2.JSONString", "TestStructReturnValidationErrors.Inner1.TestStructReturnValidationErrorsInner2.String")
-	NotEqual(t, fe, nil)
+	AssertError(t, errs, "Account.First", "Account.First", "First", "First", "required")
+	AssertError(t, errs, "Account.DisplayName", "Account.DisplayName", "DisplayName", "DisplayName", "displayname")
 
-	// check for proper JSON namespace
-	Equal(t, fe.Field(), "JSONString")
-	Equal(t, fe.StructField(), "String")
-	Equal(t, fe.Namespace(), "TestStructReturnValidationErrors.Inner1JSON.TestStructReturnValidationErrorsInner2.JSONString")
-	Equal(t, fe.StructNamespace(), "TestStructReturnValidationErrors.Inner1.TestStructReturnValidationErrorsInner2.String")
+	PanicMatches(t, func() { validate.Pipeline("") }, "Pipeline type 'string' is not a struct")
 }
 
 func TestStructLevelValidations(t *testing.T) {
@@ -10506,34 +13278,487 @@ func TestIP4AddrValidation(t *testing.T) {
 	}
 }
 
-func TestUnixAddrValidation(t *testing.T) {
-	tests := []struct {
-		param    string
-		expected bool
-	}{
-		{"", true},
-		{"v.sock", true},
+func TestUnixAddrValidation(t *testing.T) {
+	tests := []struct {
+		param    string
+		expected bool
+	}{
+		{"", true},
+		{"v.sock", true},
+	}
+
+	validate := New()
+	for i, test := range tests {
+		errs := validate.Var(test.param, "unix_addr")
+		if test.expected {
+			if !IsEqual(errs, nil) {
+				t.Fatalf("Index: %d unix_addr failed Error: %s", i, errs)
+			}
+		} else {
+			if IsEqual(errs, nil) {
+				t.Log(test.param, IsEqual(errs, nil))
+				t.Fatalf("Index: %d unix_addr failed Error: %s", i, errs)
+			} else {
+				val := getError(errs, "", "")
+				if val.Tag() != "unix_addr" {
+					t.Fatalf("Index: %d unix_addr failed Error: %s", i, errs)
+				}
+			}
+		}
+	}
+}
+
+func TestFieldLevelIndex(t *testing.T) {
+	type Row struct {
+		Name string `validate:"index"`
+	}
+
+	type Sheet struct {
+		Title string            `validate:"index"`
+		Cells []string          `validate:"dive,index"`
+		Grid  [][]string        `validate:"dive,dive,index"`
+		Tags  map[string]string `validate:"dive,keys,index,endkeys,index"`
+		Rows  []Row             `validate:"dive"`
+	}
+
+	var seen []string
+	validate := New()
+	err := validate.RegisterValidation("index", func(fl FieldLevel) bool {
+		idx, key, ok := fl.(IndexedFieldLevel).Index()
+		switch {
+		case !ok:
+			seen = append(seen, fl.FieldName()+":none")
+		case key.IsValid():
+			seen = append(seen, fmt.Sprintf("%s:%d:%v", fl.FieldName(), idx, key.Interface()))
+		default:
+			seen = append(seen, fmt.Sprintf("%s:%d", fl.FieldName(), idx))
+		}
+		return true
+	})
+	Equal(t, err, nil)
+
+	errs := validate.Struct(Sheet{
+		Cells: []string{"a", "b"},
+		Grid:  [][]string{{"a"}, {"b", "c"}},
+		Tags:  map[string]string{"k": "v"},
+		Rows:  []Row{{}},
+	})
+	Equal(t, errs, nil)
+	Equal(t, seen, []string{
+		"Title:none",
+		"Cells[0]:0", "Cells[1]:1",
+		"Grid[0][0]:0", "Grid[1][0]:0", "Grid[1][1]:1",
+		"Tags[k]:-1:k", "Tags[k]:-1:k",
+		"Name:none",
+	})
+
+	seen = nil
+	Equal(t, validate.Var("x", "index"), nil)
+	Equal(t, seen, []string{":none"})
+}
+
+func TestNumStrValidation(t *testing.T) {
+	validate := New()
+	tests := []struct {
+		value    string
+		tag      string
+		expected bool
+	}{
+		{"18446744073709551616", "gt_numstr=18446744073709551615", true},
+		{"18446744073709551615", "gt_numstr=18446744073709551615", false},
+		{"18446744073709551615", "gte_numstr=18446744073709551615", true},
+		{"9", "gt_numstr=10", false},
+		{"100", "gt_numstr=20", true},
+		{"-5", "lt_numstr=-4.5", true},
+		{"0.10", "lte_numstr=0.1", true},
+		{"0.11", "lte_numstr=0.1", false},
+		{"1e3", "lt_numstr=10000", false},
+		{"", "gte_numstr=0", false},
+		{"abc", "lt_numstr=1", false},
+	}
+
+	for i, test := range tests {
+		errs := validate.Var(test.value, test.tag)
+		if test.expected {
+			if !IsEqual(errs, nil) {
+				t.Fatalf("Index: %d %s failed Error: %s", i, test.tag, errs)
+			}
+		} else {
+			if IsEqual(errs, nil) {
+				t.Fatalf("Index: %d %s failed Error: %s", i, test.tag, errs)
+			}
+		}
+	}
+
+	PanicMatches(t, func() { _ = validate.Var(1, "gt_numstr=1") }, "Bad field type int")
+	PanicMatches(t, func() { _ = validate.Var("1", "gt_numstr=1/2") }, "Bad param '1/2', expected a decimal number")
+}
+
+func TestMultipleOfAndStepValidation(t *testing.T) {
+	validate := New()
+	tests := []struct {
+		value    interface{}
+		tag      string
+		expected bool
+	}{
+		{0.15, "multiple_of=0.05", true},
+		{0.3, "multiple_of=0.1", true},
+		{float32(0.7), "multiple_of=0.1", true},
+		{0.16, "multiple_of=0.05", false},
+		{10, "multiple_of=5", true},
+		{-10, "multiple_of=5", true},
+		{uint(12), "multiple_of=5", false},
+		{"19.95", "multiple_of=0.05", true},
+		{"19.96", "multiple_of=0.05", false},
+		{"abc", "multiple_of=0.05", false},
+		{math.NaN(), "multiple_of=0.05", false},
+		{7, "step=5;offset=2", true},
+		{-3, "step=5;offset=2", true},
+		{5, "step=5;offset=2", false},
+		{1.25, "step=0.5;offset=0.25", true},
+		{1.5, "step=0.5;offset=0.25", false},
+		{15, "step=5", true},
+	}
+
+	for i, test := range tests {
+		errs := validate.Var(test.value, test.tag)
+		if test.expected {
+			if !IsEqual(errs, nil) {
+				t.Fatalf("Index: %d %s failed Error: %s", i, test.tag, errs)
+			}
+		} else {
+			if IsEqual(errs, nil) {
+				t.Fatalf("Index: %d %s failed Error: %s", i, test.tag, errs)
+			}
+		}
+	}
+
+	PanicMatches(t, func() { _ = validate.Var(1, "multiple_of=0") }, "Bad param '0', expected a non-zero decimal number")
+	PanicMatches(t, func() { _ = validate.Var(1, "step=5;start=2") }, "Bad param '5;start=2' for 'step', expected 'step=<step>;offset=<offset>'")
+	PanicMatches(t, func() { _ = validate.Var(true, "multiple_of=2") }, "Bad field type bool")
+}
+
+func TestFiniteValidation(t *testing.T) {
+	validate := New()
+	tests := []struct {
+		value    interface{}
+		tag      string
+		expected bool
+	}{
+		{1.5, "finite", true},
+		{math.NaN(), "finite", false},
+		{math.Inf(1), "finite", false},
+		{float32(math.Inf(-1)), "finite", false},
+		{10, "finite", true},
+		{math.NaN(), "not_nan", false},
+		{math.Inf(1), "not_nan", true},
+		{math.Inf(-1), "not_inf", false},
+		{math.NaN(), "not_inf", true},
+		{uint8(1), "not_inf", true},
+	}
+
+	for i, test := range tests {
+		errs := validate.Var(test.value, test.tag)
+		if test.expected {
+			if !IsEqual(errs, nil) {
+				t.Fatalf("Index: %d %s failed Error: %s", i, test.tag, errs)
+			}
+		} else {
+			if IsEqual(errs, nil) {
+				t.Fatalf("Index: %d %s failed Error: %s", i, test.tag, errs)
+			}
+		}
+	}
+
+	PanicMatches(t, func() { _ = validate.Var("1", "finite") }, "Bad field type string")
+
+	type Reading struct {
+		Value float64 `validate:"ne=0"`
+		Limit float64 `validate:"nefield=Value"`
+	}
+
+	nan := Reading{Value: math.NaN(), Limit: math.NaN()}
+	Equal(t, validate.Var(math.NaN(), "ne=0"), nil)
+	Equal(t, validate.Struct(nan), nil)
+
+	validate = New(WithRejectNaN())
+	AssertError(t, validate.Var(math.NaN(), "ne=0"), "", "", "", "", "ne")
+	NotEqual(t, validate.Var(math.NaN(), "lte=10"), nil)
+	Equal(t, validate.Var(1.0, "ne=0"), nil)
+	Equal(t, validate.Var([]float64{math.NaN()}, "min=1"), nil)
+
+	errs := validate.Struct(nan)
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 2)
+	AssertError(t, errs, "Reading.Value", "Reading.Value", "Value", "Value", "ne")
+	AssertError(t, errs, "Reading.Limit", "Reading.Limit", "Limit", "Limit", "nefield")
+}
+
+func TestFlagsValidation(t *testing.T) {
+	validate := New()
+	tests := []struct {
+		value    interface{}
+		tag      string
+		expected bool
+	}{
+		{0x7, "has_flags=0x3", true},
+		{0x5, "has_flags=0x3", false},
+		{uint16(0x803), "has_flags=0b11", true},
+		{0x3, "no_flags=0x800", true},
+		{0x803, "no_flags=0x800", false},
+		{0xFF, "valid_mask=0xFF", true},
+		{0x1FF, "valid_mask=0xFF", false},
+		{int8(-1), "valid_mask=0xFF", true},
+		{int8(-1), "has_flags=0x80", true},
+		{int16(-1), "valid_mask=0xFF", false},
+		{0, "has_flags=0", true},
+	}
+
+	for i, test := range tests {
+		errs := validate.Var(test.value, test.tag)
+		if test.expected {
+			if !IsEqual(errs, nil) {
+				t.Fatalf("Index: %d %s failed Error: %s", i, test.tag, errs)
+			}
+		} else {
+			if IsEqual(errs, nil) {
+				t.Fatalf("Index: %d %s failed Error: %s", i, test.tag, errs)
+			}
+		}
+	}
+
+	PanicMatches(t, func() { _ = validate.Var(1.5, "has_flags=1") }, "Bad field type float64")
+	PanicMatches(t, func() { _ = validate.Var(1, "no_flags=x") }, "strconv.ParseUint: parsing \"x\": invalid syntax")
+}
+
+func TestInRangesValidation(t *testing.T) {
+	validate := New()
+	tests := []struct {
+		value    interface{}
+		tag      string
+		expected bool
+	}{
+		{1, "in_ranges=1-5 10-15 100", true},
+		{5, "in_ranges=1-5 10-15 100", true},
+		{7, "in_ranges=1-5 10-15 100", false},
+		{12, "in_ranges=1-5 10-15 100", true},
+		{100, "in_ranges=1-5 10-15 100", true},
+		{101, "in_ranges=1-5 10-15 100", false},
+		{-3, "in_ranges=-5--1 1-5", true},
+		{0, "in_ranges=-5--1 1-5", false},
+		{uint8(0x10), "in_ranges=0x0A-0x1F", true},
+		{uint64(math.MaxUint64), "in_ranges=0-10", false},
+		{"snake_case", "in_ranges=a-z _", true},
+		{"Snake_case", "in_ranges=a-z _", false},
+		{"ümlaut", "in_ranges=a-z ä-ü", true},
+		{"", "in_ranges=a-z", true},
+		{"a-b", "in_ranges=a-z -", true},
+	}
+
+	for i, test := range tests {
+		errs := validate.Var(test.value, test.tag)
+		if test.expected {
+			if !IsEqual(errs, nil) {
+				t.Fatalf("Index: %d %s failed Error: %s", i, test.tag, errs)
+			}
+		} else {
+			if IsEqual(errs, nil) {
+				t.Fatalf("Index: %d %s failed Error: %s", i, test.tag, errs)
+			}
+		}
+	}
+
+	PanicMatches(t, func() { _ = validate.Var(1, "in_ranges=5-1") }, "Bad range '5-1' for 'in_ranges'")
+	PanicMatches(t, func() { _ = validate.Var("a", "in_ranges=abc") }, "Bad range 'abc' for 'in_ranges'")
+	PanicMatches(t, func() { _ = validate.Var(1.5, "in_ranges=1-5") }, "Bad field type float64")
+}
+
+func TestPrevFieldValidation(t *testing.T) {
+	type Event struct {
+		At   time.Time
+		Seq  int
+		Name string
+	}
+
+	type Log struct {
+		Seqs   []int       `validate:"dive,gtprevfield"`
+		Names  []string    `validate:"dive,lteprevfield"`
+		Events []*Event    `validate:"dive,gtprevfield=At,gteprevfield=Seq"`
+		Times  []time.Time `validate:"dive,ltprevfield"`
+	}
+
+	now := time.Now()
+	validate := New()
+	errs := validate.Struct(Log{
+		Seqs:   []int{1, 2, 5},
+		Names:  []string{"c", "b", "b"},
+		Events: []*Event{{At: now, Seq: 1}, {At: now.Add(time.Second), Seq: 1}},
+		Times:  []time.Time{now, now.Add(-time.Second)},
+	})
+	Equal(t, errs, nil)
+
+	errs = validate.Struct(Log{
+		Seqs:   []int{1, 1, 5, 4},
+		Names:  []string{"a", "b"},
+		Events: []*Event{{At: now, Seq: 2}, {At: now, Seq: 1}},
+		Times:  []time.Time{now, now},
+	})
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 5)
+	AssertError(t, errs, "Log.Seqs[1]", "Log.Seqs[1]", "Seqs[1]", "Seqs[1]", "gtprevfield")
+	AssertError(t, errs, "Log.Seqs[3]", "Log.Seqs[3]", "Seqs[3]", "Seqs[3]", "gtprevfield")
+	AssertError(t, errs, "Log.Names[1]", "Log.Names[1]", "Names[1]", "Names[1]", "lteprevfield")
+	AssertError(t, errs, "Log.Events[1]", "Log.Events[1]", "Events[1]", "Events[1]", "gtprevfield")
+	AssertError(t, errs, "Log.Times[1]", "Log.Times[1]", "Times[1]", "Times[1]", "ltprevfield")
+
+	errs = validate.Struct(Log{Events: []*Event{{At: now, Seq: 2}, {At: now.Add(time.Second), Seq: 1}}})
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "Log.Events[1]", "Log.Events[1]", "Events[1]", "Events[1]", "gteprevfield")
+
+	PanicMatches(t, func() { _ = validate.Var(1, "gtprevfield") }, "'gtprevfield' must be used within a dive over a slice or array on field ''")
+	PanicMatches(t, func() { _ = validate.Var(map[string]int{"a": 1}, "dive,gtprevfield") }, "'gtprevfield' must be used within a dive over a slice or array on field '[a]'")
+}
+
+func TestAllErrs(t *testing.T) {
+	type Inner struct {
+		Name string `validate:"required"`
+	}
+
+	type Signup struct {
+		Password string   `validate:"allerrs,min=8,alphanum,contains=1"`
+		Username string   `validate:"min=3,alphanum"`
+		Color    string   `validate:"allerrs,hexcolor|rgb,len=7"`
+		Tags     []string `validate:"allerrs,min=2,max=3,dive,required"`
+		Inner    *Inner   `validate:"allerrs,required"`
+	}
+
+	validate := New()
+	errs := validate.Struct(Signup{Password: "ab!", Username: "a!", Color: "blue", Tags: []string{""}})
+	NotEqual(t, errs, nil)
+	ve := errs.(ValidationErrors)
+	Equal(t, len(ve), 8)
+	Equal(t, ve[0].Tag(), "min")
+	Equal(t, ve[1].Tag(), "alphanum")
+	Equal(t, ve[2].Tag(), "contains")
+	AssertError(t, errs, "Signup.Username", "Signup.Username", "Username", "Username", "min")
+	Equal(t, ve[4].Tag(), "hexcolor|rgb")
+	Equal(t, ve[5].Tag(), "len")
+	// the elements of a failing field aren't validated
+	AssertError(t, errs, "Signup.Tags", "Signup.Tags", "Tags", "Tags", "min")
+	AssertError(t, errs, "Signup.Inner", "Signup.Inner", "Inner", "Inner", "required")
+
+	errs = validate.Struct(Signup{Password: "abcdefg1", Username: "joey", Color: "#ffffff", Tags: []string{"a", ""}, Inner: &Inner{}})
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 2)
+	AssertError(t, errs, "Signup.Tags[1]", "Signup.Tags[1]", "Tags[1]", "Tags[1]", "required")
+	AssertError(t, errs, "Signup.Inner.Name", "Signup.Inner.Name", "Name", "Name", "required")
+
+	validate = New(WithAllErrors())
+	errs = validate.Var("a!", "min=3,alphanum")
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 2)
+
+	errs = validate.Struct(Signup{Password: "abcdefg1", Username: "a!", Color: "#ffffff", Tags: []string{"a", "b"}, Inner: &Inner{Name: "joey"}})
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 2)
+}
+
+func TestDiveMaxErrs(t *testing.T) {
+	type Row struct {
+		ID int `validate:"gt=0"`
+	}
+
+	type Upload struct {
+		Rows   []Row          `validate:"dive_maxerrs=2"`
+		Values []int          `validate:"dive_maxerrs=1,gt=0"`
+		Labels map[string]int `validate:"dive_maxerrs=5,keys,alpha,endkeys,gt=0"`
+	}
+
+	validate := New()
+	errs := validate.Struct(Upload{
+		Rows:   []Row{{ID: 0}, {ID: 1}, {ID: 0}, {ID: 0}, {ID: 0}},
+		Values: []int{1, 2},
+		Labels: map[string]int{"a": 1, "b2": 0},
+	})
+	NotEqual(t, errs, nil)
+	ve := errs.(ValidationErrors)
+	Equal(t, len(ve), 5)
+	AssertError(t, errs, "Upload.Rows[0].ID", "Upload.Rows[0].ID", "ID", "ID", "gt")
+	AssertError(t, errs, "Upload.Rows[2].ID", "Upload.Rows[2].ID", "ID", "ID", "gt")
+	AssertError(t, errs, "Upload.Rows", "Upload.Rows", "Rows", "Rows", "dive_maxerrs")
+	AssertError(t, errs, "Upload.Labels[b2]", "Upload.Labels[b2]", "Labels[b2]", "Labels[b2]", "alpha")
+	Equal(t, ve[3].Tag(), "alpha")
+	Equal(t, ve[4].Tag(), "gt")
+
+	fe := getError(errs, "Upload.Rows", "Upload.Rows")
+	Equal(t, fe.Param(), "2")
+	Equal(t, fe.Value(), 2)
+
+	errs = validate.Struct(Upload{Values: []int{0, 0, 0}})
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 2)
+	AssertError(t, errs, "Upload.Values[0]", "Upload.Values[0]", "Values[0]", "Values[0]", "gt")
+	fe = getError(errs, "Upload.Values", "Upload.Values")
+	Equal(t, fe.Value(), 2)
+
+	PanicMatches(t, func() { _ = validate.Var([]int{1}, "dive_maxerrs=0,gt=0") }, "Bad param '0' for 'dive_maxerrs' on field ''")
+}
+
+func TestDiveParallel(t *testing.T) {
+	type Row struct {
+		ID    int    `validate:"gt=0"`
+		Email string `validate:"required,email"`
 	}
 
-	validate := New()
-	for i, test := range tests {
-		errs := validate.Var(test.param, "unix_addr")
-		if test.expected {
-			if !IsEqual(errs, nil) {
-				t.Fatalf("Index: %d unix_addr failed Error: %s", i, errs)
-			}
-		} else {
-			if IsEqual(errs, nil) {
-				t.Log(test.param, IsEqual(errs, nil))
-				t.Fatalf("Index: %d unix_addr failed Error: %s", i, errs)
-			} else {
-				val := getError(errs, "", "")
-				if val.Tag() != "unix_addr" {
-					t.Fatalf("Index: %d unix_addr failed Error: %s", i, errs)
-				}
-			}
+	type Upload struct {
+		Rows   []Row          `validate:"min=1,dive_parallel"`
+		Codes  [3]string      `validate:"dive_parallel,len=2"`
+		Labels map[string]int `validate:"dive_parallel,keys,alpha,endkeys,gt=0"`
+	}
+
+	type SerialUpload struct {
+		Rows []Row `validate:"min=1,dive"`
+	}
+
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i] = Row{ID: i % 7, Email: "joey@bloggs.com"}
+		if i%11 == 0 {
+			rows[i].Email = "joey"
 		}
 	}
+
+	validate := New(WithParallelDive(4))
+	errs := validate.Struct(Upload{Rows: rows, Codes: [3]string{"ab", "c", "de"}, Labels: map[string]int{"b2": 1, "a": 0, "c": 1}})
+	NotEqual(t, errs, nil)
+	ve := errs.(ValidationErrors)
+
+	serial := New().Struct(SerialUpload{Rows: rows}).(ValidationErrors)
+	Equal(t, len(ve), len(serial)+3)
+	for i, fe := range serial {
+		Equal(t, ve[i].Namespace(), strings.Replace(fe.Namespace(), "SerialUpload", "Upload", 1))
+		Equal(t, ve[i].Tag(), fe.Tag())
+	}
+
+	AssertError(t, errs, "Upload.Codes[1]", "Upload.Codes[1]", "Codes[1]", "Codes[1]", "len")
+	Equal(t, ve[len(ve)-2].Namespace(), "Upload.Labels[a]")
+	Equal(t, ve[len(ve)-2].Tag(), "gt")
+	Equal(t, ve[len(ve)-1].Namespace(), "Upload.Labels[b2]")
+	Equal(t, ve[len(ve)-1].Tag(), "alpha")
+
+	Equal(t, validate.Struct(Upload{Rows: []Row{{ID: 1, Email: "joey@bloggs.com"}}, Codes: [3]string{"ab", "cd", "ef"}}), nil)
+	Equal(t, validate.Var([]int{}, "dive_parallel,gt=0"), nil)
+
+	// the elements are validated in order when capped
+	errs = New(WithParallelDive(4), WithMaxErrors(2)).Var([]int{1, 0, 0, 0}, "dive_parallel,gt=0")
+	NotEqual(t, errs, nil)
+	var truncated *TruncatedValidationError
+	Equal(t, errors.As(errs, &truncated), true)
+	Equal(t, len(truncated.Errors), 2)
+	AssertError(t, truncated.Errors, "[1]", "[1]", "[1]", "[1]", "gt")
+
+	PanicMatches(t, func() { _ = validate.Var([]int{1, 2}, "dive_parallel,strsplit") }, "'strsplit' can only be used on string fields, field '[0]' is a int")
+	PanicMatches(t, func() { _ = New(WithParallelDive(0)) }, "parallel dive workers must be positive, got 0")
 }
 
 func TestMapDiveValidation(t *testing.T) {
@@ -12699,6 +15924,40 @@ func TestUniqueValidation(t *testing.T) {
 	})
 }
 
+func TestMatrixShapeValidation(t *testing.T) {
+	type Tensor struct {
+		Grid   [][]float64 `validate:"rows_eq=3,cols_eq=3"`
+		Ragged [][]int     `validate:"rectangular"`
+		Rows   []*[2]int   `validate:"cols_eq=2"`
+	}
+
+	validate := New()
+	err := validate.Struct(Tensor{
+		Grid:   [][]float64{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}},
+		Ragged: [][]int{{1, 2}, {3, 4}},
+		Rows:   []*[2]int{{1, 2}},
+	})
+	Equal(t, err, nil)
+
+	errs := validate.Struct(Tensor{
+		Grid:   [][]float64{{1, 0, 0}, {0, 1}},
+		Ragged: [][]int{{1, 2}, {3}},
+		Rows:   []*[2]int{nil},
+	}).(ValidationErrors)
+	Equal(t, len(errs), 3)
+	AssertError(t, errs, "Tensor.Grid", "Tensor.Grid", "Grid", "Grid", "rows_eq")
+	AssertError(t, errs, "Tensor.Ragged", "Tensor.Ragged", "Ragged", "Ragged", "rectangular")
+	AssertError(t, errs, "Tensor.Rows", "Tensor.Rows", "Rows", "Rows", "cols_eq")
+
+	Equal(t, validate.Var([][]int{{1, 2}, {3}}, "cols_eq=2").(ValidationErrors)[0].Tag(), "cols_eq")
+	Equal(t, validate.Var([][]int{}, "rectangular,cols_eq=2"), nil)
+	Equal(t, validate.Var([2][]string{}, "rows_eq=2,rectangular"), nil)
+	Equal(t, Details(errs[0]).Translate("en"), "Grid must have 3 rows")
+
+	PanicMatches(t, func() { _ = validate.Var([]int{1}, "rectangular") }, "Bad field type []int")
+	PanicMatches(t, func() { _ = validate.Var([][]int{{1}}, "rows_eq=a") }, "strconv.ParseInt: parsing \"a\": invalid syntax")
+}
+
 func TestUniqueValidationStructPtrSlice(t *testing.T) {
 	testStructs := []*struct {
 		A *string
@@ -12840,42 +16099,285 @@ func TestPrivateFieldsStruct(t *testing.T) {
 		},
 	}
 
-	validate := New(WithPrivateFieldValidation())
-	for _, tc := range tcs {
-		err := validate.Struct(tc.stct)
-		NotEqual(t, err, nil)
+	validate := New(WithPrivateFieldValidation())
+	for _, tc := range tcs {
+		err := validate.Struct(tc.stct)
+		NotEqual(t, err, nil)
+
+		errs := err.(ValidationErrors)
+		Equal(t, len(errs), tc.errorNum)
+	}
+	stct := &struct {
+		f1 int `validate:"uri"`
+	}{}
+
+	PanicMatches(t, func() { _ = validate.Struct(stct) }, "Bad field type int")
+}
+
+func TestStructPartial(t *testing.T) {
+	p1 := []string{
+		"NoTag",
+		"Required",
+	}
+
+	p2 := []string{
+		"SubSlice[0].Test",
+		"Sub",
+		"SubIgnore",
+		"Anonymous.A",
+	}
+
+	p3 := []string{
+		"SubTest.Test",
+	}
+
+	p4 := []string{
+		"A",
+	}
+
+	tPartial := &TestPartial{
+		NoTag:    "NoTag",
+		Required: "Required",
+		SubSlice: []*SubTest{
+			{
+				Test: "Required",
+			},
+			{
+				Test: "Required",
+			},
+		},
+
+		Sub: &SubTest{
+			Test: "1",
+		},
+		SubIgnore: &SubTest{
+			Test: "",
+		},
+		Anonymous: struct {
+			A             string     `validate:"required"`
+			ASubSlice     []*SubTest `validate:"required,dive"`
+			SubAnonStruct []struct {
+				Test      string `validate:"required"`
+				OtherTest string `validate:"required"`
+			} `validate:"required,dive"`
+		}{
+			A: "1",
+			ASubSlice: []*SubTest{
+				{
+					Test: "Required",
+				},
+				{
+					Test: "Required",
+				},
+			},
+
+			SubAnonStruct: []struct {
+				Test      string `validate:"required"`
+				OtherTest string `validate:"required"`
+			}{
+				{"Required", "RequiredOther"},
+				{"Required", "RequiredOther"},
+			},
+		},
+	}
+
+	validate := New()
+
+	// the following should all return no errors as everything is valid in the default state
+	errs := validate.StructPartialCtx(context.Background(), tPartial, p1...)
+	Equal(t, errs, nil)
+
+	errs = validate.StructPartial(tPartial, p2...)
+	Equal(t, errs, nil)
+
+	// this isn't really a robust test, but is meant to illustrate the ANON CASE below
+	errs = validate.StructPartial(tPartial.SubSlice[0], p3...)
+	Equal(t, errs, nil)
+
+	errs = validate.StructExceptCtx(context.Background(), tPartial, p1...)
+	Equal(t, errs, nil)
+
+	errs = validate.StructExcept(tPartial, p2...)
+	Equal(t, errs, nil)
+
+	// mod tPartial for required field and re-test making sure invalid fields are NOT required:
+	tPartial.Required = ""
+
+	errs = validate.StructExcept(tPartial, p1...)
+	Equal(t, errs, nil)
+
+	errs = validate.StructPartial(tPartial, p2...)
+	Equal(t, errs, nil)
+
+	// inversion and retesting Partial to generate failures:
+	errs = validate.StructPartial(tPartial, p1...)
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "TestPartial.Required", "TestPartial.Required", "Required", "Required", "required")
+
+	errs = validate.StructExcept(tPartial, p2...)
+	AssertError(t, errs, "TestPartial.Required", "TestPartial.Required", "Required", "Required", "required")
+
+	// reset Required field, and set nested struct
+	tPartial.Required = "Required"
+	tPartial.Anonymous.A = ""
+
+	// will pass as unset fields is not going to be tested
+	errs = validate.StructPartial(tPartial, p1...)
+	Equal(t, errs, nil)
+
+	errs = validate.StructExcept(tPartial, p2...)
+	Equal(t, errs, nil)
+
+	// ANON CASE the response here is strange, it clearly does what it is being told to
+	errs = validate.StructExcept(tPartial.Anonymous, p4...)
+	Equal(t, errs, nil)
+
+	// will fail as unset field is tested
+	errs = validate.StructPartial(tPartial, p2...)
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "TestPartial.Anonymous.A", "TestPartial.Anonymous.A", "A", "A", "required")
+
+	errs = validate.StructExcept(tPartial, p1...)
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "TestPartial.Anonymous.A", "TestPartial.Anonymous.A", "A", "A", "required")
+
+	// reset nested struct and unset struct in slice
+	tPartial.Anonymous.A = "Required"
+	tPartial.SubSlice[0].Test = ""
+
+	// these will pass as unset item is NOT tested
+	errs = validate.StructPartial(tPartial, p1...)
+	Equal(t, errs, nil)
+
+	errs = validate.StructExcept(tPartial, p2...)
+	Equal(t, errs, nil)
+
+	// these will fail as unset item IS tested
+	errs = validate.StructExcept(tPartial, p1...)
+	AssertError(t, errs, "TestPartial.SubSlice[0].Test", "TestPartial.SubSlice[0].Test", "Test", "Test", "required")
+	Equal(t, len(errs.(ValidationErrors)), 1)
+
+	errs = validate.StructPartial(tPartial, p2...)
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "TestPartial.SubSlice[0].Test", "TestPartial.SubSlice[0].Test", "Test", "Test", "required")
+	Equal(t, len(errs.(ValidationErrors)), 1)
+
+	// Unset second slice member concurrently to test dive behavior:
+	tPartial.SubSlice[1].Test = ""
+	errs = validate.StructPartial(tPartial, p1...)
+	Equal(t, errs, nil)
+
+	// NOTE: When specifying nested items, it is still the users responsibility
+	// to specify the dive tag, the library does not override this.
+	errs = validate.StructExcept(tPartial, p2...)
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "TestPartial.SubSlice[1].Test", "TestPartial.SubSlice[1].Test", "Test", "Test", "required")
+
+	errs = validate.StructExcept(tPartial, p1...)
+	Equal(t, len(errs.(ValidationErrors)), 2)
+	AssertError(t, errs, "TestPartial.SubSlice[0].Test", "TestPartial.SubSlice[0].Test", "Test", "Test", "required")
+	AssertError(t, errs, "TestPartial.SubSlice[1].Test", "TestPartial.SubSlice[1].Test", "Test", "Test", "required")
+
+	errs = validate.StructPartial(tPartial, p2...)
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 1)
+	AssertError(t, errs, "TestPartial.SubSlice[0].Test", "TestPartial.SubSlice[0].Test", "Test", "Test", "required")
+
+	// reset struct in slice, and unset struct in slice in unset position
+	tPartial.SubSlice[0].Test = "Required"
+
+	// these will pass as the unset item is NOT tested
+	errs = validate.StructPartial(tPartial, p1...)
+	Equal(t, errs, nil)
+
+	errs = validate.StructPartial(tPartial, p2...)
+	Equal(t, errs, nil)
+
+	// testing for missing item by exception, yes it dives and fails
+	errs = validate.StructExcept(tPartial, p1...)
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 1)
+	AssertError(t, errs, "TestPartial.SubSlice[1].Test", "TestPartial.SubSlice[1].Test", "Test", "Test", "required")
+
+	errs = validate.StructExcept(tPartial, p2...)
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "TestPartial.SubSlice[1].Test", "TestPartial.SubSlice[1].Test", "Test", "Test", "required")
+
+	tPartial.SubSlice[1].Test = "Required"
+	tPartial.Anonymous.SubAnonStruct[0].Test = ""
+	// these will pass as the unset item is NOT tested
+	errs = validate.StructPartial(tPartial, p1...)
+	Equal(t, errs, nil)
+
+	errs = validate.StructPartial(tPartial, p2...)
+	Equal(t, errs, nil)
 
-		errs := err.(ValidationErrors)
-		Equal(t, len(errs), tc.errorNum)
+	errs = validate.StructExcept(tPartial, p1...)
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "TestPartial.Anonymous.SubAnonStruct[0].Test", "TestPartial.Anonymous.SubAnonStruct[0].Test", "Test", "Test", "required")
+
+	errs = validate.StructExcept(tPartial, p2...)
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "TestPartial.Anonymous.SubAnonStruct[0].Test", "TestPartial.Anonymous.SubAnonStruct[0].Test", "Test", "Test", "required")
+
+	// Test for unnamed struct
+	testStruct := &TestStruct{
+		String: "test",
 	}
-	stct := &struct {
-		f1 int `validate:"uri"`
-	}{}
+	unnamedStruct := struct {
+		String string `validate:"required" json:"StringVal"`
+	}{String: "test"}
+	composedUnnamedStruct := struct{ *TestStruct }{&TestStruct{String: "test"}}
 
-	PanicMatches(t, func() { _ = validate.Struct(stct) }, "Bad field type int")
+	errs = validate.StructPartial(testStruct, "String")
+	Equal(t, errs, nil)
+
+	errs = validate.StructPartial(unnamedStruct, "String")
+	Equal(t, errs, nil)
+
+	errs = validate.StructPartial(composedUnnamedStruct, "TestStruct.String")
+	Equal(t, errs, nil)
+
+	testStruct.String = ""
+	errs = validate.StructPartial(testStruct, "String")
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "TestStruct.String", "TestStruct.String", "String", "String", "required")
+
+	unnamedStruct.String = ""
+	errs = validate.StructPartial(unnamedStruct, "String")
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "String", "String", "String", "String", "required")
+
+	composedUnnamedStruct.String = ""
+	errs = validate.StructPartial(composedUnnamedStruct, "TestStruct.String")
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "TestStruct.String", "TestStruct.String", "String", "String", "required")
 }
 
-func TestStructPartial(t *testing.T) {
-	p1 := []string{
-		"NoTag",
-		"Required",
-	}
+func TestStructFiltered(t *testing.T) {
+	p1 := func(ns []byte) bool {
+		if bytes.HasSuffix(ns, []byte("NoTag")) || bytes.HasSuffix(ns, []byte("Required")) {
+			return false
+		}
 
-	p2 := []string{
-		"SubSlice[0].Test",
-		"Sub",
-		"SubIgnore",
-		"Anonymous.A",
+		return true
 	}
+	p2 := func(ns []byte) bool {
+		if bytes.HasSuffix(ns, []byte("SubSlice[0].Test")) ||
+			bytes.HasSuffix(ns, []byte("SubSlice[0]")) ||
+			bytes.HasSuffix(ns, []byte("SubSlice")) ||
+			bytes.HasSuffix(ns, []byte("Sub")) ||
+			bytes.HasSuffix(ns, []byte("SubIgnore")) ||
+			bytes.HasSuffix(ns, []byte("Anonymous")) ||
+			bytes.HasSuffix(ns, []byte("Anonymous.A")) {
+			return false
+		}
 
-	p3 := []string{
-		"SubTest.Test",
+		return true
 	}
-
-	p4 := []string{
-		"A",
+	p3 := func(ns []byte) bool {
+		return !bytes.HasSuffix(ns, []byte("SubTest.Test"))
 	}
-
 	tPartial := &TestPartial{
 		NoTag:    "NoTag",
 		Required: "Required",
@@ -12887,7 +16389,6 @@ func TestStructPartial(t *testing.T) {
 				Test: "Required",
 			},
 		},
-
 		Sub: &SubTest{
 			Test: "1",
 		},
@@ -12911,613 +16412,1237 @@ func TestStructPartial(t *testing.T) {
 					Test: "Required",
 				},
 			},
+			SubAnonStruct: []struct {
+				Test      string `validate:"required"`
+				OtherTest string `validate:"required"`
+			}{
+				{"Required", "RequiredOther"},
+				{"Required", "RequiredOther"},
+			},
+		},
+	}
+
+	validate := New()
+	// the following should all return no errors as everything is valid in the default state
+	errs := validate.StructFilteredCtx(context.Background(), tPartial, p1)
+	Equal(t, errs, nil)
+
+	errs = validate.StructFiltered(tPartial, p2)
+	Equal(t, errs, nil)
+
+	// this isn't really a robust test, but is meant to illustrate the ANON CASE below
+	errs = validate.StructFiltered(tPartial.SubSlice[0], p3)
+	Equal(t, errs, nil)
+
+	// mod tPartial for required field and re-test making sure invalid fields are NOT required:
+	tPartial.Required = ""
+	// inversion and retesting Partial to generate failures:
+	errs = validate.StructFiltered(tPartial, p1)
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "TestPartial.Required", "TestPartial.Required", "Required", "Required", "required")
+
+	// reset Required field, and set nested struct
+	tPartial.Required = "Required"
+	tPartial.Anonymous.A = ""
+
+	// will pass as unset fields is not going to be tested
+	errs = validate.StructFiltered(tPartial, p1)
+	Equal(t, errs, nil)
+
+	// will fail as unset field is tested
+	errs = validate.StructFiltered(tPartial, p2)
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "TestPartial.Anonymous.A", "TestPartial.Anonymous.A", "A", "A", "required")
+
+	// reset nested struct and unset struct in slice
+	tPartial.Anonymous.A = "Required"
+	tPartial.SubSlice[0].Test = ""
+
+	// these will pass as unset item is NOT tested
+	errs = validate.StructFiltered(tPartial, p1)
+	Equal(t, errs, nil)
+
+	errs = validate.StructFiltered(tPartial, p2)
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "TestPartial.SubSlice[0].Test", "TestPartial.SubSlice[0].Test", "Test", "Test", "required")
+	Equal(t, len(errs.(ValidationErrors)), 1)
+
+	// Unset second slice member concurrently to test dive behavior:
+	tPartial.SubSlice[1].Test = ""
+
+	errs = validate.StructFiltered(tPartial, p1)
+	Equal(t, errs, nil)
+
+	errs = validate.StructFiltered(tPartial, p2)
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 1)
+	AssertError(t, errs, "TestPartial.SubSlice[0].Test", "TestPartial.SubSlice[0].Test", "Test", "Test", "required")
+
+	// reset struct in slice, and unset struct in slice in unset position
+	tPartial.SubSlice[0].Test = "Required"
+
+	// these will pass as the unset item is NOT tested
+	errs = validate.StructFiltered(tPartial, p1)
+	Equal(t, errs, nil)
+
+	errs = validate.StructFiltered(tPartial, p2)
+	Equal(t, errs, nil)
+
+	tPartial.SubSlice[1].Test = "Required"
+	tPartial.Anonymous.SubAnonStruct[0].Test = ""
+
+	// these will pass as the unset item is NOT tested
+	errs = validate.StructFiltered(tPartial, p1)
+	Equal(t, errs, nil)
+
+	errs = validate.StructFiltered(tPartial, p2)
+	Equal(t, errs, nil)
+
+	dt := time.Now()
+	err := validate.StructFiltered(&dt, func(ns []byte) bool { return true })
+	NotEqual(t, err, nil)
+	Equal(t, err.Error(), "validator: (nil *time.Time)")
+}
+
+func TestUUIDValidation(t *testing.T) {
+	tests := []struct {
+		param    string
+		expected bool
+	}{
+		{"", false},
+		{"xxxa987fbc9-4bed-3078-cf07-9141ba07c9f3", false},
+		{"a987fbc9-4bed-3078-cf07-9141ba07c9f3xxx", false},
+		{"a987fbc94bed3078cf079141ba07c9f3", false},
+		{"934859", false},
+		{"987fbc9-4bed-3078-cf07a-9141ba07c9f3", false},
+		{"aaaaaaaa-1111-1111-aaag-111111111111", false},
+		{"a987fbc9-4bed-3078-cf07-9141ba07c9f3", true},
+	}
+
+	validate := New()
+
+	for i, test := range tests {
+		errs := validate.Var(test.param, "uuid")
+
+		if test.expected {
+			if !IsEqual(errs, nil) {
+				t.Fatalf("Index: %d UUID failed Error: %s", i, errs)
+			}
+		} else {
+			if IsEqual(errs, nil) {
+				t.Fatalf("Index: %d UUID failed Error: %s", i, errs)
+			} else {
+				val := getError(errs, "", "")
+				if val.Tag() != "uuid" {
+					t.Fatalf("Index: %d UUID failed Error: %s", i, errs)
+				}
+			}
+		}
+	}
+
+	// Test UUID validation on uuid structs type that implements Stringer interface.
+	structWithValidUUID := struct {
+		UUID uuidTestType `validate:"uuid"`
+	}{
+		UUID: uuidTestType{val: "a987fbc9-4bed-3078-cf07-9141ba07c9f3"},
+	}
+	structWithInvalidUUID := struct {
+		UUID uuidTestType `validate:"uuid"`
+	}{
+		UUID: uuidTestType{val: "934859"},
+	}
+
+	if err := validate.Struct(structWithValidUUID); err != nil {
+		t.Fatalf("UUID failed Error: %s", err)
+	}
+	if err := validate.Struct(structWithInvalidUUID); err == nil {
+		t.Fatal("UUID failed Error expected but received nil")
+	}
+
+	// Test on Alias type with Stringer interface.
+	alias := uuidAlias("a987fbc9-4bed-3078-cf07-9141ba07c9f3")
+	if err := validate.Var(alias, "uuid"); err != nil {
+		t.Fatalf("UUID failed Error: %s", err)
+	}
+}
+
+func TestValidateFn(t *testing.T) {
+	t.Run("using pointer", func(t *testing.T) {
+		validate := New()
+
+		type Test struct {
+			String string
+			Inner  *NotRed `validate:"validateFn"`
+		}
+
+		var tt Test
+		errs := validate.Struct(tt)
+		NotEqual(t, errs, nil)
 
-			SubAnonStruct: []struct {
-				Test      string `validate:"required"`
-				OtherTest string `validate:"required"`
-			}{
-				{"Required", "RequiredOther"},
-				{"Required", "RequiredOther"},
-			},
-		},
-	}
+		fe := errs.(ValidationErrors)[0]
+		Equal(t, fe.Field(), "Inner")
+		Equal(t, fe.Namespace(), "Test.Inner")
+		Equal(t, fe.Tag(), "validateFn")
 
-	validate := New()
+		tt.Inner = &NotRed{Color: "blue"}
+		errs = validate.Struct(tt)
+		Equal(t, errs, nil)
 
-	// the following should all return no errors as everything is valid in the default state
-	errs := validate.StructPartialCtx(context.Background(), tPartial, p1...)
-	Equal(t, errs, nil)
+		tt.Inner = &NotRed{Color: "red"}
+		errs = validate.Struct(tt)
+		NotEqual(t, errs, nil)
 
-	errs = validate.StructPartial(tPartial, p2...)
-	Equal(t, errs, nil)
+		fe = errs.(ValidationErrors)[0]
+		Equal(t, fe.Field(), "Inner")
+		Equal(t, fe.Namespace(), "Test.Inner")
+		Equal(t, fe.Tag(), "validateFn")
+	})
 
-	// this isn't really a robust test, but is meant to illustrate the ANON CASE below
-	errs = validate.StructPartial(tPartial.SubSlice[0], p3...)
-	Equal(t, errs, nil)
+	t.Run("using struct", func(t *testing.T) {
+		validate := New()
 
-	errs = validate.StructExceptCtx(context.Background(), tPartial, p1...)
-	Equal(t, errs, nil)
+		type Test2 struct {
+			String string
+			Inner  NotRed `validate:"validateFn"`
+		}
 
-	errs = validate.StructExcept(tPartial, p2...)
-	Equal(t, errs, nil)
+		var tt2 Test2
+		errs := validate.Struct(&tt2)
+		Equal(t, errs, nil)
 
-	// mod tPartial for required field and re-test making sure invalid fields are NOT required:
-	tPartial.Required = ""
+		tt2.Inner = NotRed{Color: "blue"}
+		errs = validate.Struct(&tt2)
+		Equal(t, errs, nil)
 
-	errs = validate.StructExcept(tPartial, p1...)
-	Equal(t, errs, nil)
+		tt2.Inner = NotRed{Color: "red"}
+		errs = validate.Struct(&tt2)
+		NotEqual(t, errs, nil)
 
-	errs = validate.StructPartial(tPartial, p2...)
-	Equal(t, errs, nil)
+		fe := errs.(ValidationErrors)[0]
+		Equal(t, fe.Field(), "Inner")
+		Equal(t, fe.Namespace(), "Test2.Inner")
+		Equal(t, fe.Tag(), "validateFn")
+	})
 
-	// inversion and retesting Partial to generate failures:
-	errs = validate.StructPartial(tPartial, p1...)
-	NotEqual(t, errs, nil)
-	AssertError(t, errs, "TestPartial.Required", "TestPartial.Required", "Required", "Required", "required")
+	t.Run("using struct with custom function", func(t *testing.T) {
+		validate := New()
 
-	errs = validate.StructExcept(tPartial, p2...)
-	AssertError(t, errs, "TestPartial.Required", "TestPartial.Required", "Required", "Required", "required")
+		type Test2 struct {
+			String string
+			Inner  NotRed `validate:"validateFn=IsNotRed"`
+		}
 
-	// reset Required field, and set nested struct
-	tPartial.Required = "Required"
-	tPartial.Anonymous.A = ""
+		var tt2 Test2
+		errs := validate.Struct(&tt2)
+		Equal(t, errs, nil)
 
-	// will pass as unset fields is not going to be tested
-	errs = validate.StructPartial(tPartial, p1...)
-	Equal(t, errs, nil)
+		tt2.Inner = NotRed{Color: "blue"}
+		errs = validate.Struct(&tt2)
+		Equal(t, errs, nil)
 
-	errs = validate.StructExcept(tPartial, p2...)
-	Equal(t, errs, nil)
+		tt2.Inner = NotRed{Color: "red"}
+		errs = validate.Struct(&tt2)
+		NotEqual(t, errs, nil)
 
-	// ANON CASE the response here is strange, it clearly does what it is being told to
-	errs = validate.StructExcept(tPartial.Anonymous, p4...)
-	Equal(t, errs, nil)
+		fe := errs.(ValidationErrors)[0]
+		Equal(t, fe.Field(), "Inner")
+		Equal(t, fe.Namespace(), "Test2.Inner")
+		Equal(t, fe.Tag(), "validateFn")
+	})
 
-	// will fail as unset field is tested
-	errs = validate.StructPartial(tPartial, p2...)
-	NotEqual(t, errs, nil)
-	AssertError(t, errs, "TestPartial.Anonymous.A", "TestPartial.Anonymous.A", "A", "A", "required")
+	t.Run("try validate method with wrong signature or not existent", func(t *testing.T) {
+		validate := New()
 
-	errs = validate.StructExcept(tPartial, p1...)
-	NotEqual(t, errs, nil)
-	AssertError(t, errs, "TestPartial.Anonymous.A", "TestPartial.Anonymous.A", "A", "A", "required")
+		type Test2 struct {
+			String string `validate:"validateFn=NotExists"` // should fail, method not found
+			Inner  NotRed `validate:"validateFn=DoNothing"` // should fail, return nothing
+			Inner2 NotRed `validate:"validateFn=String"`    // should fail, wrong return (must be error or bool)
+		}
 
-	// reset nested struct and unset struct in slice
-	tPartial.Anonymous.A = "Required"
-	tPartial.SubSlice[0].Test = ""
+		var tt2 Test2
+		err := validate.Struct(&tt2)
+		NotEqual(t, err, nil)
 
-	// these will pass as unset item is NOT tested
-	errs = validate.StructPartial(tPartial, p1...)
-	Equal(t, errs, nil)
+		errs := err.(ValidationErrors)
+		Equal(t, len(errs), 3)
 
-	errs = validate.StructExcept(tPartial, p2...)
-	Equal(t, errs, nil)
+		fe := errs[0]
+		Equal(t, fe.Field(), "String")
+		Equal(t, fe.Namespace(), "Test2.String")
+		Equal(t, fe.Tag(), "validateFn")
 
-	// these will fail as unset item IS tested
-	errs = validate.StructExcept(tPartial, p1...)
-	AssertError(t, errs, "TestPartial.SubSlice[0].Test", "TestPartial.SubSlice[0].Test", "Test", "Test", "required")
-	Equal(t, len(errs.(ValidationErrors)), 1)
+		fe = errs[1]
+		Equal(t, fe.Field(), "Inner")
+		Equal(t, fe.Namespace(), "Test2.Inner")
+		Equal(t, fe.Tag(), "validateFn")
 
-	errs = validate.StructPartial(tPartial, p2...)
+		fe = errs[2]
+		Equal(t, fe.Field(), "Inner2")
+		Equal(t, fe.Namespace(), "Test2.Inner2")
+		Equal(t, fe.Tag(), "validateFn")
+	})
+}
+
+func TestSQLValue2Validation(t *testing.T) {
+	validate := New()
+	validate.RegisterCustomTypeFunc(ValidateValuerType, valuer{}, (*driver.Valuer)(nil), sql.NullString{}, sql.NullInt64{}, sql.NullBool{}, sql.NullFloat64{})
+	validate.RegisterCustomTypeFunc(ValidateCustomType, MadeUpCustomType{})
+	validate.RegisterCustomTypeFunc(OverrideIntTypeForSomeReason, 1)
+
+	val := valuer{
+		Name: "",
+	}
+	errs := validate.Var(val, "required")
 	NotEqual(t, errs, nil)
-	AssertError(t, errs, "TestPartial.SubSlice[0].Test", "TestPartial.SubSlice[0].Test", "Test", "Test", "required")
-	Equal(t, len(errs.(ValidationErrors)), 1)
+	AssertError(t, errs, "", "", "", "", "required")
 
-	// Unset second slice member concurrently to test dive behavior:
-	tPartial.SubSlice[1].Test = ""
-	errs = validate.StructPartial(tPartial, p1...)
+	val.Name = "Valid Name"
+	errs = validate.VarCtx(context.Background(), val, "required")
 	Equal(t, errs, nil)
 
-	// NOTE: When specifying nested items, it is still the users responsibility
-	// to specify the dive tag, the library does not override this.
-	errs = validate.StructExcept(tPartial, p2...)
+	val.Name = "errorme"
+	PanicMatches(t, func() { _ = validate.Var(val, "required") }, "SQL Driver Valuer error: some kind of error")
+
+	myVal := valuer{
+		Name: "",
+	}
+	errs = validate.Var(myVal, "required")
 	NotEqual(t, errs, nil)
-	AssertError(t, errs, "TestPartial.SubSlice[1].Test", "TestPartial.SubSlice[1].Test", "Test", "Test", "required")
+	AssertError(t, errs, "", "", "", "", "required")
 
-	errs = validate.StructExcept(tPartial, p1...)
-	Equal(t, len(errs.(ValidationErrors)), 2)
-	AssertError(t, errs, "TestPartial.SubSlice[0].Test", "TestPartial.SubSlice[0].Test", "Test", "Test", "required")
-	AssertError(t, errs, "TestPartial.SubSlice[1].Test", "TestPartial.SubSlice[1].Test", "Test", "Test", "required")
+	cust := MadeUpCustomType{
+		FirstName: "Joey",
+		LastName:  "Bloggs",
+	}
 
-	errs = validate.StructPartial(tPartial, p2...)
+	c := CustomMadeUpStruct{MadeUp: cust, OverriddenInt: 2}
+	errs = validate.Struct(c)
+	Equal(t, errs, nil)
+
+	c.MadeUp.FirstName = ""
+	c.OverriddenInt = 1
+	errs = validate.Struct(c)
 	NotEqual(t, errs, nil)
-	Equal(t, len(errs.(ValidationErrors)), 1)
-	AssertError(t, errs, "TestPartial.SubSlice[0].Test", "TestPartial.SubSlice[0].Test", "Test", "Test", "required")
+	Equal(t, len(errs.(ValidationErrors)), 2)
+	AssertError(t, errs, "CustomMadeUpStruct.MadeUp", "CustomMadeUpStruct.MadeUp", "MadeUp", "MadeUp", "required")
+	AssertError(t, errs, "CustomMadeUpStruct.OverriddenInt", "CustomMadeUpStruct.OverriddenInt", "OverriddenInt", "OverriddenInt", "gt")
+}
 
-	// reset struct in slice, and unset struct in slice in unset position
-	tPartial.SubSlice[0].Test = "Required"
+func TestSQLValueValidation(t *testing.T) {
+	validate := New()
+	validate.RegisterCustomTypeFunc(ValidateValuerType, (*driver.Valuer)(nil), valuer{})
+	validate.RegisterCustomTypeFunc(ValidateCustomType, MadeUpCustomType{})
+	validate.RegisterCustomTypeFunc(OverrideIntTypeForSomeReason, 1)
+	val := valuer{
+		Name: "",
+	}
+	errs := validate.Var(val, "required")
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "", "", "", "", "required")
 
-	// these will pass as the unset item is NOT tested
-	errs = validate.StructPartial(tPartial, p1...)
+	val.Name = "Valid Name"
+	errs = validate.Var(val, "required")
 	Equal(t, errs, nil)
 
-	errs = validate.StructPartial(tPartial, p2...)
-	Equal(t, errs, nil)
+	val.Name = "errorme"
+	PanicMatches(t, func() { errs = validate.Var(val, "required") }, "SQL Driver Valuer error: some kind of error")
 
-	// testing for missing item by exception, yes it dives and fails
-	errs = validate.StructExcept(tPartial, p1...)
+	myVal := valuer{
+		Name: "",
+	}
+	errs = validate.Var(myVal, "required")
 	NotEqual(t, errs, nil)
-	Equal(t, len(errs.(ValidationErrors)), 1)
-	AssertError(t, errs, "TestPartial.SubSlice[1].Test", "TestPartial.SubSlice[1].Test", "Test", "Test", "required")
+	AssertError(t, errs, "", "", "", "", "required")
 
-	errs = validate.StructExcept(tPartial, p2...)
+	cust := MadeUpCustomType{
+		FirstName: "Joey",
+		LastName:  "Bloggs",
+	}
+	c := CustomMadeUpStruct{MadeUp: cust, OverriddenInt: 2}
+	errs = validate.Struct(c)
+	Equal(t, errs, nil)
+
+	c.MadeUp.FirstName = ""
+	c.OverriddenInt = 1
+	errs = validate.Struct(c)
 	NotEqual(t, errs, nil)
-	AssertError(t, errs, "TestPartial.SubSlice[1].Test", "TestPartial.SubSlice[1].Test", "Test", "Test", "required")
+	Equal(t, len(errs.(ValidationErrors)), 2)
+	AssertError(t, errs, "CustomMadeUpStruct.MadeUp", "CustomMadeUpStruct.MadeUp", "MadeUp", "MadeUp", "required")
+	AssertError(t, errs, "CustomMadeUpStruct.OverriddenInt", "CustomMadeUpStruct.OverriddenInt", "OverriddenInt", "OverriddenInt", "gt")
 
-	tPartial.SubSlice[1].Test = "Required"
-	tPartial.Anonymous.SubAnonStruct[0].Test = ""
-	// these will pass as the unset item is NOT tested
-	errs = validate.StructPartial(tPartial, p1...)
-	Equal(t, errs, nil)
+	// Test for empty field on structs without tags
+	type InvalidValuePanicSafetyTest struct {
+		V valuer
+	}
 
-	errs = validate.StructPartial(tPartial, p2...)
+	errs = validate.Struct(InvalidValuePanicSafetyTest{})
 	Equal(t, errs, nil)
+}
 
-	errs = validate.StructExcept(tPartial, p1...)
-	NotEqual(t, errs, nil)
-	AssertError(t, errs, "TestPartial.Anonymous.SubAnonStruct[0].Test", "TestPartial.Anonymous.SubAnonStruct[0].Test", "Test", "Test", "required")
+func TestFieldErrorIs(t *testing.T) {
+	validate := New()
+	type Test struct {
+		Name  string `validate:"required"`
+		Email string `validate:"omitempty,email"`
+		Color string `validate:"omitempty,iscolor"`
+	}
 
-	errs = validate.StructExcept(tPartial, p2...)
-	NotEqual(t, errs, nil)
-	AssertError(t, errs, "TestPartial.Anonymous.SubAnonStruct[0].Test", "TestPartial.Anonymous.SubAnonStruct[0].Test", "Test", "Test", "required")
+	err := validate.Struct(Test{Email: "not an email", Color: "nope"})
+	NotEqual(t, err, nil)
 
-	// Test for unnamed struct
-	testStruct := &TestStruct{
-		String: "test",
+	errs := err.(ValidationErrors)
+	Equal(t, len(errs), 3)
+	Equal(t, errors.Is(errs[0], ErrRequired), true)
+	Equal(t, errors.Is(errs[0], ErrTag("required")), true)
+	Equal(t, errors.Is(errs[0], ErrEmail), false)
+	Equal(t, errors.Is(errs[1], ErrEmail), true)
+	Equal(t, errors.Is(errs[1], ErrRequired), false)
+	Equal(t, errors.Is(errs[2], ErrTag("iscolor")), true)
+	Equal(t, errors.Is(errs[2], ErrTag("hexcolor|rgb|rgba|hsl|hsla")), true)
+	Equal(t, errors.Is(errs[0], errors.New("required")), false)
+	Equal(t, ErrRequired.Error(), "validator: failed on the 'required' tag")
+}
+
+func TestValidationErrorsUnwrap(t *testing.T) {
+	validate := New()
+	type Inner struct {
+		Name string `validate:"required"`
 	}
-	unnamedStruct := struct {
-		String string `validate:"required" json:"StringVal"`
-	}{String: "test"}
-	composedUnnamedStruct := struct{ *TestStruct }{&TestStruct{String: "test"}}
-
-	errs = validate.StructPartial(testStruct, "String")
-	Equal(t, errs, nil)
 
-	errs = validate.StructPartial(unnamedStruct, "String")
-	Equal(t, errs, nil)
+	type Outer struct {
+		Age int `validate:"gte=18"`
+	}
 
-	errs = validate.StructPartial(composedUnnamedStruct, "TestStruct.String")
-	Equal(t, errs, nil)
+	err := validate.Struct(Inner{})
+	NotEqual(t, err, nil)
+	Equal(t, errors.Is(err, ErrRequired), true)
+	Equal(t, errors.Is(err, ErrGte), false)
 
-	testStruct.String = ""
-	errs = validate.StructPartial(testStruct, "String")
-	NotEqual(t, errs, nil)
-	AssertError(t, errs, "TestStruct.String", "TestStruct.String", "String", "String", "required")
+	var fe FieldError
+	Equal(t, errors.As(err, &fe), true)
+	Equal(t, fe.Namespace(), "Inner.Name")
 
-	unnamedStruct.String = ""
-	errs = validate.StructPartial(unnamedStruct, "String")
-	NotEqual(t, errs, nil)
-	AssertError(t, errs, "String", "String", "String", "String", "required")
+	joined := errors.Join(err, validate.Struct(Outer{Age: 1}))
+	Equal(t, errors.Is(joined, ErrRequired), true)
+	Equal(t, errors.Is(joined, ErrGte), true)
+	Equal(t, errors.Is(joined, ErrEmail), false)
 
-	composedUnnamedStruct.String = ""
-	errs = validate.StructPartial(composedUnnamedStruct, "TestStruct.String")
-	NotEqual(t, errs, nil)
-	AssertError(t, errs, "TestStruct.String", "TestStruct.String", "String", "String", "required")
+	var ve ValidationErrors
+	Equal(t, errors.As(joined, &ve), true)
+	Equal(t, len(ve), 1)
+	Equal(t, len(ValidationErrors{}.Unwrap()), 0)
 }
 
-func TestStructFiltered(t *testing.T) {
-	p1 := func(ns []byte) bool {
-		if bytes.HasSuffix(ns, []byte("NoTag")) || bytes.HasSuffix(ns, []byte("Required")) {
-			return false
-		}
-
-		return true
+func TestValidationErrorsMergeAndPrefix(t *testing.T) {
+	validate := New()
+	type Query struct {
+		Page int `validate:"gte=1"`
 	}
-	p2 := func(ns []byte) bool {
-		if bytes.HasSuffix(ns, []byte("SubSlice[0].Test")) ||
-			bytes.HasSuffix(ns, []byte("SubSlice[0]")) ||
-			bytes.HasSuffix(ns, []byte("SubSlice")) ||
-			bytes.HasSuffix(ns, []byte("Sub")) ||
-			bytes.HasSuffix(ns, []byte("SubIgnore")) ||
-			bytes.HasSuffix(ns, []byte("Anonymous")) ||
-			bytes.HasSuffix(ns, []byte("Anonymous.A")) {
-			return false
-		}
 
-		return true
-	}
-	p3 := func(ns []byte) bool {
-		return !bytes.HasSuffix(ns, []byte("SubTest.Test"))
-	}
-	tPartial := &TestPartial{
-		NoTag:    "NoTag",
-		Required: "Required",
-		SubSlice: []*SubTest{
-			{
-				Test: "Required",
-			},
-			{
-				Test: "Required",
-			},
-		},
-		Sub: &SubTest{
-			Test: "1",
-		},
-		SubIgnore: &SubTest{
-			Test: "",
-		},
-		Anonymous: struct {
-			A             string     `validate:"required"`
-			ASubSlice     []*SubTest `validate:"required,dive"`
-			SubAnonStruct []struct {
-				Test      string `validate:"required"`
-				OtherTest string `validate:"required"`
-			} `validate:"required,dive"`
-		}{
-			A: "1",
-			ASubSlice: []*SubTest{
-				{
-					Test: "Required",
-				},
-				{
-					Test: "Required",
-				},
-			},
-			SubAnonStruct: []struct {
-				Test      string `validate:"required"`
-				OtherTest string `validate:"required"`
-			}{
-				{"Required", "RequiredOther"},
-				{"Required", "RequiredOther"},
-			},
-		},
+	type Body struct {
+		Name string `validate:"required"`
 	}
 
-	validate := New()
-	// the following should all return no errors as everything is valid in the default state
-	errs := validate.StructFilteredCtx(context.Background(), tPartial, p1)
-	Equal(t, errs, nil)
+	queryErrs := validate.Struct(Query{}).(ValidationErrors)
+	bodyErrs := validate.Struct(Body{}).(ValidationErrors)
 
-	errs = validate.StructFiltered(tPartial, p2)
-	Equal(t, errs, nil)
+	merged := queryErrs.WithNamespacePrefix("request.query").Merge(bodyErrs.WithNamespacePrefix("request.body."))
+	Equal(t, len(merged), 2)
+	AssertError(t, merged, "request.query.Query.Page", "request.query.Query.Page", "Page", "Page", "gte")
+	AssertError(t, merged, "request.body.Body.Name", "request.body.Body.Name", "Name", "Name", "required")
 
-	// this isn't really a robust test, but is meant to illustrate the ANON CASE below
-	errs = validate.StructFiltered(tPartial.SubSlice[0], p3)
-	Equal(t, errs, nil)
+	// originals are left untouched
+	AssertError(t, queryErrs, "Query.Page", "Query.Page", "Page", "Page", "gte")
+	AssertError(t, bodyErrs, "Body.Name", "Body.Name", "Name", "Name", "required")
 
-	// mod tPartial for required field and re-test making sure invalid fields are NOT required:
-	tPartial.Required = ""
-	// inversion and retesting Partial to generate failures:
-	errs = validate.StructFiltered(tPartial, p1)
-	NotEqual(t, errs, nil)
-	AssertError(t, errs, "TestPartial.Required", "TestPartial.Required", "Required", "Required", "required")
+	Equal(t, len(ValidationErrors(nil).Merge(nil)), 0)
+	Equal(t, len(queryErrs.WithNamespacePrefix("").Merge(nil)), 1)
+	Equal(t, queryErrs.WithNamespacePrefix("")[0].Namespace(), "Query.Page")
+}
 
-	// reset Required field, and set nested struct
-	tPartial.Required = "Required"
-	tPartial.Anonymous.A = ""
+func TestTagNameFuncCached(t *testing.T) {
+	var calls int
+	validate := New()
+	validate.RegisterTagNameFunc(func(fld reflect.StructField) string {
+		calls++
+		return strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
+	})
 
-	// will pass as unset fields is not going to be tested
-	errs = validate.StructFiltered(tPartial, p1)
-	Equal(t, errs, nil)
+	type Test struct {
+		Name  string `json:"name" validate:"required"`
+		Email string `json:"email" validate:"required,email"`
+	}
 
-	// will fail as unset field is tested
-	errs = validate.StructFiltered(tPartial, p2)
-	NotEqual(t, errs, nil)
-	AssertError(t, errs, "TestPartial.Anonymous.A", "TestPartial.Anonymous.A", "A", "A", "required")
+	for i := 0; i < 10; i++ {
+		err := validate.Struct(Test{})
+		NotEqual(t, err, nil)
+		AssertError(t, err, "Test.name", "Test.Name", "name", "Name", "required")
+	}
 
-	// reset nested struct and unset struct in slice
-	tPartial.Anonymous.A = "Required"
-	tPartial.SubSlice[0].Test = ""
+	Equal(t, calls, 2)
+}
 
-	// these will pass as unset item is NOT tested
-	errs = validate.StructFiltered(tPartial, p1)
-	Equal(t, errs, nil)
+func TestDeepNamespacePooled(t *testing.T) {
+	type Level3WithAVeryLongStructNameToGrowTheNamespace struct {
+		FieldWithAVeryLongNameIndeed string `validate:"required"`
+	}
 
-	errs = validate.StructFiltered(tPartial, p2)
-	NotEqual(t, errs, nil)
-	AssertError(t, errs, "TestPartial.SubSlice[0].Test", "TestPartial.SubSlice[0].Test", "Test", "Test", "required")
-	Equal(t, len(errs.(ValidationErrors)), 1)
+	type Level2WithAVeryLongStructNameToGrowTheNamespace struct {
+		InnerWithAVeryLongNameIndeed Level3WithAVeryLongStructNameToGrowTheNamespace
+	}
 
-	// Unset second slice member concurrently to test dive behavior:
-	tPartial.SubSlice[1].Test = ""
+	type Level1WithAVeryLongStructNameToGrowTheNamespace struct {
+		InnerWithAVeryLongNameIndeed Level2WithAVeryLongStructNameToGrowTheNamespace
+	}
 
-	errs = validate.StructFiltered(tPartial, p1)
-	Equal(t, errs, nil)
+	v := New()
+	expected := "Level1WithAVeryLongStructNameToGrowTheNamespace.InnerWithAVeryLongNameIndeed.InnerWithAVeryLongNameIndeed.FieldWithAVeryLongNameIndeed"
+	for i := 0; i < 3; i++ {
+		err := v.Struct(Level1WithAVeryLongStructNameToGrowTheNamespace{})
+		NotEqual(t, err, nil)
+		AssertError(t, err, expected, expected, "FieldWithAVeryLongNameIndeed", "FieldWithAVeryLongNameIndeed", "required")
+	}
+}
 
-	errs = validate.StructFiltered(tPartial, p2)
-	NotEqual(t, errs, nil)
-	Equal(t, len(errs.(ValidationErrors)), 1)
-	AssertError(t, errs, "TestPartial.SubSlice[0].Test", "TestPartial.SubSlice[0].Test", "Test", "Test", "required")
+func TestRegexPatternsAndWarmup(t *testing.T) {
+	Equal(t, RegexPatterns("alpha"), []string{alphaRegexString})
+	Equal(t, RegexPatterns("spicedb"), []string{spicedbIDRegexString, spicedbPermissionRegexString, spicedbTypeRegexString})
+	Equal(t, len(RegexPatterns("postcode_iso3166_alpha2")), len(postCodePatternDict))
+	Equal(t, len(RegexPatterns("required")), 0)
+	Equal(t, len(RegexPatterns("unknown")), 0)
 
-	// reset struct in slice, and unset struct in slice in unset position
-	tPartial.SubSlice[0].Test = "Required"
+	// every regex backed baked in tag must be published
+	for tag, patterns := range tagRegexes {
+		_, ok := bakedInValidators[tag]
+		Equal(t, ok, true)
+		for _, pattern := range patterns {
+			_, ok = lazyRegexes[pattern]
+			Equal(t, ok, true)
+		}
+	}
 
-	// these will pass as the unset item is NOT tested
-	errs = validate.StructFiltered(tPartial, p1)
-	Equal(t, errs, nil)
+	// equal patterns share their compiled regex
+	Equal(t, md4Regex(), md5Regex())
 
-	errs = validate.StructFiltered(tPartial, p2)
-	Equal(t, errs, nil)
+	WarmupRegexes("unknown", "required", "email", "postcode_iso3166_alpha2")
+	postCodeRegexDictRWLock.RLock()
+	Equal(t, len(postCodeRegexDict), len(postCodePatternDict))
+	postCodeRegexDictRWLock.RUnlock()
+	WarmupRegexes()
 
-	tPartial.SubSlice[1].Test = "Required"
-	tPartial.Anonymous.SubAnonStruct[0].Test = ""
+	reg, ok := postcodeRegex("US")
+	Equal(t, ok, true)
+	Equal(t, reg.MatchString("12345"), true)
 
-	// these will pass as the unset item is NOT tested
-	errs = validate.StructFiltered(tPartial, p1)
-	Equal(t, errs, nil)
+	_, ok = postcodeRegex("XX")
+	Equal(t, ok, false)
+}
 
-	errs = validate.StructFiltered(tPartial, p2)
-	Equal(t, errs, nil)
+func TestPostcodeProvider(t *testing.T) {
+	patterns := DefaultPostcodePatterns()
+	Equal(t, len(patterns), len(postCodePatternDict))
+	patterns["NL"] = `^[1-9]\d{3} ?(?:[A-RT-Z][A-Z]|S[BCE-RT-Z])$`
+	delete(patterns, "US")
+	_, ok := postCodePatternDict["US"]
+	Equal(t, ok, true)
 
-	dt := time.Now()
-	err := validate.StructFiltered(&dt, func(ns []byte) bool { return true })
-	NotEqual(t, err, nil)
-	Equal(t, err.Error(), "validator: (nil *time.Time)")
+	type Test struct {
+		Country string
+		Code    string `validate:"postcode_iso3166_alpha2_field=Country"`
+	}
+
+	validate := New(WithPostcodeProvider(NewPostcodeProvider(patterns)))
+	Equal(t, validate.Var("1234 AB", "postcode_iso3166_alpha2=NL"), nil)
+	NotEqual(t, validate.Var("1234 SA", "postcode_iso3166_alpha2=NL"), nil)
+	NotEqual(t, validate.Var("12345", "postcode_iso3166_alpha2=US"), nil)
+	Equal(t, validate.Struct(Test{Country: "NL", Code: "1234 AB"}), nil)
+	NotEqual(t, validate.Struct(Test{Country: "US", Code: "12345"}), nil)
+
+	// default provider is left untouched
+	validate = New()
+	Equal(t, validate.Var("1234 SA", "postcode_iso3166_alpha2=NL"), nil)
+	Equal(t, validate.Var("12345", "postcode_iso3166_alpha2=US"), nil)
+	Equal(t, validate.Struct(Test{Country: "US", Code: "12345"}), nil)
 }
 
-func TestUUIDValidation(t *testing.T) {
+func TestCountryAndCurrencyRegistries(t *testing.T) {
+	validate := New()
+	validate.AddCountry("XA", "xaa", 999)
+	validate.RemoveCountry("RU", "RUS", 643)
+	validate.AddCurrency("XYZ", 998)
+	validate.RemoveCurrency("RUB", 643)
+
+	Equal(t, validate.Var("XA", "iso3166_1_alpha2"), nil)
+	Equal(t, validate.Var("XAA", "iso3166_1_alpha3"), nil)
+	Equal(t, validate.Var(999, "iso3166_1_alpha_numeric"), nil)
+	Equal(t, validate.Var("XA", "country_code"), nil)
+	NotEqual(t, validate.Var("RU", "iso3166_1_alpha2"), nil)
+	NotEqual(t, validate.Var("RUS", "iso3166_1_alpha3"), nil)
+	NotEqual(t, validate.Var(643, "iso3166_1_alpha_numeric"), nil)
+	NotEqual(t, validate.Var("RU", "country_code"), nil)
+	Equal(t, validate.Var("DE", "iso3166_1_alpha2"), nil)
+
+	Equal(t, validate.Var("XYZ", "iso4217"), nil)
+	Equal(t, validate.Var(998, "iso4217_numeric"), nil)
+	NotEqual(t, validate.Var("RUB", "iso4217"), nil)
+	NotEqual(t, validate.Var(643, "iso4217_numeric"), nil)
+	Equal(t, validate.Var("EUR", "iso4217"), nil)
+
+	// only the alpha-3 form is removed
+	validate.RemoveCountry("", "DEU", -1)
+	Equal(t, validate.Var("DE", "iso3166_1_alpha2"), nil)
+	NotEqual(t, validate.Var("DEU", "iso3166_1_alpha3"), nil)
+
+	// other instances and the package level datasets are left untouched
+	other := New()
+	NotEqual(t, other.Var("XA", "iso3166_1_alpha2"), nil)
+	Equal(t, other.Var("RU", "iso3166_1_alpha2"), nil)
+	Equal(t, other.Var("RUB", "iso4217"), nil)
+	NotEqual(t, other.Var("XYZ", "iso4217"), nil)
+	_, ok := iso3166_1_alpha2["XA"]
+	Equal(t, ok, false)
+}
+
+func TestCountryGroupValidation(t *testing.T) {
 	tests := []struct {
-		param    string
+		value    interface{}
+		tag      string
 		expected bool
 	}{
-		{"", false},
-		{"xxxa987fbc9-4bed-3078-cf07-9141ba07c9f3", false},
-		{"a987fbc9-4bed-3078-cf07-9141ba07c9f3xxx", false},
-		{"a987fbc94bed3078cf079141ba07c9f3", false},
-		{"934859", false},
-		{"987fbc9-4bed-3078-cf07a-9141ba07c9f3", false},
-		{"aaaaaaaa-1111-1111-aaag-111111111111", false},
-		{"a987fbc9-4bed-3078-cf07-9141ba07c9f3", true},
+		{"NO", "iso3166_1_alpha2_eea", true},
+		{"CH", "iso3166_1_alpha2_eea", false},
+		{"CH", "iso3166_1_alpha2_efta", true},
+		{"DE", "iso3166_1_alpha2_efta", false},
+		{"CH", "iso3166_1_alpha2_schengen", true},
+		{"IE", "iso3166_1_alpha2_schengen", false},
+		{"NOR", "iso3166_1_alpha3_eea", true},
+		{"CHE", "iso3166_1_alpha3_efta", true},
+		{"IRL", "iso3166_1_alpha3_schengen", false},
+		{"UNK", "iso3166_1_alpha3_eea", false},
+		{578, "iso3166_1_alpha_numeric_eea", true},
+		{"756", "iso3166_1_alpha_numeric_efta", true},
+		{372, "iso3166_1_alpha_numeric_schengen", false},
+		{"CZE", "iso3166_1_alpha3_eu", true},
+		{203, "iso3166_1_alpha_numeric_eu", true},
+		{"LI", "eea_country_code", true},
+		{"LIE", "efta_country_code", true},
+		{756, "schengen_country_code", true},
+		{"GBR", "eea_country_code", false},
 	}
 
 	validate := New()
-
 	for i, test := range tests {
-		errs := validate.Var(test.param, "uuid")
-
+		errs := validate.Var(test.value, test.tag)
 		if test.expected {
 			if !IsEqual(errs, nil) {
-				t.Fatalf("Index: %d UUID failed Error: %s", i, errs)
+				t.Fatalf("Index: %d %s failed Error: %s", i, test.tag, errs)
 			}
 		} else {
 			if IsEqual(errs, nil) {
-				t.Fatalf("Index: %d UUID failed Error: %s", i, errs)
-			} else {
-				val := getError(errs, "", "")
-				if val.Tag() != "uuid" {
-					t.Fatalf("Index: %d UUID failed Error: %s", i, errs)
-				}
+				t.Fatalf("Index: %d %s failed Error: %s", i, test.tag, errs)
 			}
 		}
 	}
 
-	// Test UUID validation on uuid structs type that implements Stringer interface.
-	structWithValidUUID := struct {
-		UUID uuidTestType `validate:"uuid"`
-	}{
-		UUID: uuidTestType{val: "a987fbc9-4bed-3078-cf07-9141ba07c9f3"},
+	// membership can be updated per instance
+	validate.SetEUCountries([]string{"de", "FR"})
+	validate.SetSchengenCountries([]string{"IE"})
+	Equal(t, validate.Var("DE", "iso3166_1_alpha2_eu"), nil)
+	Equal(t, validate.Var("FRA", "iso3166_1_alpha3_eu"), nil)
+	Equal(t, validate.Var(276, "iso3166_1_alpha_numeric_eu"), nil)
+	NotEqual(t, validate.Var("SE", "iso3166_1_alpha2_eu"), nil)
+	Equal(t, validate.Var("IE", "iso3166_1_alpha2_schengen"), nil)
+	NotEqual(t, validate.Var("CH", "iso3166_1_alpha2_schengen"), nil)
+	Equal(t, validate.Var("NO", "iso3166_1_alpha2_eea"), nil)
+
+	other := New()
+	Equal(t, other.Var("SE", "iso3166_1_alpha2_eu"), nil)
+	NotEqual(t, other.Var("IE", "iso3166_1_alpha2_schengen"), nil)
+
+	// every country of the mapping table is a known country
+	for alpha2, code := range iso3166_1 {
+		_, ok := iso3166_1_alpha2[alpha2]
+		Equal(t, ok, true)
+		_, ok = iso3166_1_alpha3[code.alpha3]
+		Equal(t, ok, true)
+		_, ok = iso3166_1_alpha_numeric[code.numeric]
+		Equal(t, ok, true)
 	}
-	structWithInvalidUUID := struct {
-		UUID uuidTestType `validate:"uuid"`
+}
+
+func TestCountryGroupTag(t *testing.T) {
+	tests := []struct {
+		value    interface{}
+		param    string
+		expected bool
 	}{
-		UUID: uuidTestType{val: "934859"},
+		{"NO", "eea", true},
+		{"NOR", "eea", true},
+		{"578", "eea", true},
+		{578, "eea", true},
+		{uint16(578), "eea", true},
+		{"CH", "eea", false},
+		{"CHE", "efta", true},
+		{"DE", "eu", true},
+		{"IE", "schengen", false},
+		{"XX", "eu", false},
+		{"XXX", "eu", false},
+		{"", "eu", false},
+		{0, "eu", false},
+		{"US", "oecd", true},
+		{"JPN", "oecd", true},
+		{"CN", "oecd", false},
+		// numeric codes are normalized as by iso3166_1_alpha_numeric
+		{"40", "eu", true},
+		{"040", "eu", true},
+		{1040, "eu", true},
+		{"1040", "eu", true},
+		{"41", "eu", false},
 	}
 
-	if err := validate.Struct(structWithValidUUID); err != nil {
-		t.Fatalf("UUID failed Error: %s", err)
-	}
-	if err := validate.Struct(structWithInvalidUUID); err == nil {
-		t.Fatal("UUID failed Error expected but received nil")
+	validate := New()
+	for i, test := range tests {
+		errs := validate.Var(test.value, "country_group="+test.param)
+		if test.expected {
+			if !IsEqual(errs, nil) {
+				t.Fatalf("Index: %d country_group failed Error: %s", i, errs)
+			}
+		} else {
+			if IsEqual(errs, nil) {
+				t.Fatalf("Index: %d country_group failed Error: %s", i, errs)
+			}
+		}
 	}
 
-	// Test on Alias type with Stringer interface.
-	alias := uuidAlias("a987fbc9-4bed-3078-cf07-9141ba07c9f3")
-	if err := validate.Var(alias, "uuid"); err != nil {
-		t.Fatalf("UUID failed Error: %s", err)
+	PanicMatches(t, func() { _ = validate.Var("DE", "country_group=supported_markets") }, "Undefined country group 'supported_markets' on field ''")
+	PanicMatches(t, func() { _ = validate.Var([]string{"DE"}, "country_group=eu") }, "Bad field type []string")
+	PanicMatches(t, func() { validate.RegisterCountryGroup("", nil) }, "country group name cannot be empty")
+
+	validate.RegisterCountryGroup("supported_markets", []string{"DE", "us"})
+	type Test struct {
+		Market string `validate:"country_group=supported_markets"`
 	}
-}
 
-func TestValidateFn(t *testing.T) {
-	t.Run("using pointer", func(t *testing.T) {
-		validate := New()
+	Equal(t, validate.Struct(Test{Market: "US"}), nil)
+	Equal(t, validate.Struct(Test{Market: "DEU"}), nil)
+	errs := validate.Struct(Test{Market: "FR"})
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "Test.Market", "Test.Market", "Market", "Market", "country_group")
 
-		type Test struct {
-			String string
-			Inner  *NotRed `validate:"validateFn"`
+	// built-in groups are still available
+	Equal(t, validate.Var("FR", "country_group=eu"), nil)
+}
+
+func TestValidationWithBreaker(t *testing.T) {
+	errUnavailable := errors.New("unavailable")
+	var calls int
+	lookup := func(ctx context.Context, fl FieldLevel) (bool, error) {
+		calls++
+		if fl.Field().String() == "down" {
+			return false, errUnavailable
 		}
+		return fl.Field().String() == "known", nil
+	}
 
-		var tt Test
-		errs := validate.Struct(tt)
-		NotEqual(t, errs, nil)
+	validate := New()
+	err := validate.RegisterValidationWithBreaker("lookup", lookup, BreakerConfig{MaxFailures: 2, OpenDuration: time.Hour})
+	Equal(t, err, nil)
+	Equal(t, validate.Var("known", "lookup"), nil)
+	NotEqual(t, validate.Var("unknown", "lookup"), nil)
+	NotEqual(t, validate.Var("down", "lookup"), nil)
+	NotEqual(t, validate.Var("down", "lookup"), nil)
+	Equal(t, calls, 4)
 
-		fe := errs.(ValidationErrors)[0]
-		Equal(t, fe.Field(), "Inner")
-		Equal(t, fe.Namespace(), "Test.Inner")
-		Equal(t, fe.Tag(), "validateFn")
+	// the breaker is open, calls are short-circuited
+	NotEqual(t, validate.Var("known", "lookup"), nil)
+	Equal(t, calls, 4)
 
-		tt.Inner = &NotRed{Color: "blue"}
-		errs = validate.Struct(tt)
-		Equal(t, errs, nil)
+	calls = 0
+	validate = New()
+	err = validate.RegisterValidationWithBreaker("lookup", lookup, BreakerConfig{MaxFailures: 1, OpenDuration: time.Hour, FailOpen: true})
+	Equal(t, err, nil)
+	Equal(t, validate.Var("down", "lookup"), nil)
+	Equal(t, validate.Var("unknown", "lookup"), nil)
+	Equal(t, calls, 1)
 
-		tt.Inner = &NotRed{Color: "red"}
-		errs = validate.Struct(tt)
-		NotEqual(t, errs, nil)
+	calls = 0
+	validate = New()
+	err = validate.RegisterValidationWithBreaker("lookup", lookup, BreakerConfig{Rate: 0.001, Burst: 2})
+	Equal(t, err, nil)
+	Equal(t, validate.Var("known", "lookup"), nil)
+	Equal(t, validate.Var("known", "lookup"), nil)
+	NotEqual(t, validate.Var("known", "lookup"), nil)
+	Equal(t, calls, 2)
 
-		fe = errs.(ValidationErrors)[0]
-		Equal(t, fe.Field(), "Inner")
-		Equal(t, fe.Namespace(), "Test.Inner")
-		Equal(t, fe.Tag(), "validateFn")
-	})
+	b := &breaker{cfg: BreakerConfig{Rate: 1, MaxFailures: 1, OpenDuration: time.Minute}}
+	now := time.Now()
+	Equal(t, b.allow(now), true)
+	Equal(t, b.allow(now), false)
+	Equal(t, b.allow(now.Add(time.Second)), true)
+	b.done(now.Add(time.Second), errUnavailable)
+	Equal(t, b.allow(now.Add(time.Minute)), false)
+	Equal(t, b.allow(now.Add(2*time.Minute)), true)
+}
 
-	t.Run("using struct", func(t *testing.T) {
-		validate := New()
+func TestPwnedValidation(t *testing.T) {
+	type Signup struct {
+		Password string `validate:"pwned"`
+	}
 
-		type Test2 struct {
-			String string
-			Inner  NotRed `validate:"validateFn"`
+	errUnavailable := errors.New("unavailable")
+	var prefixes []string
+	query := func(ctx context.Context, prefix string) ([]byte, error) {
+		prefixes = append(prefixes, prefix)
+		if prefix == "1D5EE" { // 'unavailable'
+			return nil, errUnavailable
 		}
+		// SHA-1 of 'password' is 5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8
+		return []byte("0018A45C4D1DEF81644B54AB7F969B88D65:1\r\n1E4C9B93F3F0682250B6CF8331B7EE68FD8:9659365\r\nFFFFF0682250B6CF8331B7EE68FD800000:0\r\n"), nil
+	}
 
-		var tt2 Test2
-		errs := validate.Struct(&tt2)
-		Equal(t, errs, nil)
-
-		tt2.Inner = NotRed{Color: "blue"}
-		errs = validate.Struct(&tt2)
-		Equal(t, errs, nil)
+	validate := New()
+	validate.RegisterPwnedCheck(PwnedRange(query), BreakerConfig{})
 
-		tt2.Inner = NotRed{Color: "red"}
-		errs = validate.Struct(&tt2)
-		NotEqual(t, errs, nil)
+	errs := validate.Struct(Signup{Password: "password"})
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "Signup.Password", "Signup.Password", "Password", "Password", "pwned")
+	Equal(t, prefixes, []string{"5BAA6"})
 
-		fe := errs.(ValidationErrors)[0]
-		Equal(t, fe.Field(), "Inner")
-		Equal(t, fe.Namespace(), "Test2.Inner")
-		Equal(t, fe.Tag(), "validateFn")
-	})
+	Equal(t, validate.Struct(Signup{Password: "correct horse battery staple"}), nil)
+	Equal(t, validate.Struct(Signup{}), nil)
+	Equal(t, len(prefixes), 2)
 
-	t.Run("using struct with custom function", func(t *testing.T) {
-		validate := New()
+	// lookups failing fail closed by default
+	NotEqual(t, validate.Var("unavailable", "pwned"), nil)
 
-		type Test2 struct {
-			String string
-			Inner  NotRed `validate:"validateFn=IsNotRed"`
-		}
+	validate = New()
+	validate.RegisterPwnedCheck(PwnedRange(query), BreakerConfig{FailOpen: true})
+	Equal(t, validate.Var("unavailable", "pwned"), nil)
+	NotEqual(t, validate.Var("password", "pwned"), nil)
 
-		var tt2 Test2
-		errs := validate.Struct(&tt2)
-		Equal(t, errs, nil)
+	validate = New(WithTimeoutPolicy(TimeoutFailOpen, "pwned"))
+	validate.RegisterPwnedCheck(func(ctx context.Context, password string) (bool, error) {
+		<-ctx.Done()
+		return false, ctx.Err()
+	}, BreakerConfig{})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	Equal(t, validate.VarCtx(ctx, "password", "pwned"), nil)
 
-		tt2.Inner = NotRed{Color: "blue"}
-		errs = validate.Struct(&tt2)
-		Equal(t, errs, nil)
+	PanicMatches(t, func() { _ = validate.Var(1, "pwned") }, "Bad field type int")
+	PanicMatches(t, func() { New().RegisterPwnedCheck(nil, BreakerConfig{}) }, "pwned lookup function cannot be nil")
+	PanicMatches(t, func() { _ = New().Var("password", "pwned") }, "Undefined validation function 'pwned' on field ''")
+}
 
-		tt2.Inner = NotRed{Color: "red"}
-		errs = validate.Struct(&tt2)
-		NotEqual(t, errs, nil)
+func TestBatch(t *testing.T) {
+	type Row struct {
+		Email string `validate:"required,mx"`
+	}
 
-		fe := errs.(ValidationErrors)[0]
-		Equal(t, fe.Field(), "Inner")
-		Equal(t, fe.Namespace(), "Test2.Inner")
-		Equal(t, fe.Tag(), "validateFn")
-	})
+	calls := map[string]int{}
+	validate := New()
+	err := validate.RegisterValidationWithBreaker("mx", func(ctx context.Context, fl FieldLevel) (bool, error) {
+		calls[fl.Field().String()]++
+		return strings.HasSuffix(fl.Field().String(), "@bloggs.com"), nil
+	}, BreakerConfig{})
+	Equal(t, err, nil)
 
-	t.Run("try validate method with wrong signature or not existent", func(t *testing.T) {
-		validate := New()
+	errs := validate.Batch(context.Background()).
+		Add(Row{Email: "joey@bloggs.com"}).
+		Add(Row{Email: "joey@example.com"}).
+		Add(&Row{Email: "joey@bloggs.com"}).
+		Add(Row{}).
+		Add(Row{Email: "joey@example.com"}).
+		Run()
+	Equal(t, len(errs), 5)
+	Equal(t, errs[0], nil)
+	AssertError(t, errs[1], "Row.Email", "Row.Email", "Email", "Email", "mx")
+	Equal(t, errs[2], nil)
+	AssertError(t, errs[3], "Row.Email", "Row.Email", "Email", "Email", "required")
+	AssertError(t, errs[4], "Row.Email", "Row.Email", "Email", "Email", "mx")
+	Equal(t, calls, map[string]int{"joey@bloggs.com": 1, "joey@example.com": 1})
+
+	// calls are only deduplicated within a batch
+	Equal(t, validate.Struct(Row{Email: "joey@bloggs.com"}), nil)
+	Equal(t, calls["joey@bloggs.com"], 2)
+}
+
+func TestTimeoutPolicy(t *testing.T) {
+	type Host struct {
+		Addr string `validate:"lookup"`
+		TCP  string `validate:"tcp_addr"`
+	}
 
-		type Test2 struct {
-			String string `validate:"validateFn=NotExists"` // should fail, method not found
-			Inner  NotRed `validate:"validateFn=DoNothing"` // should fail, return nothing
-			Inner2 NotRed `validate:"validateFn=String"`    // should fail, wrong return (must be error or bool)
+	lookup := func(ctx context.Context, fl FieldLevel) bool {
+		select {
+		case <-ctx.Done():
+			MarkTimedOut(fl)
+			return false
+		case <-time.After(10 * time.Millisecond):
+			return true
 		}
+	}
 
-		var tt2 Test2
-		err := validate.Struct(&tt2)
-		NotEqual(t, err, nil)
+	expired, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	host := Host{Addr: "example.com", TCP: "127.0.0.1:80"}
 
-		errs := err.(ValidationErrors)
-		Equal(t, len(errs), 3)
+	// fail closed by default
+	validate := New()
+	Equal(t, validate.RegisterValidationCtx("lookup", lookup), nil)
+	errs := validate.StructCtx(expired, host)
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 1)
+	AssertError(t, errs, "Host.Addr", "Host.Addr", "Addr", "Addr", "lookup")
 
-		fe := errs[0]
-		Equal(t, fe.Field(), "String")
-		Equal(t, fe.Namespace(), "Test2.String")
-		Equal(t, fe.Tag(), "validateFn")
+	validate = New(WithTimeoutPolicy(TimeoutFailOpen, "lookup"))
+	Equal(t, validate.RegisterValidationCtx("lookup", lookup), nil)
+	Equal(t, validate.StructCtx(expired, host), nil)
 
-		fe = errs[1]
-		Equal(t, fe.Field(), "Inner")
-		Equal(t, fe.Namespace(), "Test2.Inner")
-		Equal(t, fe.Tag(), "validateFn")
+	validate = New(WithTimeoutPolicy(TimeoutWarn, "lookup"), WithTimeoutPolicy(TimeoutWarn))
+	Equal(t, validate.RegisterValidationCtx("lookup", lookup), nil)
+	errs = validate.StructCtx(expired, host)
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 2)
+	AssertError(t, errs, "Host.Addr", "Host.Addr", "Addr", "Addr", "lookup(timeout)")
+	AssertError(t, errs, "Host.TCP", "Host.TCP", "TCP", "TCP", "tcp_addr(timeout)")
 
-		fe = errs[2]
-		Equal(t, fe.Field(), "Inner2")
-		Equal(t, fe.Namespace(), "Test2.Inner2")
-		Equal(t, fe.Tag(), "validateFn")
-	})
+	// a genuine failure keeps its tag
+	errs = validate.StructCtx(context.Background(), Host{Addr: "example.com", TCP: "localhost"})
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 1)
+	AssertError(t, errs, "Host.TCP", "Host.TCP", "TCP", "TCP", "tcp_addr")
+
+	// the tags interrupted by the deadline are timed out, the ones failing on their own after it fail
+	type Slow struct {
+		Failing     string `validate:"failing"`
+		Interrupted string `validate:"interrupted"`
+	}
+
+	validate = New(WithTimeoutPolicy(TimeoutFailOpen, "interrupted", "failing"))
+	Equal(t, validate.RegisterValidationCtx("interrupted", func(ctx context.Context, fl FieldLevel) bool {
+		<-ctx.Done()
+		MarkTimedOut(fl)
+		return false
+	}), nil)
+	Equal(t, validate.RegisterValidationCtx("failing", func(ctx context.Context, fl FieldLevel) bool {
+		<-ctx.Done()
+		return false
+	}), nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	errs = validate.StructCtx(ctx, Slow{})
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 1)
+	AssertError(t, errs, "Slow.Failing", "Slow.Failing", "Failing", "Failing", "failing")
+
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	Equal(t, validate.VarCtx(ctx, "", "interrupted"), nil)
 }
 
-func TestSQLValue2Validation(t *testing.T) {
-	validate := New()
-	validate.RegisterCustomTypeFunc(ValidateValuerType, valuer{}, (*driver.Valuer)(nil), sql.NullString{}, sql.NullInt64{}, sql.NullBool{}, sql.NullFloat64{})
-	validate.RegisterCustomTypeFunc(ValidateCustomType, MadeUpCustomType{})
-	validate.RegisterCustomTypeFunc(OverrideIntTypeForSomeReason, 1)
+func TestContextAbort(t *testing.T) {
+	type Item struct {
+		Name string `validate:"required"`
+		Code string `validate:"count"`
+	}
 
-	val := valuer{
-		Name: "",
+	type Payload struct {
+		Items []Item   `validate:"dive"`
+		Tags  []string `validate:"dive,alpha"`
 	}
-	errs := validate.Var(val, "required")
-	NotEqual(t, errs, nil)
-	AssertError(t, errs, "", "", "", "", "required")
 
-	val.Name = "Valid Name"
-	errs = validate.VarCtx(context.Background(), val, "required")
-	Equal(t, errs, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
-	val.Name = "errorme"
-	PanicMatches(t, func() { _ = validate.Var(val, "required") }, "SQL Driver Valuer error: some kind of error")
+	var calls int
+	count := func(ctx context.Context, fl FieldLevel) bool {
+		// the payload's validation runs past its deadline after three items
+		if calls++; calls == 3 {
+			cancel()
+		}
+		return true
+	}
 
-	myVal := valuer{
-		Name: "",
+	validate := New(WithContextAbort())
+	Equal(t, validate.RegisterValidationCtx("count", count), nil)
+
+	payload := Payload{Items: make([]Item, 10), Tags: []string{"1"}}
+	err := validate.StructCtx(ctx, payload)
+	var aborted *AbortedValidationError
+	Equal(t, errors.As(err, &aborted), true)
+	Equal(t, errors.Is(err, context.Canceled), true)
+	Equal(t, err.Error(), "validator: validation aborted: context canceled")
+	Equal(t, calls, 3)
+	Equal(t, len(aborted.Errors), 3)
+	AssertError(t, aborted.Errors, "Payload.Items[2].Name", "Payload.Items[2].Name", "Name", "Name", "required")
+
+	// done contexts stop the calls before their first field, the pooled state being reset
+	err = validate.VarCtx(ctx, []string{"1", "2"}, "dive,alpha")
+	Equal(t, errors.As(err, &aborted), true)
+	Equal(t, len(aborted.Errors), 0)
+	Equal(t, validate.Var([]string{"a"}, "dive,alpha"), nil)
+	Equal(t, len(validate.Struct(Payload{Tags: []string{"1"}}).(ValidationErrors)), 1)
+
+	expired, cancelExpired := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancelExpired()
+	err = validate.StructCtx(expired, payload)
+	Equal(t, errors.Is(err, context.DeadlineExceeded), true)
+
+	// without WithContextAbort the calls complete
+	calls = 0
+	validate = New()
+	Equal(t, validate.RegisterValidationCtx("count", count), nil)
+	Equal(t, len(validate.StructCtx(expired, payload).(ValidationErrors)), 11)
+	Equal(t, calls, 10)
+}
+
+func TestSampling(t *testing.T) {
+	type Test struct {
+		Name  string `validate:"required"`
+		Email string `validate:"required,email"`
 	}
-	errs = validate.Var(myVal, "required")
+
+	// never sampled, only the sampled tag is skipped
+	validate := New(WithSampling(0, "email"))
+	errs := validate.Struct(Test{Email: "not an email"})
 	NotEqual(t, errs, nil)
-	AssertError(t, errs, "", "", "", "", "required")
+	Equal(t, len(errs.(ValidationErrors)), 1)
+	AssertError(t, errs, "Test.Name", "Test.Name", "Name", "Name", "required")
+	skipped, errs := validate.StructSampled(Test{Email: "not an email"})
+	NotEqual(t, errs, nil)
+	Equal(t, skipped, []SkippedRule{{Namespace: "Test.Email", Tag: "email"}})
+	errs = validate.Struct(Test{})
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "Test.Email", "Test.Email", "Email", "Email", "required")
+	Equal(t, validate.Var("not an email", "email"), nil)
+	NotEqual(t, validate.Var("not an email", "email|ip"), nil)
 
-	cust := MadeUpCustomType{
-		FirstName: "Joey",
-		LastName:  "Bloggs",
+	// always sampled
+	validate = New(WithSampling(1, "email"))
+	errs = validate.Struct(Test{Name: "name", Email: "not an email"})
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "Test.Email", "Test.Email", "Email", "Email", "email")
+	skipped, errs = validate.StructSampled(Test{Name: "name", Email: "not an email"})
+	NotEqual(t, errs, nil)
+	Equal(t, len(skipped), 0)
+
+	// defaults to the network resolving tags
+	validate = New(WithSampling(0))
+	Equal(t, validate.Var("not an address", "tcp_addr"), nil)
+	NotEqual(t, validate.Var("not an email", "email"), nil)
+
+	validate = New(WithSampling(0.5, "email"))
+	var sampled, notSampled int
+	for i := 0; i < 1000; i++ {
+		if validate.Var("not an email", "email") == nil {
+			notSampled++
+		} else {
+			sampled++
+		}
 	}
 
-	c := CustomMadeUpStruct{MadeUp: cust, OverriddenInt: 2}
-	errs = validate.Struct(c)
-	Equal(t, errs, nil)
+	Equal(t, sampled > 0, true)
+	Equal(t, notSampled > 0, true)
+}
 
-	c.MadeUp.FirstName = ""
-	c.OverriddenInt = 1
-	errs = validate.Struct(c)
-	NotEqual(t, errs, nil)
-	Equal(t, len(errs.(ValidationErrors)), 2)
-	AssertError(t, errs, "CustomMadeUpStruct.MadeUp", "CustomMadeUpStruct.MadeUp", "MadeUp", "MadeUp", "required")
-	AssertError(t, errs, "CustomMadeUpStruct.OverriddenInt", "CustomMadeUpStruct.OverriddenInt", "OverriddenInt", "OverriddenInt", "gt")
+func TestEnvironment(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	rnd := 0.9
+	validate := New(
+		WithSampling(0.5, "email"),
+		WithEnvironment(Env{
+			Clock:    func() time.Time { return now },
+			Rand:     func() float64 { return rnd },
+			Hostname: func() (string, error) { return "test-host", nil },
+		}),
+	)
+
+	Equal(t, validate.Var(now.Add(time.Hour), "future"), nil)
+	NotEqual(t, validate.Var(now, "future"), nil)
+
+	// sampled out while the random number is above the rate
+	Equal(t, validate.Var("not an email", "email"), nil)
+	rnd = 0.1
+	NotEqual(t, validate.Var("not an email", "email"), nil)
+
+	env := validate.Env()
+	Equal(t, env.Clock(), now)
+	Equal(t, env.Rand(), 0.1)
+	host, err := env.Hostname()
+	Equal(t, err, nil)
+	Equal(t, host, "test-host")
+
+	// the breaker reopens once the clock passes the open duration
+	err = validate.RegisterValidationWithBreaker("lookup", func(ctx context.Context, fl FieldLevel) (bool, error) {
+		if fl.Field().String() == "down" {
+			return false, errors.New("unavailable")
+		}
+		return true, nil
+	}, BreakerConfig{MaxFailures: 1, OpenDuration: time.Minute})
+	Equal(t, err, nil)
+	NotEqual(t, validate.Var("down", "lookup"), nil)
+	NotEqual(t, validate.Var("up", "lookup"), nil)
+	now = now.Add(time.Minute)
+	Equal(t, validate.Var("up", "lookup"), nil)
+
+	// unset funcs keep their defaults
+	env = New(WithEnvironment(Env{})).Env()
+	NotEqual(t, env.Clock, nil)
+	NotEqual(t, env.Rand, nil)
+	NotEqual(t, env.Hostname, nil)
+	Equal(t, New(WithEnvironment(Env{})).Var(time.Now().Add(time.Hour), "future"), nil)
 }
 
-func TestSQLValueValidation(t *testing.T) {
-	validate := New()
-	validate.RegisterCustomTypeFunc(ValidateValuerType, (*driver.Valuer)(nil), valuer{})
-	validate.RegisterCustomTypeFunc(ValidateCustomType, MadeUpCustomType{})
-	validate.RegisterCustomTypeFunc(OverrideIntTypeForSomeReason, 1)
-	val := valuer{
-		Name: "",
+func TestTracer(t *testing.T) {
+	type Test struct {
+		Kind   string `validate:"oneof=card iban"`
+		Number string `validate:"omitempty,required_if=Kind card"`
+		Color  string `validate:"rgb|hexcolor"`
 	}
-	errs := validate.Var(val, "required")
-	NotEqual(t, errs, nil)
-	AssertError(t, errs, "", "", "", "", "required")
 
-	val.Name = "Valid Name"
-	errs = validate.Var(val, "required")
-	Equal(t, errs, nil)
+	var steps []RecordedStep
+	validate := New(WithTracer(func(step RecordedStep) {
+		steps = append(steps, step)
+	}))
 
-	val.Name = "errorme"
-	PanicMatches(t, func() { errs = validate.Var(val, "required") }, "SQL Driver Valuer error: some kind of error")
+	err := validate.Struct(Test{Kind: "card", Color: "#fff"})
+	Equal(t, err, nil)
+	Equal(t, steps, []RecordedStep{
+		{Namespace: "Test.Kind", Tag: "oneof", Param: "card iban", Decision: DecisionPassed},
+		{Namespace: "Test.Number", Tag: "omitempty", Decision: DecisionSkippedOmitEmpty},
+		{Namespace: "Test.Color", Tag: "rgb", Decision: DecisionOrBranchFailed},
+		{Namespace: "Test.Color", Tag: "hexcolor", Decision: DecisionOrBranchTaken},
+	})
 
-	myVal := valuer{
-		Name: "",
-	}
-	errs = validate.Var(myVal, "required")
-	NotEqual(t, errs, nil)
-	AssertError(t, errs, "", "", "", "", "required")
+	rec, err := validate.StructTrace(Test{Kind: "cash", Color: "#fff"})
+	NotEqual(t, err, nil)
+	Equal(t, rec.Steps[0], RecordedStep{Namespace: "Test.Kind", Tag: "oneof", Param: "card iban", Decision: DecisionFailed})
+	Equal(t, len(rec.Steps), 4)
+	Equal(t, len(steps), 8)
 
-	cust := MadeUpCustomType{
-		FirstName: "Joey",
-		LastName:  "Bloggs",
+	rec, err = New().StructTrace(Test{Kind: "iban", Color: "#fff"})
+	Equal(t, err, nil)
+	Equal(t, rec.String(), "Test.Kind oneof=card iban: passed\n"+
+		"Test.Number omitempty: skipped by omitempty\n"+
+		"Test.Color rgb: or branch failed\n"+
+		"Test.Color hexcolor: or branch taken")
+	Equal(t, DecisionOrBranchFailed.String(), "or branch failed")
+}
+
+func TestHooks(t *testing.T) {
+	type hookCtxKey struct{}
+	type Test struct {
+		Name string   `validate:"required"`
+		Tags []string `validate:"dive,alpha"`
+	}
+
+	var before, after []ValidationCall
+	var afterCtx []interface{}
+	now := time.Unix(0, 0)
+	validate := New(WithClock(func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}), WithHooks(func(ctx context.Context, call ValidationCall) context.Context {
+		before = append(before, call)
+		return context.WithValue(ctx, hookCtxKey{}, call.Method)
+	}, func(ctx context.Context, call ValidationCall) {
+		after = append(after, call)
+		afterCtx = append(afterCtx, ctx.Value(hookCtxKey{}))
+	}))
+
+	Equal(t, validate.Struct(Test{Name: "a"}), nil)
+	NotEqual(t, validate.StructPartial(&Test{Tags: []string{"1", "2"}}, "Tags"), nil)
+	NotEqual(t, validate.Var(1, "gte=2"), nil)
+	NotEqual(t, validate.Struct(nil), nil)
+	typed, err := CompileWith[Test](validate)
+	Equal(t, err, nil)
+	NotEqual(t, typed.Validate(context.Background(), &Test{}), nil)
+
+	Equal(t, len(before), 5)
+	Equal(t, len(after), 5)
+	Equal(t, afterCtx, []interface{}{"Struct", "StructPartial", "Var", "Struct", "Typed"})
+	Equal(t, before[0].Type == reflect.TypeOf(Test{}), true)
+	Equal(t, before[0].Start, time.Unix(1, 0))
+	Equal(t, after[0].Duration, time.Second)
+	Equal(t, after[0].Errors, 0)
+	Equal(t, after[0].Err, nil)
+	Equal(t, after[1].TypeName(), "Test")
+	Equal(t, after[1].Errors, 2)
+	Equal(t, after[2].TypeName(), "int")
+	Equal(t, after[2].Errors, 1)
+	Equal(t, after[3].TypeName(), "nil")
+	Equal(t, after[3].Errors, 0)
+	Equal(t, after[3].Err.Error(), "validator: (nil)")
+	Equal(t, after[4].Errors, 1)
+
+	// panicking calls are reported before panicking again, strict errors as returned
+	after = nil
+	PanicMatches(t, func() { _ = validate.Var(1, "undefinedtag") }, "Undefined validation function 'undefinedtag' on field ''")
+	Equal(t, len(after), 1)
+	Equal(t, after[0].Err.Error(), "validator: Undefined validation function 'undefinedtag' on field '' validating int")
+
+	after = nil
+	validate = New(WithStrictErrors(), WithHooks(nil, func(ctx context.Context, call ValidationCall) {
+		after = append(after, call)
+	}))
+	err = validate.Var(1, "undefinedtag")
+	NotEqual(t, err, nil)
+	Equal(t, after[0].Err, err)
+}
+
+func TestRecording(t *testing.T) {
+	type Test struct {
+		Nickname string  `validate:"omitempty,min=3"`
+		Color    string  `validate:"required,rgb|hexcolor"`
+		Age      int     `validate:"gte=18"`
+		Ref      *string `validate:"omitnil,uuid"`
 	}
-	c := CustomMadeUpStruct{MadeUp: cust, OverriddenInt: 2}
-	errs = validate.Struct(c)
-	Equal(t, errs, nil)
 
-	c.MadeUp.FirstName = ""
-	c.OverriddenInt = 1
-	errs = validate.Struct(c)
+	validate := New()
+	ctx, rec := NewRecordingContext(context.Background())
+	errs := validate.StructCtx(ctx, Test{Color: "#fff", Age: 1})
 	NotEqual(t, errs, nil)
-	Equal(t, len(errs.(ValidationErrors)), 2)
-	AssertError(t, errs, "CustomMadeUpStruct.MadeUp", "CustomMadeUpStruct.MadeUp", "MadeUp", "MadeUp", "required")
-	AssertError(t, errs, "CustomMadeUpStruct.OverriddenInt", "CustomMadeUpStruct.OverriddenInt", "OverriddenInt", "OverriddenInt", "gt")
 
-	// Test for empty field on structs without tags
-	type InvalidValuePanicSafetyTest struct {
-		V valuer
-	}
+	Equal(t, rec.Steps, []RecordedStep{
+		{Namespace: "Test.Nickname", Tag: "omitempty", Decision: DecisionSkippedOmitEmpty},
+		{Namespace: "Test.Color", Tag: "required", Decision: DecisionPassed},
+		{Namespace: "Test.Color", Tag: "rgb", Decision: DecisionOrBranchFailed},
+		{Namespace: "Test.Color", Tag: "hexcolor", Decision: DecisionOrBranchTaken},
+		{Namespace: "Test.Age", Tag: "gte", Param: "18", Decision: DecisionFailed},
+		{Namespace: "Test.Ref", Tag: "omitnil", Decision: DecisionSkippedOmitNil},
+	})
+	Equal(t, rec.String(), "Test.Nickname omitempty: skipped by omitempty\n"+
+		"Test.Color required: passed\n"+
+		"Test.Color rgb: or branch failed\n"+
+		"Test.Color hexcolor: or branch taken\n"+
+		"Test.Age gte=18: failed\n"+
+		"Test.Ref omitnil: skipped by omitnil")
+
+	r, ok := RecordingFromContext(ctx)
+	Equal(t, ok, true)
+	Equal(t, r, rec)
 
-	errs = validate.Struct(InvalidValuePanicSafetyTest{})
+	ctx, rec = NewRecordingContext(context.Background())
+	errs = validate.VarCtx(ctx, "blue", "rgb|hexcolor")
+	NotEqual(t, errs, nil)
+	Equal(t, rec.Steps, []RecordedStep{{Tag: "rgb", Decision: DecisionOrBranchFailed}, {Tag: "rgb|hexcolor", Decision: DecisionFailed}})
+
+	// a recording is kept by the calls adding warnings to its context
+	ctx, rec = NewRecordingContext(context.Background())
+	warns, errs := validate.StructWithWarningsCtx(ctx, Test{Color: "#fff", Age: 18})
 	Equal(t, errs, nil)
+	Equal(t, len(warns), 0)
+	Equal(t, len(rec.Steps), 6)
+
+	r, ok = RecordingFromContext(ctx)
+	Equal(t, ok, true)
+	Equal(t, r, rec)
+
+	_, ok = RecordingFromContext(context.Background())
+	Equal(t, ok, false)
+	Equal(t, Decision(100).String(), "unknown")
 }
 
 func AssertError(t *testing.T, err error, nsKey, structNsKey, field, structField, expectedTag string) {
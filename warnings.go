@@ -0,0 +1,30 @@
+package validator
+
+import "context"
+
+// warnings collects the failures of the warning tags of a validation call.
+type warnings struct {
+	errs ValidationErrors
+}
+
+// StructWithWarningsCtx validates s like StructCtx, the failures of the tags marked with the 'warn:' modifier,
+// e. g. `validate:"warn:max=255,required"`, being returned as warnings rather than as errors.
+// A failing warning tag doesn't stop the validation of the field, allowing soft limits,
+// e. g. for deprecations, to be reported alongside the errors.
+// Validation calls other than StructWithWarnings ignore the failures of warning tags.
+//
+// It returns the warnings, and InvalidValidationError for bad values passed in and nil or ValidationErrors as error otherwise.
+// The errors are an error rather than ValidationErrors, as for StructCtx, so that InvalidValidationError,
+// TruncatedValidationError and the IntegrityError of RegisterStructIntegrity can be returned,
+// use errors.As to get the ValidationErrors.
+func (v *Validate) StructWithWarningsCtx(ctx context.Context, s interface{}) (warns ValidationErrors, err error) {
+	w := new(warnings)
+	err = v.StructCtx(withCallState(ctx, func(cs *callState) { cs.warns = w }), s)
+	return w.errs, err
+}
+
+// StructWithWarnings validates s like Struct, returning the failures of the warning tags separately,
+// see StructWithWarningsCtx.
+func (v *Validate) StructWithWarnings(s interface{}) (warns ValidationErrors, err error) {
+	return v.StructWithWarningsCtx(context.Background(), s)
+}